@@ -0,0 +1,282 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package analyzer defines an analysis pass checking the literal keys of the
+// argument maps passed to dwarfreflect.Function methods such as CallWithMap
+// against the parameter names of the wrapped function, so typos are caught at
+// vet time rather than at run time.
+//
+// The wrapped function is determined when the *dwarfreflect.Function comes
+// from dwarfreflect.NewFunction or Registry.Register called with a function
+// or method declared in source, directly or through a local variable assigned
+// once, possibly through the With* methods. Functions configured with With or
+// WithOptionConstructors may accept other keys and are not checked, nor are
+// functions with unnamed parameters.
+//
+// Example:
+//
+//	go vet -vettool=$(which dwarfreflect-vet) ./...
+//
+//	fn, _ := dwarfreflect.NewFunction(CreateUser) // func CreateUser(name string, age int)
+//	fn.CallWithMap(map[string]any{"nmae": "Ada", "age": 36})
+//	// CallWithMap key "nmae" is not a parameter of CreateUser (name, age); did you mean "name"?
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const dwarfreflectPath = "github.com/matteo-grella/dwarfreflect"
+
+// Analyzer checks the literal argument map keys of dwarfreflect calls.
+var Analyzer = &analysis.Analyzer{
+	Name:     "dwarfreflectkeys",
+	Doc:      "check literal argument map keys passed to dwarfreflect.Function methods against the parameter names",
+	URL:      "https://pkg.go.dev/github.com/matteo-grella/dwarfreflect/analyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// mapArgIndex gives the position of the argument map of the checked methods.
+var mapArgIndex = map[string]int{
+	"CallWithMap":        0,
+	"CallVoid":           0,
+	"CallOne":            0,
+	"MapToArgs":          0,
+	"ExplainBind":        0,
+	"CallInjected":       1,
+	"CallInTx":           1,
+	"ExplainBindContext": 1,
+	"CallWithMeta":       2,
+}
+
+// keyPreserving lists the methods returning a Function that accepts the same
+// keys as their receiver.
+var keyPreserving = map[string]bool{
+	"AdoptTagsFrom":          true,
+	"Freeze":                 true,
+	"Shadow":                 true,
+	"WithAuthorizer":         true,
+	"WithCircuitBreaker":     true,
+	"WithContextDecorator":   true,
+	"WithErrorContext":       true,
+	"WithExamples":           true,
+	"WithObserver":           true,
+	"WithParamMeta":          true,
+	"WithPolicy":             true,
+	"WithRequired":           true,
+	"WithStructVariantLimit": true,
+}
+
+// target is a function whose parameter names are known.
+type target struct {
+	name   string
+	params []string
+}
+
+type checker struct {
+	pass *analysis.Pass
+
+	// assigned holds the expressions assigned to local variables of type
+	// *dwarfreflect.Function, nil for variables assigned more than once.
+	assigned map[types.Object]ast.Expr
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{pass: pass, assigned: make(map[types.Object]ast.Expr)}
+
+	inspect.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			c.recordAssignment(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+			c.recordAssignment(lhs, n.Values)
+		}
+	})
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		c.checkCall(n.(*ast.CallExpr))
+	})
+	return nil, nil
+}
+
+// recordAssignment records the expressions assigned to Function variables.
+func (c *checker) recordAssignment(lhs, rhs []ast.Expr) {
+	for i, l := range lhs {
+		id, ok := ast.Unparen(l).(*ast.Ident)
+		if !ok || id.Name == "_" {
+			continue
+		}
+		obj := c.pass.TypesInfo.ObjectOf(id)
+		if obj == nil || !isFunctionPtr(obj.Type()) {
+			continue
+		}
+
+		var value ast.Expr
+		switch {
+		case len(lhs) == len(rhs):
+			value = rhs[i]
+		case len(rhs) == 1 && i == 0:
+			value = rhs[0] // fn, err := dwarfreflect.NewFunction(F)
+		}
+
+		if _, seen := c.assigned[obj]; seen {
+			c.assigned[obj] = nil
+		} else {
+			c.assigned[obj] = value
+		}
+	}
+}
+
+// checkCall reports the unknown literal keys of the argument map of a call.
+func (c *checker) checkCall(call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	index, ok := mapArgIndex[sel.Sel.Name]
+	if !ok || index >= len(call.Args) || !c.isFunctionMethod(sel) {
+		return
+	}
+	lit, ok := ast.Unparen(call.Args[index]).(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	tgt, ok := c.resolve(sel.X, 0)
+	if !ok {
+		return
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		tv, ok := c.pass.TypesInfo.Types[kv.Key]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			continue
+		}
+		key := constant.StringVal(tv.Value)
+		if slices.Contains(tgt.params, key) {
+			continue
+		}
+
+		msg := "%s key %q is not a parameter of %s (%s)"
+		args := []any{sel.Sel.Name, key, tgt.name, strings.Join(tgt.params, ", ")}
+		for _, param := range tgt.params {
+			if strings.EqualFold(param, key) {
+				msg += "; did you mean %q?"
+				args = append(args, param)
+				break
+			}
+		}
+		c.pass.Reportf(kv.Key.Pos(), msg, args...)
+	}
+}
+
+// resolve determines the function wrapped by the Function expr evaluates to.
+func (c *checker) resolve(expr ast.Expr, depth int) (target, bool) {
+	if depth > 16 {
+		return target{}, false
+	}
+
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj := c.pass.TypesInfo.ObjectOf(e)
+		value, ok := c.assigned[obj]
+		if !ok || value == nil {
+			return target{}, false
+		}
+		return c.resolve(value, depth+1)
+
+	case *ast.CallExpr:
+		fn := typeutil.StaticCallee(c.pass.TypesInfo, e)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != dwarfreflectPath {
+			return target{}, false
+		}
+		sig := fn.Type().(*types.Signature)
+		switch {
+		case sig.Recv() == nil && fn.Name() == "NewFunction" && len(e.Args) == 1:
+			return c.wrapped(e.Args[0])
+		case sig.Recv() != nil && isNamed(sig.Recv().Type(), "Registry") && fn.Name() == "Register" && len(e.Args) == 2:
+			return c.wrapped(e.Args[1])
+		case sig.Recv() != nil && isFunctionPtr(sig.Recv().Type()) && keyPreserving[fn.Name()]:
+			sel, ok := ast.Unparen(e.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return target{}, false
+			}
+			return c.resolve(sel.X, depth+1)
+		}
+	}
+	return target{}, false
+}
+
+// wrapped returns the target of a function value passed to NewFunction.
+// Only functions and methods declared in source have known names: the
+// parameters of function variables depend on what they hold at run time.
+func (c *checker) wrapped(expr ast.Expr) (target, bool) {
+	var obj types.Object
+	var methodExpr bool
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj = c.pass.TypesInfo.Uses[e]
+	case *ast.SelectorExpr:
+		if sel, ok := c.pass.TypesInfo.Selections[e]; ok {
+			obj, methodExpr = sel.Obj(), sel.Kind() == types.MethodExpr
+		} else {
+			obj = c.pass.TypesInfo.Uses[e.Sel]
+		}
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return target{}, false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	var params []string
+	if methodExpr {
+		params = append(params, sig.Recv().Name())
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		params = append(params, sig.Params().At(i).Name())
+	}
+	for _, param := range params {
+		if param == "" || param == "_" {
+			return target{}, false
+		}
+	}
+	return target{name: fn.Name(), params: params}, true
+}
+
+// isFunctionMethod reports whether sel selects a method of *dwarfreflect.Function.
+func (c *checker) isFunctionMethod(sel *ast.SelectorExpr) bool {
+	selection, ok := c.pass.TypesInfo.Selections[sel]
+	return ok && selection.Kind() == types.MethodVal && isFunctionPtr(selection.Recv())
+}
+
+func isFunctionPtr(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	return ok && isNamed(ptr.Elem(), "Function")
+}
+
+func isNamed(t types.Type, name string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Name() == name && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == dwarfreflectPath
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
@@ -0,0 +1,42 @@
+package a
+
+import (
+	"context"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+func CreateUser(ctx context.Context, userName string, age int) error { return nil }
+
+func Unnamed(string, int) {}
+
+type Server struct{}
+
+func (s *Server) Handle(path string, code int) {}
+
+func calls(ctx context.Context, reg *dwarfreflect.Registry, srv *Server, key string) {
+	fn, _ := dwarfreflect.NewFunction(CreateUser)
+	fn.CallWithMap(map[string]any{"userName": "ada", "age": 36})
+	fn.CallWithMap(map[string]any{"username": "ada", "age": 36}) // want `CallWithMap key "username" is not a parameter of CreateUser \(ctx, userName, age\); did you mean "userName"\?`
+	fn.CallWithMap(map[string]any{"ctx": ctx, "years": 36})      // want `CallWithMap key "years" is not a parameter of CreateUser`
+	fn.CallWithMap(map[string]any{key: "dynamic"})
+	fn.CallInjected(ctx, map[string]any{"agee": 1})                                       // want `CallInjected key "agee"`
+	fn.CallWithMeta(ctx, dwarfreflect.CallMeta{}, map[string]any{"name": ""})             // want `CallWithMeta key "name"`
+	fn.WithParamMeta("age", dwarfreflect.ParamMeta{}).CallWithMap(map[string]any{"a": 1}) // want `CallWithMap key "a"`
+
+	configured := fn.With()
+	configured.CallWithMap(map[string]any{"alias": 1})
+
+	method, _ := dwarfreflect.NewFunction((*Server).Handle)
+	method.CallWithMap(map[string]any{"s": srv, "path": "/", "code": 200, "status": 1}) // want `key "status" is not a parameter of Handle \(s, path, code\)`
+
+	bound, _ := reg.Register("handle", srv.Handle)
+	bound.CallWithMap(map[string]any{"path": "/", "s": srv}) // want `key "s" is not a parameter of Handle \(path, code\)`
+
+	unnamed, _ := dwarfreflect.NewFunction(Unnamed)
+	unnamed.CallWithMap(map[string]any{"p0": ""})
+
+	reassigned, _ := dwarfreflect.NewFunction(CreateUser)
+	reassigned, _ = dwarfreflect.NewFunction(Unnamed)
+	reassigned.CallWithMap(map[string]any{"p0": ""})
+}
@@ -0,0 +1,36 @@
+// Package dwarfreflect is a stub of the API checked by the analyzer.
+package dwarfreflect
+
+import "context"
+
+type Function struct{}
+
+type Registry struct{}
+
+type CallOptions struct{}
+
+type CallMeta struct{}
+
+type ParamMeta struct{}
+
+type Option func(*Function) *Function
+
+func NewFunction(fn any) (*Function, error) { return nil, nil }
+
+func (r *Registry) Register(name string, fn any) (*Function, error) { return nil, nil }
+
+func (t *Function) CallWithMap(argMap map[string]any, opts ...CallOptions) ([]any, error) {
+	return nil, nil
+}
+
+func (t *Function) CallInjected(ctx context.Context, argMap map[string]any, opts ...CallOptions) ([]any, error) {
+	return nil, nil
+}
+
+func (t *Function) CallWithMeta(ctx context.Context, meta CallMeta, argMap map[string]any, opts ...CallOptions) ([]any, error) {
+	return nil, nil
+}
+
+func (t *Function) WithParamMeta(param string, meta ParamMeta) *Function { return t }
+
+func (t *Function) With(opts ...Option) *Function { return t }
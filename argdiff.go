@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ArgChange describes a parameter whose argument differs between two calls.
+type ArgChange struct {
+	Param string
+	Type  reflect.Type
+
+	// Old and New are the bound arguments, typed as the parameter, or nil
+	// when Redacted.
+	Old, New any
+
+	// Redacted marks a parameter whose values must not be logged: those
+	// marked ParamMeta.Sensitive, unsafe ones and those whose names contain
+	// "password", "secret" or "token", ignoring case.
+	Redacted bool
+}
+
+// String formats the change for logs, e.g. cents: 500 -> 700.
+func (c ArgChange) String() string {
+	if c.Redacted {
+		return c.Param + ": <redacted> changed"
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Param, formatDiffValue(c.Old), formatDiffValue(c.New))
+}
+
+// ArgChanges are the changes reported by DiffArgs.
+type ArgChanges []ArgChange
+
+// String formats the changes for logs, separated by semicolons.
+func (c ArgChanges) String() string {
+	parts := make([]string, len(c))
+	for i, change := range c {
+		parts[i] = change.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DiffArgs compares the arguments of two calls of fn, such as an operation
+// re-invoked with modified parameters, returning the parameters whose
+// arguments differ in parameter order, for audit trails. Both argument maps
+// are bound like CallWithMap binds them, with opts, so defaults fill in
+// missing parameters and values are compared as typed by their parameter:
+// as CanonicalArgs encodes them, or with reflect.DeepEqual when they cannot
+// be encoded. context.Context parameters are left out.
+//
+// Example:
+//
+//	changes, err := dwarfreflect.DiffArgs(fn, original, retried)
+//	log.Printf("refund re-run by %s: %v", admin, changes)
+//	// refund re-run by ann: cents: 500 -> 700; reason: "damaged" -> "lost"
+func DiffArgs(fn *Function, before, after map[string]any, opts ...CallOptions) (ArgChanges, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	old, err := fn.bindValues(before, options)
+	if err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	updated, err := fn.bindValues(after, options)
+	if err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+
+	var changes ArgChanges
+	for i, name := range fn.paramNames {
+		typ := fn.paramTypes[i]
+		if typ == contextType || equalArgs(old[i], updated[i]) {
+			continue
+		}
+		change := ArgChange{Param: name, Type: typ}
+		if fn.isRedacted(name) || isUnsafeType(typ) {
+			change.Redacted = true
+		} else {
+			change.Old, change.New = old[i].Interface(), updated[i].Interface()
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// equalArgs reports whether two bound arguments are equal, comparing their
+// canonical encodings (see CanonicalArgs) or, when either cannot be encoded,
+// their values with reflect.DeepEqual.
+func equalArgs(a, b reflect.Value) bool {
+	var bufA, bufB bytes.Buffer
+	if writeCanonical(&bufA, a, 0) == nil && writeCanonical(&bufB, b, 0) == nil {
+		return bytes.Equal(bufA.Bytes(), bufB.Bytes())
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// formatDiffValue formats an argument for ArgChange.String, quoting strings.
+func formatDiffValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
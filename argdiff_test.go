@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func argDiffTestRefund(ctx context.Context, orderID int, cents int, reason string, apiToken string, tags map[string]string) error {
+	return nil
+}
+
+func TestDiffArgs(t *testing.T) {
+	fn := mustNewFunction(t, argDiffTestRefund).WithParamMeta("reason", ParamMeta{Default: "damaged"})
+	before := map[string]any{"orderID": 7, "cents": 500, "apiToken": "t1", "tags": map[string]string{"a": "1", "b": "2"}}
+	after := map[string]any{"ctx": context.TODO(), "orderID": 7, "cents": 700, "reason": "lost", "apiToken": "t2", "tags": map[string]string{"b": "2", "a": "1"}}
+
+	changes, err := DiffArgs(fn, before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %v", changes)
+	}
+	if c := changes[0]; c.Param != "cents" || c.Old != 500 || c.New != 700 || c.Type.Kind().String() != "int" {
+		t.Errorf("unexpected change %+v", c)
+	}
+	if c := changes[2]; c.Param != "apiToken" || !c.Redacted || c.Old != nil || c.New != nil {
+		t.Errorf("expected a redacted token change, got %+v", c)
+	}
+	if want := `cents: 500 -> 700; reason: "damaged" -> "lost"; apiToken: <redacted> changed`; changes.String() != want {
+		t.Errorf("expected %q, got %q", want, changes.String())
+	}
+
+	if changes, err := DiffArgs(fn, before, before); err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes, got %v, %v", changes, err)
+	}
+	if _, err := DiffArgs(fn, before, map[string]any{"orderID": 7}); err == nil || !strings.HasPrefix(err.Error(), "after: missing required parameters") {
+		t.Errorf("expected a binding error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// largeArity is the number of parameters from which a Function indexes its
+// parameter names by name, rather than scanning them.
+const largeArity = 16
+
+// maxListedNames bounds the parameter names listed by binding errors.
+const maxListedNames = 8
+
+// overflowTag marks the field of a struct holding the parameters grouped by
+// StructOptions.MaxFields, whose fields CallWithStruct also looks into.
+const overflowTag = `dwarfreflect:"overflow"`
+
+// overflowFieldName is the name of the overflow field, before collisions.
+const overflowFieldName = "Options"
+
+// newParamIndex returns the position of each of paramNames by name, or nil
+// for functions of fewer than largeArity parameters. The first of duplicate
+// names, such as _, wins.
+func newParamIndex(paramNames []string) map[string]int {
+	if len(paramNames) < largeArity {
+		return nil
+	}
+	index := make(map[string]int, len(paramNames))
+	for i, name := range paramNames {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+// paramPosition returns the position of the parameter named name, or -1.
+func (t *Function) paramPosition(name string) int {
+	if t.paramIndex != nil {
+		if i, ok := t.paramIndex[name]; ok {
+			return i
+		}
+		return -1
+	}
+	return slices.Index(t.paramNames, name)
+}
+
+// hasParam reports whether the function has a parameter named name.
+func (t *Function) hasParam(name string) bool {
+	return t.paramPosition(name) >= 0
+}
+
+// listNames formats names for error messages, listing the first
+// maxListedNames and counting the others: [a b c ... and 12 more].
+func listNames(names []string) string {
+	if len(names) <= maxListedNames {
+		return fmt.Sprint(names)
+	}
+	return fmt.Sprintf("[%s ... and %d more]", strings.Join(names[:maxListedNames], " "), len(names)-maxListedNames)
+}
+
+// groupOverflow moves the fields past the first maxFields-1 of fields into a
+// struct held by an overflow field, tagged json:"options" by default and
+// named Options unless a kept field is. Fields are returned unchanged when
+// they fit.
+func groupOverflow(fields []reflect.StructField, maxFields int) []reflect.StructField {
+	if maxFields <= 0 || len(fields) <= maxFields {
+		return fields
+	}
+	kept := slices.Clone(fields[:maxFields-1])
+	overflow := fields[maxFields-1:]
+
+	name := overflowFieldName
+	for n := 2; slices.ContainsFunc(kept, func(f reflect.StructField) bool { return f.Name == name }); n++ {
+		name = fmt.Sprintf("%s%d", overflowFieldName, n)
+	}
+	return append(kept, reflect.StructField{
+		Name: name,
+		Type: reflect.StructOf(overflow),
+		Tag:  reflect.StructTag(`json:"` + strings.ToLower(name[:1]) + name[1:] + `" ` + overflowTag),
+	})
+}
+
+// isOverflowField reports whether field holds parameters grouped by
+// StructOptions.MaxFields.
+func isOverflowField(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Struct && field.Tag.Get("dwarfreflect") == "overflow"
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testFuncReport has 20 parameters, as report and export entry points
+// accumulating flags over the years do.
+//
+//go:noinline
+func testFuncReport(title string, author string, year int, month int, day int,
+	pageSize int, landscape bool, margin float64, font string, fontSize int,
+	header string, footer string, showPageNumbers bool, toc bool, index bool,
+	language string, draft bool, watermark string, compress bool, copies int,
+) string {
+	return fmt.Sprintf("%s by %s, %d-%02d-%02d, %d copies, %s, draft %v, watermark %q",
+		title, author, year, month, day, copies, language, draft, watermark)
+}
+
+// reportArgs returns an argument for every parameter of testFuncReport.
+func reportArgs() map[string]any {
+	return map[string]any{
+		"title": "Q3", "author": "ann", "year": 2025, "month": 9, "day": 30,
+		"pageSize": 4, "landscape": false, "margin": 1.5, "font": "serif", "fontSize": 11,
+		"header": "h", "footer": "f", "showPageNumbers": true, "toc": true, "index": false,
+		"language": "en", "draft": true, "watermark": "internal", "compress": false, "copies": 3,
+	}
+}
+
+const reportResult = `Q3 by ann, 2025-09-30, 3 copies, en, draft true, watermark "internal"`
+
+func TestLargeArity_ParamIndex(t *testing.T) {
+	fn := mustNewFunction(t, testFuncReport)
+	if len(fn.paramIndex) != 20 {
+		t.Fatalf("expected 20 indexed parameters, got %d", len(fn.paramIndex))
+	}
+	for i, name := range fn.paramNames {
+		if got := fn.paramPosition(name); got != i {
+			t.Errorf("%s: expected position %d, got %d", name, i, got)
+		}
+	}
+	if fn.hasParam("pages") {
+		t.Error("expected no parameter pages")
+	}
+	if small := mustNewFunction(t, testFunc1); small.paramIndex != nil || small.paramPosition("age") != 1 {
+		t.Errorf("expected small functions to scan their names, got %v", small.paramIndex)
+	}
+
+	results, err := fn.CallWithMap(reportArgs())
+	if err != nil || results[0].String() != reportResult {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+}
+
+func TestLargeArity_MissingError(t *testing.T) {
+	fn := mustNewFunction(t, testFuncReport)
+	_, err := fn.CallWithMap(map[string]any{"title": "Q3"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := "missing required parameters [author year month day pageSize landscape margin font ... and 11 more] " +
+		"(function github.com/matteo-grella/dwarfreflect.testFuncReport expects 20: " +
+		"[title author year month day pageSize landscape margin ... and 12 more])"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("expected %q, got %q", want, err)
+	}
+
+	_, err = mustNewFunction(t, testFunc1).CallWithMap(map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "missing required parameters [name age] (function github.com/matteo-grella/dwarfreflect.testFunc1 expects 2: [name age])") {
+		t.Errorf("expected every name listed, got %v", err)
+	}
+}
+
+func TestLargeArity_MaxFields(t *testing.T) {
+	fn := mustNewFunction(t, testFuncReport)
+	opts := StructOptions{MaxFields: 6}
+
+	typ := fn.GetStructTypeWithOptions(opts)
+	if typ.NumField() != 6 {
+		t.Fatalf("expected 6 fields, got %v", typ)
+	}
+	if got := structFieldNames(typ); got != "Title Author Year Month Day Options" {
+		t.Errorf("unexpected fields %s", got)
+	}
+	overflow := typ.Field(5)
+	if overflow.Type.NumField() != 15 || overflow.Tag.Get("json") != "options" {
+		t.Errorf("expected 15 grouped parameters tagged options, got %v %q", overflow.Type, overflow.Tag)
+	}
+
+	// Grouped parameters round-trip through JSON and CallWithStruct
+	params := fn.NewParamsPtr(opts)
+	data := `{"Title":"Q3","Author":"ann","Year":2025,"Month":9,"Day":30,"options":{"Language":"en","Draft":true,"Watermark":"internal","Copies":3}}`
+	if err := json.Unmarshal([]byte(data), params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := fn.CallWithStruct(params)
+	if err != nil || results[0].String() != reportResult {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+
+	// Functions that fit are not grouped
+	if typ := mustNewFunction(t, testFunc1).GetStructTypeWithOptions(opts); typ.NumField() != 2 {
+		t.Errorf("expected no grouping, got %v", typ)
+	}
+}
+
+func TestGroupOverflow(t *testing.T) {
+	fields := []reflect.StructField{
+		{Name: "Options", Type: reflect.TypeFor[string]()},
+		{Name: "B", Type: reflect.TypeFor[int]()},
+		{Name: "C", Type: reflect.TypeFor[int]()},
+	}
+	grouped := groupOverflow(fields, 2)
+	if len(grouped) != 2 || grouped[1].Name != "Options2" || grouped[1].Tag.Get("json") != "options2" || !isOverflowField(grouped[1]) {
+		t.Errorf("expected a renamed overflow field, got %+v", grouped)
+	}
+	if len(fields) != 3 || fields[1].Name != "B" {
+		t.Error("expected the fields to be left unchanged")
+	}
+	if got := groupOverflow(fields, 3); !reflect.DeepEqual(got, fields) {
+		t.Errorf("expected fields that fit unchanged, got %+v", got)
+	}
+
+	// A parameter named options still binds its own field
+	typ := reflect.StructOf(append(grouped[:1:1], reflect.StructField{Name: "Options2", Type: grouped[1].Type, Tag: grouped[1].Tag}))
+	indexes, err := structFieldIndexes(typ, []string{"options", "b", "c"}, []reflect.Type{fields[0].Type, fields[1].Type, fields[2].Type})
+	if err != nil || !reflect.DeepEqual(indexes, [][]int{{0}, {1, 0}, {1, 1}}) {
+		t.Errorf("unexpected indexes %v, %v", indexes, err)
+	}
+}
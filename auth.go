@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrUnauthorized is wrapped by errors of authorizers created with RequireRole.
+var ErrUnauthorized = errors.New("dwarfreflect: unauthorized")
+
+// CallMeta describes a call about to be made, as seen by authorizers and
+// MetaObservers. Adapters and middleware supply the caller, transport and
+// request details with WithCallMeta or CallWithMeta; Function and Args are
+// filled in by the Function.
+type CallMeta struct {
+	// Function is the function being called.
+	Function *Function
+
+	// Args holds the bound non-context arguments by parameter name.
+	Args map[string]any
+
+	// Caller identifies who makes the call, e.g. a user or service name.
+	Caller string
+
+	// Transport names the surface the call arrived through, e.g. "http",
+	// "net/rpc" or "cli".
+	Transport string
+
+	// RequestID correlates the call with the request that caused it.
+	RequestID string
+
+	// Attributes holds any other adapter-level metadata.
+	Attributes map[string]any
+}
+
+// Authorizer decides whether a call may proceed. A non-nil error rejects the
+// call: the function is not invoked and the error is returned to the caller.
+type Authorizer func(ctx context.Context, meta CallMeta) error
+
+// WithAuthorizer returns a copy of the Function that consults authorizer
+// before every call, whichever Call variant or adapter makes it. Authorizers
+// run in the order they were added and all must pass. ctx is the caller's
+// context even for functions without a context.Context parameter; variants
+// without a context argument pass context.Background().
+//
+// Example:
+//
+//	admin := fn.WithAuthorizer(dwarfreflect.RequireRole("admin"))
+//	ctx = dwarfreflect.WithRoles(ctx, claims.Roles...)
+//	results, err := admin.CallWithContext(ctx, userID)
+//	if errors.Is(err, dwarfreflect.ErrUnauthorized) {
+//	    http.Error(w, err.Error(), http.StatusForbidden)
+//	}
+func (t *Function) WithAuthorizer(authorizer Authorizer) *Function {
+	clone := *t
+	clone.authorizers = append(slices.Clip(t.authorizers), authorizer)
+	return &clone
+}
+
+// authorize runs the authorizers against a call with prepared arguments.
+func (t *Function) authorize(ctx context.Context, args []reflect.Value) error {
+	if len(t.authorizers) == 0 {
+		return nil
+	}
+
+	meta := t.callMeta(ctx, args)
+
+	for _, authorize := range t.authorizers {
+		if err := authorize(ctx, meta); err != nil {
+			return fmt.Errorf("call to %s rejected: %w", t.funcName, err)
+		}
+	}
+	return nil
+}
+
+type rolesKey struct{}
+
+// WithRoles returns a context carrying the caller's role claims, as checked by
+// RequireRole. Typically called by authentication middleware.
+//
+// Example:
+//
+//	ctx := dwarfreflect.WithRoles(r.Context(), token.Roles...)
+func WithRoles(ctx context.Context, roles ...string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, slices.Clone(roles))
+}
+
+// Roles returns the role claims carried by ctx.
+func Roles(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// RequireRole returns an Authorizer admitting callers whose context, as set by
+// WithRoles, carries at least one of roles.
+func RequireRole(roles ...string) Authorizer {
+	return func(ctx context.Context, meta CallMeta) error {
+		for _, role := range Roles(ctx) {
+			if slices.Contains(roles, role) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: requires one of roles %v", ErrUnauthorized, roles)
+	}
+}
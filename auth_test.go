@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithAuthorizer_RequireRole(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4).WithAuthorizer(RequireRole("admin", "ops"))
+
+	_, err := fn.CallWithContext(context.Background(), 1, "Alice")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	ctx := WithRoles(context.Background(), "viewer", "ops")
+	results, err := fn.CallWithContext(ctx, 1, "Alice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "id=1, name=Alice" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+
+	// Map-based variants see the context bound to the context parameter
+	if _, err := fn.CallWithMap(map[string]any{"ctx": ctx, "id": 1, "name": "Alice"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := fn.CallWithMap(map[string]any{"ctx": context.Background(), "id": 1, "name": "Alice"}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestWithAuthorizer_CallMeta(t *testing.T) {
+	var seen CallMeta
+	calls := 0
+	base := mustNewFunction(t, testFunc1)
+	fn := base.WithAuthorizer(func(ctx context.Context, meta CallMeta) error {
+		seen = meta
+		if meta.Args["age"].(int) < 18 {
+			return errors.New("minors not allowed")
+		}
+		return nil
+	}).WithAuthorizer(func(ctx context.Context, meta CallMeta) error {
+		calls++
+		return nil
+	})
+
+	if _, err := fn.Call("Bob", 3); err == nil || calls != 0 {
+		t.Errorf("expected the first authorizer to reject the call, got %v (%d later calls)", err, calls)
+	}
+	if seen.Function != fn || seen.Args["name"] != "Bob" {
+		t.Errorf("unexpected call meta: %+v", seen)
+	}
+
+	if _, err := fn.Call("Alice", 30); err != nil || calls != 1 {
+		t.Errorf("expected both authorizers to pass, got %v (%d calls)", err, calls)
+	}
+	if _, err := base.Call("Bob", 3); err != nil {
+		t.Errorf("expected the original function to be unaffected, got %v", err)
+	}
+}
+
+func TestWithAuthorizer_NoContextParameter(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1).WithAuthorizer(RequireRole("admin"))
+
+	if _, err := fn.CallWithContext(WithRoles(context.Background(), "admin"), "Alice", 30); err != nil {
+		t.Errorf("expected roles from the call context, got %v", err)
+	}
+	if _, err := fn.Call("Alice", 30); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build bench
+
+// Package bench runs representative dwarfreflect workloads programmatically,
+// so applications can measure the overhead in their own binaries and releases
+// can be checked for performance regressions against a stored baseline.
+//
+// The package is only built with the bench tag, and like dwarfreflect itself it
+// needs DWARF information in the running binary:
+//
+//	go build -tags bench ./cmd/app
+//	go test -tags bench -ldflags=-w=false ./bench
+//
+// Example:
+//
+//	results, err := bench.Run(bench.Workloads()...)
+//	regressions := bench.Compare(baseline, results, 0.10) // allow 10% slowdown
+package bench
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// CallWithMapOps is the number of CallWithMap calls made per iteration of the
+// CallWithMap workload.
+const CallWithMapOps = 1_000_000
+
+// Workload is a named benchmark body. Ops is the number of operations each
+// iteration performs, used to report per-operation figures; zero means one.
+type Workload struct {
+	Name  string
+	Ops   int
+	Setup func() error // Optional, run once before benchmarking
+	Run   func(b *testing.B)
+}
+
+// Result holds per-operation measurements of a workload.
+type Result struct {
+	Name        string  `json:"name"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+}
+
+// String formats the result like `go test -bench` output.
+func (r Result) String() string {
+	return fmt.Sprintf("%-24s %10d %12.1f ns/op %10.1f B/op %8.2f allocs/op",
+		r.Name, r.Iterations, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+}
+
+// Regression reports a workload slower than its baseline beyond the tolerance.
+type Regression struct {
+	Name     string
+	Baseline Result
+	Current  Result
+	Ratio    float64 // Current.NsPerOp / Baseline.NsPerOp
+}
+
+// Run benchmarks each workload with testing.Benchmark and returns the results
+// in order. It fails if a workload's setup fails.
+func Run(workloads ...Workload) ([]Result, error) {
+	results := make([]Result, 0, len(workloads))
+	for _, w := range workloads {
+		if w.Setup != nil {
+			if err := w.Setup(); err != nil {
+				return nil, fmt.Errorf("workload %s: %w", w.Name, err)
+			}
+		}
+
+		br := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			w.Run(b)
+		})
+
+		ops := float64(max(w.Ops, 1))
+		iterations := float64(max(br.N, 1))
+		results = append(results, Result{
+			Name:        w.Name,
+			Iterations:  br.N,
+			NsPerOp:     float64(br.NsPerOp()) / ops,
+			AllocsPerOp: float64(br.MemAllocs) / iterations / ops,
+			BytesPerOp:  float64(br.MemBytes) / iterations / ops,
+		})
+	}
+	return results, nil
+}
+
+// Compare returns the workloads of current whose time per operation exceeds
+// their baseline by more than tolerance (0.1 = 10%). Workloads missing from
+// baseline are ignored.
+func Compare(baseline, current []Result, tolerance float64) []Regression {
+	byName := make(map[string]Result, len(baseline))
+	for _, r := range baseline {
+		byName[r.Name] = r
+	}
+
+	var regressions []Regression
+	for _, cur := range current {
+		base, ok := byName[cur.Name]
+		if !ok || base.NsPerOp <= 0 {
+			continue
+		}
+		if ratio := cur.NsPerOp / base.NsPerOp; ratio > 1+tolerance {
+			regressions = append(regressions, Regression{Name: cur.Name, Baseline: base, Current: cur, Ratio: ratio})
+		}
+	}
+	return regressions
+}
+
+// Workloads returns the standard workloads: indexing the running binary,
+// CallWithMap calls and struct generation.
+func Workloads() []Workload {
+	execPath, _ := os.Executable()
+	return []Workload{
+		Index(execPath),
+		NewFunction(),
+		CallWithMap(),
+		StructGeneration(),
+	}
+}
+
+// Index measures loading and indexing the DWARF data of the executable at
+// path. Point it at a large binary to measure worst-case startup cost.
+func Index(path string) Workload {
+	return Workload{
+		Name: "Index",
+		Run: func(b *testing.B) {
+			for b.Loop() {
+				if _, err := dwarfreflect.NewDWARFResolver(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		},
+	}
+}
+
+// sample is the function wrapped by the call workloads: primitive arguments,
+// one context and a result, the most common handler shape.
+func sample(ctx context.Context, name string, count int, ratio float64, enabled bool) (string, error) {
+	if !enabled {
+		return "", nil
+	}
+	return name, nil
+}
+
+// NewFunction measures wrapping a function, i.e. the DWARF lookup per function.
+func NewFunction() Workload {
+	return Workload{
+		Name: "NewFunction",
+		Run: func(b *testing.B) {
+			for b.Loop() {
+				if _, err := dwarfreflect.NewFunction(sample); err != nil {
+					b.Fatal(err)
+				}
+			}
+		},
+	}
+}
+
+// CallWithMap measures CallWithMapOps named-argument calls per iteration,
+// reporting figures per call.
+func CallWithMap() Workload {
+	var fn *dwarfreflect.Function
+	args := map[string]any{
+		"ctx":     context.Background(),
+		"name":    "alice",
+		"count":   3,
+		"ratio":   0.5,
+		"enabled": true,
+	}
+
+	return Workload{
+		Name: "CallWithMap",
+		Ops:  CallWithMapOps,
+		Setup: func() (err error) {
+			fn, err = dwarfreflect.NewFunction(sample)
+			return err
+		},
+		Run: func(b *testing.B) {
+			for b.Loop() {
+				for range CallWithMapOps {
+					if _, err := fn.CallWithMap(args); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		},
+	}
+}
+
+// StructGeneration measures building the parameter struct types with custom
+// field names and tags, as adapters do per function.
+func StructGeneration() Workload {
+	var fn *dwarfreflect.Function
+	opts := dwarfreflect.StructOptions{
+		FieldNamer: strings.ToUpper,
+		TagBuilder: func(name string, _ reflect.Type) string { return `json:"` + name + `"` },
+	}
+
+	return Workload{
+		Name: "StructGeneration",
+		Setup: func() (err error) {
+			fn, err = dwarfreflect.NewFunction(sample)
+			return err
+		},
+		Run: func(b *testing.B) {
+			for b.Loop() {
+				_ = fn.NewNonContextParamsPtr(opts)
+			}
+		},
+	}
+}
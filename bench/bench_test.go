@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build bench
+
+package bench
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	results, err := Run(NewFunction(), StructGeneration())
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "NewFunction" || results[1].Name != "StructGeneration" {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	for _, r := range results {
+		if r.Iterations == 0 || r.NsPerOp <= 0 {
+			t.Errorf("expected measurements for %s, got %+v", r.Name, r)
+		}
+	}
+}
+
+func TestRun_SetupError(t *testing.T) {
+	failing := Workload{Name: "failing", Setup: func() error { return errors.New("setup failed") }, Run: func(b *testing.B) {}}
+	if _, err := Run(failing); err == nil || !strings.Contains(err.Error(), "failing") {
+		t.Errorf("expected setup error, got %v", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{{Name: "a", NsPerOp: 100}, {Name: "b", NsPerOp: 100}}
+	current := []Result{{Name: "a", NsPerOp: 105}, {Name: "b", NsPerOp: 150}, {Name: "new", NsPerOp: 1}}
+
+	regressions := Compare(baseline, current, 0.1)
+	if len(regressions) != 1 || regressions[0].Name != "b" || regressions[0].Ratio != 1.5 {
+		t.Errorf("expected only b to regress by 1.5x, got %+v", regressions)
+	}
+}
+
+func BenchmarkWorkloads(b *testing.B) {
+	for _, w := range Workloads() {
+		if w.Setup != nil {
+			if err := w.Setup(); err != nil {
+				b.Skipf("setup %s: %v", w.Name, err)
+			}
+		}
+		b.Run(w.Name, w.Run)
+	}
+}
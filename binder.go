@@ -0,0 +1,332 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// BinderOptions customizes how a Binder turns named input into arguments.
+type BinderOptions struct {
+	CallOptions
+
+	// DisallowUnknown rejects input keys that are not parameter names instead
+	// of ignoring them.
+	DisallowUnknown bool
+}
+
+// Binder converts named input into prepared Args for a Function without
+// calling it, separating validation from invocation. A Binder is safe for
+// concurrent use.
+type Binder struct {
+	function *Function
+	options  BinderOptions
+}
+
+// Args are the validated, non-context arguments of a prepared call. They can
+// be inspected, kept and invoked later, any number of times.
+type Args struct {
+	function *Function
+	values   []any // non-context arguments in parameter order
+}
+
+// Binder returns a Binder for the function.
+//
+// Example:
+//
+//	b := fn.Binder(dwarfreflect.BinderOptions{DisallowUnknown: true})
+//	args, err := b.FromJSON(body) // validate now
+//	results, err := args.Invoke(ctx) // call later
+func (t *Function) Binder(opts ...BinderOptions) *Binder {
+	var options BinderOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return &Binder{function: t, options: options}
+}
+
+// FromMap binds a map of parameter names to values, with the same conversion
+// and default rules as CallWithMap. context.Context parameters need not be
+// present: they receive the context passed to Args.Invoke.
+func (b *Binder) FromMap(argMap map[string]any) (*Args, error) {
+	t := b.function
+	if err := b.checkUnknown(slices.Collect(maps.Keys(argMap))); err != nil {
+		return nil, err
+	}
+
+	// Keep parameters only, so extra keys are ignored as by CallWithMap, and
+	// bind contexts at invocation: placeholders satisfy bindMap
+	contextPositions := t.GetContextPositions()
+	params := make(map[string]any, len(t.paramNames))
+	for i, name := range t.paramNames {
+		if slices.Contains(contextPositions, i) {
+			params[name] = context.Background()
+		} else if arg, ok := argMap[name]; ok {
+			params[name] = arg
+		}
+	}
+	for name := range t.options {
+		if arg, ok := argMap[name]; ok {
+			params[name] = arg
+		}
+	}
+
+	all := make([]any, len(t.paramNames))
+	if err := t.bindMap(params, b.options.CallOptions, nil, nil, all); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(all)-len(contextPositions))
+	for i, arg := range all {
+		if !slices.Contains(contextPositions, i) {
+			values = append(values, arg)
+		}
+	}
+	return &Args{function: t, values: values}, nil
+}
+
+// FromJSON binds a JSON object of named arguments, decoding each value into
+// its parameter type.
+func (b *Binder) FromJSON(data []byte) (*Args, error) {
+	t := b.function
+
+	// Reject deeply nested payloads before decoding them
+	if err := b.options.Limits.checkJSON(t.funcName, data); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("arguments of function %s must be a JSON object: %w", t.funcName, err)
+	}
+	if err := b.checkUnknown(slices.Collect(maps.Keys(raw))); err != nil {
+		return nil, err
+	}
+
+	names, types := t.GetNonContextParameters()
+	argMap := make(map[string]any, len(raw))
+	for i, name := range names {
+		data, present := raw[name]
+		if !present {
+			continue
+		}
+		if isUnsafeType(types[i]) && !b.options.AllowUnsafe {
+			return nil, &UnsafeParameterError{Function: t.funcName, Param: name, Type: types[i]}
+		}
+
+		v := reflect.New(types[i])
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		argMap[name] = v.Elem().Interface()
+	}
+	for name := range t.options {
+		if data, present := raw[name]; present {
+			v := reflect.New(t.optionArgType(name))
+			if err := json.Unmarshal(data, v.Interface()); err != nil {
+				return nil, fmt.Errorf("option %q: %w", name, err)
+			}
+			argMap[name] = v.Elem().Interface()
+		}
+	}
+
+	return b.FromMap(argMap)
+}
+
+// FromValues binds submitted values such as url.Values, with the same rules as
+// CallWithForm: absent checkboxes bind false, absent optional parameters bind
+// their default or zero value.
+func (b *Binder) FromValues(values map[string][]string) (*Args, error) {
+	t := b.function
+	if err := b.checkUnknown(slices.Collect(maps.Keys(values))); err != nil {
+		return nil, err
+	}
+
+	names, types := t.GetNonContextParameters()
+	argMap := make(map[string]any, len(names))
+	for i, name := range names {
+		if isUnsafeType(types[i]) && !b.options.AllowUnsafe {
+			return nil, &UnsafeParameterError{Function: t.funcName, Param: name, Type: types[i]}
+		}
+
+		submitted, present := values[name]
+		if !present || len(submitted) == 0 {
+			arg, err := t.absentFormValue(name, types[i])
+			if err != nil {
+				return nil, err
+			}
+			argMap[name] = arg
+			continue
+		}
+
+		v, err := parseFormValues(submitted, types[i])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		argMap[name] = v.Interface()
+	}
+
+	return b.FromMap(argMap)
+}
+
+// checkUnknown enforces BinderOptions.DisallowUnknown on the input keys.
+func (b *Binder) checkUnknown(keys []string) error {
+	if !b.options.DisallowUnknown {
+		return nil
+	}
+
+	names, _ := b.function.GetNonContextParameters()
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	for name := range b.function.options {
+		known[name] = true
+	}
+
+	var unknown []string
+	for _, key := range keys {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown parameters %v (function %s expects %v)", unknown, b.function.funcName, names)
+	}
+	return nil
+}
+
+// Function returns the function the arguments were bound for.
+func (a *Args) Function() *Function {
+	return a.function
+}
+
+// Get returns the bound value of the named non-context parameter.
+func (a *Args) Get(name string) (any, bool) {
+	names, _ := a.function.GetNonContextParameters()
+	for i, paramName := range names {
+		if paramName == name {
+			return a.values[i], true
+		}
+	}
+	return nil, false
+}
+
+// Map returns the bound non-context arguments keyed by parameter name.
+func (a *Args) Map() map[string]any {
+	names, _ := a.function.GetNonContextParameters()
+	argMap := make(map[string]any, len(names))
+	for i, name := range names {
+		argMap[name] = a.values[i]
+	}
+	return argMap
+}
+
+// Invoke calls the function with the bound arguments, injecting ctx into
+// context.Context parameters.
+//
+// Example:
+//
+//	results, err := args.Invoke(ctx)
+func (a *Args) Invoke(ctx context.Context) ([]reflect.Value, error) {
+	return a.function.CallWithContext(ctx, a.values...)
+}
+
+// PreparedCall is the persisted form of Args produced by Args.Marshal.
+// Workers can decode it to route a job to its function before calling
+// UnmarshalArgs.
+type PreparedCall struct {
+	// Function is the full runtime name of the function.
+	Function string `json:"function"`
+
+	// Signature is the named non-context signature the arguments were bound
+	// for, e.g. "func(id int, name string) (string, error)".
+	Signature string `json:"signature"`
+
+	// Args are the named non-context arguments as a JSON object.
+	Args json.RawMessage `json:"args"`
+}
+
+// Marshal encodes the prepared call as JSON: the function identity and
+// signature together with the named arguments, so it can be persisted to a
+// durable queue and executed later with UnmarshalArgs. Arguments must be JSON
+// encodable.
+//
+// Example:
+//
+//	data, err := args.Marshal()
+//	queue.Push(data)
+func (a *Args) Marshal() ([]byte, error) {
+	encoded, err := json.Marshal(a.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments of function %s: %w", a.function.funcName, err)
+	}
+	return json.Marshal(PreparedCall{
+		Function:  a.function.funcName,
+		Signature: a.function.Signature(),
+		Args:      encoded,
+	})
+}
+
+// UnmarshalArgs decodes a call prepared with Args.Marshal into Args for fn,
+// validating them again. It fails if the call was prepared for another
+// function or if the function's signature changed since.
+//
+// Example:
+//
+//	data := queue.Pop()
+//	args, err := dwarfreflect.UnmarshalArgs(fn, data)
+//	results, err := args.Invoke(ctx)
+func UnmarshalArgs(fn *Function, data []byte, opts ...BinderOptions) (*Args, error) {
+	var call PreparedCall
+	if err := json.Unmarshal(data, &call); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prepared call: %w", err)
+	}
+	if call.Function != fn.funcName {
+		return nil, fmt.Errorf("prepared call is for function %s, not %s", call.Function, fn.funcName)
+	}
+	if signature := fn.Signature(); call.Signature != signature {
+		return nil, fmt.Errorf("prepared call signature %q of function %s does not match %q", call.Signature, fn.funcName, signature)
+	}
+
+	return fn.Binder(opts...).FromJSON(call.Args)
+}
+
+// Signature renders the named non-context signature of the function, the
+// contract checked by UnmarshalArgs and hashed by ID.
+//
+// Example:
+//
+//	func GetUser(ctx context.Context, id int, name string) (string, error)
+//	fn.Signature() // "func(id int, name string) (string, error)"
+func (t *Function) Signature() string {
+	names, types := t.GetNonContextParameters()
+	params := make([]string, len(names))
+	for i, name := range names {
+		params[i] = name + " " + types[i].String()
+	}
+
+	results := make([]string, t.functionType.NumOut())
+	for i := range results {
+		results[i] = t.functionType.Out(i).String()
+	}
+
+	signature := "func(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return signature
+	case 1:
+		return signature + " " + results[0]
+	default:
+		return signature + " (" + strings.Join(results, ", ") + ")"
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBinder_FromMap(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+
+	args, err := fn.Binder().FromMap(map[string]any{"id": 7, "name": "Alice", "extra": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args.Function() != fn {
+		t.Error("expected args to reference the function")
+	}
+	if v, ok := args.Get("id"); !ok || v != 7 {
+		t.Errorf("expected id 7, got %v (%v)", v, ok)
+	}
+	if _, ok := args.Get("ctx"); ok {
+		t.Error("context parameters must not be bound")
+	}
+	if !reflect.DeepEqual(args.Map(), map[string]any{"id": 7, "name": "Alice"}) {
+		t.Errorf("unexpected map: %v", args.Map())
+	}
+
+	// Prepared arguments can be invoked any number of times
+	for range 2 {
+		results, err := args.Invoke(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].String() != "id=7, name=Alice" {
+			t.Errorf("unexpected result: %s", results[0].String())
+		}
+	}
+}
+
+func TestBinder_FromMapErrors(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+
+	if _, err := fn.Binder().FromMap(map[string]any{"id": 7}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected missing parameter error, got %v", err)
+	}
+	if _, err := fn.Binder().FromMap(map[string]any{"id": "x", "name": "Alice"}); err == nil {
+		t.Error("expected type error")
+	}
+
+	strict := fn.Binder(BinderOptions{DisallowUnknown: true})
+	if _, err := strict.FromMap(map[string]any{"id": 7, "name": "Alice", "extra": true}); err == nil || !strings.Contains(err.Error(), "extra") {
+		t.Errorf("expected unknown parameter error, got %v", err)
+	}
+}
+
+func TestBinder_FromJSON(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+
+	args, err := fn.Binder().FromJSON([]byte(`{"name":"Bob","active":true,"scores":[1,2]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := args.Get("scores"); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Errorf("unexpected scores: %v", v)
+	}
+
+	if _, err := fn.Binder().FromJSON([]byte(`{"name":1,"active":true,"scores":[]}`)); err == nil || !strings.Contains(err.Error(), `"name"`) {
+		t.Errorf("expected decoding error for name, got %v", err)
+	}
+	if _, err := fn.Binder().FromJSON([]byte(`[1]`)); err == nil {
+		t.Error("expected error for non-object payload")
+	}
+	strict := fn.Binder(BinderOptions{DisallowUnknown: true})
+	if _, err := strict.FromJSON([]byte(`{"name":"Bob","active":true,"scores":[],"x":1}`)); err == nil {
+		t.Error("expected unknown parameter error")
+	}
+}
+
+func TestBinder_FromValues(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+
+	args, err := fn.Binder().FromValues(map[string][]string{"name": {"Carol"}, "scores": {"3", "4"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]any{"name": "Carol", "active": false, "scores": []int{3, 4}}
+	if !reflect.DeepEqual(args.Map(), expected) {
+		t.Errorf("expected %v, got %v", expected, args.Map())
+	}
+
+	if _, err := fn.Binder().FromValues(map[string][]string{"active": {"on"}}); err == nil {
+		t.Error("expected missing name error")
+	}
+}
+
+func TestArgs_MarshalRoundTrip(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+
+	args, err := fn.Binder().FromMap(map[string]any{"id": 7, "name": "Alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := args.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var call PreparedCall
+	if err := json.Unmarshal(data, &call); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if call.Function != fn.GetFunctionName() {
+		t.Errorf("unexpected function %q", call.Function)
+	}
+	if call.Signature != "func(id int, name string) (string, error)" {
+		t.Errorf("unexpected signature %q", call.Signature)
+	}
+
+	restored, err := UnmarshalArgs(fn, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := restored.Invoke(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "id=7, name=Alice" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestUnmarshalArgs_Mismatch(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+	other := mustNewFunction(t, testFunc1)
+
+	args, err := other.Binder().FromMap(map[string]any{"name": "Bob", "age": 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := args.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := UnmarshalArgs(fn, data); err == nil || !strings.Contains(err.Error(), "not") {
+		t.Errorf("expected function mismatch error, got %v", err)
+	}
+
+	var call PreparedCall
+	json.Unmarshal(data, &call)
+	call.Signature = "func(name string) string"
+	stale, _ := json.Marshal(call)
+	if _, err := UnmarshalArgs(other, stale); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("expected signature mismatch error, got %v", err)
+	}
+	if _, err := UnmarshalArgs(other, []byte("garbage")); err == nil {
+		t.Error("expected decoding error")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Call variants while a Function's circuit breaker is open.
+var ErrCircuitOpen = errors.New("dwarfreflect: circuit open")
+
+// errPanicked records a panicking call as a failure in the circuit breaker.
+var errPanicked = errors.New("dwarfreflect: call panicked")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects calls with ErrCircuitOpen until the cooldown elapses.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial call through after the cooldown.
+	CircuitHalfOpen
+)
+
+// String returns the lowercase name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker short-circuits calls after a run of consecutive errors.
+// After Threshold consecutive calls return a non-nil trailing error, the
+// circuit opens and calls fail fast with ErrCircuitOpen for Cooldown. Then a
+// single trial call is let through: success closes the circuit, failure opens
+// it again. A CircuitBreaker is safe for concurrent use and may be shared by
+// several functions to trip them together.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker opening after threshold
+// consecutive errors and staying open for cooldown.
+//
+// Example:
+//
+//	guarded := fn.WithCircuitBreaker(dwarfreflect.NewCircuitBreaker(5, 30*time.Second))
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Reset closes the circuit and clears the failure count.
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.state = CircuitClosed
+	cb.failures = 0
+}
+
+// allow reports whether a call may proceed, moving an open circuit whose
+// cooldown has elapsed to half-open for a single trial call.
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		return nil
+	case CircuitHalfOpen:
+		// A trial call is already in flight
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit with the outcome of a call.
+func (cb *CircuitBreaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err == nil {
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// WithCircuitBreaker returns a copy of the Function guarded by cb. While the
+// circuit is open, Call variants return ErrCircuitOpen without invoking the
+// function. A call counts as failed when its trailing error result is non-nil,
+// after any retries configured with WithPolicy. A panicking call also counts
+// as failed before the panic propagates.
+//
+// Example:
+//
+//	guarded := fn.WithCircuitBreaker(dwarfreflect.NewCircuitBreaker(5, 30*time.Second))
+//	if _, err := guarded.CallWithMap(args); errors.Is(err, dwarfreflect.ErrCircuitOpen) {
+//	    // fail fast
+//	}
+func (t *Function) WithCircuitBreaker(cb *CircuitBreaker) *Function {
+	clone := *t
+	clone.breaker = cb
+	return &clone
+}
+
+// GetCircuitBreaker returns the CircuitBreaker set with WithCircuitBreaker, or nil.
+func (t *Function) GetCircuitBreaker() *CircuitBreaker {
+	return t.breaker
+}
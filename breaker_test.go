@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	f := &flaky{failures: 2, err: errTransient}
+	fn := mustNewFunction(t, f.Fetch).WithCircuitBreaker(cb)
+
+	for i := 0; i < 2; i++ {
+		if _, err := fn.Call(1); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected open circuit, got %s", cb.State())
+	}
+
+	if _, err := fn.Call(1); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if f.calls != 2 {
+		t.Errorf("expected open circuit to skip the call, got %d calls", f.calls)
+	}
+
+	now = now.Add(time.Minute)
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("expected half-open circuit, got %s", cb.State())
+	}
+	results, err := fn.Call(1)
+	if err != nil || results[0].String() != "ok" {
+		t.Fatalf("expected trial call to succeed, got %v, %v", results, err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected closed circuit after success, got %s", cb.State())
+	}
+}
+
+func TestWithCircuitBreaker_HalfOpenFailure(t *testing.T) {
+	now := time.Unix(0, 0)
+	cb := NewCircuitBreaker(1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	fn := mustNewFunction(t, (&flaky{failures: 10, err: errTransient}).Fetch).WithCircuitBreaker(cb)
+
+	fn.Call(1)
+	now = now.Add(time.Second)
+	fn.Call(1)
+	if cb.State() != CircuitOpen {
+		t.Errorf("expected failed trial to reopen the circuit, got %s", cb.State())
+	}
+
+	cb.Reset()
+	if cb.State() != CircuitClosed {
+		t.Errorf("expected closed circuit after reset, got %s", cb.State())
+	}
+}
+
+func TestWithCircuitBreaker_Panic(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute)
+	fn := mustNewFunction(t, func(x int) int { panic("boom") }).WithCircuitBreaker(cb)
+
+	func() {
+		defer func() { recover() }()
+		fn.Call(1)
+	}()
+	if cb.State() != CircuitOpen {
+		t.Errorf("expected panic to trip the circuit, got %s", cb.State())
+	}
+}
+
+func TestWithCircuitBreaker_DoesNotMutateOriginal(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	if fn.WithCircuitBreaker(NewCircuitBreaker(1, time.Second)).GetCircuitBreaker() == nil {
+		t.Error("expected breaker on copy")
+	}
+	if fn.GetCircuitBreaker() != nil {
+		t.Error("expected original to have no breaker")
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"debug/elf"
+	"debug/pe"
+	"fmt"
+	"go/version"
+	"io"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// BuildReport describes the debug information of the running binary, from
+// its build settings and its sections, without loading DWARF.
+type BuildReport struct {
+	// GoVersion is the toolchain that built the binary, e.g. go1.24.3.
+	GoVersion string
+
+	// LDFlags is the -ldflags build setting, empty if none was recorded.
+	LDFlags string
+
+	// StripsDWARF reports linker flags that omit DWARF: -w, or -s since Go
+	// 1.22 unless -w=false is given.
+	StripsDWARF bool
+
+	// StripsSymbols reports the -s linker flag, omitting the symbol table.
+	StripsSymbols bool
+
+	// Executable is the binary checked for debug sections.
+	Executable string
+
+	// Format is the executable format of the binary.
+	Format ExecutableFormat
+
+	// DebugSections reports a .debug_info section (__debug_info on
+	// Mach-O) in the binary.
+	DebugSections bool
+
+	// Err tells why the binary itself could not be checked, in which case
+	// HasDWARF relies on the build settings alone.
+	Err error
+}
+
+// HasDWARF reports whether the binary carries DWARF: its debug sections
+// when they could be checked, otherwise the absence of stripping linker
+// flags. Test binaries built by go test without -ldflags=-w=false omit DWARF
+// without recording a flag, so only the section check detects them.
+func (r BuildReport) HasDWARF() bool {
+	if r.Err == nil {
+		return r.DebugSections
+	}
+	return !r.StripsDWARF
+}
+
+// String summarizes the report for logs and error messages.
+func (r BuildReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DWARF: %v", r.HasDWARF())
+	if r.GoVersion != "" {
+		fmt.Fprintf(&b, ", built with %s", r.GoVersion)
+	}
+	if r.LDFlags != "" {
+		fmt.Fprintf(&b, ", -ldflags=%q", r.LDFlags)
+	}
+	if r.StripsDWARF {
+		b.WriteString(" (omits DWARF; rebuild without -w and -s)")
+	}
+	if r.Err != nil {
+		fmt.Fprintf(&b, ", binary not checked: %v", r.Err)
+	} else {
+		fmt.Fprintf(&b, ", %s binary %s", r.Format, r.Executable)
+	}
+	return b.String()
+}
+
+// ReadBuildReport inspects the build settings and the sections of the
+// running binary, a cheap check that loads no DWARF, so applications can
+// refuse to start, or fall back to NewFunctionFromSignature, when built
+// without debug information rather than failing on the first NewFunction.
+//
+// Example:
+//
+//	if report := dwarfreflect.ReadBuildReport(); !report.HasDWARF() {
+//	    log.Fatalf("built without debug information: %v", report)
+//	}
+func ReadBuildReport() BuildReport {
+	var report BuildReport
+	if info, ok := debug.ReadBuildInfo(); ok {
+		report.GoVersion = info.GoVersion
+		for _, setting := range info.Settings {
+			if setting.Key == "-ldflags" {
+				report.LDFlags = setting.Value
+			}
+		}
+		report.StripsDWARF, report.StripsSymbols = linkerStrips(report.LDFlags, report.GoVersion)
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	report.Executable = path
+	file, err := openExecutable(path)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	defer file.Close()
+	report.Format, report.DebugSections, report.Err = debugSections(file)
+	return report
+}
+
+// BuildHasDWARF reports whether the running binary carries DWARF, as
+// ReadBuildReport().HasDWARF() does.
+//
+// Example:
+//
+//	if !dwarfreflect.BuildHasDWARF() {
+//	    log.Fatal("rebuild without -ldflags=-w: dwarfreflect needs DWARF")
+//	}
+func BuildHasDWARF() bool {
+	return ReadBuildReport().HasDWARF()
+}
+
+// linkerStrips reports whether ldflags omit DWARF and the symbol table. The
+// linker accepts -w, --w, -w=true and the like; since Go 1.22, -s implies -w.
+func linkerStrips(ldflags, goVersion string) (dwarf, symbols bool) {
+	var w *bool
+	for _, arg := range strings.Fields(ldflags) {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "w" && name != "s") {
+			continue
+		}
+		enabled := true
+		if hasValue {
+			enabled, _ = strconv.ParseBool(value)
+		}
+		if name == "w" {
+			w = &enabled
+		} else {
+			symbols = enabled
+		}
+	}
+
+	if w != nil {
+		return *w, symbols
+	}
+	return symbols && (goVersion == "" || version.Compare(version.Lang(goVersion), "go1.22") >= 0), symbols
+}
+
+// debugSections detects the format of an executable and reports whether it
+// has a DWARF info section.
+func debugSections(ra io.ReaderAt) (ExecutableFormat, bool, error) {
+	format, err := DetectExecutableFormatFromReader(ra)
+	if err != nil {
+		return FormatUnknown, false, err
+	}
+
+	switch format {
+	case FormatELF:
+		f, err := elf.NewFile(ra)
+		if err != nil {
+			return format, false, err
+		}
+		return format, f.Section(".debug_info") != nil || f.Section(".zdebug_info") != nil, nil
+	case FormatPE:
+		f, err := pe.NewFile(ra)
+		if err != nil {
+			return format, false, err
+		}
+		return format, f.Section(".debug_info") != nil || f.Section(".zdebug_info") != nil, nil
+	case FormatMachO:
+		f, err := openMachO(ra)
+		if err != nil {
+			return format, false, err
+		}
+		return format, f.Section("__debug_info") != nil || f.Section("__zdebug_info") != nil, nil
+	default:
+		return format, false, fmt.Errorf("unsupported executable format: %v", format)
+	}
+}
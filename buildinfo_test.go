@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkerStrips(t *testing.T) {
+	tests := []struct {
+		ldflags, goVersion string
+		dwarf, symbols     bool
+	}{
+		{"", "go1.24.3", false, false},
+		{"-X main.version=1.0", "go1.24.3", false, false},
+		{"-w", "go1.24.3", true, false},
+		{"--w=true -X main.v=1", "go1.24.3", true, false},
+		{"-s", "go1.24.3", true, true},
+		{"-s", "go1.21.0", false, true},
+		{"-s -w=false", "go1.24.3", false, true},
+		{"-w -w=0", "go1.24.3", false, false},
+	}
+	for _, tt := range tests {
+		dwarf, symbols := linkerStrips(tt.ldflags, tt.goVersion)
+		if dwarf != tt.dwarf || symbols != tt.symbols {
+			t.Errorf("linkerStrips(%q, %s) = %v, %v; want %v, %v", tt.ldflags, tt.goVersion, dwarf, symbols, tt.dwarf, tt.symbols)
+		}
+	}
+}
+
+func TestDebugSections(t *testing.T) {
+	for _, name := range []string{"greet.elf", "greet.exe", "greet.macho", "greet.fat"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "fixtures", name))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, found, err := debugSections(bytes.NewReader(data)); err != nil || !found {
+			t.Errorf("%s: expected debug sections, got %v, %v", name, found, err)
+		}
+	}
+
+	if _, _, err := debugSections(bytes.NewReader([]byte("not a binary"))); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestReadBuildReport(t *testing.T) {
+	report := ReadBuildReport()
+	if report.Err != nil {
+		t.Skipf("binary not checked: %v", report.Err)
+	}
+
+	available, _, _ := GetDWARFStatus()
+	if report.HasDWARF() != available || BuildHasDWARF() != available {
+		t.Errorf("report %v disagrees with the resolver, which found DWARF: %v", report, available)
+	}
+	if report.GoVersion == "" {
+		t.Error("expected the Go version from the build info")
+	}
+}
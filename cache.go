@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"debug/elf"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Cache stores the parameter and result names of functions across processes,
+// set with InitOptions.Cache. Short-lived processes, such as serverless
+// instances, then skip loading and indexing DWARF at cold start when every
+// function they wrap is in the cache. Implementations backed by Redis or
+// memcached may encode entries as JSON; they treat failures as misses, and
+// must be safe for concurrent use.
+//
+// Keys name the function, its type and the build of the binary, from its Go
+// build ID, which changes with its code and build flags. Binaries linked with
+// an empty build ID (-ldflags=-buildid=) are only told apart by their VCS
+// revision: scope the cache of such builds, e.g. by prefixing keys with a
+// deploy ID.
+type Cache interface {
+	// Get returns the entry stored under key, and reports false on a miss.
+	Get(key string) (CacheEntry, bool)
+
+	// Set stores entry under key.
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is what a Cache stores of a function: the names resolved from
+// DWARF.
+type CacheEntry struct {
+	Params   []string `json:"params"`
+	Results  []string `json:"results"`
+	DWARFKey string   `json:"dwarfKey,omitempty"` // matched DWARF entry name
+}
+
+// deferredLoadState holds the DWARF load the global resolver defers when it
+// has a Cache, until a function misses it.
+type deferredLoadState struct {
+	once    sync.Once
+	pending bool
+	path    string
+	cache   Cache
+}
+
+// buildID identifies the build of the running binary in cache keys: by its
+// Go build ID, a hash of its code and build flags, and by its module and VCS
+// revision, which identify binaries built with an empty build ID.
+var buildID = sync.OnceValue(func() string {
+	var id []string
+	if info, ok := debug.ReadBuildInfo(); ok {
+		id = append(id, info.Main.Path, info.Main.Version)
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				id = append(id, setting.Value)
+			}
+		}
+	}
+	if goID := executableBuildID(globalResolver.deferred.path); goID != "" {
+		id = append(id, goID)
+	}
+	if len(id) == 0 {
+		return "unknown"
+	}
+	return strings.Join(id, "@")
+})
+
+// goBuildIDPrefix starts the Go build ID the linker writes at the start of
+// the text of the binaries it does not store it in an ELF note for.
+const goBuildIDPrefix = "\xff Go build ID: \""
+
+// executableBuildID returns the Go build ID of the running binary, or of the
+// binary at explicitPath when it is set, or "" when it cannot be read. Only
+// the headers and first bytes of the binary are read, not its DWARF.
+func executableBuildID(explicitPath string) string {
+	path := explicitPath
+	if path == "" {
+		var err error
+		if path, err = os.Executable(); err != nil {
+			return ""
+		}
+	}
+	file, err := openExecutable(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	if binary, err := elf.NewFile(file); err == nil {
+		// The note holds the name size, description size and type, then the
+		// name "Go\x00\x00" and the build ID as description
+		note := binary.Section(".note.go.buildid")
+		if note == nil {
+			return ""
+		}
+		data, err := note.Data()
+		if err != nil || len(data) < 16 {
+			return ""
+		}
+		size := binary.ByteOrder.Uint32(data[4:8])
+		if uint64(size) > uint64(len(data)-16) {
+			return ""
+		}
+		return string(data[16 : 16+size])
+	}
+
+	head := make([]byte, 32*1024)
+	n, _ := file.ReadAt(head, 0)
+	head = head[:n]
+	start := bytes.Index(head, []byte(goBuildIDPrefix))
+	if start < 0 {
+		return ""
+	}
+	head = head[start+len(goBuildIDPrefix):]
+	end := bytes.IndexByte(head, '"')
+	if end < 0 {
+		return ""
+	}
+	return string(head[:end])
+}
+
+// cacheKey returns the key of the function funcName of type fnType. The type
+// tells apart generic instantiations sharing code.
+func cacheKey(funcName string, fnType reflect.Type) string {
+	return "dwarfreflect:" + buildID() + ":" + funcName + ":" + fnType.String()
+}
+
+// cachedNames returns the names of funcName stored in the cache of the global
+// resolver, if it has one. Entries that do not fit fnType are ignored.
+func cachedNames(funcName string, fnType reflect.Type) (CacheEntry, bool) {
+	cache := globalResolver.deferred.cache
+	if cache == nil {
+		return CacheEntry{}, false
+	}
+	entry, ok := cache.Get(cacheKey(funcName, fnType))
+	if !ok || len(entry.Params) != fnType.NumIn() || len(entry.Results) != fnType.NumOut() {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// storeNames stores the names of funcName resolved from DWARF in the cache of
+// the global resolver, if it has one.
+func storeNames(funcName string, fnType reflect.Type, entry CacheEntry) {
+	if cache := globalResolver.deferred.cache; cache != nil {
+		cache.Set(cacheKey(funcName, fnType), entry)
+	}
+}
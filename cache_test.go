@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//go:noinline
+func cacheTestHandler(accountName string, limit int) (balance float64, err error) {
+	return float64(limit) * float64(len(accountName)), nil
+}
+
+// mapCache is a Cache held in memory, as one shared by processes would be.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+func (c *mapCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *mapCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// initCachedResolver reinitializes the global resolver with cache, as a new
+// process would, until the end of the test.
+func initCachedResolver(t *testing.T, cache Cache) {
+	t.Helper()
+	resolverOnce.Do(initResolver) // or it would replace the resolver
+	initResolverWith(InitOptions{Cache: cache})
+	resetFunctionInfos()
+	t.Cleanup(func() {
+		initResolver()
+		resetFunctionInfos()
+	})
+}
+
+func TestCache(t *testing.T) {
+	cache := &mapCache{entries: make(map[string]CacheEntry)}
+
+	// A cold cache loads DWARF on the first lookup and fills the cache
+	initCachedResolver(t, cache)
+	if globalResolver.dwarfData != nil {
+		t.Fatal("expected DWARF loading to be deferred")
+	}
+	fn := mustNewFunction(t, cacheTestHandler)
+	if globalResolver.dwarfData == nil {
+		t.Error("expected DWARF to be loaded on a cache miss")
+	}
+	key := cacheKey("github.com/matteo-grella/dwarfreflect.cacheTestHandler", reflect.TypeOf(cacheTestHandler))
+	want := CacheEntry{
+		Params:   []string{"accountName", "limit"},
+		Results:  []string{"balance", "err"},
+		DWARFKey: "github.com/matteo-grella/dwarfreflect.cacheTestHandler",
+	}
+	if got := cache.entries[key]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v cached, got %+v", want, got)
+	}
+	if fn.Provenance().Source != SourceExactMatch {
+		t.Errorf("expected an exact match, got %v", fn.Provenance())
+	}
+
+	// A new process finds the function in the cache without loading DWARF
+	initCachedResolver(t, cache)
+	fn = mustNewFunction(t, cacheTestHandler)
+	if globalResolver.dwarfData != nil {
+		t.Error("expected no DWARF loading on a cache hit")
+	}
+	if got := fn.Provenance(); got.Source != SourceCache || got.DWARFKey != want.DWARFKey {
+		t.Errorf("expected cache provenance, got %v", got)
+	}
+	if names, _ := fn.GetParameterInfo(); !reflect.DeepEqual(names, want.Params) {
+		t.Errorf("expected %v, got %v", want.Params, names)
+	}
+	if got := fn.GetResultNames(); !reflect.DeepEqual(got, want.Results) {
+		t.Errorf("expected results %v, got %v", want.Results, got)
+	}
+	results, err := fn.CallWithMap(map[string]any{"accountName": "abc", "limit": 2})
+	if err != nil || results[0].Float() != 6 {
+		t.Errorf("unexpected call results %v, %v", results, err)
+	}
+
+	// Other lookups load DWARF
+	if _, err := GetIndexStats(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if globalResolver.dwarfData == nil {
+		t.Error("expected DWARF to be loaded by GetIndexStats")
+	}
+}
+
+func TestCache_MismatchedEntry(t *testing.T) {
+	cache := &mapCache{entries: make(map[string]CacheEntry)}
+	key := cacheKey("github.com/matteo-grella/dwarfreflect.cacheTestHandler", reflect.TypeOf(cacheTestHandler))
+	cache.entries[key] = CacheEntry{Params: []string{"stale"}, Results: []string{"r0"}}
+
+	initCachedResolver(t, cache)
+	fn := mustNewFunction(t, cacheTestHandler)
+	if names, _ := fn.GetParameterInfo(); !reflect.DeepEqual(names, []string{"accountName", "limit"}) {
+		t.Errorf("expected the stale entry to be ignored, got %v", names)
+	}
+	if got := cache.entries[key].Params; !reflect.DeepEqual(got, []string{"accountName", "limit"}) {
+		t.Errorf("expected the entry to be replaced, got %v", got)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	key := cacheKey("main.Handle", reflect.TypeOf(cacheTestHandler))
+	if !strings.HasPrefix(key, "dwarfreflect:"+buildID()+":") || !strings.HasSuffix(key, ":main.Handle:func(string, int) (float64, error)") {
+		t.Errorf("unexpected key %q", key)
+	}
+}
+
+func TestExecutableBuildID(t *testing.T) {
+	if id := executableBuildID(""); strings.Count(id, "/") < 1 || !strings.Contains(buildID(), id) {
+		t.Errorf("expected the Go build ID of the test binary in %q, got %q", buildID(), id)
+	}
+
+	// Non-ELF binaries carry it at the start of their text
+	path := filepath.Join(t.TempDir(), "app.exe")
+	if err := os.WriteFile(path, []byte("MZ\x00\x00\xff Go build ID: \"abc/def\"\n \xff"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if id := executableBuildID(path); id != "abc/def" {
+		t.Errorf("expected abc/def, got %q", id)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxCanonicalDepth bounds the nesting of canonicalized values, which also
+// stops cyclic pointers.
+const maxCanonicalDepth = 64
+
+// CanonicalArgs returns a deterministic serialization of named arguments,
+// for cache keys, deduplication and idempotency keys: equal arguments always
+// serialize to the same bytes, whatever the order or representation they
+// were supplied in.
+//
+// Arguments are first bound like CallWithMap binds them, with opts, so that
+// defaults fill in missing parameters and values take the type of their
+// parameter: with CallOptions.AllowConvert, int64(42) and 42 for an int
+// parameter are the same. The result is a JSON object of the non-context
+// parameters with keys sorted. Maps are written with sorted keys, values held
+// by interfaces as {"type":…,"value":…} so that int 1 and float64 1 differ,
+// types implementing json.Marshaler or encoding.TextMarshaler (such as
+// time.Time) as they marshal, and NaN and infinities as strings. Channels,
+// functions and unsafe pointers cannot be serialized and fail.
+//
+// Example:
+//
+//	key, err := fn.CanonicalArgs(map[string]any{"name": "ann", "age": 30})
+//	// {"age":30,"name":"ann"}
+func (t *Function) CanonicalArgs(argMap map[string]any, opts ...CallOptions) ([]byte, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	values, err := t.bindValues(argMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var bound []reflect.Value
+	for i, value := range values {
+		if t.paramTypes[i] != contextType {
+			names, bound = append(names, t.paramNames[i]), append(bound, value)
+		}
+	}
+	return t.canonicalValues(names, bound)
+}
+
+// bindValues binds argMap like CallWithMap, without requiring context.Context
+// parameters, which are injected at call time rather than part of the
+// arguments: they bind context.Background() when absent.
+func (t *Function) bindValues(argMap map[string]any, options CallOptions) ([]reflect.Value, error) {
+	for i, name := range t.paramNames {
+		if _, exists := argMap[name]; !exists && t.paramTypes[i] == contextType {
+			argMap = maps.Clone(argMap)
+			argMap[name] = context.Background()
+		}
+	}
+	values := make([]reflect.Value, len(t.paramNames))
+	if err := t.bindMap(argMap, options, nil, values, nil); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// canonicalValues serializes the named values of arguments as an object
+// sorted by name, see CanonicalArgs.
+func (t *Function) canonicalValues(names []string, values []reflect.Value) ([]byte, error) {
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return strings.Compare(names[a], names[b])
+	})
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for n, i := range order {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(&buf, names[i])
+		buf.WriteByte(':')
+		if err := writeCanonical(&buf, values[i], 0); err != nil {
+			return nil, fmt.Errorf("cannot canonicalize parameter %q of function %s: %w", names[i], t.funcName, err)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ArgsHash returns a hex-encoded SHA-256 hash of the function's ID and its
+// CanonicalArgs, identifying a call of the function with equal arguments
+// across processes and builds with the same signature.
+//
+// Example:
+//
+//	key, err := fn.ArgsHash(argMap)
+//	if seen, _ := store.SetNX(key); seen {
+//	    return // duplicate delivery
+//	}
+func (t *Function) ArgsHash(argMap map[string]any, opts ...CallOptions) (string, error) {
+	canonical, err := t.CanonicalArgs(argMap, opts...)
+	if err != nil {
+		return "", err
+	}
+	return t.hashArgs(canonical), nil
+}
+
+// hashArgs hashes canonical arguments of the function, see ArgsHash.
+func (t *Function) hashArgs(canonical []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(t.ID()))
+	hash.Write([]byte{0})
+	hash.Write(canonical)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// writeCanonical appends the canonical encoding of v to buf.
+func writeCanonical(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if depth > maxCanonicalDepth {
+		return fmt.Errorf("value nested deeper than %d levels", maxCanonicalDepth)
+	}
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+
+	typ := v.Type()
+	if v.CanInterface() && typ.Kind() != reflect.Interface &&
+		(typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType)) {
+		if typ.Kind() == reflect.Pointer && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		encoded, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		// Compact the marshaled JSON, so that its formatting does not matter
+		return json.Compact(buf, encoded)
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			writeCanonicalString(buf, strconv.FormatFloat(f, 'g', -1, 64))
+		} else {
+			buf.WriteString(strconv.FormatFloat(f, 'g', -1, typ.Bits()))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		writeCanonicalString(buf, strconv.FormatComplex(v.Complex(), 'g', -1, typ.Bits()))
+	case reflect.String:
+		writeCanonicalString(buf, v.String())
+	case reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteString(`{"type":`)
+		writeCanonicalString(buf, v.Elem().Type().String())
+		buf.WriteString(`,"value":`)
+		if err := writeCanonical(buf, v.Elem(), depth+1); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+	case reflect.Pointer:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeCanonical(buf, v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := range v.Len() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, v.Index(i), depth+1); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Map:
+		return writeCanonicalMap(buf, v, depth)
+	case reflect.Struct:
+		buf.WriteByte('{')
+		for i := range typ.NumField() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, typ.Field(i).Name)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v.Field(i), depth+1); err != nil {
+				return fmt.Errorf("field %s: %w", typ.Field(i).Name, err)
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("cannot serialize %v", typ)
+	}
+	return nil
+}
+
+// writeCanonicalMap appends the canonical encoding of the map v to buf: an
+// object sorted by key, whose keys are strings or the canonical encodings of
+// other keys.
+func writeCanonicalMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		key := iter.Key()
+		if key.Kind() == reflect.String {
+			entries = append(entries, entry{key.String(), iter.Value()})
+			continue
+		}
+		var encoded bytes.Buffer
+		if err := writeCanonical(&encoded, key, depth+1); err != nil {
+			return fmt.Errorf("key: %w", err)
+		}
+		entries = append(entries, entry{encoded.String(), iter.Value()})
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return strings.Compare(a.key, b.key) })
+
+	buf.WriteByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(buf, e.key)
+		buf.WriteByte(':')
+		if err := writeCanonical(buf, e.value, depth+1); err != nil {
+			return fmt.Errorf("key %q: %w", e.key, err)
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeCanonicalString appends s to buf as a JSON string.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	encoded, _ := json.Marshal(s) // strings always marshal
+	buf.Write(encoded)
+}
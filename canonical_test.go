@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type canonicalFilter struct {
+	Since  time.Time
+	Labels map[string]int
+	Extra  any
+}
+
+//go:noinline
+func canonicalTestSearch(ctx context.Context, query string, limit int, filter *canonicalFilter) int {
+	return limit
+}
+
+func TestCanonicalArgs(t *testing.T) {
+	fn := mustNewFunction(t, canonicalTestSearch)
+	since := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+
+	got, err := fn.CanonicalArgs(map[string]any{
+		"limit": int64(10),
+		"query": "shoes",
+		"filter": &canonicalFilter{
+			Since:  since,
+			Labels: map[string]int{"b": 2, "a": 1},
+			Extra:  1,
+		},
+	}, CallOptions{AllowConvert: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"filter":{"Since":"2025-09-30T12:00:00Z","Labels":{"a":1,"b":2},"Extra":{"type":"int","value":1}},"limit":10,"query":"shoes"}`
+	if string(got) != want {
+		t.Errorf("expected\n%s, got\n%s", want, got)
+	}
+
+	// The same arguments in other representations serialize identically
+	for range 10 {
+		again, err := fn.CanonicalArgs(map[string]any{
+			"ctx":   context.TODO(),
+			"query": "shoes",
+			"limit": 10,
+			"filter": &canonicalFilter{
+				Since:  since,
+				Labels: map[string]int{"a": 1, "b": 2},
+				Extra:  1,
+			},
+		})
+		if err != nil || !bytes.Equal(again, got) {
+			t.Fatalf("expected %s, got %s, %v", got, again, err)
+		}
+	}
+
+	// Dynamic types tell apart values that JSON would not
+	other, err := fn.CanonicalArgs(map[string]any{"query": "shoes", "limit": 10, "filter": &canonicalFilter{Extra: 1.0}})
+	if err != nil || !strings.Contains(string(other), `"Extra":{"type":"float64","value":1}`) {
+		t.Errorf("expected a typed float, got %s, %v", other, err)
+	}
+
+	if _, err := fn.CanonicalArgs(map[string]any{"query": "shoes"}); err == nil || !strings.Contains(err.Error(), "missing required parameters") {
+		t.Errorf("expected a binding error, got %v", err)
+	}
+	_, err = fn.CanonicalArgs(map[string]any{"query": "shoes", "limit": 1, "filter": &canonicalFilter{Extra: func() {}}})
+	if err == nil || !strings.Contains(err.Error(), `parameter "filter"`) || !strings.Contains(err.Error(), "cannot serialize func()") {
+		t.Errorf("expected a serialization error, got %v", err)
+	}
+}
+
+func TestArgsHash(t *testing.T) {
+	fn := mustNewFunction(t, canonicalTestSearch)
+	first, err := fn.ArgsHash(map[string]any{"query": "shoes", "limit": 10, "filter": (*canonicalFilter)(nil)})
+	if err != nil || len(first) != 64 {
+		t.Fatalf("unexpected hash %q, %v", first, err)
+	}
+	if second, _ := fn.ArgsHash(map[string]any{"limit": uint8(10), "query": "shoes", "filter": (*canonicalFilter)(nil)}, CallOptions{AllowConvert: true}); second != first {
+		t.Errorf("expected equal hashes, got %s and %s", first, second)
+	}
+	if third, _ := fn.ArgsHash(map[string]any{"query": "boots", "limit": 10, "filter": (*canonicalFilter)(nil)}); third == first {
+		t.Error("expected other arguments to hash differently")
+	}
+}
+
+func TestWriteCanonical(t *testing.T) {
+	type node struct{ Next *node }
+	cyclic := &node{}
+	cyclic.Next = cyclic
+
+	for _, tt := range []struct {
+		value any
+		want  string
+		err   string
+	}{
+		{value: map[int]string{10: "b", 2: "a"}, want: `{"10":"b","2":"a"}`},
+		{value: []float64{math.NaN(), math.Inf(-1), 0.1}, want: `["NaN","-Inf",0.1]`},
+		{value: float32(0.1), want: `0.1`},
+		{value: [2]bool{true}, want: `[true,false]`},
+		{value: []int(nil), want: `null`},
+		{value: make(chan int), err: "cannot serialize chan int"},
+		{value: cyclic, err: "nested deeper than"},
+	} {
+		var buf bytes.Buffer
+		err := writeCanonical(&buf, reflect.ValueOf(tt.value), 0)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%T: expected error %q, got %v", tt.value, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || buf.String() != tt.want {
+			t.Errorf("%T: expected %s, got %s, %v", tt.value, tt.want, buf.String(), err)
+		}
+	}
+}
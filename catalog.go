@@ -0,0 +1,341 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
+)
+
+// CatalogEntry is a function listed by a Catalog.
+type CatalogEntry struct {
+	// Name is the DWARF name, e.g. github.com/org/app/api.(*Server).Handle.
+	Name string
+
+	// Package is the import path of the defining package.
+	Package string
+
+	// Receiver is the receiver type name of a method, empty for functions.
+	Receiver string
+
+	// BaseName is the function or method name, e.g. Handle.
+	BaseName string
+
+	// Params are the input parameters, receiver first for methods.
+	Params []DWARFParameter
+
+	// Results are the result parameters.
+	Results []DWARFParameter
+}
+
+// Catalog lists the functions of a binary with their parameter names and
+// types, for searching and browsing in development tools. Closures, method
+// value wrappers and other compiler-generated functions are left out. A
+// Catalog is immutable and safe for concurrent use.
+type Catalog struct {
+	entries []CatalogEntry // sorted by name
+}
+
+// PackageNode is a node of the package tree of a Catalog: an import path
+// element, with the functions of the package of that path, if any, and the
+// nodes of longer paths.
+type PackageNode struct {
+	// Path is the import path up to this node, empty for the root.
+	Path string
+
+	// Functions are the functions of the package at Path.
+	Functions []CatalogEntry
+
+	// Children are the nodes one path element deeper, sorted by path.
+	Children []*PackageNode
+}
+
+// NewCatalog returns a Catalog of the functions of the running binary whose
+// names start with packagePrefix, e.g. "github.com/org/app/".
+//
+// Example:
+//
+//	catalog, err := dwarfreflect.NewCatalog("github.com/org/app/")
+//	for _, entry := range catalog.Search("userID") {
+//	    fmt.Println(entry.Name)
+//	}
+func NewCatalog(packagePrefix string) (*Catalog, error) {
+	if err := loadResolver(); err != nil {
+		return nil, err
+	}
+	return globalResolver.Catalog(packagePrefix)
+}
+
+// Catalog returns a Catalog of the functions of the resolver's binary whose
+// names start with packagePrefix.
+func (dr *DWARFResolver) Catalog(packagePrefix string) (*Catalog, error) {
+	if dr.dwarfData == nil {
+		return nil, fmt.Errorf("DWARF debug information not available")
+	}
+	sigs, err := dr.collectSignatures(packagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read DWARF of %s: %w", dr.source(), err)
+	}
+
+	catalog := &Catalog{entries: make([]CatalogEntry, 0, len(sigs))}
+	for name, params := range sigs {
+		sym := symbols.Parse(name)
+		if sym.Package == "" || sym.Closure != nil || sym.Wrapper != "" || sym.MethodValue {
+			continue
+		}
+		entry := CatalogEntry{Name: name, Package: sym.Package, Receiver: sym.Receiver, BaseName: sym.Name}
+		for _, p := range params {
+			if p.Result {
+				entry.Results = append(entry.Results, p)
+			} else {
+				entry.Params = append(entry.Params, p)
+			}
+		}
+		catalog.entries = append(catalog.entries, entry)
+	}
+	slices.SortFunc(catalog.entries, func(a, b CatalogEntry) int { return strings.Compare(a.Name, b.Name) })
+	return catalog, nil
+}
+
+// Len returns the number of functions in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
+
+// Entries returns every function of the catalog, sorted by name.
+func (c *Catalog) Entries() []CatalogEntry {
+	return slices.Clone(c.entries)
+}
+
+// Lookup returns the function with the given DWARF name.
+func (c *Catalog) Lookup(name string) (CatalogEntry, bool) {
+	i, found := slices.BinarySearchFunc(c.entries, name, func(e CatalogEntry, name string) int {
+		return strings.Compare(e.Name, name)
+	})
+	if !found {
+		return CatalogEntry{}, false
+	}
+	return c.entries[i], true
+}
+
+// Search returns the functions matching every whitespace-separated term of
+// query, ignoring case: a term matches a function whose name, receiver or
+// package, or the name of one of whose parameters, contains it. Functions
+// with a parameter or base name equal to a term come first, then functions
+// whose names start with a term, then the others, each group sorted by name.
+//
+// Example:
+//
+//	catalog.Search("userID")       // functions with a parameter named userID, first
+//	catalog.Search("users delete") // e.g. app/users.Delete and app/users.(*Store).DeleteAll
+func (c *Catalog) Search(query string) []CatalogEntry {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	type match struct {
+		entry CatalogEntry
+		rank  int
+	}
+	var matches []match
+	for _, entry := range c.entries {
+		best := 0
+		for _, term := range terms {
+			rank := entry.matchRank(term)
+			if rank == 0 {
+				best = 0
+				break
+			}
+			best = max(best, rank)
+		}
+		if best > 0 {
+			matches = append(matches, match{entry, best})
+		}
+	}
+
+	// Entries are sorted by name already: a stable sort keeps that order
+	slices.SortStableFunc(matches, func(a, b match) int { return cmp.Compare(b.rank, a.rank) })
+	results := make([]CatalogEntry, len(matches))
+	for i, m := range matches {
+		results[i] = m.entry
+	}
+	return results
+}
+
+// matchRank ranks how well a lower-case term matches the entry: 3 for an
+// exact parameter or base name, 2 for a base name prefix, 1 for any other
+// substring and 0 for no match.
+func (e CatalogEntry) matchRank(term string) int {
+	base := strings.ToLower(e.BaseName)
+	rank := 0
+	switch {
+	case base == term:
+		return 3
+	case strings.HasPrefix(base, term):
+		rank = 2
+	case strings.Contains(strings.ToLower(e.Name), term):
+		rank = 1
+	}
+	for _, p := range e.Params {
+		name := strings.ToLower(p.Name)
+		if name == term {
+			return 3
+		}
+		if rank == 0 && strings.Contains(name, term) {
+			rank = 1
+		}
+	}
+	return rank
+}
+
+// Package returns the functions of the package with the given import path.
+func (c *Catalog) Package(path string) []CatalogEntry {
+	var entries []CatalogEntry
+	for _, entry := range c.entries {
+		if entry.Package == path {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Packages returns the import paths of the packages in the catalog, sorted.
+func (c *Catalog) Packages() []string {
+	paths := make([]string, len(c.entries))
+	for i, entry := range c.entries {
+		paths[i] = entry.Package
+	}
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// Tree returns the packages of the catalog as a tree of import path
+// elements, for browsing.
+//
+// Example:
+//
+//	var walk func(node *dwarfreflect.PackageNode, depth int)
+//	walk = func(node *dwarfreflect.PackageNode, depth int) {
+//	    fmt.Printf("%*s%s (%d functions)\n", 2*depth, "", node.Path, len(node.Functions))
+//	    for _, child := range node.Children {
+//	        walk(child, depth+1)
+//	    }
+//	}
+//	walk(catalog.Tree(), 0)
+func (c *Catalog) Tree() *PackageNode {
+	root := &PackageNode{}
+	nodes := map[string]*PackageNode{"": root}
+
+	var node func(path string) *PackageNode
+	node = func(path string) *PackageNode {
+		if n, ok := nodes[path]; ok {
+			return n
+		}
+		n := &PackageNode{Path: path}
+		nodes[path] = n
+		parent := ""
+		if slash := strings.LastIndex(path, "/"); slash >= 0 {
+			parent = path[:slash]
+		}
+		p := node(parent)
+		p.Children = append(p.Children, n)
+		return n
+	}
+
+	for _, entry := range c.entries {
+		n := node(entry.Package)
+		n.Functions = append(n.Functions, entry)
+	}
+	for _, n := range nodes {
+		slices.SortFunc(n.Children, func(a, b *PackageNode) int { return strings.Compare(a.Path, b.Path) })
+	}
+	return root
+}
+
+// Accepting returns the functions having an input parameter of each of
+// types, in any order, e.g. functions accepting a context.Context and a
+// *sql.Tx.
+//
+// Example:
+//
+//	entries := catalog.Accepting(reflect.TypeFor[context.Context](), reflect.TypeFor[*sql.Tx]())
+func (c *Catalog) Accepting(types ...reflect.Type) []CatalogEntry {
+	return c.having(types, func(e CatalogEntry) []DWARFParameter { return e.Params })
+}
+
+// Returning returns the functions having a result of each of types, in any
+// order.
+//
+// Example:
+//
+//	entries := catalog.Returning(reflect.TypeFor[*User](), reflect.TypeFor[error]())
+func (c *Catalog) Returning(types ...reflect.Type) []CatalogEntry {
+	return c.having(types, func(e CatalogEntry) []DWARFParameter { return e.Results })
+}
+
+// having returns the entries whose parameters, as selected by params,
+// include one of each of types.
+func (c *Catalog) having(types []reflect.Type, params func(CatalogEntry) []DWARFParameter) []CatalogEntry {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = dwarfTypeName(typ)
+	}
+
+	var entries []CatalogEntry
+	for _, entry := range c.entries {
+		available := params(entry)
+		used := make([]bool, len(available))
+		matched := true
+		for _, name := range names {
+			i := -1
+			for j, p := range available {
+				if !used[j] && p.Type == name {
+					i = j
+					break
+				}
+			}
+			if i < 0 {
+				matched = false
+				break
+			}
+			used[i] = true
+		}
+		if matched {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// dwarfTypeName returns the name DWARF gives typ, which qualifies named types
+// by import path rather than package name: *database/sql.Tx for *sql.Tx.
+func dwarfTypeName(typ reflect.Type) string {
+	if typ.Name() != "" {
+		if typ.PkgPath() == "" {
+			return typ.Name()
+		}
+		return typ.PkgPath() + "." + typ.Name()
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return "*" + dwarfTypeName(typ.Elem())
+	case reflect.Slice:
+		return "[]" + dwarfTypeName(typ.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", typ.Len(), dwarfTypeName(typ.Elem()))
+	case reflect.Map:
+		return "map[" + dwarfTypeName(typ.Key()) + "]" + dwarfTypeName(typ.Elem())
+	case reflect.Chan:
+		return strings.TrimSuffix(typ.String(), typ.Elem().String()) + dwarfTypeName(typ.Elem())
+	default:
+		return typ.String()
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func testFuncCatalogLookup(ctx context.Context, userID int, name string) (*debugStatus, error) {
+	return nil, nil
+}
+
+func entryNames(entries []CatalogEntry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	return names
+}
+
+func TestCatalog_Fixture(t *testing.T) {
+	dr, err := NewDWARFResolver("testdata/fixtures/greet.elf")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	catalog, err := dr.Catalog("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handle, ok := catalog.Lookup("main.(*Server).Handle")
+	if !ok {
+		t.Fatalf("expected Handle in %v", entryNames(catalog.Entries()))
+	}
+	if handle.Package != "main" || handle.Receiver != "Server" || handle.BaseName != "Handle" ||
+		len(handle.Params) != 3 || len(handle.Results) != 1 {
+		t.Errorf("unexpected entry: %+v", handle)
+	}
+
+	tests := map[string][]string{
+		"path":         {"main.(*Server).Handle"},
+		"GREET":        {"main.Greet"},
+		"e":            {"main.(*Server).Handle", "main.Greet"},
+		"server code":  {"main.(*Server).Handle"},
+		"server times": nil,
+		"":             nil,
+	}
+	for query, want := range tests {
+		if got := entryNames(catalog.Search(query)); !slices.Equal(got, want) {
+			t.Errorf("Search(%q) = %v, want %v", query, got, want)
+		}
+	}
+
+	if got := catalog.Packages(); !slices.Equal(got, []string{"main"}) {
+		t.Errorf("unexpected packages: %v", got)
+	}
+	root := catalog.Tree()
+	if len(root.Children) != 1 || root.Children[0].Path != "main" || len(root.Children[0].Functions) != 2 {
+		t.Errorf("unexpected tree: %+v", root)
+	}
+}
+
+func TestCatalog_Running(t *testing.T) {
+	if available, _, _ := GetDWARFStatus(); !available {
+		t.Skip("DWARF not available")
+	}
+	mustNewFunction(t, testFuncCatalogLookup)
+
+	catalog, err := NewCatalog("github.com/matteo-grella/dwarfreflect")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const name = "github.com/matteo-grella/dwarfreflect.testFuncCatalogLookup"
+
+	if results := catalog.Search("userID"); len(results) == 0 || results[0].Name != name {
+		t.Errorf("expected an exact parameter match first, got %v", entryNames(results))
+	}
+	accepting := entryNames(catalog.Accepting(reflect.TypeFor[context.Context](), reflect.TypeFor[int]()))
+	if !slices.Contains(accepting, name) {
+		t.Errorf("expected %s among functions accepting a context and an int", name)
+	}
+	if slices.Contains(entryNames(catalog.Accepting(reflect.TypeFor[int](), reflect.TypeFor[int]())), name) {
+		t.Error("expected each type to need its own parameter")
+	}
+	if !slices.Contains(entryNames(catalog.Returning(reflect.TypeFor[*debugStatus]())), name) {
+		t.Errorf("expected %s among functions returning *debugStatus", name)
+	}
+
+	tree := catalog.Tree()
+	var symbolsNode *PackageNode
+	for _, n := range tree.Children[0].Children[0].Children[0].Children {
+		if n.Path == "github.com/matteo-grella/dwarfreflect/symbols" {
+			symbolsNode = n
+		}
+	}
+	if symbolsNode == nil || len(symbolsNode.Functions) == 0 {
+		t.Errorf("expected the symbols package under the dwarfreflect node")
+	}
+}
+
+func TestDWARFTypeName(t *testing.T) {
+	tests := map[reflect.Type]string{
+		reflect.TypeFor[int]():                         "int",
+		reflect.TypeFor[*debugStatus]():                "*github.com/matteo-grella/dwarfreflect.debugStatus",
+		reflect.TypeFor[[]context.Context]():           "[]context.Context",
+		reflect.TypeFor[map[string][2]*CatalogEntry](): "map[string][2]*github.com/matteo-grella/dwarfreflect.CatalogEntry",
+		reflect.TypeFor[<-chan error]():                "<-chan error",
+	}
+	for typ, want := range tests {
+		if got := dwarfTypeName(typ); got != want {
+			t.Errorf("dwarfTypeName(%v) = %s, want %s", typ, got, want)
+		}
+	}
+}
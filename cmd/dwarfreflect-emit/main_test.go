@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+)
+
+const fixtureSource = `package main
+
+import (
+	"context"
+	"time"
+)
+
+type User struct{ Name string }
+
+type Server struct{}
+
+func CreateUser(ctx context.Context, userName string, tags []string, deadline *time.Time) (user *User, err error) {
+	return &User{Name: userName}, nil
+}
+
+func (s *Server) Handle(path string, _ int) error { return nil }
+
+func main() {
+	CreateUser(context.Background(), "x", nil, nil)
+	new(Server).Handle("/", 0)
+}
+`
+
+func TestRun(t *testing.T) {
+	binary := dwarfreflecttest.BuildFixture(t, fixtureSource)
+
+	var out bytes.Buffer
+	if err := run([]string{binary, "main.CreateUser", "main.(*Server).Handle"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	src := out.String()
+	for _, want := range []string{
+		"// Code generated by dwarfreflect-emit. DO NOT EDIT.",
+		"package main",
+		`"context"`,
+		`"time"`,
+		"type CreateUserParams struct {",
+		"Ctx      context.Context `json:\"ctx\" param:\"ctx\"`",
+		"Tags     []string        `json:\"tags\" param:\"tags\"`",
+		"Deadline *time.Time      `json:\"deadline\" param:\"deadline\"`",
+		"type CreateUserResults struct {\n\tUser *User `json:\"user\"`\n}",
+		"type ServerHandleParams struct {",
+		"Arg2 int     `json:\"arg2\" param:\"arg2\"`",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "ServerHandleResults") {
+		t.Error("expected no results struct for a lone error result")
+	}
+
+	if err := run([]string{binary, "main.Missing"}, &out); err == nil {
+		t.Error("expected error for a missing function")
+	}
+}
+
+func TestQualify(t *testing.T) {
+	e := &emitter{localPath: "github.com/org/app/api", imports: make(map[string]string)}
+	tests := map[string]string{
+		"string":                                    "string",
+		"*github.com/org/app/api.Server":            "*Server",
+		"[]*github.com/org/app/models.User":         "[]*models.User",
+		"map[string]example.com/mod/v2/store.Entry": "map[string]store.Entry",
+		"func(context.Context) error":               "func(context.Context) error",
+	}
+	for typeName, want := range tests {
+		if got := e.qualify(typeName); got != want {
+			t.Errorf("qualify(%q) = %q, want %q", typeName, got, want)
+		}
+	}
+	if e.imports["example.com/mod/v2/store"] != "store" || e.imports["context"] != "context" {
+		t.Errorf("unexpected imports %v", e.imports)
+	}
+}
+
+func TestTypeNameFor(t *testing.T) {
+	tests := map[string]string{
+		"main.CreateUser":                          "CreateUser",
+		"github.com/org/app/api.(*Server).Handle":  "ServerHandle",
+		"github.com/org/app/api.Cache.Get":         "CacheGet",
+		"example.com/lib.Map[go.shape.int,string]": "Map",
+	}
+	for funcName, want := range tests {
+		if got := typeNameFor(funcName); got != want {
+			t.Errorf("typeNameFor(%q) = %q, want %q", funcName, got, want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Command dwarfreflect-vet runs the dwarfreflect analyzer, checking literal
+// argument map keys passed to CallWithMap and related methods against the
+// parameter names of the wrapped functions. It runs standalone or as a vet
+// tool.
+//
+// Usage:
+//
+//	go vet -vettool=$(which dwarfreflect-vet) ./...
+//	dwarfreflect-vet ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/matteo-grella/dwarfreflect/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
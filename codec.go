@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec decodes encoded payloads (msgpack, CBOR, ...) into generated parameter structs.
+// Most codec libraries fit directly, e.g.:
+//
+//	dwarfreflect.RegisterCodec("msgpack", dwarfreflect.Codec{Unmarshal: msgpack.Unmarshal, Tag: "msgpack"})
+//	dwarfreflect.RegisterCodec("cbor", dwarfreflect.Codec{Unmarshal: cbor.Unmarshal, Tag: "cbor"})
+type Codec struct {
+	// Unmarshal decodes data into v, a pointer to a struct.
+	Unmarshal func(data []byte, v any) error
+
+	// Tag is the struct tag key the codec reads field names from.
+	// Generated structs carry this tag with the parameter name.
+	Tag string
+}
+
+// Global codec registry used by CallWithEncoded, pre-populated with JSON
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"json": {Unmarshal: json.Unmarshal, Tag: "json"},
+	}
+)
+
+// RegisterCodec makes a codec available to CallWithEncoded under name,
+// replacing any codec previously registered with the same name.
+func RegisterCodec(name string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = codec
+}
+
+// CallWithEncoded decodes data with the named codec into the generated parameter
+// struct and invokes the function. context.Context parameters receive
+// context.Background(); use CallWithEncodedContext to provide one. Functions
+// with unsafe parameters (see GetUnsafePositions) are rejected with an
+// *UnsafeParameterError, as encoded payloads are untrusted input.
+//
+// Example:
+//
+//	results, err := fn.CallWithEncoded("cbor", payload)
+func (t *Function) CallWithEncoded(codecName string, data []byte) ([]reflect.Value, error) {
+	return t.CallWithEncodedContext(context.Background(), codecName, data)
+}
+
+// CallWithEncodedContext is like CallWithEncoded but injects ctx into
+// context.Context parameters.
+func (t *Function) CallWithEncodedContext(ctx context.Context, codecName string, data []byte) ([]reflect.Value, error) {
+	codecsMu.RLock()
+	codec, ok := codecs[codecName]
+	codecsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown codec %q", codecName)
+	}
+	if positions := t.GetUnsafePositions(); len(positions) > 0 {
+		i := positions[0]
+		return nil, t.bindFailed(&UnsafeParameterError{Function: t.funcName, Param: t.paramNames[i], Type: t.paramTypes[i]})
+	}
+
+	paramNames, paramTypes := t.GetNonContextParameters()
+	codecStruct := t.createStructTypeFromParams(paramNames, paramTypes, StructOptions{
+		TagBuilder: func(paramName string, paramType reflect.Type) string {
+			return fmt.Sprintf(`%s:"%s"`, codec.Tag, paramName)
+		},
+	})
+
+	params := reflect.New(codecStruct)
+	if err := codec.Unmarshal(data, params.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode %s parameters of function %s: %w", codecName, t.funcName, err)
+	}
+
+	return t.CallWithNonContextStructAndContext(ctx, params.Interface())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestCallWithEncoded_JSON(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	results, err := fn.CallWithEncoded("json", []byte(`{"name":"Alice","age":30}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].String() != "Alice is 30 years old" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestCallWithEncoded_RegisteredCodec(t *testing.T) {
+	RegisterCodec("xml", Codec{Unmarshal: xml.Unmarshal, Tag: "xml"})
+
+	fn := mustNewFunction(t, testFunc4)
+	results, err := fn.CallWithEncodedContext(context.Background(), "xml",
+		[]byte(`<params><id>7</id><name>widget</name></params>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].String() != "id=7, name=widget" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestCallWithEncoded_Errors(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	if _, err := fn.CallWithEncoded("unknown", nil); err == nil {
+		t.Error("expected error for unknown codec")
+	}
+	if _, err := fn.CallWithEncoded("json", []byte(`{"age":"thirty"}`)); err == nil {
+		t.Error("expected error for undecodable payload")
+	}
+}
+
+func TestCallWithEncoded_UnsafeRejected(t *testing.T) {
+	fn := mustNewFunction(t, testFuncUnsafe)
+	_, err := fn.CallWithEncoded("json", []byte(`{"label":"x"}`))
+	var unsafeErr *UnsafeParameterError
+	if !errors.As(err, &unsafeErr) || unsafeErr.Param != "addr" {
+		t.Errorf("expected an UnsafeParameterError for addr, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFunction_ConcurrentUse exercises calls, struct generation and
+// configuration on a shared Function; run with -race to check the
+// goroutine-safety contract.
+func TestFunction_ConcurrentUse(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+	breaker := NewCircuitBreaker(1000, time.Second)
+
+	const goroutines, iterations = 16, 200
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if err := concurrentStep(fn, breaker, g, i); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// The shared Function was never modified by the derived configurations
+	if _, ok := fn.GetParamMeta("name"); ok {
+		t.Error("expected no metadata on the shared function")
+	}
+	if _, ok := fn.GetPolicy(); ok || fn.GetCircuitBreaker() != nil || fn.IsFrozen() {
+		t.Error("expected the shared function to keep its configuration")
+	}
+	if names, _ := fn.GetParameterInfo(); names[1] != "id" {
+		t.Errorf("expected parameter names to be unaffected, got %v", names)
+	}
+}
+
+func concurrentStep(fn *Function, breaker *CircuitBreaker, g, i int) error {
+	ctx := context.Background()
+	expected := fmt.Sprintf("id=%d, name=g%d", i, g)
+
+	var derived *Function
+	switch i % 4 {
+	case 0:
+		derived = fn
+	case 1:
+		derived = fn.WithParamMeta("name", ParamMeta{Default: fmt.Sprintf("g%d", g)})
+	case 2:
+		derived = fn.WithPolicy(Policy{Retries: 1}).WithCircuitBreaker(breaker)
+	case 3:
+		derived = fn.Freeze()
+	}
+
+	results, err := derived.CallWithMap(map[string]any{"ctx": ctx, "id": i, "name": fmt.Sprintf("g%d", g)})
+	if err != nil {
+		return err
+	}
+	if got := results[0].String(); got != expected {
+		return fmt.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if _, err := derived.CallWithContext(ctx, i, "x"); err != nil {
+		return err
+	}
+	if _, err := derived.ExplainBind(map[string]any{"id": i}); err == nil {
+		return fmt.Errorf("expected missing parameter error")
+	}
+
+	_ = derived.NewParamsPtr()
+	_ = derived.NewNonContextParamsPtr()
+	_ = derived.GetNonContextStructType()
+
+	// Returned slices belong to the caller
+	names, types := fn.GetParameterInfo()
+	names[1], types[1] = "mutated", nil
+	return nil
+}
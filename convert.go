@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// allowConvert reports whether arguments of the named parameter may be
+// converted to its type: per ParamMeta.Convert, and per
+// CallOptions.AllowConvert for parameters without one.
+func (t *Function) allowConvert(name string, options CallOptions) bool {
+	if convert := t.paramMeta[name].Convert; convert != nil {
+		return *convert
+	}
+	return options.AllowConvert
+}
+
+// convertArg converts v to the type to with a Go conversion, reporting false
+// when Go does not allow one. Conversions that would change the value fail:
+// numbers must be represented exactly, and integers are not converted to
+// strings, which Go would turn into the rune of that code point.
+func convertArg(v reflect.Value, to reflect.Type) (reflect.Value, bool, error) {
+	from := v.Type()
+	switch {
+	case !v.CanConvert(to):
+		return reflect.Value{}, false, nil
+	case to.Kind() == reflect.String && (v.CanInt() || v.CanUint()):
+		return reflect.Value{}, false, nil
+	case isNumeric(from) && isNumeric(to):
+		converted, err := numberConverter(to)(v)
+		if err != nil {
+			return reflect.Value{}, true, err
+		}
+		return converted, true, nil
+	}
+	return v.Convert(to), true, nil
+}
+
+// convertBinding converts the argument rv of parameter i, returning it
+// unchanged with a nil error when it needs no conversion or cannot be
+// converted, in which case the binding checks that follow report it.
+func (t *Function) convertBinding(i int, rv reflect.Value, options CallOptions, binding *ParamBinding) (reflect.Value, error) {
+	paramType := t.paramTypes[i]
+	if rv.Type() == paramType || !t.allowConvert(t.paramNames[i], options) {
+		return rv, nil
+	}
+	if rv.Type().AssignableTo(paramType) && (!options.StrictTypes || strictTypeMatch(rv.Type(), paramType)) {
+		return rv, nil
+	}
+
+	converted, ok, err := convertArg(rv, paramType)
+	if err != nil {
+		return rv, fmt.Errorf("parameter %q: cannot convert %v to %v: %w", t.paramNames[i], rv.Type(), paramType, err)
+	}
+	if !ok {
+		return rv, nil
+	}
+	binding.Coercion = CoercionConverted
+	return converted, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type convertName string
+
+//go:noinline
+func convertTestArchive(archiveID int64, owner convertName, body string, tags strictTags) string {
+	return string(owner) + "/" + body + "/" + strings.Join(tags, ",")
+}
+
+func convertArgs() map[string]any {
+	return map[string]any{"archiveID": 7, "owner": "ann", "body": []byte("data"), "tags": []string{"a", "b"}}
+}
+
+func TestAllowConvert(t *testing.T) {
+	fn := mustNewFunction(t, convertTestArchive)
+
+	if _, err := fn.CallWithMap(convertArgs()); err == nil || !strings.Contains(err.Error(), "cannot assign int to int64") {
+		t.Errorf("expected an assignment error by default, got %v", err)
+	}
+
+	results, err := fn.CallWithMap(convertArgs(), CallOptions{AllowConvert: true})
+	if err != nil || results[0].String() != "ann/data/a,b" {
+		t.Fatalf("unexpected results %v, %v", results, err)
+	}
+
+	report, err := fn.ExplainBind(convertArgs(), CallOptions{AllowConvert: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, binding := range report.Params {
+		// tags is assignable, and left alone unless StrictTypes is set
+		want := CoercionConverted
+		if binding.Param == "tags" {
+			want = CoercionNone
+		}
+		if binding.Coercion != want {
+			t.Errorf("%s: expected coercion %v, got %v", binding.Param, want, binding.Coercion)
+		}
+	}
+
+	// With StrictTypes, assignable arguments are converted to the exact type
+	if _, err := fn.CallWithMap(convertArgs(), CallOptions{AllowConvert: true, StrictTypes: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAllowConvert_PerParameter(t *testing.T) {
+	yes, no := true, false
+	fn := mustNewFunction(t, convertTestArchive).
+		WithParamMeta("archiveID", ParamMeta{Convert: &yes}).
+		WithParamMeta("owner", ParamMeta{Convert: &yes}).
+		WithParamMeta("body", ParamMeta{Convert: &yes})
+
+	if _, err := fn.CallWithMap(convertArgs()); err != nil {
+		t.Errorf("expected per-parameter conversion, got %v", err)
+	}
+
+	fn = fn.WithParamMeta("body", ParamMeta{Convert: &no})
+	if _, err := fn.CallWithMap(convertArgs(), CallOptions{AllowConvert: true}); err == nil || !strings.Contains(err.Error(), `parameter "body"`) {
+		t.Errorf("expected body to opt out of conversion, got %v", err)
+	}
+}
+
+func TestConvertArg(t *testing.T) {
+	for _, tt := range []struct {
+		value any
+		to    reflect.Type
+		want  any
+		ok    bool
+		err   string
+	}{
+		{value: 7, to: reflect.TypeFor[int64](), want: int64(7), ok: true},
+		{value: 300, to: reflect.TypeFor[uint8](), ok: true, err: "overflows"},
+		{value: 2.5, to: reflect.TypeFor[int](), ok: true, err: "not an integer"},
+		{value: "ann", to: reflect.TypeFor[convertName](), want: convertName("ann"), ok: true},
+		{value: []byte("hi"), to: reflect.TypeFor[string](), want: "hi", ok: true},
+		{value: 65, to: reflect.TypeFor[string]()},
+		{value: []int{1}, to: reflect.TypeFor[[2]int]()},
+		{value: "x", to: reflect.TypeFor[int]()},
+	} {
+		got, ok, err := convertArg(reflect.ValueOf(tt.value), tt.to)
+		switch {
+		case ok != tt.ok:
+			t.Errorf("%v to %v: expected ok %v", tt.value, tt.to, tt.ok)
+		case tt.err != "":
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%v to %v: expected error %q, got %v", tt.value, tt.to, tt.err, err)
+			}
+		case ok && (err != nil || got.Interface() != tt.want):
+			t.Errorf("%v to %v: expected %v, got %v, %v", tt.value, tt.to, tt.want, got, err)
+		}
+	}
+}
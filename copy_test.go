@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+type copyNode struct {
+	Value int
+	Next  *copyNode
+	Tags  []string
+	Attrs map[string]any
+}
+
+func TestDeepCopy(t *testing.T) {
+	original := &copyNode{
+		Value: 1,
+		Tags:  []string{"a", "b"},
+		Attrs: map[string]any{"nested": []int{1, 2}},
+	}
+	original.Next = original // cycle
+
+	copied := deepCopy(reflect.ValueOf(original)).Interface().(*copyNode)
+
+	if copied == original {
+		t.Fatal("expected a new pointer")
+	}
+	if copied.Next != copied {
+		t.Error("expected cycle to be preserved in the copy")
+	}
+	if !reflect.DeepEqual(copied.Tags, original.Tags) {
+		t.Errorf("expected equal tags, got %v", copied.Tags)
+	}
+
+	copied.Tags[0] = "changed"
+	copied.Attrs["nested"].([]int)[0] = 99
+	if original.Tags[0] != "a" {
+		t.Error("mutating the copied slice changed the original")
+	}
+	if original.Attrs["nested"].([]int)[0] != 1 {
+		t.Error("mutating a slice nested in an interface changed the original")
+	}
+}
+
+func TestDeepCopy_Nil(t *testing.T) {
+	var m map[string]int
+	if c := deepCopy(reflect.ValueOf(m)); !c.IsNil() {
+		t.Error("expected nil map to stay nil")
+	}
+	if c := deepCopy(reflect.Value{}); c.IsValid() {
+		t.Error("expected invalid value to stay invalid")
+	}
+}
+
+func testFuncMutating(scores []int, meta map[string]string) int {
+	scores[0] = -1
+	meta["touched"] = "yes"
+	return len(scores)
+}
+
+func TestCallWithMap_CopyArgs(t *testing.T) {
+	fn := mustNewFunction(t, testFuncMutating)
+	scores := []int{1, 2, 3}
+	meta := map[string]string{}
+
+	if _, err := fn.CallWithMap(map[string]any{
+		"scores": scores,
+		"meta":   meta,
+	}, CallOptions{CopyArgs: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if scores[0] != 1 {
+		t.Errorf("expected caller slice untouched, got %v", scores)
+	}
+	if _, ok := meta["touched"]; ok {
+		t.Error("expected caller map untouched")
+	}
+
+	if _, err := fn.CallWithMap(map[string]any{
+		"scores": scores,
+		"meta":   meta,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scores[0] != -1 {
+		t.Error("expected arguments to be shared without CopyArgs")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CoreDump answers post-mortem questions about a crashed process, from its
+// core dump and the executable it ran: which function holds a program counter
+// of a goroutine stack, and the names and types of its parameters. Only ELF
+// core dumps, as written by Linux, are supported. A CoreDump is safe for
+// concurrent use.
+type CoreDump struct {
+	resolver *DWARFResolver
+	offset   uint64 // run-time minus DWARF address of code in the process
+}
+
+// PCFunction describes the function holding a program counter.
+type PCFunction struct {
+	Name   string           // DWARF name, e.g. "main.(*Server).handle"
+	Entry  uint64           // run-time address of its first instruction
+	Params []DWARFParameter // inputs first, then results
+}
+
+// OpenCore loads the core dump at corePath of a process that ran the
+// executable at executablePath, which must carry DWARF.
+//
+// Example:
+//
+//	core, err := dwarfreflect.OpenCore("core.1234", "bin/app")
+//	fn, err := core.FuncForPC(0x4a1f3c) // from a goroutine stack in the dump
+//	for _, p := range fn.Params {
+//	    fmt.Println(p.Name, p.Type)
+//	}
+func OpenCore(corePath, executablePath string) (*CoreDump, error) {
+	coreFile, err := os.Open(corePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open core dump: %v", err)
+	}
+	defer coreFile.Close()
+
+	exeFile, err := os.Open(executablePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open executable: %v", err)
+	}
+	defer exeFile.Close()
+
+	c, err := NewCoreDump(coreFile, exeFile)
+	if err != nil {
+		return nil, err
+	}
+	c.resolver.executablePath = executablePath
+	return c, nil
+}
+
+// NewCoreDump is like OpenCore for a core dump and an executable read through
+// readers, e.g. ones downloaded from a crash collector. Both are read while
+// NewCoreDump runs only.
+func NewCoreDump(core, executable io.ReaderAt) (*CoreDump, error) {
+	dr, err := NewResolverFromReader(executable, FormatELF)
+	if err != nil {
+		return nil, err
+	}
+	exe, err := elf.NewFile(executable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ELF file: %v", err)
+	}
+	coreFile, err := elf.NewFile(core)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open core dump: %v", err)
+	}
+	if coreFile.Type != elf.ET_CORE {
+		return nil, fmt.Errorf("not a core dump: ELF type %v", coreFile.Type)
+	}
+
+	// The kernel records the run-time entry point of the executable among
+	// the auxiliary vector of the process; executables that are not
+	// position-independent run at their link address anyway
+	c := &CoreDump{resolver: dr}
+	entry, ok, err := coreEntryPoint(coreFile)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to read core dump notes: %w", err)
+	case ok:
+		c.offset = entry - exe.Entry
+	case exe.Type == elf.ET_DYN:
+		return nil, errors.New("cannot locate the position-independent executable: the core dump has no auxiliary vector")
+	}
+	return c, nil
+}
+
+// Resolver returns the resolver of the executable, e.g. to look functions up
+// by name.
+func (c *CoreDump) Resolver() *DWARFResolver {
+	return c.resolver
+}
+
+// LoadOffset returns the difference between the run-time addresses of code in
+// the process and its addresses in the executable, which is non-zero for
+// position-independent executables.
+func (c *CoreDump) LoadOffset() uint64 {
+	return c.offset
+}
+
+// FuncForPC returns the function whose code holds the run-time address pc in
+// the process, anywhere in its body as on a stack. Functions inlined at pc
+// are not reported: pc belongs to the function they were inlined into.
+func (c *CoreDump) FuncForPC(pc uint64) (*PCFunction, error) {
+	reader, entry, ok := c.resolver.subprogramAt(pc-c.offset, false)
+	if !ok {
+		return nil, fmt.Errorf("no function at %#x in %s", pc, c.resolver.source())
+	}
+
+	fn := &PCFunction{Params: []DWARFParameter{}}
+	fn.Name, _ = entryName(entry)
+	// The entry of abstract functions is in the concrete copy, look it up by name
+	if low, ok := entry.Val(dwarf.AttrLowpc).(uint64); ok {
+		fn.Entry = low + c.offset
+	} else if lowPCs, err := c.resolver.lowPCs(fn.Name); err == nil {
+		if low, ok := lowPCs[fn.Name]; ok {
+			fn.Entry = low + c.offset
+		}
+	}
+	if entry.Children {
+		params, err := c.resolver.readParameters(reader, make(map[dwarf.Offset]string))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read DWARF of %s: %w", c.resolver.source(), err)
+		}
+		fn.Params = params
+	}
+	return fn, nil
+}
+
+// Note types and auxiliary vector keys of Linux core dumps.
+const (
+	noteAuxv = 6 // NT_AUXV
+	auxEntry = 9 // AT_ENTRY
+)
+
+// coreEntryPoint returns the entry point of the executable recorded in the
+// auxiliary vector of a core dump, and reports false if it has none.
+func coreEntryPoint(core *elf.File) (uint64, bool, error) {
+	wordSize := 8
+	if core.Class == elf.ELFCLASS32 {
+		wordSize = 4
+	}
+	word := func(b []byte) uint64 {
+		if wordSize == 4 {
+			return uint64(core.ByteOrder.Uint32(b))
+		}
+		return core.ByteOrder.Uint64(b)
+	}
+
+	for _, prog := range core.Progs {
+		if prog.Type != elf.PT_NOTE {
+			continue
+		}
+		notes, err := io.ReadAll(prog.Open())
+		if err != nil {
+			return 0, false, err
+		}
+
+		// Notes are a name size, a description size and a type, followed by
+		// the name and the description, each padded to 4 bytes
+		for len(notes) >= 12 {
+			nameSize := uint64(core.ByteOrder.Uint32(notes[0:]))
+			descSize := uint64(core.ByteOrder.Uint32(notes[4:]))
+			noteType := core.ByteOrder.Uint32(notes[8:])
+			descStart := 12 + align4(nameSize)
+			if descStart+descSize > uint64(len(notes)) {
+				return 0, false, errors.New("truncated note")
+			}
+			desc := notes[descStart : descStart+descSize]
+			notes = notes[min(descStart+align4(descSize), uint64(len(notes))):]
+
+			if noteType != noteAuxv {
+				continue
+			}
+			for ; len(desc) >= 2*wordSize; desc = desc[2*wordSize:] {
+				if word(desc) == auxEntry {
+					return word(desc[wordSize:]), true, nil
+				}
+			}
+		}
+	}
+	return 0, false, nil
+}
+
+// align4 rounds n up to a multiple of 4.
+func align4(n uint64) uint64 {
+	return (n + 3) &^ 3
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func coreTestHandler(orderID int, items []string) (total float64, err error) {
+	for range items {
+		total += float64(orderID)
+	}
+	return total, nil
+}
+
+// writeTestCore writes a little-endian ELF64 core dump holding only an
+// auxiliary vector with entry as AT_ENTRY, or no note at all when entry is 0.
+func writeTestCore(t *testing.T, entry uint64) string {
+	t.Helper()
+
+	var note bytes.Buffer
+	if entry != 0 {
+		auxv := []uint64{6 /* AT_PAGESZ */, 4096, auxEntry, entry, 0 /* AT_NULL */, 0}
+		binary.Write(&note, binary.LittleEndian, []uint32{5, uint32(8 * len(auxv)), noteAuxv})
+		note.WriteString("CORE\x00\x00\x00\x00")
+		binary.Write(&note, binary.LittleEndian, auxv)
+	}
+
+	const ehsize, phentsize = 64, 56
+	var core bytes.Buffer
+	binary.Write(&core, binary.LittleEndian, elf.Header64{
+		Ident:     [16]byte{0x7f, 'E', 'L', 'F', byte(elf.ELFCLASS64), byte(elf.ELFDATA2LSB), byte(elf.EV_CURRENT)},
+		Type:      uint16(elf.ET_CORE),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Phoff:     ehsize,
+		Ehsize:    ehsize,
+		Phentsize: phentsize,
+		Phnum:     1,
+	})
+	binary.Write(&core, binary.LittleEndian, elf.Prog64{
+		Type:   uint32(elf.PT_NOTE),
+		Off:    ehsize + phentsize,
+		Filesz: uint64(note.Len()),
+		Align:  4,
+	})
+	core.Write(note.Bytes())
+
+	path := filepath.Join(t.TempDir(), "core")
+	if err := os.WriteFile(path, core.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// openTestCore opens a core dump of this process, as if it had crashed.
+func openTestCore(t *testing.T) *CoreDump {
+	t.Helper()
+	initResolver()
+	if resolverInitErr != nil {
+		t.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+	exe, err := elf.Open(globalResolver.executablePath)
+	if err != nil {
+		t.Skipf("not an ELF executable: %v", err)
+	}
+	defer exe.Close()
+	offset, err := globalResolver.loadOffset()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	core, err := OpenCore(writeTestCore(t, exe.Entry+uint64(offset)), globalResolver.executablePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if core.LoadOffset() != uint64(offset) {
+		t.Errorf("expected load offset %#x, got %#x", offset, core.LoadOffset())
+	}
+	return core
+}
+
+func TestCoreDump_FuncForPC(t *testing.T) {
+	core := openTestCore(t)
+	coreTestHandler(1, nil)
+
+	entry := uint64(reflect.ValueOf(coreTestHandler).Pointer())
+	want := []DWARFParameter{
+		{Name: "orderID", Type: "int"},
+		{Name: "items", Type: "[]string"},
+		{Name: "total", Type: "float64", Result: true},
+		{Name: "err", Type: "error", Result: true},
+	}
+	// A stack holds addresses anywhere in the body
+	for _, pc := range []uint64{entry, entry + 1} {
+		fn, err := core.FuncForPC(pc)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fn.Name != "github.com/matteo-grella/dwarfreflect.coreTestHandler" || fn.Entry != entry {
+			t.Errorf("%#x: unexpected function %s at %#x", pc, fn.Name, fn.Entry)
+		}
+		if !reflect.DeepEqual(fn.Params, want) {
+			t.Errorf("%#x: expected %v, got %v", pc, want, fn.Params)
+		}
+	}
+
+	if _, err := core.FuncForPC(1); err == nil || !strings.Contains(err.Error(), "no function") {
+		t.Errorf("expected no function error, got %v", err)
+	}
+	if names, ok := core.Resolver().ParameterNames("github.com/matteo-grella/dwarfreflect.coreTestHandler"); !ok || len(names) != 4 {
+		t.Errorf("expected the names of coreTestHandler, got %v (%v)", names, ok)
+	}
+}
+
+func TestOpenCore_Errors(t *testing.T) {
+	initResolver()
+	if resolverInitErr != nil {
+		t.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+	exePath := globalResolver.executablePath
+	exe, err := elf.Open(exePath)
+	if err != nil {
+		t.Skipf("not an ELF executable: %v", err)
+	}
+	defer exe.Close()
+
+	if _, err := OpenCore(exePath, exePath); err == nil || !strings.Contains(err.Error(), "not a core dump") {
+		t.Errorf("expected not a core dump error, got %v", err)
+	}
+	if _, err := OpenCore(filepath.Join(t.TempDir(), "missing"), exePath); err == nil {
+		t.Error("expected an error for a missing core dump")
+	}
+
+	core, err := OpenCore(writeTestCore(t, 0), exePath)
+	switch {
+	case exe.Type == elf.ET_DYN && err == nil:
+		t.Error("expected an error locating a position-independent executable")
+	case exe.Type != elf.ET_DYN && (err != nil || core.LoadOffset() != 0):
+		t.Errorf("expected the link address, got %v", err)
+	}
+}
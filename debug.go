@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type debugStatus struct {
+	DWARFAvailable bool                     `json:"dwarfAvailable"`
+	FunctionCount  int                      `json:"functionCount"`
+	Error          string                   `json:"error,omitempty"`
+	Executable     string                   `json:"executable"`
+	Format         string                   `json:"format"`
+	Supported      bool                     `json:"supported"`
+	SupportReason  string                   `json:"supportReason"`
+	Functions      map[string]debugFunction `json:"functions,omitempty"`
+}
+
+type debugFunction struct {
+	Function   string       `json:"function"`
+	Kind       string       `json:"kind"`
+	Provenance string       `json:"provenance"`
+	Deprecated string       `json:"deprecated,omitempty"`
+	Params     []debugValue `json:"params"`
+	Results    []debugValue `json:"results"`
+}
+
+type debugValue struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Examples []any  `json:"examples,omitempty"`
+}
+
+type debugLookup struct {
+	Function    string           `json:"function"`
+	Candidates  []debugCandidate `json:"candidates"`
+	InputParams []string         `json:"inputParams,omitempty"`
+	AllParams   []string         `json:"allParams,omitempty"`
+	Suggestions []string         `json:"suggestions,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+type debugCandidate struct {
+	Key    string   `json:"key"`
+	Found  bool     `json:"found"`
+	Params []string `json:"params,omitempty"`
+}
+
+// DebugHandler returns an http.Handler serving JSON diagnostics: DWARF status,
+// executable format and capabilities, and the signatures of the functions in
+// the given registries. With a "lookup" query parameter it instead reports how a
+// runtime function name is matched against DWARF entries, suggesting the
+// nearest names when none matches.
+// Mount it like pprof:
+//
+//	http.Handle("/debug/dwarfreflect", dwarfreflect.DebugHandler(reg))
+//	// GET /debug/dwarfreflect?lookup=main.ProcessUser
+func DebugHandler(registries ...*Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		if name := r.URL.Query().Get("lookup"); name != "" {
+			body = debugLookupFor(name)
+		} else {
+			body = debugStatusFor(registries)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(body)
+	})
+}
+
+func debugStatusFor(registries []*Registry) debugStatus {
+	var status debugStatus
+
+	available, funcCount, err := GetDWARFStatus()
+	status.DWARFAvailable, status.FunctionCount = available, funcCount
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	format, execPath, _ := GetExecutableInfo()
+	status.Executable, status.Format = execPath, format.String()
+	status.Supported, status.SupportReason, _ = IsDWARFSupported()
+
+	for _, reg := range registries {
+		for _, name := range reg.Names() {
+			fn, _ := reg.Get(name)
+			if status.Functions == nil {
+				status.Functions = make(map[string]debugFunction)
+			}
+			status.Functions[name] = debugFunctionFor(fn)
+		}
+	}
+
+	return status
+}
+
+func debugFunctionFor(fn *Function) debugFunction {
+	info := debugFunction{
+		Function:   fn.funcName,
+		Kind:       fn.kind.String(),
+		Provenance: fn.provenance.String(),
+		Params:     make([]debugValue, len(fn.paramNames)),
+		Results:    make([]debugValue, len(fn.resultNames)),
+	}
+	info.Deprecated, _ = fn.Deprecation()
+	for i, name := range fn.paramNames {
+		info.Params[i] = debugValue{Name: name, Type: fn.paramTypes[i].String(), Examples: fn.paramMeta[name].Examples}
+	}
+	for i, name := range fn.resultNames {
+		info.Results[i] = debugValue{Name: name, Type: fn.functionType.Out(i).String()}
+	}
+	return info
+}
+
+func debugLookupFor(funcName string) debugLookup {
+	lookup := debugLookup{Function: funcName, Candidates: []debugCandidate{}}
+
+	if err := loadResolver(); err != nil {
+		lookup.Error = err.Error()
+		return lookup
+	}
+
+	for _, candidate := range globalResolver.candidates(funcName) {
+		params, found := globalResolver.functions.lookup(candidate)
+		lookup.Candidates = append(lookup.Candidates, debugCandidate{
+			Key:    candidate,
+			Found:  found,
+			Params: params,
+		})
+	}
+
+	inputParams, allParams, err := DebugDWARFParameters(funcName)
+	lookup.InputParams, lookup.AllParams = inputParams, allParams
+	if err != nil {
+		lookup.Error = err.Error()
+		lookup.Suggestions = globalResolver.Suggest(funcName, 3)
+	}
+
+	return lookup
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandler_Status(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "greet", mustNewFunction(t, testFunc1).WithExamples(map[string]any{"name": "Alice"}))
+
+	rec := httptest.NewRecorder()
+	DebugHandler(reg).ServeHTTP(rec, httptest.NewRequest("GET", "/debug/dwarfreflect", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected content type: %s", ct)
+	}
+
+	var status debugStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if !status.DWARFAvailable || status.FunctionCount == 0 {
+		t.Errorf("expected DWARF to be reported available, got %+v", status)
+	}
+
+	greet, ok := status.Functions["greet"]
+	if !ok {
+		t.Fatalf("expected greet in functions, got %v", status.Functions)
+	}
+	if len(greet.Params) != 2 || !reflect.DeepEqual(greet.Params[0], debugValue{Name: "name", Type: "string", Examples: []any{"Alice"}}) {
+		t.Errorf("unexpected params: %+v", greet.Params)
+	}
+	if greet.Kind != "Go" || greet.Provenance == "" {
+		t.Errorf("unexpected function info: %+v", greet)
+	}
+}
+
+func TestDebugHandler_Lookup(t *testing.T) {
+	mustNewFunction(t, testFunc1)
+
+	rec := httptest.NewRecorder()
+	DebugHandler().ServeHTTP(rec, httptest.NewRequest("GET",
+		"/debug/dwarfreflect?lookup=github.com/matteo-grella/dwarfreflect.testFunc1", nil))
+
+	var lookup debugLookup
+	if err := json.Unmarshal(rec.Body.Bytes(), &lookup); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(lookup.Candidates) == 0 || !lookup.Candidates[0].Found {
+		t.Errorf("expected the first candidate to be found, got %+v", lookup.Candidates)
+	}
+	if len(lookup.InputParams) != 2 || lookup.Error != "" {
+		t.Errorf("unexpected lookup: %+v", lookup)
+	}
+
+	rec = httptest.NewRecorder()
+	DebugHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/?lookup=missing.Function", nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), &lookup); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if lookup.Error == "" {
+		t.Error("expected error for missing function")
+	}
+
+	rec = httptest.NewRecorder()
+	DebugHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/?lookup=github.com/matteo-grella/dwarfreflect.testFunc", nil))
+	lookup = debugLookup{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &lookup); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(lookup.Suggestions) != 3 || !strings.HasPrefix(lookup.Suggestions[0], "github.com/matteo-grella/dwarfreflect.testFunc") {
+		t.Errorf("expected 3 suggestions, got %v", lookup.Suggestions)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DeprecationOptions configures a deprecation, see Function.Deprecate.
+type DeprecationOptions struct {
+	// OnCall is called before every call of the deprecated function, e.g. to
+	// count the remaining callers of an old API version by CallMeta.Caller.
+	OnCall func(ctx context.Context, fn *Function)
+
+	// Date is when the function was or will be deprecated, sent in the
+	// Deprecation header of SetDeprecationHeaders.
+	Date time.Time
+}
+
+// deprecation is the deprecation of a Function, shared by its copies.
+type deprecation struct {
+	message string
+	options DeprecationOptions
+	logged  sync.Once
+}
+
+// Deprecate returns a copy of the Function marked deprecated, with message
+// telling callers what to use instead. The deprecation surfaces wherever the
+// function is described: Registry.Deprecations, JSONSchema ("deprecated":
+// true), the generated TypeScript (@deprecated) and the debug handler. Calls
+// still succeed: the first is logged at warning level through the package
+// logger (see SetLogger) and every one is reported to
+// DeprecationOptions.OnCall. sseadapter adds the headers of
+// SetDeprecationHeaders to its responses; other HTTP handlers call it
+// themselves.
+//
+// Example:
+//
+//	reg.Register("users.Create", fn.Deprecate("use users.CreateV2"))
+func (t *Function) Deprecate(message string, opts ...DeprecationOptions) *Function {
+	var options DeprecationOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	clone := *t
+	clone.deprecation = &deprecation{message: message, options: options}
+	return &clone
+}
+
+// Deprecation returns the deprecation message of the function, reporting
+// whether it is deprecated.
+func (t *Function) Deprecation() (string, bool) {
+	if t.deprecation == nil {
+		return "", false
+	}
+	return t.deprecation.message, true
+}
+
+// warnDeprecated reports a call of a deprecated function, see Deprecate.
+func (t *Function) warnDeprecated(ctx context.Context) {
+	t.deprecation.logged.Do(func() {
+		Logger().Warn("call to deprecated function", "function", t.funcName, "deprecation", t.deprecation.message)
+	})
+	if onCall := t.deprecation.options.OnCall; onCall != nil {
+		onCall(ctx, t)
+	}
+}
+
+// Deprecations returns the deprecation messages of the deprecated functions of
+// the registry by name.
+//
+// Example:
+//
+//	for name, message := range reg.Deprecations() {
+//	    fmt.Printf("%s is deprecated: %s\n", name, message)
+//	}
+func (r *Registry) Deprecations() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deprecations := make(map[string]string)
+	for name, fn := range r.functions {
+		if message, ok := fn.Deprecation(); ok {
+			deprecations[name] = message
+		}
+	}
+	return deprecations
+}
+
+// SetDeprecationHeaders marks the response to a call of fn as deprecated when
+// fn is: a Deprecation header and a Warning header with the deprecation
+// message. With DeprecationOptions.Date, the Deprecation header is the date
+// as defined by RFC 9745 (@ and Unix seconds); without, it is "true", as in
+// the drafts preceding it. It does nothing for other functions.
+//
+// Example:
+//
+//	fn = fn.Deprecate("use users.CreateV2", dwarfreflect.DeprecationOptions{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+//	dwarfreflect.SetDeprecationHeaders(w.Header(), fn)
+//	// Deprecation: @1748736000
+//	// Warning: 299 - "Deprecated: use users.CreateV2"
+func SetDeprecationHeaders(header http.Header, fn *Function) {
+	message, ok := fn.Deprecation()
+	if !ok {
+		return
+	}
+	if date := fn.deprecation.options.Date; !date.IsZero() {
+		header.Set("Deprecation", "@"+strconv.FormatInt(date.Unix(), 10))
+	} else {
+		header.Set("Deprecation", "true")
+	}
+	header.Set("Warning", "299 - "+strconv.Quote("Deprecated: "+message))
+}
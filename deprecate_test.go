@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func deprecateTestCreateUser(userName string) (created bool) {
+	return userName != ""
+}
+
+func TestDeprecate(t *testing.T) {
+	var calls int
+	base := mustNewFunction(t, deprecateTestCreateUser)
+	fn := base.Deprecate("use users.CreateV2", DeprecationOptions{
+		OnCall: func(ctx context.Context, fn *Function) { calls++ },
+	})
+	if _, ok := base.Deprecation(); ok {
+		t.Error("expected the original Function to be left alone")
+	}
+	if message, ok := fn.Deprecation(); !ok || message != "use users.CreateV2" {
+		t.Errorf("unexpected deprecation %q, %v", message, ok)
+	}
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { SetLogger(nil) })
+	for range 3 {
+		if _, err := fn.CallWithMap(map[string]any{"userName": "ann"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected OnCall for every call, got %d", calls)
+	}
+	if got := strings.Count(buf.String(), "call to deprecated function"); got != 1 {
+		t.Errorf("expected one warning, got %d in %q", got, buf.String())
+	}
+
+	reg := NewRegistry()
+	reg.Register("users.Create", fn)
+	reg.Register("users.CreateV2", base)
+	if got := reg.Deprecations(); len(got) != 1 || got["users.Create"] != "use users.CreateV2" {
+		t.Errorf("unexpected deprecations %v", got)
+	}
+
+	schema, _ := json.Marshal(fn.JSONSchema())
+	if !strings.Contains(string(schema), `"description":"Deprecated: use users.CreateV2"`) || !strings.Contains(string(schema), `"deprecated":true`) {
+		t.Errorf("expected a deprecated schema, got %s", schema)
+	}
+
+	var ts strings.Builder
+	if err := reg.EmitTypeScriptClient(&ts, TypeScriptOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(ts.String(), "/** @deprecated use users.CreateV2 */"); got != 3 {
+		t.Errorf("expected the params, results and method deprecated, got %d in\n%s", got, ts.String())
+	}
+
+	header := http.Header{}
+	SetDeprecationHeaders(header, base)
+	if len(header) != 0 {
+		t.Errorf("expected no headers, got %v", header)
+	}
+	SetDeprecationHeaders(header, fn)
+	if header.Get("Deprecation") != "true" || header.Get("Warning") != `299 - "Deprecated: use users.CreateV2"` {
+		t.Errorf("unexpected headers %v", header)
+	}
+
+	dated := base.Deprecate("use users.CreateV2", DeprecationOptions{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+	SetDeprecationHeaders(header, dated)
+	if got := header.Get("Deprecation"); got != "@1748736000" {
+		t.Errorf("expected an RFC 9745 date, got %q", got)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"debug/dwarf"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DWARFParameter is a formal parameter of a function as recorded in DWARF.
+type DWARFParameter struct {
+	Name   string
+	Type   string // Go type name, e.g. "[]string" or "*main.User"
+	Result bool   // true for result parameters
+}
+
+// SignatureChange describes a function whose parameters differ between two binaries.
+type SignatureChange struct {
+	Function string
+	Old      []DWARFParameter
+	New      []DWARFParameter
+}
+
+// BinaryDiff reports function signature differences between two binaries.
+type BinaryDiff struct {
+	Added   []string
+	Removed []string
+	Changed []SignatureChange
+}
+
+// Empty reports whether the two binaries expose identical signatures.
+func (d *BinaryDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffBinaries compares the DWARF signatures of functions whose name starts with
+// packagePrefix in the executables at pathA (old) and pathB (new), reporting
+// added and removed functions and parameter name or type changes.
+// Useful in CI to detect accidental API breaks in plugin contracts.
+//
+// Example:
+//
+//	diff, err := dwarfreflect.DiffBinaries("old/app", "new/app", "github.com/org/app/plugins")
+//	for _, c := range diff.Changed {
+//	    fmt.Println("changed:", c.Function)
+//	}
+func DiffBinaries(pathA, pathB, packagePrefix string) (*BinaryDiff, error) {
+	resolverA, err := NewDWARFResolver(pathA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", pathA, err)
+	}
+	resolverB, err := NewDWARFResolver(pathB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", pathB, err)
+	}
+
+	return DiffResolvers(resolverA, resolverB, packagePrefix)
+}
+
+// DiffResolvers is like DiffBinaries for already loaded resolvers, e.g. ones
+// created with NewResolverFromReader from binaries held in memory.
+func DiffResolvers(a, b *DWARFResolver, packagePrefix string) (*BinaryDiff, error) {
+	sigsA, err := a.collectSignatures(packagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signatures from %s: %w", a.source(), err)
+	}
+	sigsB, err := b.collectSignatures(packagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signatures from %s: %w", b.source(), err)
+	}
+
+	return diffSignatures(sigsA, sigsB), nil
+}
+
+// diffSignatures compares two signature sets, with results sorted by function name.
+func diffSignatures(oldSigs, newSigs map[string][]DWARFParameter) *BinaryDiff {
+	diff := &BinaryDiff{}
+
+	for name, oldParams := range oldSigs {
+		newParams, exists := newSigs[name]
+		if !exists {
+			diff.Removed = append(diff.Removed, name)
+			continue
+		}
+		if !slices.Equal(oldParams, newParams) {
+			diff.Changed = append(diff.Changed, SignatureChange{
+				Function: name,
+				Old:      oldParams,
+				New:      newParams,
+			})
+		}
+	}
+
+	for name := range newSigs {
+		if _, exists := oldSigs[name]; !exists {
+			diff.Added = append(diff.Added, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Function < diff.Changed[j].Function
+	})
+
+	return diff
+}
+
+// Signatures returns the DWARF parameters, with their types, of every function
+// whose name starts with packagePrefix, keyed by function name. Inputs come
+// first, then results.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewDWARFResolver("bin/app")
+//	sigs, err := dr.Signatures("main.")
+//	for _, p := range sigs["main.CreateUser"] {
+//	    fmt.Println(p.Name, p.Type, p.Result)
+//	}
+func (dr *DWARFResolver) Signatures(packagePrefix string) (map[string][]DWARFParameter, error) {
+	return dr.collectSignatures(packagePrefix)
+}
+
+// collectSignatures walks the DWARF data and returns the parameters, with their
+// types, of every function whose name starts with prefix.
+func (dr *DWARFResolver) collectSignatures(prefix string) (map[string][]DWARFParameter, error) {
+	reader := dr.dwarfData.Reader()
+	typeNames := make(map[dwarf.Offset]string)
+	signatures := make(map[string][]DWARFParameter)
+
+	for {
+		entry, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			break
+		}
+
+		if entry.Tag != dwarf.TagSubprogram || !entry.Children {
+			continue
+		}
+
+		funcName, _ := entry.Val(dwarf.AttrName).(string)
+		if funcName == "" || !strings.HasPrefix(funcName, prefix) {
+			reader.SkipChildren()
+			continue
+		}
+
+		params, err := dr.readParameters(reader, typeNames)
+		if err != nil {
+			return nil, err
+		}
+		signatures[funcName] = params
+	}
+
+	return signatures, nil
+}
+
+// readParameters reads the formal parameters, with their types, among the
+// children of the subprogram entry reader was positioned after.
+func (dr *DWARFResolver) readParameters(reader *dwarf.Reader, typeNames map[dwarf.Offset]string) ([]DWARFParameter, error) {
+	params := []DWARFParameter{}
+	for {
+		child, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		if child == nil || child.Tag == 0 {
+			return params, nil
+		}
+
+		if child.Tag == dwarf.TagFormalParameter {
+			name, _ := child.Val(dwarf.AttrName).(string)
+			result, _ := child.Val(dwarf.AttrVarParam).(bool)
+			params = append(params, DWARFParameter{
+				Name:   name,
+				Type:   dr.typeName(child, typeNames),
+				Result: result,
+			})
+		}
+
+		if child.Children {
+			reader.SkipChildren()
+		}
+	}
+}
+
+// typeName returns the Go type name referenced by entry, caching lookups by offset.
+func (dr *DWARFResolver) typeName(entry *dwarf.Entry, cache map[dwarf.Offset]string) string {
+	offset, ok := entry.Val(dwarf.AttrType).(dwarf.Offset)
+	if !ok {
+		return ""
+	}
+
+	if name, ok := cache[offset]; ok {
+		return name
+	}
+
+	var name string
+	reader := dr.dwarfData.Reader()
+	reader.Seek(offset)
+	if typeEntry, err := reader.Next(); err == nil && typeEntry != nil {
+		name, _ = typeEntry.Val(dwarf.AttrName).(string)
+	}
+	cache[offset] = name
+
+	return name
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffSignatures(t *testing.T) {
+	oldSigs := map[string][]DWARFParameter{
+		"pkg.Kept":    {{Name: "id", Type: "int"}},
+		"pkg.Renamed": {{Name: "id", Type: "int"}},
+		"pkg.Retyped": {{Name: "id", Type: "int"}, {Name: "~r0", Type: "error", Result: true}},
+		"pkg.Gone":    {},
+	}
+	newSigs := map[string][]DWARFParameter{
+		"pkg.Kept":    {{Name: "id", Type: "int"}},
+		"pkg.Renamed": {{Name: "userID", Type: "int"}},
+		"pkg.Retyped": {{Name: "id", Type: "int64"}, {Name: "~r0", Type: "error", Result: true}},
+		"pkg.New":     {},
+	}
+
+	diff := diffSignatures(oldSigs, newSigs)
+
+	if !reflect.DeepEqual(diff.Added, []string{"pkg.New"}) {
+		t.Errorf("unexpected added: %v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []string{"pkg.Gone"}) {
+		t.Errorf("unexpected removed: %v", diff.Removed)
+	}
+	if len(diff.Changed) != 2 || diff.Changed[0].Function != "pkg.Renamed" || diff.Changed[1].Function != "pkg.Retyped" {
+		t.Errorf("unexpected changed: %+v", diff.Changed)
+	}
+	if diff.Empty() {
+		t.Error("expected non-empty diff")
+	}
+}
+
+func TestDiffBinaries_Self(t *testing.T) {
+	execPath, err := os.Executable()
+	if err != nil {
+		t.Skipf("Cannot get executable path: %v", err)
+	}
+
+	diff, err := DiffBinaries(execPath, execPath, "github.com/matteo-grella/dwarfreflect.")
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !diff.Empty() {
+		t.Errorf("expected no differences comparing a binary with itself, got %+v", diff)
+	}
+}
+
+func TestCollectSignatures(t *testing.T) {
+	initResolver()
+	if resolverInitErr != nil {
+		t.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+
+	sigs, err := globalResolver.collectSignatures("github.com/matteo-grella/dwarfreflect.testFuncNamedResults")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []DWARFParameter{
+		{Name: "a", Type: "int"},
+		{Name: "b", Type: "int"},
+		{Name: "quotient", Type: "int", Result: true},
+		{Name: "err", Type: "error", Result: true},
+	}
+	params := sigs["github.com/matteo-grella/dwarfreflect.testFuncNamedResults"]
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected %+v, got %+v", expected, params)
+	}
+}
+
+func TestDiffBinaries_MissingFile(t *testing.T) {
+	if _, err := DiffBinaries("/non/existent/a", "/non/existent/b", ""); err == nil {
+		t.Error("expected error for non-existent binaries")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// maxRecentErrors is the number of call errors kept for DumpState.
+const maxRecentErrors = 32
+
+// recentError is a failed call as reported by DumpState.
+type recentError struct {
+	time     time.Time
+	function string
+	binding  bool // arguments could not be bound, the function was not called
+	err      string
+}
+
+// recentErrors is a ring buffer of the latest call errors of all Functions.
+var recentErrors struct {
+	mu      sync.Mutex
+	entries [maxRecentErrors]recentError
+	total   int
+}
+
+// recordError adds a failed call to the recent errors.
+func recordError(fn *Function, binding bool, err error) {
+	entry := recentError{time: time.Now(), function: fn.funcName, binding: binding, err: err.Error()}
+
+	recentErrors.mu.Lock()
+	recentErrors.entries[recentErrors.total%maxRecentErrors] = entry
+	recentErrors.total++
+	recentErrors.mu.Unlock()
+}
+
+// DumpState writes a human-readable snapshot of the package state to w: DWARF
+// resolver status, cache sizes, the functions of the given registries and the
+// most recent call errors of all Functions. It is meant for debugging
+// production incidents involving dynamic dispatch without a debugger; see
+// DumpOnSignal to trigger it externally.
+//
+// Example:
+//
+//	dwarfreflect.DumpState(os.Stderr, reg)
+func DumpState(w io.Writer, registries ...*Registry) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "dwarfreflect state at %s\n", time.Now().Format(time.RFC3339))
+
+	fmt.Fprintln(tw, "\nresolver:")
+	available, funcCount, err := GetDWARFStatus()
+	fmt.Fprintf(tw, "  dwarf available:\t%v\n", available)
+	fmt.Fprintf(tw, "  indexed functions:\t%d\n", funcCount)
+	if err != nil {
+		fmt.Fprintf(tw, "  error:\t%v\n", err)
+	}
+	format, execPath, _ := GetExecutableInfo()
+	fmt.Fprintf(tw, "  executable:\t%s (%s)\n", execPath, format)
+	supported, reason, _ := IsDWARFSupported()
+	fmt.Fprintf(tw, "  supported:\t%v (%s)\n", supported, reason)
+
+	fmt.Fprintln(tw, "\ncaches:")
+	fmt.Fprintf(tw, "  resolved functions:\t%d\n", countEntries(&functionInfos))
+	fmt.Fprintf(tw, "  wrapped functions:\t%d\n", countEntries(&wrapped))
+
+	for i, reg := range registries {
+		names := reg.Names()
+		fmt.Fprintf(tw, "\nregistry %d (%d functions):\n", i+1, len(names))
+		for _, name := range names {
+			fn, ok := reg.Get(name)
+			if !ok {
+				continue // unregistered meanwhile
+			}
+			fmt.Fprintf(tw, "  %s\t%s%s\t%s\n", name, fn.GetBaseFunctionName(), fn.Signature()[len("func"):], fn.provenance)
+		}
+	}
+
+	recentErrors.mu.Lock()
+	total := recentErrors.total
+	entries := recentErrors.entries
+	recentErrors.mu.Unlock()
+
+	shown := min(total, maxRecentErrors)
+	fmt.Fprintf(tw, "\nrecent errors (%d of %d):\n", shown, total)
+	for i := total - 1; i >= total-shown; i-- {
+		entry := entries[i%maxRecentErrors]
+		kind := "call"
+		if entry.binding {
+			kind = "binding"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", entry.time.Format(time.RFC3339Nano), entry.function, kind, entry.err)
+	}
+
+	return tw.Flush()
+}
+
+// countEntries returns the number of entries of a cache: functionInfos for
+// the functions whose resolution is cached, wrapped for the Wrap cache.
+func countEntries(cache *sync.Map) int {
+	count := 0
+	cache.Range(func(key, value any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// callError returns the error of a completed call: the call's own error or
+// the function's trailing error result.
+func callError(results []reflect.Value, err error) error {
+	if err != nil {
+		return err
+	}
+	return trailingError(results)
+}
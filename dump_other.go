@@ -0,0 +1,17 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build !unix
+
+package dwarfreflect
+
+import (
+	"errors"
+	"io"
+)
+
+// DumpOnSignal writes DumpState to w each time the process receives SIGUSR1.
+// SIGUSR1 does not exist on this platform: call DumpState directly instead.
+func DumpOnSignal(w io.Writer, registries ...*Registry) (stop func(), err error) {
+	return nil, errors.New("dwarfreflect: DumpOnSignal requires SIGUSR1, unavailable on this platform")
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDumpState(t *testing.T) {
+	reg := NewRegistry()
+	fn := mustRegister(t, reg, "Divide", rpcDivide)
+	mustRegister(t, reg, "Add", rpcAdd)
+
+	fn.Call(nil, 1, 0)
+	fn.CallWithMap(map[string]any{"a": "x"})
+
+	var out strings.Builder
+	if err := DumpState(&out, reg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dump := out.String()
+
+	for _, expected := range []string{
+		"dwarf available:",
+		"resolved functions:",
+		"wrapped functions:",
+		"registry 1 (2 functions):",
+		"Divide",
+		"rpcDivide(a int, b int) (int, error)",
+		"division by zero",
+		"binding",
+		"missing required parameters",
+	} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("expected %q in dump:\n%s", expected, dump)
+		}
+	}
+}
+
+func TestRecentErrors_Ring(t *testing.T) {
+	fn := mustNewFunction(t, rpcDivide)
+	for range maxRecentErrors + 5 {
+		fn.Call(nil, 1, 0)
+	}
+
+	var out strings.Builder
+	DumpState(&out)
+	if !strings.Contains(out.String(), "recent errors (32 of") {
+		t.Errorf("expected the ring to keep %d errors:\n%s", maxRecentErrors, out.String())
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build unix
+
+package dwarfreflect
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// DumpOnSignal writes DumpState to w each time the process receives SIGUSR1,
+// until stop is called. It is opt-in: nothing listens for signals unless it is
+// called. On platforms without SIGUSR1 it returns an error.
+//
+// Example:
+//
+//	stop, err := dwarfreflect.DumpOnSignal(os.Stderr, reg)
+//	defer stop()
+//	// kill -USR1 <pid>
+func DumpOnSignal(w io.Writer, registries ...*Registry) (stop func(), err error) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				DumpState(w, registries...)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}, nil
+}
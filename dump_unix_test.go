@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build unix
+
+package dwarfreflect
+
+import (
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// syncWriter collects writes from the signal goroutine.
+type syncWriter struct {
+	mu  sync.Mutex
+	out strings.Builder
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.Write(p)
+}
+
+func (w *syncWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.String()
+}
+
+func TestDumpOnSignal(t *testing.T) {
+	var w syncWriter
+	stop, err := DumpOnSignal(&w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(w.String(), "recent errors") {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a dump after SIGUSR1")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stop()
+	stop() // idempotent
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package dwarfreflecttest provides helpers for testing code built on
+// dwarfreflect: skipping tests when the test binary has no DWARF (e.g. when
+// run with -ldflags=-w), building fixture binaries for resolver tests, and
+// golden signature assertions.
+//
+// Example:
+//
+//	func TestHandler(t *testing.T) {
+//	    fn := dwarfreflecttest.NewFunction(t, CreateUser)
+//	    dwarfreflecttest.AssertSignature(t, fn, "func(name string, age int) error")
+//	}
+package dwarfreflecttest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGoldenReport
+// rewrite golden files instead of comparing against them.
+const UpdateGoldenEnv = "DWARFREFLECT_UPDATE_GOLDEN"
+
+// RequireDWARF skips the test when the running binary has no usable DWARF
+// debug information.
+func RequireDWARF(t testing.TB) {
+	t.Helper()
+	if available, _, err := dwarfreflect.GetDWARFStatus(); !available {
+		t.Skipf("DWARF not available: %v", err)
+	}
+}
+
+// IsDWARFError reports whether err means DWARF information is unavailable,
+// rather than a mistake of the caller.
+func IsDWARFError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "DWARF")
+}
+
+// NewFunction wraps fn with dwarfreflect.NewFunction, skipping the test when
+// DWARF is unavailable and failing it on any other error.
+func NewFunction(t testing.TB, fn any) *dwarfreflect.Function {
+	t.Helper()
+	function, err := dwarfreflect.NewFunction(fn)
+	if err != nil {
+		if IsDWARFError(err) {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("NewFunction: %v", err)
+	}
+	return function
+}
+
+// Register registers fn in reg under name, skipping the test when DWARF is
+// unavailable and failing it on any other error.
+func Register(t testing.TB, reg *dwarfreflect.Registry, name string, fn any) *dwarfreflect.Function {
+	t.Helper()
+	function, err := reg.Register(name, fn)
+	if err != nil {
+		if IsDWARFError(err) {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("Register(%q): %v", name, err)
+	}
+	return function
+}
+
+// BuildOptions customizes fixture builds.
+type BuildOptions struct {
+	// StripDWARF links with -w, producing a binary without DWARF.
+	StripDWARF bool
+
+	// GOOS and GOARCH cross-compile the fixture, e.g. to get PE or Mach-O
+	// binaries on Linux. Default: the host platform.
+	GOOS   string
+	GOARCH string
+}
+
+// BuildFixture compiles source, the contents of a main package's main.go, into
+// a binary in a temporary directory and returns its path. The test is skipped
+// when no Go toolchain is available and fails when the build does.
+//
+// Example:
+//
+//	path := dwarfreflecttest.BuildFixture(t, `package main
+//	func Greet(name string) string { return "hi " + name }
+//	func main() { Greet("x") }`)
+//	resolver, err := dwarfreflect.NewDWARFResolver(path)
+func BuildFixture(t testing.TB, source string, opts ...BuildOptions) string {
+	t.Helper()
+	var options BuildOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("Go toolchain not available: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module fixture\n\ngo 1.24\n",
+		"main.go": source,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("BuildFixture: %v", err)
+		}
+	}
+
+	binary := filepath.Join(dir, "fixture")
+	if goos := options.GOOS; goos == "windows" || (goos == "" && runtime.GOOS == "windows") {
+		binary += ".exe"
+	}
+	// Keep the fixture's functions as written: no inlining or optimizations
+	args := []string{"build", "-o", binary, "-gcflags=all=-N -l"}
+	if options.StripDWARF {
+		args = append(args, "-ldflags=-w")
+	}
+
+	cmd := exec.Command(goTool, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "GOFLAGS=")
+	if options.GOOS != "" {
+		cmd.Env = append(cmd.Env, "GOOS="+options.GOOS)
+	}
+	if options.GOARCH != "" {
+		cmd.Env = append(cmd.Env, "GOARCH="+options.GOARCH)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("BuildFixture: go build failed: %v\n%s", err, output)
+	}
+	return binary
+}
+
+// AssertSignature fails the test unless fn's named non-context signature, as
+// rendered by Function.Signature, is want.
+func AssertSignature(t testing.TB, fn *dwarfreflect.Function, want string) {
+	t.Helper()
+	if got := fn.Signature(); got != want {
+		t.Errorf("signature of %s:\n got: %s\nwant: %s", fn.GetFunctionName(), got, want)
+	}
+}
+
+// AssertParameterNames fails the test unless fn's parameter names, context
+// parameters included, are want.
+func AssertParameterNames(t testing.TB, fn *dwarfreflect.Function, want ...string) {
+	t.Helper()
+	got, _ := fn.GetParameterInfo()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("parameter names of %s: got %v, want %v", fn.GetFunctionName(), got, want)
+	}
+}
+
+// AssertGoldenReport compares the signatures of the functions registered in
+// reg against the golden file at path, one "name signature" line per
+// function, so that changes to the dynamic surface show up in review. Run the
+// tests with DWARFREFLECT_UPDATE_GOLDEN=1 to write the file.
+func AssertGoldenReport(t testing.TB, reg *dwarfreflect.Registry, path string) {
+	t.Helper()
+
+	var got bytes.Buffer
+	for _, fn := range reg.Report().Functions {
+		fmt.Fprintf(&got, "%s %s\n", fn.Name, fn.Signature)
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("AssertGoldenReport: %v", err)
+		}
+		if err := os.WriteFile(path, got.Bytes(), 0o644); err != nil {
+			t.Fatalf("AssertGoldenReport: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("AssertGoldenReport: %v (run with %s=1 to create it)", err, UpdateGoldenEnv)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("signatures differ from %s (run with %s=1 to update):\n got:\n%s\nwant:\n%s", path, UpdateGoldenEnv, got.Bytes(), want)
+	}
+}
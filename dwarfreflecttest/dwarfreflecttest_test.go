@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflecttest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+func createUser(ctx context.Context, name string, age int) (id int, err error) {
+	return 0, nil
+}
+
+func deleteUser(id int) error {
+	return nil
+}
+
+const fixtureSource = `package main
+
+func Greet(name string, times int) string {
+	out := ""
+	for range times {
+		out += "hi " + name
+	}
+	return out
+}
+
+func main() {
+	println(Greet("x", 1))
+}
+`
+
+func TestNewFunction(t *testing.T) {
+	RequireDWARF(t)
+
+	fn := NewFunction(t, createUser)
+	AssertParameterNames(t, fn, "ctx", "name", "age")
+	AssertSignature(t, fn, "func(name string, age int) (int, error)")
+}
+
+func TestIsDWARFError(t *testing.T) {
+	if IsDWARFError(nil) || IsDWARFError(errors.New("boom")) {
+		t.Error("unexpected DWARF error")
+	}
+	if !IsDWARFError(errors.New("DWARF debug information not available")) {
+		t.Error("expected DWARF error")
+	}
+}
+
+func TestAssertGoldenReport(t *testing.T) {
+	reg := dwarfreflect.NewRegistry()
+	Register(t, reg, "users.Create", createUser)
+	Register(t, reg, "users.Delete", deleteUser)
+
+	AssertGoldenReport(t, reg, filepath.Join("testdata", "users.golden"))
+
+	// Missing golden files are written when updating
+	path := filepath.Join(t.TempDir(), "new", "report.golden")
+	t.Setenv(UpdateGoldenEnv, "1")
+	AssertGoldenReport(t, reg, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the golden file to be written: %v", err)
+	}
+	expected, _ := os.ReadFile(filepath.Join("testdata", "users.golden"))
+	if string(data) != string(expected) {
+		t.Errorf("unexpected golden file:\n%s", data)
+	}
+}
+
+func TestBuildFixture(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds binaries")
+	}
+
+	resolver, err := dwarfreflect.NewDWARFResolver(BuildFixture(t, fixtureSource))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, ok := resolver.ParameterNames("main.Greet")
+	if !ok || len(names) < 2 || !slices.Equal(names[:2], []string{"name", "times"}) { // results follow
+		t.Errorf("unexpected parameter names %v (%v)", names, ok)
+	}
+
+	stripped := BuildFixture(t, fixtureSource, BuildOptions{StripDWARF: true})
+	if _, err := dwarfreflect.NewDWARFResolver(stripped); err == nil {
+		t.Error("expected stripped fixture to have no DWARF")
+	}
+}
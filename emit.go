@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// EmitGoStruct writes Go declarations of the parameter and result structs of
+// the function, as returned by GetStructType and GetResultStructType, tags
+// included: typeName+"Params" for the parameters and typeName+"Results" for
+// the results, if there are any besides a trailing error. The output is
+// gofmt-formatted and has neither a package clause nor imports, which the
+// caller provides for the packages of the referenced types. Use it to freeze
+// shapes generated at run time into source; see cmd/dwarfreflect-emit to
+// emit them from the DWARF of a binary instead.
+//
+// Example:
+//
+//	func CreateUser(ctx context.Context, userName string, age int) (id int, err error)
+//	err := fn.EmitGoStruct(os.Stdout, "CreateUser")
+//	// // CreateUserParams holds the parameters of main.CreateUser.
+//	// type CreateUserParams struct {
+//	//     Ctx      context.Context `json:"ctx" param:"ctx"`
+//	//     UserName string          `json:"userName" param:"userName"`
+//	//     Age      int             `json:"age" param:"age"`
+//	// }
+//	//
+//	// // CreateUserResults holds the results of main.CreateUser.
+//	// type CreateUserResults struct {
+//	//     Id int `json:"id"`
+//	// }
+func (t *Function) EmitGoStruct(w io.Writer, typeName string) error {
+	if !token.IsIdentifier(typeName) {
+		return fmt.Errorf("invalid type name %q", typeName)
+	}
+
+	var src bytes.Buffer
+	writeGoStruct(&src, typeName+"Params", "parameters", t.funcName, t.structType)
+	if t.resultType.NumField() > 0 {
+		src.WriteString("\n")
+		writeGoStruct(&src, typeName+"Results", "results", t.funcName, t.resultType)
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot format structs of function %s: %w", t.funcName, err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// writeGoStruct writes the declaration of a named struct type with the fields
+// of typ.
+func writeGoStruct(buf *bytes.Buffer, typeName, what, funcName string, typ reflect.Type) {
+	fmt.Fprintf(buf, "// %s holds the %s of %s.\n", typeName, what, funcName)
+	fmt.Fprintf(buf, "type %s struct {\n", typeName)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fmt.Fprintf(buf, "\t%s %s", field.Name, field.Type)
+		if field.Tag != "" {
+			buf.WriteString(" " + tagLiteral(field.Tag))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// tagLiteral quotes a struct tag as a raw string when possible, as tags are
+// usually written.
+func tagLiteral(tag reflect.StructTag) string {
+	if strconv.CanBackquote(string(tag)) {
+		return "`" + string(tag) + "`"
+	}
+	return strconv.Quote(string(tag))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEmitGoStruct(t *testing.T) {
+	fn := mustNewFunction(t, testFuncNamedResults)
+
+	var buf bytes.Buffer
+	if err := fn.EmitGoStruct(&buf, "Divide"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "// DivideParams holds the parameters of github.com/matteo-grella/dwarfreflect.testFuncNamedResults.\n" +
+		"type DivideParams struct {\n" +
+		"\tA int `json:\"a\" param:\"a\"`\n" +
+		"\tB int `json:\"b\" param:\"b\"`\n" +
+		"}\n\n" +
+		"// DivideResults holds the results of github.com/matteo-grella/dwarfreflect.testFuncNamedResults.\n" +
+		"type DivideResults struct {\n" +
+		"\tQuotient int `json:\"quotient\"`\n" +
+		"}\n"
+	if buf.String() != want {
+		t.Errorf("unexpected source:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestEmitGoStruct_ErrorOnly(t *testing.T) {
+	fn := mustNewFunction(t, testFuncErrorOnly)
+
+	var buf bytes.Buffer
+	if err := fn.EmitGoStruct(&buf, "Check"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("CheckResults")) {
+		t.Errorf("expected no results struct, got:\n%s", buf.String())
+	}
+
+	if err := fn.EmitGoStruct(&buf, "not valid"); err == nil {
+		t.Error("expected error for an invalid type name")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrorContextOptions configures the call context added to errors by
+// WithErrorContext.
+type ErrorContextOptions struct {
+	// Redact lists parameters whose values are replaced by <redacted>, besides
+	// those marked ParamMeta.Sensitive and those whose names contain
+	// "password", "secret" or "token", ignoring case.
+	Redact []string
+
+	// MaxValueLength truncates longer formatted values, ending them with "...".
+	// Default: 64; negative: no limit.
+	MaxValueLength int
+}
+
+// WithErrorContext returns a copy of the Function wrapping the trailing error
+// returned by the function with the call and its arguments, named as the
+// parameters, so errors bubbling out of dynamic dispatch tell which call
+// failed. context.Context, unsafe and redacted parameters are left out or
+// masked; the original error stays reachable with errors.Is and errors.As.
+// Errors of the call itself, such as binding errors, are not wrapped.
+//
+// Example:
+//
+//	func ProcessUser(ctx context.Context, id int, name string, token string) error
+//	fn = fn.WithErrorContext()
+//	err := fn.CallVoid(map[string]any{"ctx": ctx, "id": 42, "name": "x", "token": "t0k"})
+//	// ProcessUser(id=42, name="x", token=<redacted>): user not found
+func (t *Function) WithErrorContext(opts ...ErrorContextOptions) *Function {
+	var options ErrorContextOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	options.Redact = slices.Clone(options.Redact)
+	if options.MaxValueLength == 0 {
+		options.MaxValueLength = 64
+	}
+
+	clone := *t
+	clone.errorContext = &options
+	return &clone
+}
+
+// addErrorContext wraps the trailing error of results, if any, with the call
+// made with args.
+func (t *Function) addErrorContext(results []reflect.Value, args []reflect.Value) {
+	err := trailingError(results)
+	if err == nil {
+		return
+	}
+	wrapped := fmt.Errorf("%s: %w", t.describeCall(args), err)
+	results[len(results)-1] = reflect.ValueOf(&wrapped).Elem()
+}
+
+// describeCall formats the call made with args, e.g. ProcessUser(id=42, name="x").
+func (t *Function) describeCall(args []reflect.Value) string {
+	var b strings.Builder
+	b.WriteString(t.GetBaseFunctionName())
+	b.WriteString("(")
+	first := true
+	for i, arg := range args {
+		name, typ := t.paramNames[i], t.paramTypes[i]
+		if typ == contextType {
+			continue
+		}
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+
+		b.WriteString(name)
+		b.WriteString("=")
+		switch {
+		case t.isRedacted(name) || isUnsafeType(typ):
+			b.WriteString("<redacted>")
+		default:
+			b.WriteString(t.formatArg(arg))
+		}
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// isRedacted reports whether the value of the named parameter must not
+// appear in error context or argument diffs.
+func (t *Function) isRedacted(name string) bool {
+	if t.paramMeta[name].Sensitive || (t.errorContext != nil && slices.Contains(t.errorContext.Redact, name)) {
+		return true
+	}
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "password") || strings.Contains(lower, "secret") || strings.Contains(lower, "token")
+}
+
+// formatArg formats an argument value, quoting strings and truncating long
+// values.
+func (t *Function) formatArg(arg reflect.Value) string {
+	var s string
+	if arg.Kind() == reflect.String {
+		s = fmt.Sprintf("%q", arg.String())
+	} else if arg.IsValid() && arg.CanInterface() {
+		s = fmt.Sprintf("%v", arg.Interface())
+	} else {
+		s = "<invalid>"
+	}
+
+	if limit := t.errorContext.MaxValueLength; limit > 0 && len(s) > limit {
+		s = strings.ToValidUTF8(s[:limit], "") + "..."
+	}
+	return s
+}
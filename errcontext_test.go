@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errUserNotFound = errors.New("user not found")
+
+func testFuncProcessUser(ctx context.Context, id int, name string, apiToken string, pin int) error {
+	if id < 0 {
+		return errUserNotFound
+	}
+	return nil
+}
+
+func TestWithErrorContext(t *testing.T) {
+	fn := mustNewFunction(t, testFuncProcessUser).
+		WithParamMeta("pin", ParamMeta{Sensitive: true}).
+		WithErrorContext()
+
+	err := fn.CallVoid(map[string]any{"ctx": context.Background(), "id": -1, "name": "x", "apiToken": "t0k", "pin": 1234})
+	expected := `testFuncProcessUser(id=-1, name="x", apiToken=<redacted>, pin=<redacted>): user not found`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+	if !errors.Is(err, errUserNotFound) {
+		t.Error("expected the original error to stay reachable")
+	}
+
+	if err := fn.CallVoid(map[string]any{"ctx": context.Background(), "id": 1, "name": "x", "apiToken": "", "pin": 0}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	plain := mustNewFunction(t, testFuncProcessUser)
+	if err := plain.CallVoid(map[string]any{"ctx": context.Background(), "id": -1, "name": "x", "apiToken": "", "pin": 0}); err != errUserNotFound {
+		t.Errorf("expected errors to be left alone by default, got %v", err)
+	}
+}
+
+func TestWithErrorContext_Options(t *testing.T) {
+	fn := mustNewFunction(t, testFuncProcessUser).WithErrorContext(ErrorContextOptions{
+		Redact:         []string{"name"},
+		MaxValueLength: 3,
+	})
+
+	results, err := fn.CallWithContext(context.Background(), -12345, "x", "", 0)
+	if err != nil {
+		t.Fatalf("unexpected call error: %v", err)
+	}
+	got := results[0].Interface().(error).Error()
+	if !strings.HasPrefix(got, "testFuncProcessUser(id=-12..., name=<redacted>,") {
+		t.Errorf("unexpected error context: %s", got)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// procSelfExe refers to the running binary on Linux, even after the file it
+// was started from has been deleted or replaced.
+var procSelfExe = "/proc/self/exe"
+
+// deletedSuffix is appended by Linux to the /proc/self/exe link target once
+// the running binary has been deleted or replaced.
+const deletedSuffix = " (deleted)"
+
+// captured holds the running binary opened at package initialization, so that
+// lazy resolver initialization still finds it when it lives in a temporary
+// directory removed in the meantime, as with go run and cached test binaries.
+var captured struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+func init() {
+	path, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if file, err := openExecutable(path); err == nil {
+		captured.path, captured.file = path, file
+	}
+}
+
+// takeCapturedExecutable returns the binary captured at package initialization,
+// if any, handing over ownership of the file: later calls return nil.
+func takeCapturedExecutable() (string, *os.File) {
+	captured.mu.Lock()
+	defer captured.mu.Unlock()
+
+	path, file := captured.path, captured.file
+	captured.path, captured.file = "", nil
+	return path, file
+}
+
+// InitOptions configures the initialization of the global resolver.
+type InitOptions struct {
+	// ExecutablePath is the binary to read DWARF data from instead of the one
+	// reported by os.Executable, for containers with exotic layouts where the
+	// running binary cannot be located or opened.
+	ExecutablePath string
+
+	// Suggestions is how many of the nearest DWARF function names the error
+	// of a failed lookup lists ("did you mean main.ProcessUserV2?"). Zero
+	// disables them: finding them scans every entry.
+	Suggestions int
+
+	// Cache stores the names resolved from DWARF across processes, e.g. in
+	// Redis for short-lived serverless instances. With a cache, DWARF is
+	// loaded on the first function missing from it rather than at
+	// initialization.
+	Cache Cache
+}
+
+// ExecutableError reports that the running binary could not be opened to read
+// its DWARF data, typically because it was deleted or replaced after start or
+// lives on a filesystem that refuses reads.
+type ExecutableError struct {
+	Path string
+	Err  error
+}
+
+func (e *ExecutableError) Error() string {
+	return fmt.Sprintf("cannot open executable %s: %v (pass the binary path with dwarfreflect.Init(dwarfreflect.InitOptions{ExecutablePath: ...}))",
+		e.Path, e.Err)
+}
+
+func (e *ExecutableError) Unwrap() error {
+	return e.Err
+}
+
+// Init initializes the global resolver used by NewFunction with options.
+// It must be called before any other use of the package, which otherwise
+// initializes the resolver lazily with default options; later calls fail.
+//
+// Example:
+//
+//	func main() {
+//	    if err := dwarfreflect.Init(dwarfreflect.InitOptions{ExecutablePath: "/app/server"}); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}
+func Init(options InitOptions) error {
+	initialized := false
+	resolverOnce.Do(func() {
+		initialized = true
+		initResolverWith(options)
+	})
+	if !initialized {
+		return errors.New("dwarfreflect: resolver already initialized")
+	}
+	return resolverInitErr
+}
+
+// openExecutable opens the running binary found at path. On Linux it falls
+// back to reading /proc/self/exe when the binary was deleted or replaced after
+// start (os.Executable would then name the replacement, or nothing) or when
+// path cannot be opened, e.g. on some FUSE mounts.
+func openExecutable(path string) (*os.File, error) {
+	var openErr error
+	if target, err := os.Readlink(procSelfExe); err == nil && strings.HasSuffix(target, deletedSuffix) {
+		openErr = errors.New("binary was deleted or replaced after start")
+	} else {
+		file, err := os.Open(path)
+		if err == nil {
+			return file, nil
+		}
+		openErr = err
+	}
+
+	if runtime.GOOS == "linux" {
+		if file, err := os.Open(procSelfExe); err == nil {
+			return file, nil
+		}
+	}
+	return nil, &ExecutableError{Path: path, Err: openErr}
+}
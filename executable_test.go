@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestOpenExecutable(t *testing.T) {
+	execPath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := openExecutable(execPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file.Close()
+}
+
+func TestOpenExecutable_Fallback(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("/proc/self/exe fallback is Linux only")
+	}
+
+	file, err := openExecutable(filepath.Join(t.TempDir(), "gone"))
+	if err != nil {
+		t.Fatalf("expected /proc/self/exe fallback, got %v", err)
+	}
+	defer file.Close()
+
+	if format, err := DetectExecutableFormatFromReader(file); err != nil || format != FormatELF {
+		t.Errorf("expected the running ELF binary, got %v, %v", format, err)
+	}
+}
+
+func TestOpenExecutable_Error(t *testing.T) {
+	original := procSelfExe
+	procSelfExe = filepath.Join(t.TempDir(), "exe")
+	defer func() { procSelfExe = original }()
+
+	_, err := openExecutable(filepath.Join(t.TempDir(), "gone"))
+	var execErr *ExecutableError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected *ExecutableError, got %v", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the open error to be wrapped, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ExecutablePath") {
+		t.Errorf("expected remediation in message, got %q", err.Error())
+	}
+}
+
+func TestInit_AlreadyInitialized(t *testing.T) {
+	resolverOnce.Do(initResolver)
+	if err := Init(InitOptions{}); err == nil || !strings.Contains(err.Error(), "already initialized") {
+		t.Errorf("expected already initialized error, got %v", err)
+	}
+}
+
+func TestLoadDWARFData_CapturedExecutable(t *testing.T) {
+	execPath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(execPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Simulate a go run binary removed before lazy initialization
+	tempPath := filepath.Join(t.TempDir(), "binary")
+	if err := os.WriteFile(tempPath, data, 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := os.Open(tempPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.Remove(tempPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	captured.mu.Lock()
+	captured.path, captured.file = tempPath, file
+	captured.mu.Unlock()
+
+	resolver := &DWARFResolver{}
+	err = resolver.loadDWARFData("")
+	if _, statErr := file.Stat(); statErr == nil {
+		t.Error("expected the captured file to be closed after loading")
+	}
+	if _, again := takeCapturedExecutable(); again != nil {
+		t.Error("expected the captured file to be taken once")
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolver.executablePath != tempPath {
+		t.Errorf("expected captured path %s, got %s", tempPath, resolver.executablePath)
+	}
+	if _, ok := resolver.ParameterNames("github.com/matteo-grella/dwarfreflect.testFunc1"); !ok {
+		t.Error("expected DWARF data of the removed binary to be loaded")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// BindSource identifies where a parameter's value came from during binding.
+type BindSource int
+
+const (
+	BindExact    BindSource = iota // value taken from the argument map key equal to the parameter name
+	BindDefault                    // key absent; value taken from ParamMeta.Default
+	BindMissing                    // key absent and no default; binding fails
+	BindInjected                   // key absent; value injected by CallInjected
+	BindAlias                      // value taken from an alias key of the parameter (see Aliases)
+)
+
+// String returns a human-readable name for the bind source
+func (s BindSource) String() string {
+	switch s {
+	case BindExact:
+		return "exact key"
+	case BindDefault:
+		return "default"
+	case BindMissing:
+		return "missing"
+	case BindInjected:
+		return "injected"
+	case BindAlias:
+		return "alias"
+	default:
+		return "unknown"
+	}
+}
+
+// Coercion identifies a conversion applied to a value during binding.
+type Coercion int
+
+const (
+	CoercionNone      Coercion = iota // value assigned as-is
+	CoercionParsed                    // string parsed with a registered parser (see RegisterParser)
+	CoercionConverted                 // value converted to the parameter type (see CallOptions.AllowConvert)
+)
+
+// String returns a human-readable name for the coercion
+func (c Coercion) String() string {
+	switch c {
+	case CoercionNone:
+		return "none"
+	case CoercionParsed:
+		return "parsed from string"
+	case CoercionConverted:
+		return "converted"
+	default:
+		return "unknown"
+	}
+}
+
+// ParamBinding describes how a single parameter was bound.
+type ParamBinding struct {
+	Param     string
+	Type      reflect.Type // parameter type, i.e. the final type of the bound value
+	Source    BindSource
+	Key       string       // argument map key used, empty unless Source is BindExact or BindAlias
+	ValueType reflect.Type // type of the value before coercion, nil when missing
+	Coercion  Coercion
+	Copied    bool  // value was deep-copied (CallOptions.CopyArgs)
+	Err       error // binding error for this parameter, if any
+}
+
+// BindReport traces how an argument map binds to a function's parameters.
+type BindReport struct {
+	Function string
+	Params   []ParamBinding
+
+	// UnusedKeys lists argument map keys that match no parameter.
+	UnusedKeys []string
+
+	// Suggestions maps unused keys to the parameter they match when compared
+	// case-insensitively, the most common reason for a payload not binding.
+	Suggestions map[string]string
+}
+
+// record appends a binding; it is a no-op on a nil report so the regular
+// binding path pays nothing for explain mode.
+func (r *BindReport) record(binding ParamBinding) {
+	if r != nil {
+		r.Params = append(r.Params, binding)
+	}
+}
+
+// String formats the report, one line per parameter and unused key.
+func (r *BindReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bind %s:\n", r.Function)
+	for _, p := range r.Params {
+		fmt.Fprintf(&b, "  %s %v: %s", p.Param, p.Type, p.Source)
+		if p.Key != "" {
+			fmt.Fprintf(&b, " %q", p.Key)
+		}
+		if p.Coercion != CoercionNone {
+			fmt.Fprintf(&b, ", %s (%v)", p.Coercion, p.ValueType)
+		}
+		if p.Copied {
+			b.WriteString(", copied")
+		}
+		if p.Err != nil {
+			fmt.Fprintf(&b, ", error: %v", p.Err)
+		}
+		b.WriteByte('\n')
+	}
+	for _, key := range r.UnusedKeys {
+		fmt.Fprintf(&b, "  unused key %q", key)
+		if param, ok := r.Suggestions[key]; ok {
+			fmt.Fprintf(&b, " (did you mean %q?)", param)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ExplainBind binds argMap like MapToArgs and reports, per parameter, the
+// source key, default or coercion used and the final type, along with unused
+// keys. The error is the one MapToArgs would return; the report is returned
+// even on error and covers every parameter bound up to the failure.
+//
+// Example:
+//
+//	report, err := fn.ExplainBind(payload)
+//	fmt.Print(report)
+//	// bind main.CreateUser:
+//	//   name string: exact key "name"
+//	//   age int: default
+//	//   unused key "Age" (did you mean "age"?)
+func (t *Function) ExplainBind(argMap map[string]any, opts ...CallOptions) (*BindReport, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	report := &BindReport{Function: t.funcName}
+	for key := range argMap {
+		if _, alias := t.aliases[key]; !alias && !t.hasParam(key) {
+			report.UnusedKeys = append(report.UnusedKeys, key)
+		}
+	}
+	sort.Strings(report.UnusedKeys)
+	for _, key := range report.UnusedKeys {
+		for _, param := range t.paramNames {
+			if strings.EqualFold(key, param) {
+				if report.Suggestions == nil {
+					report.Suggestions = make(map[string]string)
+				}
+				report.Suggestions[key] = param
+				break
+			}
+		}
+	}
+
+	err := t.bindMap(argMap, options, report, nil, nil)
+	return report, err
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func testFuncExplain(name string, ip net.IP, retries int) string {
+	return name
+}
+
+func TestExplainBind(t *testing.T) {
+	fn := mustNewFunction(t, testFuncExplain).WithParamMeta("retries", ParamMeta{Default: 3})
+
+	report, err := fn.ExplainBind(map[string]any{"name": "a", "ip": "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Params) != 3 {
+		t.Fatalf("expected 3 bindings, got %d", len(report.Params))
+	}
+
+	name, ip, retries := report.Params[0], report.Params[1], report.Params[2]
+	if name.Source != BindExact || name.Key != "name" || name.Coercion != CoercionNone {
+		t.Errorf("unexpected name binding: %+v", name)
+	}
+	if ip.Coercion != CoercionParsed || ip.ValueType.Kind().String() != "string" || ip.Type.String() != "net.IP" {
+		t.Errorf("unexpected ip binding: %+v", ip)
+	}
+	if retries.Source != BindDefault || retries.Key != "" {
+		t.Errorf("unexpected retries binding: %+v", retries)
+	}
+}
+
+func TestExplainBind_Alias(t *testing.T) {
+	fn := mustNewFunction(t, testFuncExplain).With(Aliases(map[string]string{"address": "ip"}))
+
+	report, err := fn.ExplainBind(map[string]any{"name": "a", "address": "10.0.0.1", "retries": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := report.Params[0]; name.Source != BindExact || name.Key != "name" {
+		t.Errorf("unexpected name binding: %+v", name)
+	}
+	if ip := report.Params[1]; ip.Source != BindAlias || ip.Key != "address" {
+		t.Errorf("expected ip bound from alias key address, got %+v", ip)
+	}
+	if s := report.String(); !strings.Contains(s, `ip net.IP: alias "address"`) {
+		t.Errorf("expected the alias in report:\n%s", s)
+	}
+}
+
+func TestExplainBind_Errors(t *testing.T) {
+	fn := mustNewFunction(t, testFuncExplain)
+
+	report, err := fn.ExplainBind(map[string]any{"Name": "a", "ip": "10.0.0.1"})
+	if err == nil {
+		t.Fatal("expected missing parameter error")
+	}
+	if len(report.UnusedKeys) != 1 || report.Suggestions["Name"] != "name" {
+		t.Errorf("expected suggestion for Name, got %v %v", report.UnusedKeys, report.Suggestions)
+	}
+	var missing []string
+	for _, p := range report.Params {
+		if p.Source == BindMissing {
+			missing = append(missing, p.Param)
+		}
+	}
+	if strings.Join(missing, ",") != "name,retries" {
+		t.Errorf("expected name and retries missing, got %v", missing)
+	}
+	if s := report.String(); !strings.Contains(s, `did you mean "name"?`) {
+		t.Errorf("expected suggestion in report:\n%s", s)
+	}
+
+	report, err = fn.ExplainBind(map[string]any{"name": "a", "ip": "bad", "retries": 1})
+	if err == nil {
+		t.Fatal("expected parse error")
+	}
+	if last := report.Params[len(report.Params)-1]; last.Param != "ip" || last.Err == nil {
+		t.Errorf("expected failing ip binding last, got %+v", last)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// externalTestJoin is a helper of an external test package, as test
+// utilities wrapping their own functions declare them.
+//
+//go:noinline
+func externalTestJoin(sep string, parts ...string) (joined string) {
+	return strings.Join(parts, sep)
+}
+
+type externalTestFixture struct{ prefix string }
+
+//go:noinline
+func (f *externalTestFixture) Label(itemName string) string {
+	return f.prefix + itemName
+}
+
+// newExternalFunction wraps fn, skipping the test without DWARF.
+func newExternalFunction(t *testing.T, fn any) *dwarfreflect.Function {
+	t.Helper()
+	f, err := dwarfreflect.NewFunction(fn)
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return f
+}
+
+func TestExternalTestPackage(t *testing.T) {
+	fn := newExternalFunction(t, externalTestJoin)
+	if names, _ := fn.GetParameterInfo(); strings.Join(names, ",") != "sep,parts" {
+		t.Errorf("expected sep,parts, got %v", names)
+	}
+	if got := fn.GetPackagePath(); got != "github.com/matteo-grella/dwarfreflect_test" {
+		t.Errorf("expected the external test package, got %q", got)
+	}
+	if got := fn.ModulePath(); got != "github.com/matteo-grella/dwarfreflect" {
+		t.Errorf("expected the module of the tested package, got %q", got)
+	}
+	results, err := fn.CallWithMap(map[string]any{"sep": "-", "parts": []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "a-b" {
+		t.Errorf("expected a-b, got %q", got)
+	}
+
+	fixture := &externalTestFixture{prefix: "item:"}
+	method := newExternalFunction(t, fixture.Label)
+	if names, _ := method.GetParameterInfo(); strings.Join(names, ",") != "itemName" {
+		t.Errorf("expected itemName, got %v", names)
+	}
+}
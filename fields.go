@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CollisionPolicy chooses what struct generation does when parameters map to
+// the same field name, such as name and Name, which reflect.StructOf rejects.
+type CollisionPolicy int
+
+const (
+	// CollisionSuffix renames the field of each colliding parameter after the
+	// first, in parameter order, with an index suffix: Name, Name2, Name3.
+	// StructOptions.RenameCollision customizes the names.
+	CollisionSuffix CollisionPolicy = iota
+
+	// CollisionError fails with a *FieldCollisionError.
+	CollisionError
+)
+
+// FieldCollisionError reports two parameters mapped to the same struct field
+// name under CollisionError.
+type FieldCollisionError struct {
+	Function string
+	Field    string
+	First    string // parameter the field was named for first
+	Second   string // parameter colliding with it
+}
+
+func (e *FieldCollisionError) Error() string {
+	return fmt.Sprintf("parameters %q and %q of function %s both map to struct field %s",
+		e.First, e.Second, e.Function, e.Field)
+}
+
+// uniqueFieldNames resolves collisions among fieldNames, the field names of
+// paramNames in parameter order, as opts asks: in place with CollisionSuffix,
+// or with a *FieldCollisionError naming funcName.
+func uniqueFieldNames(funcName string, paramNames, fieldNames []string, opts StructOptions) error {
+	rename := opts.RenameCollision
+	if rename == nil {
+		rename = func(_, fieldName string, n int) string {
+			return fieldName + strconv.Itoa(n)
+		}
+	}
+
+	owners := make(map[string]string, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		first, taken := owners[fieldName]
+		if !taken {
+			owners[fieldName] = paramNames[i]
+			continue
+		}
+		if opts.Collisions == CollisionError {
+			return &FieldCollisionError{Function: funcName, Field: fieldName, First: first, Second: paramNames[i]}
+		}
+
+		// Later parameters keep their own names; an injective renamer finds
+		// a free name within len(fieldNames) attempts
+		unique := ""
+		for n := 2; n <= len(fieldNames)+1 && unique == ""; n++ {
+			candidate := rename(paramNames[i], fieldName, n)
+			if _, used := owners[candidate]; !used && !slices.Contains(fieldNames[i+1:], candidate) {
+				unique = candidate
+			}
+		}
+		if unique == "" {
+			return &FieldCollisionError{Function: funcName, Field: fieldName, First: first, Second: paramNames[i]}
+		}
+		fieldNames[i] = unique
+		owners[unique] = paramNames[i]
+	}
+	return nil
+}
+
+// FieldForParam returns the name of the field holding the named parameter in
+// the struct type of the function (see GetStructType). Field names are
+// parameter names made exported Go identifiers, which differ from them
+// beyond the first letter for names that are not valid identifiers, such as
+// unnamed parameters (~p0 becomes P0) or names from other toolchains, and
+// when parameters collide (see CollisionPolicy).
+//
+// Example:
+//
+//	func Handle(_ int, path string) // the first parameter is ~p0 in DWARF
+//	field, _ := fn.FieldForParam("~p0") // "P0"
+func (t *Function) FieldForParam(param string) (string, bool) {
+	for i := range t.structType.NumField() {
+		if field := t.structType.Field(i); field.Tag.Get("param") == param {
+			return field.Name, true
+		}
+	}
+	return "", false
+}
+
+// ParamForField returns the name of the parameter held by the named field of
+// the struct type of the function, reversing FieldForParam.
+func (t *Function) ParamForField(field string) (string, bool) {
+	if structField, ok := t.structType.FieldByName(field); ok {
+		return structField.Tag.Lookup("param")
+	}
+	return "", false
+}
+
+// safeFieldName turns name into an exported identifier, as reflect.StructOf
+// requires of field names: runes other than letters, digits and underscores
+// become underscores, leading underscores are dropped and the first letter
+// is capitalized, or X prepended where no letter can be (0a, _, 日本).
+// Exported identifiers are kept as they are, and capitalized names cannot be
+// keywords (type becomes Type).
+func safeFieldName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	name = capitalizeFirst(strings.TrimLeft(name, "_"))
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(first) {
+		name = "X" + name
+	}
+	return name
+}
+
+// contextFieldName is the name of the field added by StructOptions.IncludeContext.
+const contextFieldName = "Context"
+
+// appendContextField adds the field holding the context of context.Context
+// parameters to fields, those of paramNames, named Context unless a
+// parameter field is, in which case it collides as opts says.
+func (t *Function) appendContextField(paramNames []string, fields []reflect.StructField, opts StructOptions) ([]reflect.StructField, error) {
+	names := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	names = append(names, contextFieldName)
+	if err := uniqueFieldNames(t.funcName, append(slices.Clone(paramNames), "context"), names, opts); err != nil {
+		return nil, err
+	}
+
+	return append(fields, reflect.StructField{
+		Name: names[len(fields)],
+		Type: contextType,
+		Tag:  `json:"-"`,
+	}), nil
+}
+
+// structContext returns the value of the first field of structValue of type
+// context.Context, as added by StructOptions.IncludeContext.
+func structContext(structValue reflect.Value) (context.Context, bool) {
+	for _, field := range reflect.VisibleFields(structValue.Type()) {
+		if field.IsExported() && field.Type == contextType {
+			ctx, _ := structValue.FieldByIndex(field.Index).Interface().(context.Context)
+			return cmp.Or(ctx, context.Background()), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func testFuncCollidingParams(name string, Name string, name2 int) (n int, N int) {
+	return len(name) + len(Name), name2
+}
+
+func structFieldNames(typ reflect.Type) string {
+	names := make([]string, typ.NumField())
+	for i := range names {
+		names[i] = typ.Field(i).Name
+	}
+	return strings.Join(names, " ")
+}
+
+func TestFieldCollisions_Suffix(t *testing.T) {
+	fn := mustNewFunction(t, testFuncCollidingParams)
+	if got := structFieldNames(fn.GetStructType()); got != "Name Name3 Name2" {
+		t.Errorf("unexpected parameter fields: %s", got)
+	}
+	if got := structFieldNames(fn.GetResultStructType()); got != "N N2" {
+		t.Errorf("unexpected result fields: %s", got)
+	}
+
+	params := reflect.New(fn.GetStructType())
+	if err := json.Unmarshal([]byte(`{"name": "ab", "Name": "c", "name2": 7}`), params.Interface()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := fn.CallWithStruct(params.Elem().Interface())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Int() != 3 || results[1].Int() != 7 {
+		t.Errorf("unexpected results: %v %v", results[0], results[1])
+	}
+}
+
+func TestFieldCollisions_Error(t *testing.T) {
+	fn := mustNewFunction(t, testFuncCollidingParams)
+	_, err := fn.StructVariant(StructOptions{Collisions: CollisionError})
+
+	var collision *FieldCollisionError
+	if !errors.As(err, &collision) {
+		t.Fatalf("expected a *FieldCollisionError, got %v", err)
+	}
+	if collision.Field != "Name" || collision.First != "name" || collision.Second != "Name" {
+		t.Errorf("unexpected collision: %+v", collision)
+	}
+
+	defer func() {
+		if recovered := recover(); !errors.As(recovered.(error), &collision) {
+			t.Errorf("expected a *FieldCollisionError panic, got %v", recovered)
+		}
+	}()
+	fn.GetStructTypeWithOptions(StructOptions{Collisions: CollisionError})
+}
+
+func TestFieldCollisions_Rename(t *testing.T) {
+	fn := mustNewFunction(t, testFuncCollidingParams)
+	typ, err := fn.StructVariant(StructOptions{
+		RenameCollision: func(paramName, fieldName string, n int) string {
+			return fmt.Sprintf("%s_%d", fieldName, n)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := structFieldNames(typ); got != "Name Name_2 Name2" {
+		t.Errorf("unexpected fields: %s", got)
+	}
+
+	_, err = fn.StructVariant(StructOptions{
+		RenameCollision: func(string, string, int) string { return "Name2" },
+	})
+	var collision *FieldCollisionError
+	if !errors.As(err, &collision) {
+		t.Errorf("expected a renamer without free names to fail, got %v", err)
+	}
+}
+
+//go:noinline
+func testFuncOddParams(_ int, _x string, 日本 bool) (string, error) {
+	return _x, nil
+}
+
+func TestSafeFieldName(t *testing.T) {
+	tests := map[string]string{
+		"name": "Name", "Name": "Name", "type": "Type", "len": "Len", "~p0": "P0",
+		"_x": "X", "_": "X", "0a": "X0a", "日本": "X日本", "a.b": "A_b",
+	}
+	for name, want := range tests {
+		if got := safeFieldName(name); got != want {
+			t.Errorf("safeFieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFieldForParam(t *testing.T) {
+	fn := mustNewFunction(t, testFuncOddParams)
+	if got := structFieldNames(fn.GetStructType()); got != "P0 X X日本" {
+		t.Fatalf("unexpected fields: %s", got)
+	}
+	if got := structFieldNames(fn.GetResultStructType()); got != "R0" {
+		t.Errorf("unexpected result fields: %s", got)
+	}
+
+	for i, param := range fn.paramNames {
+		field, ok := fn.FieldForParam(param)
+		if !ok || field != fn.GetStructType().Field(i).Name {
+			t.Errorf("FieldForParam(%q) = %q, %v", param, field, ok)
+		}
+		if back, ok := fn.ParamForField(field); !ok || back != param {
+			t.Errorf("ParamForField(%q) = %q, %v, want %q", field, back, ok, param)
+		}
+	}
+	if _, ok := fn.FieldForParam("missing"); ok {
+		t.Error("expected no field for an unknown parameter")
+	}
+
+	params := reflect.New(fn.GetStructType()).Elem()
+	field, _ := fn.FieldForParam("_x")
+	params.FieldByName(field).SetString("bound")
+	results, err := fn.CallWithStruct(params.Interface())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "bound" {
+		t.Errorf("unexpected result: %q", got)
+	}
+}
+
+type testContextKey struct{}
+
+//go:noinline
+func testFuncTracedGreeting(ctx context.Context, name string, age int) string {
+	value, _ := ctx.Value(testContextKey{}).(string)
+	return fmt.Sprintf("%s %s %d", value, name, age)
+}
+
+//go:noinline
+func testFuncRecordCall(ctx context.Context, context int) string {
+	value, _ := ctx.Value(testContextKey{}).(string)
+	return fmt.Sprintf("%s %d", value, context)
+}
+
+func TestIncludeContext(t *testing.T) {
+	fn := mustNewFunction(t, testFuncTracedGreeting)
+	typ := fn.GetNonContextStructTypeWithOptions(StructOptions{IncludeContext: true})
+	field, ok := typ.FieldByName("Context")
+	if !ok || field.Type != contextType || field.Tag != `json:"-"` {
+		t.Fatalf("expected a Context field tagged json:\"-\", got %v", typ)
+	}
+
+	params := reflect.New(typ).Elem()
+	params.FieldByName("Context").Set(reflect.ValueOf(context.WithValue(context.Background(), testContextKey{}, "traced")))
+	params.FieldByName("Name").SetString("alice")
+	params.FieldByName("Age").SetInt(30)
+	data, _ := json.Marshal(params.Interface())
+	if got := string(data); strings.Contains(got, "Context") {
+		t.Errorf("expected the context to stay out of JSON, got %s", got)
+	}
+	results, err := fn.CallWithStruct(params.Interface())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "traced alice 30" {
+		t.Errorf("unexpected result: %q", got)
+	}
+
+	if typ := fn.GetNonContextStructTypeWithOptions(StructOptions{}); typ.NumField() != 2 {
+		t.Errorf("expected no Context field without IncludeContext, got %v", typ)
+	}
+}
+
+func TestIncludeContext_Collision(t *testing.T) {
+	fn := mustNewFunction(t, testFuncRecordCall)
+	typ := fn.GetNonContextStructTypeWithOptions(StructOptions{IncludeContext: true})
+	if got := structFieldNames(typ); got != "Context Context2" {
+		t.Fatalf("unexpected fields: %s", got)
+	}
+
+	params := reflect.New(typ).Elem()
+	params.Field(0).SetInt(7)
+	params.Field(1).Set(reflect.ValueOf(context.WithValue(context.Background(), testContextKey{}, "traced")))
+	results, err := fn.CallWithStruct(params.Interface())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "traced 7" {
+		t.Errorf("unexpected result: %q", got)
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ParamMeta holds optional per-parameter metadata used by form generation and binding.
+type ParamMeta struct {
+	// Description is a human-readable label for the parameter.
+	Description string
+
+	// Enum restricts the parameter to the listed values.
+	Enum []any
+
+	// Default is used by map and form binding when the parameter is not supplied.
+	Default any
+
+	// Examples are sample values surfaced in JSON Schema, form descriptors and
+	// the debug handler to document the parameter.
+	Examples []any
+
+	// Required overrides whether the parameter must be supplied. By default
+	// pointer parameters are optional and bind nil when absent, while all
+	// others are required unless they have a Default.
+	Required *bool
+
+	// Sensitive marks a parameter whose value must not be disclosed, such as
+	// a password: it is redacted from the call context added to errors by
+	// WithErrorContext.
+	Sensitive bool
+
+	// Convert overrides CallOptions.AllowConvert for the parameter: true
+	// converts its arguments to its type, false never does.
+	Convert *bool
+}
+
+// WithParamMeta returns a copy of the Function with metadata attached to the named parameter.
+//
+// Example:
+//
+//	fn = fn.WithParamMeta("role", dwarfreflect.ParamMeta{
+//	    Description: "Account role",
+//	    Enum:        []any{"admin", "user"},
+//	    Default:     "user",
+//	})
+func (t *Function) WithParamMeta(param string, meta ParamMeta) *Function {
+	clone := *t
+	clone.paramMeta = maps.Clone(t.paramMeta)
+	if clone.paramMeta == nil {
+		clone.paramMeta = make(map[string]ParamMeta)
+	}
+	clone.paramMeta[param] = meta
+	return &clone
+}
+
+// WithExamples returns a copy of the Function with an example value added to
+// the metadata of each named parameter, keeping its other metadata.
+//
+// Example:
+//
+//	fn = fn.WithExamples(map[string]any{"userName": "alice", "age": 30})
+func (t *Function) WithExamples(examples map[string]any) *Function {
+	clone := *t
+	clone.paramMeta = maps.Clone(t.paramMeta)
+	if clone.paramMeta == nil {
+		clone.paramMeta = make(map[string]ParamMeta)
+	}
+	for param, example := range examples {
+		meta := clone.paramMeta[param]
+		meta.Examples = append(slices.Clip(meta.Examples), example)
+		clone.paramMeta[param] = meta
+	}
+	return &clone
+}
+
+// WithRequired returns a copy of the Function overriding whether the named
+// parameter must be supplied, keeping its other metadata. Optional parameters
+// absent from the input bind their zero value and are left out of the
+// required list of generated schemas.
+//
+// Example:
+//
+//	func Search(query string, limit int, cursor *string) []Result
+//	fn = fn.WithRequired("limit", false) // absent limit binds 0
+//	fn = fn.WithRequired("cursor", true) // cursor must be supplied, possibly as null
+func (t *Function) WithRequired(param string, required bool) *Function {
+	clone := *t
+	clone.paramMeta = maps.Clone(t.paramMeta)
+	if clone.paramMeta == nil {
+		clone.paramMeta = make(map[string]ParamMeta)
+	}
+	meta := clone.paramMeta[param]
+	meta.Required = &required
+	clone.paramMeta[param] = meta
+	return &clone
+}
+
+// isOptional reports whether the named parameter may be absent from the
+// input, binding its zero value: pointers and variadic parameters unless
+// overridden with WithRequired.
+// Parameters with a Default are handled separately.
+func (t *Function) isOptional(name string, typ reflect.Type) bool {
+	if t.isScoped(name) {
+		return true // bound from the call context, see WithScopedParams
+	}
+	if required := t.paramMeta[name].Required; required != nil {
+		return !*required
+	}
+	return typ.Kind() == reflect.Pointer || t.isVariadicParam(name)
+}
+
+// GetParamMeta returns the metadata attached to the named parameter, if any.
+func (t *Function) GetParamMeta(param string) (ParamMeta, bool) {
+	meta, ok := t.paramMeta[param]
+	return meta, ok
+}
+
+// FormField describes a parameter as an input field for generated UI forms.
+type FormField struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`  // Go type, e.g. "int" or "[]string"
+	Input    string `json:"input"` // HTML input type hint: text, number, checkbox, select, datetime-local
+	Multiple bool   `json:"multiple,omitempty"`
+	Required bool   `json:"required"`
+	Enum     []any  `json:"enum,omitempty"`
+	Default  any    `json:"default,omitempty"`
+	Example  any    `json:"example,omitempty"` // first of ParamMeta.Examples, e.g. for placeholders
+}
+
+// FormDescriptor returns one field per parameter, in parameter order, for
+// auto-generating admin UI forms. Context and unsafe parameters are left out.
+// Pointer parameters and parameters with a default are optional; labels come
+// from ParamMeta.Description or are derived from the parameter name.
+//
+// Example:
+//
+//	func CreateUser(ctx context.Context, userName string, maxItems int) error
+//	fields := fn.FormDescriptor()
+//	// [{Name: "userName", Label: "User Name", Input: "text", Required: true}, {Name: "maxItems", ...}]
+func (t *Function) FormDescriptor() []FormField {
+	names, types := t.GetNonContextParameters()
+	fields := make([]FormField, 0, len(names))
+
+	for i, name := range names {
+		if isUnsafeType(types[i]) {
+			continue
+		}
+
+		meta := t.paramMeta[name]
+		field := FormField{
+			Name:     name,
+			Label:    meta.Description,
+			Type:     types[i].String(),
+			Input:    formInputType(types[i]),
+			Required: !t.isOptional(name, types[i]) && meta.Default == nil,
+			Enum:     meta.Enum,
+			Default:  meta.Default,
+		}
+		if field.Label == "" {
+			field.Label = humanizeName(name)
+		}
+		if len(meta.Examples) > 0 {
+			field.Example = meta.Examples[0]
+		}
+		if len(field.Enum) > 0 {
+			field.Input = "select"
+		}
+		if types[i].Kind() == reflect.Slice && types[i].Elem().Kind() != reflect.Uint8 {
+			field.Multiple = true
+		}
+		if field.Input == "checkbox" || field.Multiple {
+			field.Required = false // unchecked boxes and empty selections are simply absent
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// CallWithForm invokes the function with submitted form values such as url.Values.
+// Values are converted to parameter types; absent checkboxes bind false, absent
+// optional parameters bind their default or zero value. context.Context
+// parameters receive context.Background(); use CallWithFormContext to provide one.
+//
+// Example:
+//
+//	r.ParseForm()
+//	results, err := fn.CallWithForm(r.Form)
+func (t *Function) CallWithForm(form map[string][]string) ([]reflect.Value, error) {
+	return t.CallWithFormContext(context.Background(), form)
+}
+
+// CallWithFormContext is like CallWithForm but injects ctx into context.Context parameters.
+func (t *Function) CallWithFormContext(ctx context.Context, form map[string][]string) ([]reflect.Value, error) {
+	names, types := t.GetNonContextParameters()
+	args := make([]any, len(names))
+
+	for i, name := range names {
+		if isUnsafeType(types[i]) {
+			return nil, &UnsafeParameterError{Function: t.funcName, Param: name, Type: types[i]}
+		}
+
+		values, present := form[name]
+		if !present || len(values) == 0 {
+			arg, err := t.absentFormValue(name, types[i])
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+			continue
+		}
+
+		v, err := parseFormValues(values, types[i])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		args[i] = v.Interface()
+	}
+
+	return t.CallWithContext(ctx, args...)
+}
+
+func (t *Function) absentFormValue(name string, typ reflect.Type) (any, error) {
+	if meta, ok := t.paramMeta[name]; ok && meta.Default != nil {
+		return meta.Default, nil
+	}
+	if t.isOptional(name, typ) || typ.Kind() == reflect.Bool || typ.Kind() == reflect.Slice {
+		return reflect.Zero(typ).Interface(), nil
+	}
+	return nil, fmt.Errorf("missing required form value %q (function %s)", name, t.funcName)
+}
+
+// parseFormValues converts submitted values to typ; slices take every value.
+func parseFormValues(values []string, typ reflect.Type) (reflect.Value, error) {
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(typ, len(values), len(values))
+		for i, s := range values {
+			v, err := parseStringValue(s, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(v)
+		}
+		return slice, nil
+	}
+	return parseStringValue(values[0], typ)
+}
+
+// parseStringValue converts a textual value to typ using registered parsers,
+// encoding.TextUnmarshaler, or the conversion rules of the underlying kind.
+func parseStringValue(s string, typ reflect.Type) (reflect.Value, error) {
+	if v, found, err := parseString(s, typ); found {
+		return v, err
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		elem, err := parseStringValue(s, typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	v := reflect.New(typ).Elem()
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(s))
+		return v, err
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		switch strings.ToLower(s) {
+		case "on", "yes":
+			v.SetBool(true)
+		default:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typ == reflect.TypeFor[time.Duration]() {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.SetInt(int64(d))
+			break
+		}
+		n, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	case reflect.Slice: // []byte
+		v.SetBytes([]byte(s))
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot convert %q to %v", s, typ)
+	}
+
+	return v, nil
+}
+
+// formInputType suggests an HTML input type for a parameter type.
+func formInputType(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer || (typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8) {
+		typ = typ.Elem()
+	}
+
+	if typ == reflect.TypeFor[time.Time]() {
+		return "datetime-local"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "checkbox"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if typ == reflect.TypeFor[time.Duration]() {
+			return "text"
+		}
+		return "number"
+	default:
+		return "text"
+	}
+}
+
+// humanizeName turns a parameter name into a label:
+// "userID" -> "User ID", "max_items" -> "Max Items".
+func humanizeName(name string) string {
+	var words []string
+	var word []rune
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	for i, w := range words {
+		words[i] = capitalizeFirst(w)
+	}
+	return strings.Join(words, " ")
+}
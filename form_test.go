@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFuncForm(ctx context.Context, userName string, maxItems int, admin bool, tags []string, role string, timeout *time.Duration) string {
+	t := "none"
+	if timeout != nil {
+		t = timeout.String()
+	}
+	return fmt.Sprintf("%s/%d/%v/%s/%s/%s", userName, maxItems, admin, strings.Join(tags, ","), role, t)
+}
+
+func newTestFormFunction(t *testing.T) *Function {
+	return mustNewFunction(t, testFuncForm).WithParamMeta("role", ParamMeta{
+		Description: "Account role",
+		Enum:        []any{"admin", "user"},
+		Default:     "user",
+	})
+}
+
+func TestFormDescriptor(t *testing.T) {
+	fields := newTestFormFunction(t).FormDescriptor()
+
+	expected := []FormField{
+		{Name: "userName", Label: "User Name", Type: "string", Input: "text", Required: true},
+		{Name: "maxItems", Label: "Max Items", Type: "int", Input: "number", Required: true},
+		{Name: "admin", Label: "Admin", Type: "bool", Input: "checkbox"},
+		{Name: "tags", Label: "Tags", Type: "[]string", Input: "text", Multiple: true},
+		{Name: "role", Label: "Account role", Type: "string", Input: "select", Enum: []any{"admin", "user"}, Default: "user"},
+		{Name: "timeout", Label: "Timeout", Type: "*time.Duration", Input: "text"},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected fields:\n got %+v\nwant %+v", fields, expected)
+	}
+}
+
+func TestFormDescriptor_Examples(t *testing.T) {
+	fn := newTestFormFunction(t).WithExamples(map[string]any{"maxItems": 25})
+	fields := fn.FormDescriptor()
+
+	if fields[1].Example != 25 {
+		t.Errorf("expected maxItems example 25, got %v", fields[1].Example)
+	}
+	if meta, _ := fn.GetParamMeta("role"); meta.Default != "user" {
+		t.Errorf("expected examples to keep existing metadata, got %+v", meta)
+	}
+}
+
+func TestCallWithForm(t *testing.T) {
+	fn := newTestFormFunction(t)
+
+	results, err := fn.CallWithForm(url.Values{
+		"userName": {"alice"},
+		"maxItems": {"5"},
+		"admin":    {"on"},
+		"tags":     {"a", "b"},
+		"timeout":  {"1m"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "alice/5/true/a,b/user/1m0s" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+
+	results, err = fn.CallWithForm(url.Values{
+		"userName": {"bob"},
+		"maxItems": {"1"},
+		"role":     {"admin"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "bob/1/false//admin/none" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestCallWithForm_Errors(t *testing.T) {
+	fn := newTestFormFunction(t)
+
+	if _, err := fn.CallWithForm(url.Values{"userName": {"x"}}); err == nil {
+		t.Error("expected error for missing required value")
+	}
+	if _, err := fn.CallWithForm(url.Values{"userName": {"x"}, "maxItems": {"many"}}); err == nil {
+		t.Error("expected error for non-numeric value")
+	}
+}
+
+func TestCallWithMap_Default(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1).WithParamMeta("age", ParamMeta{Default: 18})
+
+	results, err := fn.CallWithMap(map[string]any{"name": "Zoe"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "Zoe is 18 years old" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestHumanizeName(t *testing.T) {
+	tests := map[string]string{
+		"name":      "Name",
+		"userID":    "User ID",
+		"max_items": "Max Items",
+		"HTTPPort":  "HTTP Port",
+		"x":         "X",
+	}
+
+	for input, expected := range tests {
+		if got := humanizeName(input); got != expected {
+			t.Errorf("humanizeName(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
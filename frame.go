@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"sync"
+)
+
+// newFramePool returns a pool of argument frames sized for size parameters.
+// Copies made by With* methods share the pool, since they share the signature.
+func newFramePool(size int) *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			frame := make([]reflect.Value, size)
+			return &frame
+		},
+	}
+}
+
+// getFrame returns an argument frame from the Function's pool.
+func (t *Function) getFrame() *[]reflect.Value {
+	if t.frames == nil {
+		frame := make([]reflect.Value, len(t.paramTypes))
+		return &frame
+	}
+	return t.frames.Get().(*[]reflect.Value)
+}
+
+// putFrame clears a frame, so it holds no argument references, and returns it to the pool.
+func (t *Function) putFrame(frame *[]reflect.Value) {
+	if t.frames == nil {
+		return
+	}
+	clear(*frame)
+	t.frames.Put(frame)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+)
+
+// frozenState holds per-call data precomputed by Freeze, so the call path
+// only reads immutable values.
+type frozenState struct {
+	contextPositions []int
+	unsafePositions  []int
+	nonContextNames  []string
+	nonContextTypes  []reflect.Type
+	nonContextStruct reflect.Type
+}
+
+// Freeze returns an immutable snapshot of the Function with everything derived
+// from its signature precomputed: context and unsafe positions, non-context
+// parameters and the non-context struct type. The snapshot is safe for
+// concurrent use without locks and does no per-call reflection setup beyond
+// the invocation itself. Freezing an already frozen Function returns it as-is.
+//
+// Example:
+//
+//	handler := fn.Freeze()
+//	// share handler across goroutines serving requests
+func (t *Function) Freeze() *Function {
+	if t.frozen != nil {
+		return t
+	}
+
+	frozen := *t
+	names, types := t.GetNonContextParameters()
+	frozen.frozen = &frozenState{
+		contextPositions: t.GetContextPositions(),
+		unsafePositions:  t.GetUnsafePositions(),
+		nonContextNames:  names,
+		nonContextTypes:  types,
+		nonContextStruct: t.GetNonContextStructType(),
+	}
+
+	return &frozen
+}
+
+// IsFrozen reports whether the Function is a snapshot returned by Freeze.
+func (t *Function) IsFrozen() bool {
+	return t.frozen != nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+	frozen := fn.Freeze()
+
+	if fn.IsFrozen() {
+		t.Error("expected original function to stay unfrozen")
+	}
+	if !frozen.IsFrozen() {
+		t.Error("expected snapshot to be frozen")
+	}
+	if frozen.Freeze() != frozen {
+		t.Error("expected freezing a frozen function to return it as-is")
+	}
+
+	if !reflect.DeepEqual(frozen.GetContextPositions(), fn.GetContextPositions()) {
+		t.Errorf("context positions differ: %v vs %v", frozen.GetContextPositions(), fn.GetContextPositions())
+	}
+	if frozen.GetNonContextStructType() != fn.GetNonContextStructType() {
+		t.Error("expected identical non-context struct types")
+	}
+}
+
+func TestFreeze_ConcurrentCalls(t *testing.T) {
+	frozen := mustNewFunction(t, testFunc4).Freeze()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			results, err := frozen.CallWithContext(context.Background(), id, "x")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if expected := fmt.Sprintf("id=%d, name=x", id); results[0].String() != expected {
+				errs <- fmt.Errorf("expected %q, got %q", expected, results[0].String())
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// functionInfo is what NewFunction derives from a function's code: its DWARF
+// parameter and result names and the generated struct types. It does not
+// depend on the function value, so wrapping the same function again, or
+// closures and method values sharing its code, reuses it.
+type functionInfo struct {
+	paramNames  []string
+	paramIndex  map[string]int
+	paramTypes  []reflect.Type
+	structType  reflect.Type
+	resultNames []string
+	resultType  reflect.Type
+	funcName    string
+	packagePath string
+	kind        FunctionKind
+	provenance  Provenance
+	frames      *sync.Pool
+}
+
+// functionKey identifies function code. The type is part of the key because
+// generic instantiations sharing a GC shape may share code.
+type functionKey struct {
+	pc  uintptr
+	typ reflect.Type
+}
+
+// functionInfos caches functionInfo by functionKey, so registries wrapping
+// hundreds of handlers, or wrapping the same handler in several registries,
+// resolve each function once. The cache is bounded by the program's code.
+var functionInfos sync.Map
+
+// lookupFunctionInfo returns the cached functionInfo of the function at pc,
+// resolving it on first use. Failures are not cached.
+func lookupFunctionInfo(pc uintptr, fnType reflect.Type) (*functionInfo, error) {
+	key := functionKey{pc: pc, typ: fnType}
+	if info, ok := functionInfos.Load(key); ok {
+		return info.(*functionInfo), nil
+	}
+
+	info, err := newFunctionInfo(pc, fnType)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := functionInfos.LoadOrStore(key, info)
+	return actual.(*functionInfo), nil
+}
+
+// resetFunctionInfos discards the cached function information, e.g. when a
+// new name normalizer may change how functions resolve.
+func resetFunctionInfos() {
+	functionInfos.Clear()
+}
+
+func newFunctionInfo(pc uintptr, fnType reflect.Type) (*functionInfo, error) {
+	// Get function runtime information
+	runtimeFunc := runtime.FuncForPC(pc)
+	funcName := runtimeFunc.Name()
+	packagePath := extractPackagePath(funcName)
+	file, _ := runtimeFunc.FileLine(runtimeFunc.Entry())
+	kind := classifyFunction(funcName, file)
+
+	paramTypes := make([]reflect.Type, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		paramTypes[i] = fnType.In(i)
+	}
+
+	var paramNames, resultNames []string
+	var provenance Provenance
+	if entry, ok := cachedNames(funcName, fnType); ok {
+		paramNames, resultNames = entry.Params, entry.Results
+		provenance = Provenance{Source: SourceCache, DWARFKey: entry.DWARFKey}
+		Logger().Debug("dwarfreflect: names from cache", "function", funcName)
+	} else {
+		var err error
+		if paramNames, resultNames, provenance, err = resolveNames(pc, funcName, kind, fnType); err != nil {
+			return nil, err
+		}
+		if provenance.Source != SourceGoFile && provenance.Source != SourcePositionalFallback {
+			storeNames(funcName, fnType, CacheEntry{Params: paramNames, Results: resultNames, DWARFKey: provenance.DWARFKey})
+		}
+	}
+
+	return &functionInfo{
+		paramNames:  paramNames,
+		paramIndex:  newParamIndex(paramNames),
+		paramTypes:  paramTypes,
+		structType:  createStructType(paramNames, paramTypes),
+		resultNames: resultNames,
+		resultType:  createResultStructType(fnType, resultNames),
+		funcName:    funcName,
+		packagePath: packagePath,
+		kind:        kind,
+		provenance:  provenance,
+		frames:      newFramePool(len(paramTypes)),
+	}, nil
+}
+
+// resolveNames resolves the parameter and result names of the function at pc
+// from DWARF, by name and then by address, or else from registered source.
+// Functions other than Go ones fall back to positional names.
+func resolveNames(pc uintptr, funcName string, kind FunctionKind, fnType reflect.Type) (paramNames, resultNames []string, provenance Provenance, err error) {
+	loadResolver() // failures surface as missing names below
+
+	paramNames, dwarfKey, err := globalResolver.lookupParameterNames(funcName, fnType.NumIn())
+	provenance = newProvenance(funcName, dwarfKey)
+	if err != nil {
+		// The runtime name may match no entry while the code address does
+		if dwarfName, allParams, ok := globalResolver.lookupPC(pc); ok && len(allParams) >= fnType.NumIn() &&
+			!slices.ContainsFunc(allParams[:fnType.NumIn()], isPlaceholderName) {
+			paramNames, resultNames, err = allParams[:fnType.NumIn()], positionalResultNames(fnType.NumOut()), nil
+			if len(allParams) == fnType.NumIn()+fnType.NumOut() {
+				for i, name := range allParams[fnType.NumIn():] {
+					if !isPlaceholderName(name) {
+						resultNames[i] = name
+					}
+				}
+			}
+			provenance = Provenance{Source: SourcePCMatch, DWARFKey: dwarfName}
+			Logger().Debug("dwarfreflect: DWARF entry matched by address", "function", funcName, "entry", dwarfName)
+		}
+	}
+	if err != nil {
+		if names, results, ok := lookupSourceNames(funcName, fnType.NumIn(), fnType.NumOut()); ok {
+			paramNames, resultNames = names, positionalResultNames(len(results))
+			for i, name := range results {
+				if !isPlaceholderName(name) {
+					resultNames[i] = name
+				}
+			}
+			provenance = Provenance{Source: SourceGoFile}
+			Logger().Debug("dwarfreflect: parameter names from registered source", "function", funcName)
+		} else if kind == KindGo {
+			if resolverInitErr != nil {
+				return nil, nil, Provenance{}, resolverInitErr
+			}
+			return nil, nil, Provenance{}, err
+		} else {
+			// Assembly and cgo functions rarely have DWARF parameters: rebuilding won't help
+			paramNames = positionalNames(fnType.NumIn())
+			provenance = Provenance{Source: SourcePositionalFallback}
+			Logger().Debug("dwarfreflect: positional parameter names", "function", funcName, "kind", kind)
+		}
+	}
+
+	if resultNames == nil {
+		resultNames = globalResolver.discoverResultNames(funcName, fnType.NumIn(), fnType.NumOut())
+	}
+	return paramNames, resultNames, provenance, nil
+}
+
+// isPlaceholderName reports whether name stands for an unnamed parameter or
+// result (~p0, ~r0, ...).
+func isPlaceholderName(name string) bool {
+	return strings.HasPrefix(name, "~")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewFunction_SharesFunctionInfo(t *testing.T) {
+	fn1 := mustNewFunction(t, testFunc4)
+	fn2 := mustNewFunction(t, testFunc4)
+
+	if fn1 == fn2 {
+		t.Fatal("each call must return a new Function")
+	}
+	if &fn1.paramNames[0] != &fn2.paramNames[0] || fn1.frames != fn2.frames {
+		t.Error("expected the resolution results to be shared")
+	}
+
+	reg := NewRegistry()
+	registered := mustRegister(t, reg, "a", testFunc4)
+	if &registered.paramNames[0] != &fn1.paramNames[0] {
+		t.Error("expected registries to share the resolution results")
+	}
+}
+
+func TestNewFunction_SharedInfoClosures(t *testing.T) {
+	makeAdder := func(base int) func(value int) int {
+		return func(value int) int { return base + value }
+	}
+
+	add1 := mustNewFunction(t, makeAdder(1))
+	add2 := mustNewFunction(t, makeAdder(2))
+
+	// Closures share code, hence names, but keep their own captured values
+	if !reflect.DeepEqual(add1.paramNames, add2.paramNames) {
+		t.Errorf("expected equal names, got %v and %v", add1.paramNames, add2.paramNames)
+	}
+	r1, _ := add1.Call(10)
+	r2, _ := add2.Call(10)
+	if r1[0].Int() != 11 || r2[0].Int() != 12 {
+		t.Errorf("unexpected results %v and %v", r1[0], r2[0])
+	}
+}
+
+func TestResetFunctionInfos(t *testing.T) {
+	fn1 := mustNewFunction(t, testFunc4)
+	resetFunctionInfos()
+	fn2 := mustNewFunction(t, testFunc4)
+
+	if &fn1.paramNames[0] == &fn2.paramNames[0] {
+		t.Error("expected a new resolution after reset")
+	}
+	if !reflect.DeepEqual(fn1.paramNames, fn2.paramNames) {
+		t.Error("expected the same parameter names")
+	}
+}
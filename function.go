@@ -4,41 +4,153 @@
 package dwarfreflect
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"reflect"
-	"runtime"
 	"slices"
 	"strings"
+	"sync"
 	"unicode"
 )
 
 // StructOptions customizes struct generation from function parameters.
 type StructOptions struct {
-	// FieldNamer transforms parameter names to struct field names.
-	// Default: capitalizeFirst (makes fields exported).
+	// FieldNamer transforms parameter names to struct field names; names
+	// that are not exported identifiers are made so as FieldForParam
+	// describes. Default: capitalizeFirst (makes fields exported).
 	FieldNamer func(paramName string) string
 
 	// TagBuilder creates struct tags for each parameter.
 	// Receives parameter name and type, returns complete tag string.
 	TagBuilder func(paramName string, paramType reflect.Type) string
+
+	// SortFields orders fields alphabetically by parameter name instead of by
+	// parameter position, for stable JSON output across signature changes.
+	// Use StructLayout to map parameters to fields.
+	SortFields bool
+
+	// Collisions chooses what happens when parameters map to the same field
+	// name, e.g. name and Name. Default: CollisionSuffix.
+	Collisions CollisionPolicy
+
+	// RenameCollision names the field of a parameter whose field name is
+	// taken, for attempts n = 2, 3, ... until the name is free.
+	// Default: fieldName followed by n. Ignored with CollisionError.
+	RenameCollision func(paramName, fieldName string, n int) string
+
+	// IncludeContext adds a Context context.Context field tagged json:"-" to
+	// the structs of non-context parameters (NewNonContextParams and the
+	// like) of functions taking a context.Context, so that call records
+	// keep it. CallWithStruct passes it to the context parameters. Structs
+	// of all parameters hold those parameters already.
+	IncludeContext bool
+
+	// MaxFields bounds the fields of the struct, for functions with many
+	// parameters: past MaxFields-1 fields, the remaining parameters are
+	// grouped into the fields of a nested struct held by an Options field
+	// (json:"options"). CallWithStruct looks into it. StructLayout does not
+	// describe grouped fields. Default: 0, no bound.
+	MaxFields int
+}
+
+// CallOptions customizes how named arguments are bound before invocation.
+type CallOptions struct {
+	// AllowUnsafe permits binding unsafe.Pointer and uintptr parameters from maps.
+	// Only enable it for trusted callers: these values bypass Go's memory safety.
+	AllowUnsafe bool
+
+	// CopyArgs deep-copies slice, map and pointer arguments before invocation,
+	// so the callee cannot mutate the caller's values and vice versa.
+	CopyArgs bool
+
+	// Limits bounds the size of bound arguments; violations are reported as
+	// *LimitError before the function is called.
+	Limits Limits
+
+	// StrictTypes requires arguments to have exactly the type of their
+	// parameter, or to implement it when it is an interface. By default an
+	// argument only needs to be assignable, which lets a []string flow into a
+	// Tags parameter, or a Tags into a []string one, silently. Mismatches are
+	// reported as *StrictTypeError. Defaults are not checked.
+	StrictTypes bool
+
+	// AllowConvert converts arguments that are not assignable to their
+	// parameter but convertible to it, as int to int64, string to a defined
+	// string type or []byte to string, rather than failing. Numbers must be
+	// represented exactly, and integers are never converted to strings. With
+	// StrictTypes, assignable arguments of other types are converted too.
+	// ParamMeta.Convert overrides it per parameter.
+	AllowConvert bool
+}
+
+// UnsafeParameterError reports an attempt to bind an unsafe.Pointer or uintptr
+// parameter from untrusted input without CallOptions.AllowUnsafe.
+type UnsafeParameterError struct {
+	Function string
+	Param    string
+	Type     reflect.Type
+}
+
+func (e *UnsafeParameterError) Error() string {
+	return fmt.Sprintf("parameter %q of function %s has unsafe type %v (set CallOptions.AllowUnsafe to bind it)",
+		e.Param, e.Function, e.Type)
 }
 
 // Function wraps a Go function to enable enhanced reflection capabilities
 // including parameter name extraction and struct generation.
+//
+// A Function is safe for concurrent use. Its configuration is copy-on-write:
+// With* methods never modify the receiver but return a configured copy, so a
+// Function shared between goroutines can be called, inspected and used as the
+// base of new configurations at the same time. The only state shared between
+// copies is internally synchronized (circuit breakers, argument frame pools,
+// struct variant caches).
+// Slices returned by getters are copies the caller may modify, except those of
+// a frozen Function, which must be treated as read-only.
 type Function struct {
 	function     reflect.Value
 	functionType reflect.Type
 	paramNames   []string
+	paramIndex   map[string]int // positions by name of large arity functions, see paramPosition
 	paramTypes   []reflect.Type
 	structType   reflect.Type
+	resultNames  []string
+	resultType   reflect.Type
 	funcName     string
 	packagePath  string
+	kind         FunctionKind
+	provenance   Provenance
+	frozen       *frozenState
+
+	contextDecorators []ContextDecorator
+	paramMeta         map[string]ParamMeta
+	policy            *Policy
+	breaker           *CircuitBreaker
+	authorizers       []Authorizer
+	observers         []Observer
+	shadow            *shadowConfig
+	recorder          *recorder                // see Record
+	options           map[string]reflect.Value // functional option constructors by argument name
+	frames            *sync.Pool               // argument frames reused by CallWithMap
+	variants          *structVariants
+	adoptedTags       map[string]reflect.StructTag // struct tags by parameter name, see AdoptTagsFrom
+	aliases           map[string]string            // parameter names by alternative argument key, see Aliases
+	pagination        *PaginationOptions           // see Paginated
+	errorContext      *ErrorContextOptions         // see WithErrorContext
+	scoped            []string                     // scoping parameter names, see WithScopedParams
+	deprecation       *deprecation                 // see Deprecate
 }
 
+// ContextDecorator derives the context injected into context.Context parameters,
+// e.g. to attach tracing spans, baggage, request IDs or tenant information.
+type ContextDecorator func(ctx context.Context) context.Context
+
 // NewFunction creates a Function wrapper that extracts parameter names from DWARF debug info.
 // It returns an error if the provided value is not a function or if DWARF information
-// is unavailable.
+// is unavailable. Resolution results are cached by code address, so wrapping the
+// same function again, e.g. in several registries, repeats no DWARF lookups or
+// struct generation.
 //
 // Example:
 //
@@ -46,7 +158,7 @@ type Function struct {
 //	fn := dwarfreflect.NewFunction(MyFunc)
 func NewFunction(fn any) (*Function, error) {
 	resolverOnce.Do(initResolver)
-	if resolverInitErr != nil {
+	if resolverInitErr != nil && !hasSourceNames() {
 		return nil, resolverInitErr
 	}
 
@@ -57,32 +169,25 @@ func NewFunction(fn any) (*Function, error) {
 		return nil, fmt.Errorf("NewFunction requires a function")
 	}
 
-	// Get function runtime information
-	pc := fnValue.Pointer()
-	runtimeFunc := runtime.FuncForPC(pc)
-	funcName := runtimeFunc.Name()
-	packagePath := extractPackagePath(funcName)
-
-	paramTypes := make([]reflect.Type, fnType.NumIn())
-	for i := 0; i < fnType.NumIn(); i++ {
-		paramTypes[i] = fnType.In(i)
-	}
-
-	paramNames, err := globalResolver.discoverParameterNames(funcName, len(paramTypes))
+	info, err := lookupFunctionInfo(fnValue.Pointer(), fnType)
 	if err != nil {
 		return nil, err
 	}
 
-	structType := createStructType(paramNames, paramTypes)
-
 	return &Function{
 		function:     fnValue,
 		functionType: fnType,
-		paramNames:   paramNames,
-		paramTypes:   paramTypes,
-		structType:   structType,
-		funcName:     funcName,
-		packagePath:  packagePath,
+		paramNames:   info.paramNames,
+		paramIndex:   info.paramIndex,
+		paramTypes:   info.paramTypes,
+		structType:   info.structType,
+		resultNames:  info.resultNames,
+		resultType:   info.resultType,
+		funcName:     info.funcName,
+		packagePath:  info.packagePath,
+		frames:       info.frames,
+		kind:         info.kind,
+		provenance:   info.provenance,
 	}, nil
 }
 
@@ -148,39 +253,84 @@ func (t *Function) NewNonContextParamsPtr(opts ...StructOptions) interface{} {
 }
 
 // GetStructType returns the reflect.Type for a struct matching all function parameters.
+// Fields always appear in parameter order; see StructOptions.SortFields for
+// alphabetical ordering.
 func (t *Function) GetStructType() reflect.Type {
 	return t.structType
 }
 
 // GetStructTypeWithOptions returns a customized struct type for all function parameters.
+// It panics with a *StructVariantError when opts would exceed the limit set
+// with WithStructVariantLimit, or a *FieldCollisionError under
+// CollisionError; use StructVariant to get the error instead.
 func (t *Function) GetStructTypeWithOptions(opts StructOptions) reflect.Type {
-	return t.createStructTypeFromParams(t.paramNames, t.paramTypes, opts)
+	return mustStructVariant(t.StructVariant(opts))
 }
 
 // GetNonContextStructType returns a struct type excluding context.Context parameters.
 func (t *Function) GetNonContextStructType() reflect.Type {
+	if t.frozen != nil {
+		return t.frozen.nonContextStruct
+	}
 	paramNames, paramTypes := t.GetNonContextParameters()
 	return t.createStructTypeFromParams(paramNames, paramTypes, StructOptions{})
 }
 
 // GetNonContextStructTypeWithOptions returns a customized struct type excluding context.Context parameters.
+// It panics like GetStructTypeWithOptions; use NonContextStructVariant to get
+// the error instead.
 func (t *Function) GetNonContextStructTypeWithOptions(opts StructOptions) reflect.Type {
-	paramNames, paramTypes := t.GetNonContextParameters()
-	return t.createStructTypeFromParams(paramNames, paramTypes, opts)
+	return mustStructVariant(t.NonContextStructVariant(opts))
 }
 
 // createStructType creates an anonymous struct type from parameter info
 func createStructType(paramNames []string, paramTypes []reflect.Type) reflect.Type {
 	fields := make([]reflect.StructField, len(paramNames))
+	fieldNames := make([]string, len(paramNames))
+	for i, name := range paramNames {
+		fieldNames[i] = safeFieldName(name)
+	}
+	uniqueFieldNames("", paramNames, fieldNames, StructOptions{}) // suffixes never fail
 
 	for i, name := range paramNames {
-		// Capitalize first letter for exported field
-		fieldName := capitalizeFirst(name)
+		fieldName := fieldNames[i]
+
+		// Keep unsafe parameters out of the JSON view of the struct
+		jsonName := name
+		if isUnsafeType(paramTypes[i]) {
+			jsonName = "-"
+		}
 
 		fields[i] = reflect.StructField{
 			Name: fieldName,
 			Type: paramTypes[i],
-			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s" param:"%s"`, name, name)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s" param:"%s"`, jsonName, name)),
+		}
+	}
+
+	return reflect.StructOf(fields)
+}
+
+// createResultStructType creates an anonymous struct type from the function results,
+// leaving out a trailing error which is reported separately.
+func createResultStructType(fnType reflect.Type, resultNames []string) reflect.Type {
+	count := fnType.NumOut()
+	if count > 0 && fnType.Out(count-1) == errorType {
+		count--
+	}
+
+	fieldNames := make([]string, count)
+	for i := range fieldNames {
+		fieldNames[i] = safeFieldName(resultNames[i])
+	}
+	uniqueFieldNames("", resultNames[:count], fieldNames, StructOptions{}) // suffixes never fail
+
+	fields := make([]reflect.StructField, count)
+	for i := 0; i < count; i++ {
+		fields[i] = reflect.StructField{
+			Name: fieldNames[i],
+			Type: fnType.Out(i),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, resultNames[i])),
 		}
 	}
 
@@ -188,16 +338,34 @@ func createStructType(paramNames []string, paramTypes []reflect.Type) reflect.Ty
 }
 
 func (t *Function) createStructTypeFromParams(paramNames []string, paramTypes []reflect.Type, opts StructOptions) reflect.Type {
+	fields, err := t.structFields(paramNames, paramTypes, opts)
+	if err != nil {
+		panic(err) // only CollisionError fails, and callers do not ask for it
+	}
+	return reflect.StructOf(fields)
+}
+
+// structFields returns the fields of the struct generated from parameters of
+// the function funcName, or a *FieldCollisionError.
+func structFields(funcName string, paramNames []string, paramTypes []reflect.Type, opts StructOptions) ([]reflect.StructField, error) {
 	// Set default field namer if not provided
 	fieldNamer := opts.FieldNamer
 	if fieldNamer == nil {
 		fieldNamer = capitalizeFirst
 	}
+	fieldNames := make([]string, len(paramNames))
+	for i, paramName := range paramNames {
+		fieldNames[i] = safeFieldName(fieldNamer(paramName))
+	}
+	if err := uniqueFieldNames(funcName, paramNames, fieldNames, opts); err != nil {
+		return nil, err
+	}
 
 	// Create struct fields
 	fields := make([]reflect.StructField, len(paramNames))
-	for i, paramName := range paramNames {
-		fieldName := fieldNamer(paramName)
+	for fieldIndex, i := range fieldOrder(paramNames, opts) {
+		paramName := paramNames[i]
+		fieldName := fieldNames[i]
 
 		var tag reflect.StructTag
 		if opts.TagBuilder != nil {
@@ -205,14 +373,53 @@ func (t *Function) createStructTypeFromParams(paramNames []string, paramTypes []
 			tag = reflect.StructTag(tagString)
 		}
 
-		fields[i] = reflect.StructField{
+		fields[fieldIndex] = reflect.StructField{
 			Name: fieldName,
 			Type: paramTypes[i],
 			Tag:  tag,
 		}
 	}
 
-	return reflect.StructOf(fields)
+	return fields, nil
+}
+
+// fieldOrder returns the parameter index of each struct field: parameter
+// order, or alphabetical by parameter name when opts.SortFields is set.
+func fieldOrder(paramNames []string, opts StructOptions) []int {
+	order := make([]int, len(paramNames))
+	for i := range order {
+		order[i] = i
+	}
+	if opts.SortFields {
+		slices.SortStableFunc(order, func(a, b int) int {
+			return strings.Compare(paramNames[a], paramNames[b])
+		})
+	}
+	return order
+}
+
+// StructLayout maps each parameter index to the index of its field in the
+// struct generated with opts, for custom binders that set fields by position.
+// Without SortFields, field order always matches parameter order and the
+// layout is the identity.
+//
+// Example:
+//
+//	func CreateUser(name string, age int) {}
+//	layout := fn.StructLayout(dwarfreflect.StructOptions{SortFields: true}) // [1 0]: age, name
+//	params := reflect.New(fn.GetStructTypeWithOptions(opts)).Elem()
+//	params.Field(layout[0]).SetString("Alice")
+func (t *Function) StructLayout(opts ...StructOptions) []int {
+	var options StructOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	layout := make([]int, len(t.paramNames))
+	for fieldIndex, paramIndex := range fieldOrder(t.paramNames, options) {
+		layout[paramIndex] = fieldIndex
+	}
+	return layout
 }
 
 // Call invokes the function with individual arguments.
@@ -222,6 +429,16 @@ func (t *Function) createStructTypeFromParams(paramNames []string, paramTypes []
 //
 //	results := fn.Call("Alice", 30, true)
 func (t *Function) Call(args ...any) ([]reflect.Value, error) {
+	callArgs, err := t.valuesOf(args)
+	if err != nil {
+		return nil, t.bindFailed(err)
+	}
+	return t.invoke(callArgs)
+}
+
+// valuesOf converts positional arguments to reflect.Values, validating their
+// count and types.
+func (t *Function) valuesOf(args []any) ([]reflect.Value, error) {
 	if len(args) != len(t.paramTypes) {
 		return nil, fmt.Errorf("wrong number of arguments: expected %d, got %d",
 			len(t.paramTypes), len(args))
@@ -231,6 +448,12 @@ func (t *Function) Call(args ...any) ([]reflect.Value, error) {
 	callArgs := make([]reflect.Value, len(args))
 	for i, arg := range args {
 		argValue := reflect.ValueOf(arg)
+		if arg == nil && canBeNil(t.paramTypes[i]) {
+			argValue = reflect.Zero(t.paramTypes[i]) // untyped nil
+		} else if arg == nil {
+			return nil, fmt.Errorf("argument %d (%s): cannot assign nil to %v",
+				i, t.paramNames[i], t.paramTypes[i])
+		}
 
 		// Validate type compatibility
 		if !argValue.Type().AssignableTo(t.paramTypes[i]) {
@@ -241,36 +464,144 @@ func (t *Function) Call(args ...any) ([]reflect.Value, error) {
 		callArgs[i] = argValue
 	}
 
-	return t.function.Call(callArgs), nil
+	return callArgs, nil
+}
+
+// invoke calls the underlying function with fully prepared arguments. The
+// call context is taken from the first context.Context argument, if any.
+func (t *Function) invoke(args []reflect.Value) ([]reflect.Value, error) {
+	return t.invokeContext(t.argContext(args), args)
+}
+
+// invokeContext calls the underlying function with fully prepared arguments,
+// applying the authorizers, observers, circuit breaker, policy and shadow. ctx is the
+// call context, consulted even by functions without a context.Context
+// parameter. Every Call variant ends up here.
+func (t *Function) invokeContext(ctx context.Context, args []reflect.Value) ([]reflect.Value, error) {
+	shutdownCtx, endCall, err := beginCall()
+	if err != nil {
+		return nil, err
+	}
+	defer endCall()
+	// Streams outlive the call, and with them the contexts they were given
+	if !inflight.keepCalls.Load() && slices.Contains(t.paramTypes, contextType) && !t.IsStream() {
+		var callCtx *shutdownContext
+		callCtx, args = cancelOnShutdown(shutdownCtx, ctx, args, t.paramTypes)
+		defer callCtx.release()
+		ctx = callCtx
+	}
+
+	if len(t.scoped) > 0 {
+		if args, err = t.applyScope(ctx, args); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.authorize(ctx, args); err != nil {
+		return nil, err
+	}
+	if t.deprecation != nil {
+		t.warnDeprecated(ctx)
+	}
+
+	shadow := t.prepareShadow(args)
+	record := t.prepareRecord(args)
+	results, err := t.observedCall(ctx, args)
+	if err == nil && shadow != nil {
+		shadow(results)
+	}
+	if err == nil && record != nil {
+		record(results)
+	}
+	if t.errorContext != nil && err == nil {
+		t.addErrorContext(results, args)
+	}
+	return results, err
+}
+
+// argContext returns the first non-nil context.Context argument, or
+// context.Background().
+func (t *Function) argContext(args []reflect.Value) context.Context {
+	for i, paramType := range t.paramTypes {
+		if arg := args[i]; paramType == contextType && arg.IsValid() && !arg.IsZero() {
+			return arg.Interface().(context.Context)
+		}
+	}
+	return context.Background()
+}
+
+// guardedCall applies the circuit breaker around callWithPolicy.
+func (t *Function) guardedCall(args []reflect.Value) ([]reflect.Value, error) {
+	if t.breaker == nil {
+		return t.callWithPolicy(args), nil
+	}
+
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			t.breaker.record(errPanicked)
+		}
+	}()
+
+	results := t.callWithPolicy(args)
+	completed = true
+	t.breaker.record(trailingError(results))
+	return results, nil
+}
+
+// call calls the underlying function once. Variadic functions receive their
+// last argument as the variadic slice itself, as bound by every Call variant.
+func (t *Function) call(args []reflect.Value) []reflect.Value {
+	if t.functionType.IsVariadic() {
+		return t.function.CallSlice(args)
+	}
+	return t.function.Call(args)
 }
 
 // CallWithReflect invokes the function with reflect.Value arguments.
 // Lower-level version of Call for advanced use cases.
 func (t *Function) CallWithReflect(args []reflect.Value) ([]reflect.Value, error) {
 	if len(args) != len(t.paramTypes) {
-		return nil, fmt.Errorf("wrong number of arguments: expected %d, got %d",
-			len(t.paramTypes), len(args))
+		return nil, t.bindFailed(fmt.Errorf("wrong number of arguments: expected %d, got %d",
+			len(t.paramTypes), len(args)))
 	}
 
 	// Validate types
 	for i, arg := range args {
 		if !arg.Type().AssignableTo(t.paramTypes[i]) {
-			return nil, fmt.Errorf("argument %d (%s): cannot assign %v to %v",
-				i, t.paramNames[i], arg.Type(), t.paramTypes[i])
+			return nil, t.bindFailed(fmt.Errorf("argument %d (%s): cannot assign %v to %v",
+				i, t.paramNames[i], arg.Type(), t.paramTypes[i]))
 		}
 	}
 
-	return t.function.Call(args), nil
+	return t.invoke(args)
 }
 
-// CallWithStruct invokes the function using values from a generated struct.
-// The struct must match the type returned by GetStructType().
+// CallWithStruct invokes the function using values from a struct, such as
+// the one returned by NewParams. Any struct works whose fields hold every
+// parameter: a field tagged param:"name" holds parameter name, as does a
+// field named after it (Name for name, or ID for id ignoring case) if no
+// field has the tag. Fields may appear in any order, be promoted from
+// embedded structs, and fields that match no parameter are ignored. Structs
+// without fields for context.Context parameters, such as those generated with
+// StructOptions.IncludeContext, pass them the value of their context.Context
+// field.
 //
 // Example:
 //
 //	params := fn.NewParamsPtr().(*struct{Name string; Age int})
 //	params.Name, params.Age = "Alice", 30
 //	results := fn.CallWithStruct(params)
+//
+//	type Request struct {
+//	    TraceID string
+//	    Age     int
+//	    User    string `param:"name"`
+//	}
+//	results = fn.CallWithStruct(Request{User: "Alice", Age: 30})
 func (t *Function) CallWithStruct(argStruct any) ([]reflect.Value, error) {
 	structValue := reflect.ValueOf(argStruct)
 
@@ -278,21 +609,25 @@ func (t *Function) CallWithStruct(argStruct any) ([]reflect.Value, error) {
 		structValue = structValue.Elem()
 	}
 
-	if structValue.Type() != t.structType {
-		return nil, fmt.Errorf("struct type mismatch: expected %v, got %v",
-			t.structType, structValue.Type())
+	// Generated structs hold the parameters in order
+	if structValue.Type() == t.structType {
+		args := make([]reflect.Value, len(t.paramNames))
+		for i := range args {
+			args[i] = structValue.Field(i)
+		}
+		return t.invoke(args)
 	}
 
-	// Extract values from struct fields
-	args := make([]reflect.Value, len(t.paramNames))
-	for i, paramName := range t.paramNames {
-		fieldName := capitalizeFirst(paramName)
-		fieldValue := structValue.FieldByName(fieldName)
-		args[i] = fieldValue
+	args, err := structArgs(structValue, t.paramNames, t.paramTypes)
+	if err != nil {
+		// Structs generated with IncludeContext hold one field for every
+		// context parameter
+		if ctx, ok := structContext(structValue); ok && len(t.GetContextPositions()) > 0 {
+			return t.CallWithNonContextStructAndContext(ctx, structValue.Interface())
+		}
+		return nil, t.bindFailed(err)
 	}
-
-	// Call the function
-	return t.function.Call(args), nil
+	return t.invoke(args)
 }
 
 // CallWithContext invokes the function with automatic context injection.
@@ -306,9 +641,16 @@ func (t *Function) CallWithContext(ctx context.Context, args ...any) ([]reflect.
 	contextPositions := t.GetContextPositions()
 	if len(contextPositions) == 0 {
 		// No context parameters - just call normally
-		return t.Call(args...)
+		callArgs, err := t.valuesOf(args)
+		if err != nil {
+			return nil, t.bindFailed(err)
+		}
+		return t.invokeContext(ctx, callArgs)
 	}
 
+	// Decorate once so every context position receives the same context
+	ctx = t.decorateContext(ctx)
+
 	// Create full argument list with context injected
 	fullArgs := make([]any, len(t.paramTypes))
 	argIndex := 0
@@ -318,19 +660,48 @@ func (t *Function) CallWithContext(ctx context.Context, args ...any) ([]reflect.
 			fullArgs[i] = ctx
 		} else {
 			if argIndex >= len(args) {
-				return nil, fmt.Errorf("not enough arguments: expected %d non-context args, got %d",
-					len(t.paramTypes)-len(contextPositions), len(args))
+				return nil, t.bindFailed(fmt.Errorf("not enough arguments: expected %d non-context args, got %d",
+					len(t.paramTypes)-len(contextPositions), len(args)))
 			}
 			fullArgs[i] = args[argIndex]
 			argIndex++
 		}
 	}
 
-	return t.Call(fullArgs...)
+	callArgs, err := t.valuesOf(fullArgs)
+	if err != nil {
+		return nil, t.bindFailed(err)
+	}
+	return t.invokeContext(ctx, callArgs)
+}
+
+// WithContextDecorator returns a copy of the Function that passes the context
+// through decorator before injecting it into context.Context parameters.
+// Decorators run in the order they were added, once per call, so functions
+// with multiple context parameters receive the same decorated context.
+//
+// Example:
+//
+//	traced := fn.WithContextDecorator(func(ctx context.Context) context.Context {
+//	    return context.WithValue(ctx, requestIDKey, requestID)
+//	})
+func (t *Function) WithContextDecorator(decorator ContextDecorator) *Function {
+	clone := *t
+	clone.contextDecorators = append(slices.Clip(t.contextDecorators), decorator)
+	return &clone
+}
+
+// decorateContext applies the context decorators in order.
+func (t *Function) decorateContext(ctx context.Context) context.Context {
+	for _, decorate := range t.contextDecorators {
+		ctx = decorate(ctx)
+	}
+	return ctx
 }
 
 // CallWithNonContextStructAndContext invokes the function using a non-context struct plus context injection.
-// The struct should be created with NewNonContextParams().
+// The struct is typically created with NewNonContextParams(), but any struct
+// holding the non-context parameters works, matched as by CallWithStruct.
 //
 // Example:
 //
@@ -342,19 +713,14 @@ func (t *Function) CallWithNonContextStructAndContext(ctx context.Context, argSt
 		structValue = structValue.Elem()
 	}
 
-	nonContextStructType := t.GetNonContextStructType()
-	if !structTypesCompatible(structValue.Type(), nonContextStructType) {
-		return nil, fmt.Errorf("struct type mismatch: expected %v, got %v",
-			nonContextStructType, structValue.Type())
+	nonContextNames, nonContextTypes := t.GetNonContextParameters()
+	values, err := structArgs(structValue, nonContextNames, nonContextTypes)
+	if err != nil {
+		return nil, t.bindFailed(err)
 	}
-
-	// Extract values from non-context struct fields
-	nonContextNames, _ := t.GetNonContextParameters()
-	args := make([]any, len(nonContextNames))
-	for i, paramName := range nonContextNames {
-		fieldName := capitalizeFirst(paramName)
-		fieldValue := structValue.FieldByName(fieldName)
-		args[i] = fieldValue.Interface()
+	args := make([]any, len(values))
+	for i, value := range values {
+		args[i] = value.Interface()
 	}
 
 	// Use existing CallWithContext which handles context injection
@@ -364,6 +730,10 @@ func (t *Function) CallWithNonContextStructAndContext(ctx context.Context, argSt
 // CallWithMap invokes the function using a map of parameter names to values.
 // Enables semantic function calls using actual parameter names.
 // Extra keys in the map are ignored for flexibility.
+// Parameters missing from the map take their ParamMeta.Default, if set;
+// optional ones (pointers by default, see WithRequired) bind their zero value.
+// Parameters of type unsafe.Pointer or uintptr are rejected with an
+// *UnsafeParameterError unless CallOptions.AllowUnsafe is set.
 //
 // Example:
 //
@@ -372,59 +742,234 @@ func (t *Function) CallWithNonContextStructAndContext(ctx context.Context, argSt
 //	    "age": 30,
 //	    "active": true,
 //	})
-func (t *Function) CallWithMap(argMap map[string]any) ([]reflect.Value, error) {
-	args, err := t.MapToArgs(argMap)
+func (t *Function) CallWithMap(argMap map[string]any, opts ...CallOptions) ([]reflect.Value, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	// Bind straight into a pooled frame: no intermediate []any and no
+	// per-call argument slice
+	frame := t.getFrame()
+	if err := t.bindMap(argMap, options, nil, *frame, nil); err != nil {
+		t.putFrame(frame)
+		return nil, t.bindFailed(err)
+	}
+
+	results, err := t.invoke(*frame)
+	t.putFrame(frame)
+	return results, err
+}
+
+// CallVoid invokes the function using a map of parameter names to values and
+// returns only its error, for functions returning nothing or just an error.
+// Functions with any other result shape are rejected without being called.
+//
+// Example:
+//
+//	func DeleteUser(ctx context.Context, userID int) error
+//	err := fn.CallVoid(map[string]any{"ctx": ctx, "userID": 42})
+func (t *Function) CallVoid(argMap map[string]any, opts ...CallOptions) error {
+	out := t.functionType.NumOut()
+	if out > 1 || (out == 1 && t.functionType.Out(0) != errorType) {
+		return fmt.Errorf("CallVoid: function %s must return nothing or only error, returns %d values", t.funcName, out)
+	}
+
+	results, err := t.CallWithMap(argMap, opts...)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return trailingError(results)
+}
 
-	callArgs := make([]reflect.Value, len(args))
-	for i, arg := range args {
-		callArgs[i] = reflect.ValueOf(arg)
+// CallOne invokes the function using a map of parameter names to values and
+// returns its single result, for functions returning T or (T, error).
+// Functions with any other result shape, including those returning only an
+// error (see CallVoid), are rejected without being called.
+//
+// Example:
+//
+//	func GetUser(ctx context.Context, userID int) (*User, error)
+//	user, err := fn.CallOne(map[string]any{"ctx": ctx, "userID": 42})
+func (t *Function) CallOne(argMap map[string]any, opts ...CallOptions) (any, error) {
+	out := t.functionType.NumOut()
+	if out == 0 || out > 2 || (out == 2 && t.functionType.Out(1) != errorType) || (out == 1 && t.functionType.Out(0) == errorType) {
+		return nil, fmt.Errorf("CallOne: function %s must return T or (T, error), returns %d values", t.funcName, out)
 	}
 
-	return t.function.Call(callArgs), nil
+	results, err := t.CallWithMap(argMap, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := trailingError(results); out == 2 && err != nil {
+		return results[0].Interface(), err
+	}
+	return results[0].Interface(), nil
 }
 
 // MapToArgs converts a parameter map to a []any slice in correct parameter order.
 // Used internally by CallWithMap but exposed for advanced use cases.
-func (t *Function) MapToArgs(argMap map[string]any) ([]any, error) {
-	if len(argMap) != len(t.paramTypes) {
-		return nil, fmt.Errorf("wrong number of arguments: expected %d, got %d",
-			len(t.paramTypes), len(argMap))
+func (t *Function) MapToArgs(argMap map[string]any, opts ...CallOptions) ([]any, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	args := make([]any, len(t.paramNames))
+	if err := t.bindMap(argMap, options, nil, nil, args); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// bindMap implements MapToArgs, storing the bound arguments in parameter order
+// into values and/or args, whichever is non-nil, so the call path can bind
+// straight into a pooled frame. Each binding step is recorded into report when
+// it is non-nil (see ExplainBind).
+func (t *Function) bindMap(argMap map[string]any, options CallOptions, report *BindReport, values []reflect.Value, args []any) error {
+	if positions := t.GetUnsafePositions(); len(positions) > 0 && !options.AllowUnsafe {
+		i := positions[0]
+		return &UnsafeParameterError{
+			Function: t.funcName,
+			Param:    t.paramNames[i],
+			Type:     t.paramTypes[i],
+		}
+	}
+
+	given := argMap // before aliases are resolved, for the report
+	if len(t.aliases) > 0 {
+		var err error
+		if argMap, err = t.resolveAliases(argMap); err != nil {
+			return err
+		}
+	}
+
+	argCount := len(argMap)
+	for key := range t.options {
+		if _, exists := argMap[key]; exists {
+			argCount--
+		}
+	}
+	if argCount > len(t.paramTypes) {
+		return fmt.Errorf("wrong number of arguments: expected %d, got %d",
+			len(t.paramTypes), argCount)
 	}
 
 	var missing []string
-	for _, paramName := range t.paramNames {
-		if _, exists := argMap[paramName]; !exists {
+	for i, paramName := range t.paramNames {
+		if _, exists := argMap[paramName]; !exists && t.paramMeta[paramName].Default == nil && !t.isOptional(paramName, t.paramTypes[i]) {
 			missing = append(missing, paramName)
+			if report != nil {
+				report.record(ParamBinding{Param: paramName, Type: t.paramTypes[i], Source: BindMissing})
+			}
 		}
 	}
 	if len(missing) > 0 {
-		return nil, fmt.Errorf(
-			"missing required parameters %v (function %s expects %v)",
-			missing, t.funcName, t.paramNames,
+		return fmt.Errorf(
+			"missing required parameters %s (function %s expects %d: %s)",
+			listNames(missing), t.funcName, len(t.paramNames), listNames(t.paramNames),
 		)
 	}
 
-	// Prepare function arguments in the correct parameter order
-	args := make([]any, len(t.paramNames))
+	// Bind function arguments in the correct parameter order
 	for i, paramName := range t.paramNames {
-		argValue := argMap[paramName] // At this point every paramName is in argMap
+		binding := ParamBinding{Param: paramName, Type: t.paramTypes[i], Source: BindExact, Key: paramName}
+
+		var rv reflect.Value
+		argValue, exists := argMap[paramName]
+		if !exists {
+			// At this point every missing param has a default or is optional
+			argValue = t.paramMeta[paramName].Default
+			if argValue == nil {
+				rv = reflect.Zero(t.paramTypes[i])
+			}
+			binding.Source, binding.Key = BindDefault, ""
+		} else if _, direct := given[paramName]; !direct && report != nil {
+			binding.Source, binding.Key = BindAlias, t.aliasKey(given, paramName)
+		}
+		if exists && argValue == nil {
+			if !canBeNil(t.paramTypes[i]) {
+				err := fmt.Errorf("parameter %q: cannot assign nil to %v", paramName, t.paramTypes[i])
+				binding.Err = err
+				report.record(binding)
+				return err
+			}
+			rv = reflect.Zero(t.paramTypes[i]) // untyped nil
+		}
 
 		// Validate type compatibility
-		rv := reflect.ValueOf(argValue)
+		if !rv.IsValid() {
+			rv = reflect.ValueOf(argValue)
+		}
+		binding.ValueType = rv.Type()
+		if s, ok := argValue.(string); ok && !rv.Type().AssignableTo(t.paramTypes[i]) {
+			// Strings may be parsed into types with a registered parser
+			parsed, found, err := parseString(s, t.paramTypes[i])
+			if err != nil {
+				err = fmt.Errorf("parameter %q: cannot parse %q as %v: %w",
+					paramName, s, t.paramTypes[i], err)
+				binding.Err = err
+				report.record(binding)
+				return err
+			}
+			if found {
+				rv, argValue = parsed, parsed.Interface()
+				binding.Coercion = CoercionParsed
+			}
+		}
+		if converted, err := t.convertBinding(i, rv, options, &binding); err != nil {
+			binding.Err = err
+			report.record(binding)
+			return err
+		} else if binding.Coercion == CoercionConverted {
+			rv, argValue = converted, converted.Interface()
+		}
 		if !rv.Type().AssignableTo(t.paramTypes[i]) {
-			return nil, fmt.Errorf(
+			err := fmt.Errorf(
 				"parameter %q: cannot assign %v to %v",
 				paramName, rv.Type(), t.paramTypes[i],
 			)
+			binding.Err = err
+			report.record(binding)
+			return err
 		}
 
-		args[i] = argMap[paramName]
+		if options.StrictTypes && exists && !strictTypeMatch(rv.Type(), t.paramTypes[i]) {
+			err := &StrictTypeError{Function: t.funcName, Param: paramName, Want: t.paramTypes[i], Got: rv.Type()}
+			binding.Err = err
+			report.record(binding)
+			return err
+		}
+
+		if options.Limits.enabled() {
+			if err := options.Limits.check(t.funcName, paramName, rv); err != nil {
+				binding.Err = err
+				report.record(binding)
+				return err
+			}
+		}
+
+		if options.CopyArgs {
+			rv = deepCopy(rv)
+			argValue = rv.Interface()
+			binding.Copied = true
+		}
+		if len(t.options) > 0 && t.isVariadicParam(paramName) {
+			var err error
+			if rv, err = t.bindOptions(argMap, rv); err != nil {
+				return err
+			}
+			argValue = rv.Interface()
+		}
+		if values != nil {
+			values[i] = rv
+		}
+		if args != nil {
+			args[i] = argValue
+		}
+		report.record(binding)
 	}
 
-	return args, nil
+	return nil
 }
 
 // GetParameterInfo returns the parameter names and types extracted from the function.
@@ -435,7 +980,7 @@ func (t *Function) MapToArgs(argMap map[string]any) ([]any, error) {
 //	// names: ["name", "age", "active"]
 //	// types: [string, int, bool]
 func (t *Function) GetParameterInfo() ([]string, []reflect.Type) {
-	return t.paramNames, t.paramTypes
+	return slices.Clone(t.paramNames), slices.Clone(t.paramTypes)
 }
 
 // GetFunctionName returns the full runtime function name.
@@ -469,12 +1014,30 @@ func (t *Function) GetPackagePath() string {
 	return t.packagePath
 }
 
+// ModulePath returns the path of the module providing the function's package,
+// as recorded in the binary's build info. For packages missing from the build
+// info it is inferred from the package path, and "std" for the standard library.
+//
+// Example:
+//
+//	fn.GetPackagePath() // "example.com/lib/v2/codec"
+//	fn.ModulePath()     // "example.com/lib/v2"
+func (t *Function) ModulePath() string {
+	if t.packagePath == "" {
+		return "" // Synthesized functions belong to no package
+	}
+	return modulePathFor(t.packagePath, buildModules())
+}
+
 // GetContextPositions returns the parameter indices where context.Context appears.
 // Used internally for context injection.
 //
 // Example: [0, 2] means context is the 1st and 3rd parameter
 func (t *Function) GetContextPositions() []int {
-	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	if t.frozen != nil {
+		return t.frozen.contextPositions
+	}
+
 	var positions []int
 
 	for i, paramType := range t.paramTypes {
@@ -486,10 +1049,32 @@ func (t *Function) GetContextPositions() []int {
 	return positions
 }
 
+// GetUnsafePositions returns the parameter indices whose type is unsafe.Pointer or uintptr.
+// Such parameters are excluded from the JSON view of generated structs and
+// rejected by map-based binding unless CallOptions.AllowUnsafe is set.
+func (t *Function) GetUnsafePositions() []int {
+	if t.frozen != nil {
+		return t.frozen.unsafePositions
+	}
+
+	var positions []int
+
+	for i, paramType := range t.paramTypes {
+		if isUnsafeType(paramType) {
+			positions = append(positions, i)
+		}
+	}
+
+	return positions
+}
+
 // GetNonContextParameters returns parameter names and types excluding context.Context.
 // Used for creating structs without context fields.
 func (t *Function) GetNonContextParameters() ([]string, []reflect.Type) {
-	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	if t.frozen != nil {
+		return t.frozen.nonContextNames, t.frozen.nonContextTypes
+	}
+
 	var names []string
 	var types []reflect.Type
 
@@ -512,6 +1097,52 @@ func (t *Function) GetReturnTypes() []reflect.Type {
 	return returnTypes
 }
 
+// GetResultNames returns the names of the function results.
+// Unnamed results are reported as r0, r1, ...
+//
+// Example:
+//
+//	func Divide(a, b int) (quotient int, err error)
+//	names := fn.GetResultNames() // ["quotient", "err"]
+func (t *Function) GetResultNames() []string {
+	return slices.Clone(t.resultNames)
+}
+
+// GetResultStructType returns a struct type matching the function results,
+// excluding a trailing error.
+func (t *Function) GetResultStructType() reflect.Type {
+	return t.resultType
+}
+
+// NewResultsPtr creates a pointer to a struct matching the function results.
+// Returns interface{} containing *struct.
+func (t *Function) NewResultsPtr() interface{} {
+	return reflect.New(t.resultType).Interface()
+}
+
+// ResultsToStruct packs call results into a new result struct.
+// Returns interface{} containing *struct, plus the trailing error returned by
+// the function, if any.
+//
+// Example:
+//
+//	results, _ := fn.Call(10, 2)
+//	out, err := fn.ResultsToStruct(results) // &struct{Quotient int}, nil
+func (t *Function) ResultsToStruct(results []reflect.Value) (any, error) {
+	out := reflect.New(t.resultType)
+	for i := 0; i < t.resultType.NumField(); i++ {
+		out.Elem().Field(i).Set(results[i])
+	}
+
+	if len(results) > t.resultType.NumField() {
+		if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
+			return out.Interface(), err
+		}
+	}
+
+	return out.Interface(), nil
+}
+
 // GetReturnInfo returns return types and whether the last return implements error interface.
 // Useful for error handling patterns.
 //
@@ -527,12 +1158,15 @@ func (t *Function) GetReturnInfo() ([]reflect.Type, bool) {
 	}
 
 	// Check if last return type implements error interface
-	errorType := reflect.TypeOf((*error)(nil)).Elem()
 	lastIsError := returnTypes[len(returnTypes)-1].Implements(errorType)
 
 	return returnTypes, lastIsError
 }
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // structTypesCompatible checks if two struct types have the same fields (ignoring tags).
 func structTypesCompatible(t1, t2 reflect.Type) bool {
 	if t1.Kind() != reflect.Struct || t2.Kind() != reflect.Struct {
@@ -555,6 +1189,101 @@ func structTypesCompatible(t1, t2 reflect.Type) bool {
 	return true
 }
 
+// structArgs extracts the arguments for paramNames from the fields of
+// structValue, matched by param tag or else by name as CallWithStruct
+// documents.
+func structArgs(structValue reflect.Value, paramNames []string, paramTypes []reflect.Type) ([]reflect.Value, error) {
+	if structValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %v", structValue.Type())
+	}
+	indexes, err := structFieldIndexes(structValue.Type(), paramNames, paramTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]reflect.Value, len(indexes))
+	for i, index := range indexes {
+		field, err := structValue.FieldByIndexErr(index)
+		if err != nil {
+			return nil, fmt.Errorf("field for parameter %q: %w", paramNames[i], err)
+		}
+		if !field.CanInterface() {
+			return nil, fmt.Errorf("field for parameter %q is promoted through an unexported embedded struct", paramNames[i])
+		}
+		args[i] = field
+	}
+	return args, nil
+}
+
+// structFieldIndexes returns the index of the field of structType holding
+// each of paramNames: the exported field tagged param:"name", or else the
+// untagged exported field named after the parameter, exactly (Name for name)
+// or ignoring case (ID for id), at the top level or in the overflow field of
+// StructOptions.MaxFields.
+func structFieldIndexes(structType reflect.Type, paramNames []string, paramTypes []reflect.Type) ([][]int, error) {
+	fields := reflect.VisibleFields(structType)
+	for _, field := range fields {
+		// Parameters grouped by StructOptions.MaxFields, after the others
+		if field.IsExported() && isOverflowField(field) {
+			for _, nested := range reflect.VisibleFields(field.Type) {
+				nested.Index = append(slices.Clone(field.Index), nested.Index...)
+				fields = append(fields, nested)
+			}
+		}
+	}
+	indexes := make([][]int, len(paramNames))
+
+	for i, paramName := range paramNames {
+		var match, byName, byFold *reflect.StructField
+		for _, field := range fields {
+			if !field.IsExported() || field.Anonymous || isOverflowField(field) {
+				continue
+			}
+			if tag, ok := field.Tag.Lookup("param"); ok {
+				if tag == paramName {
+					match = &field
+					break
+				}
+				continue
+			}
+			if byName == nil && field.Name == safeFieldName(paramName) {
+				byName = &field
+			}
+			if byFold == nil && strings.EqualFold(field.Name, paramName) {
+				byFold = &field
+			}
+		}
+		if match == nil {
+			match = cmp.Or(byName, byFold)
+		}
+
+		if match == nil {
+			return nil, fmt.Errorf("struct %v has no field for parameter %q", structType, paramName)
+		}
+		if !match.Type.AssignableTo(paramTypes[i]) {
+			return nil, fmt.Errorf("field %s of struct %v has type %v, not assignable to parameter %q of type %v",
+				match.Name, structType, match.Type, paramName, paramTypes[i])
+		}
+		indexes[i] = match.Index
+	}
+	return indexes, nil
+}
+
+// canBeNil reports whether values of type t can be nil.
+func canBeNil(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnsafeType reports whether t carries a raw memory address.
+func isUnsafeType(t reflect.Type) bool {
+	return t.Kind() == reflect.UnsafePointer || t.Kind() == reflect.Uintptr
+}
+
 // capitalizeFirst capitalizes the first letter of a string.
 func capitalizeFirst(s string) string {
 	if s == "" {
@@ -5,10 +5,12 @@ package dwarfreflect
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"unsafe"
 )
 
 func mustNewFunction(t *testing.T, fn any) *Function {
@@ -261,6 +263,53 @@ func TestCallWithStruct_TypeMismatch(t *testing.T) {
 	}
 }
 
+type structArgsMeta struct {
+	TraceID string
+}
+
+type structArgsRequest struct {
+	structArgsMeta
+	Age  int
+	User string `param:"name"`
+	Name string // ignored: the param tag of User takes precedence
+}
+
+func TestCallWithStruct_ByName(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	for _, argStruct := range []any{
+		structArgsRequest{User: "Ada", Age: 36, Name: "ignored"},
+		&struct {
+			Age  int
+			Name string
+		}{Age: 36, Name: "Ada"},
+	} {
+		results, err := fn.CallWithStruct(argStruct)
+		if err != nil {
+			t.Fatalf("unexpected error for %T: %v", argStruct, err)
+		}
+		if got := results[0].String(); got != "Ada is 36 years old" {
+			t.Errorf("unexpected result for %T: %q", argStruct, got)
+		}
+	}
+
+	if _, err := fn.CallWithStruct(struct {
+		Name string
+		Age  string
+	}{}); err == nil || !strings.Contains(err.Error(), "not assignable") {
+		t.Errorf("expected a type error, got %v", err)
+	}
+
+	ctxFn := mustNewFunction(t, testFunc4)
+	results, err := ctxFn.CallWithNonContextStructAndContext(context.Background(), struct {
+		Label string `param:"name"`
+		ID    int
+	}{"x", 7})
+	if err != nil || results[0].String() != "id=7, name=x" {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+}
+
 func TestCallWithMap(t *testing.T) {
 	fn := mustNewFunction(t, testFunc1)
 	results, err := fn.CallWithMap(map[string]any{
@@ -296,6 +345,29 @@ func TestCallWithMap_WrongType(t *testing.T) {
 	}
 }
 
+func TestCallWithMap_NilValue(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+	results, err := fn.CallWithMap(map[string]any{
+		"name":   "Heidi",
+		"active": true,
+		"scores": nil,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scores := results[0].Interface().(map[string]any)["scores"].([]int); scores != nil {
+		t.Errorf("expected nil scores, got %v", scores)
+	}
+
+	if _, err := fn.CallWithMap(map[string]any{
+		"name":   "Heidi",
+		"active": nil,
+		"scores": []int{1},
+	}); err == nil || !strings.Contains(err.Error(), "cannot assign nil to bool") {
+		t.Errorf("expected a binding error for nil bool, got %v", err)
+	}
+}
+
 func TestMapToArgs(t *testing.T) {
 	fn := mustNewFunction(t, testFunc1)
 	args, err := fn.MapToArgs(map[string]any{
@@ -354,6 +426,39 @@ func TestCallWithContext_NoContextParams(t *testing.T) {
 	}
 }
 
+type testCtxKey string
+
+func testFuncContextValues(ctx1 context.Context, name string, ctx2 context.Context) string {
+	return fmt.Sprintf("%v/%v/%s", ctx1.Value(testCtxKey("tenant")), ctx2.Value(testCtxKey("request")), name)
+}
+
+func TestWithContextDecorator(t *testing.T) {
+	fn := mustNewFunction(t, testFuncContextValues)
+	decorated := fn.
+		WithContextDecorator(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, testCtxKey("tenant"), "acme")
+		}).
+		WithContextDecorator(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, testCtxKey("request"), "req-1")
+		})
+
+	results, err := decorated.CallWithContext(context.Background(), "x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "acme/req-1/x" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+
+	results, err = fn.CallWithContext(context.Background(), "x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "<nil>/<nil>/x" {
+		t.Errorf("expected original function to stay undecorated, got %s", results[0].String())
+	}
+}
+
 func TestCallWithNonContextStructAndContext(t *testing.T) {
 	fn := mustNewFunction(t, testFunc4)
 	params := fn.NewNonContextParamsPtr()
@@ -453,6 +558,147 @@ func TestGetReturnInfo(t *testing.T) {
 	}
 }
 
+func testFuncNamedResults(a, b int) (quotient int, err error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return a / b, nil
+}
+
+func TestGetResultNames(t *testing.T) {
+	names := mustNewFunction(t, testFuncNamedResults).GetResultNames()
+	if len(names) != 2 || names[0] != "quotient" || names[1] != "err" {
+		t.Errorf("expected [quotient err], got %v", names)
+	}
+
+	names = mustNewFunction(t, testFunc4).GetResultNames()
+	if len(names) != 2 || names[0] != "r0" || names[1] != "r1" {
+		t.Errorf("expected [r0 r1] for unnamed results, got %v", names)
+	}
+}
+
+func TestResultsToStruct(t *testing.T) {
+	fn := mustNewFunction(t, testFuncNamedResults)
+
+	rt := fn.GetResultStructType()
+	if rt.NumField() != 1 || rt.Field(0).Name != "Quotient" {
+		t.Fatalf("expected struct{Quotient int}, got %v", rt)
+	}
+
+	results, err := fn.Call(10, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := fn.ResultsToStruct(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q := reflect.ValueOf(out).Elem().Field(0).Int(); q != 5 {
+		t.Errorf("expected quotient 5, got %d", q)
+	}
+
+	results, _ = fn.Call(1, 0)
+	if _, err := fn.ResultsToStruct(results); err == nil {
+		t.Error("expected the function error to be returned")
+	}
+}
+
+func testFuncErrorOnly(fail bool) error {
+	if fail {
+		return fmt.Errorf("failed")
+	}
+	return nil
+}
+
+func TestCallWithMap_Allocs(t *testing.T) {
+	fn := mustNewFunction(t, testFunc2)
+	args := map[string]any{"x": 1.5, "y": 2.5}
+
+	// Only reflect.Value.Call allocates: its results slice and the boxed result
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := fn.CallWithMap(args); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 2 {
+		t.Errorf("expected at most 2 allocations per call, got %v", allocs)
+	}
+}
+
+//go:noinline
+func testFuncAllocsContext(ctx context.Context, x int) int {
+	return x
+}
+
+func TestCallWithMap_AllocsContext(t *testing.T) {
+	fn := mustNewFunction(t, testFuncAllocsContext)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	args := map[string]any{"ctx": ctx, "x": 1}
+
+	// Context parameters add the boxing of the call context and, for
+	// Shutdown, the call context and the copy of the arguments holding it
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := fn.CallWithMap(args); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 5 {
+		t.Errorf("expected at most 5 allocations per call, got %v", allocs)
+	}
+
+	SetShutdownCancellation(false)
+	defer SetShutdownCancellation(true)
+	allocs = testing.AllocsPerRun(100, func() {
+		if _, err := fn.CallWithMap(args); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 3 {
+		t.Errorf("expected at most 3 allocations per call without shutdown cancellation, got %v", allocs)
+	}
+}
+
+func TestCallVoid(t *testing.T) {
+	fn := mustNewFunction(t, testFuncErrorOnly)
+	if err := fn.CallVoid(map[string]any{"fail": false}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := fn.CallVoid(map[string]any{"fail": true}); err == nil || err.Error() != "failed" {
+		t.Errorf("expected function error, got %v", err)
+	}
+	if err := fn.CallVoid(map[string]any{}); err == nil {
+		t.Error("expected binding error for missing parameter")
+	}
+
+	if err := mustNewFunction(t, testFunc1).CallVoid(map[string]any{"name": "x", "age": 1}); err == nil {
+		t.Error("expected error for function with non-error result")
+	}
+}
+
+func TestCallOne(t *testing.T) {
+	fn := mustNewFunction(t, testFuncNamedResults)
+	v, err := fn.CallOne(map[string]any{"a": 10, "b": 2})
+	if err != nil || v != 5 {
+		t.Errorf("expected 5, got %v, %v", v, err)
+	}
+	if _, err := fn.CallOne(map[string]any{"a": 1, "b": 0}); err == nil {
+		t.Error("expected function error")
+	}
+
+	v, err = mustNewFunction(t, testFunc1).CallOne(map[string]any{"name": "Bob", "age": 3})
+	if err != nil || v != "Bob is 3 years old" {
+		t.Errorf("unexpected result: %v, %v", v, err)
+	}
+
+	if _, err := mustNewFunction(t, testFunc3).CallOne(map[string]any{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := mustNewFunction(t, testFuncErrorOnly).CallOne(map[string]any{"fail": false}); err == nil || !strings.Contains(err.Error(), "must return T or (T, error)") {
+		t.Errorf("expected a function returning only an error to be rejected, got %v", err)
+	}
+}
+
 func TestGetBaseFunctionName(t *testing.T) {
 	fn := mustNewFunction(t, testFunc1)
 	baseName := fn.GetBaseFunctionName()
@@ -643,6 +889,110 @@ func TestGetPackagePath(t *testing.T) {
 	}
 }
 
+func testFuncUnsafe(label string, addr uintptr, ptr unsafe.Pointer) string {
+	return fmt.Sprintf("%s@%x", label, addr)
+}
+
+func TestGetUnsafePositions(t *testing.T) {
+	fn := mustNewFunction(t, testFuncUnsafe)
+	positions := fn.GetUnsafePositions()
+	if len(positions) != 2 || positions[0] != 1 || positions[1] != 2 {
+		t.Errorf("expected unsafe positions [1 2], got %v", positions)
+	}
+
+	if positions := mustNewFunction(t, testFunc1).GetUnsafePositions(); len(positions) != 0 {
+		t.Errorf("expected no unsafe positions, got %v", positions)
+	}
+}
+
+func TestCallWithMap_UnsafeRejected(t *testing.T) {
+	fn := mustNewFunction(t, testFuncUnsafe)
+	_, err := fn.CallWithMap(map[string]any{
+		"label": "x",
+		"addr":  uintptr(16),
+		"ptr":   unsafe.Pointer(nil),
+	})
+
+	var unsafeErr *UnsafeParameterError
+	if !errors.As(err, &unsafeErr) {
+		t.Fatalf("expected *UnsafeParameterError, got %v", err)
+	}
+	if unsafeErr.Param != "addr" {
+		t.Errorf("expected addr to be reported, got %s", unsafeErr.Param)
+	}
+}
+
+func TestCallWithMap_AllowUnsafe(t *testing.T) {
+	fn := mustNewFunction(t, testFuncUnsafe)
+	results, err := fn.CallWithMap(map[string]any{
+		"label": "x",
+		"addr":  uintptr(16),
+		"ptr":   unsafe.Pointer(nil),
+	}, CallOptions{AllowUnsafe: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].String() != "x@10" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestNewParams_UnsafeExcludedFromJSON(t *testing.T) {
+	fn := mustNewFunction(t, testFuncUnsafe)
+	rt := fn.GetStructType()
+
+	if tag := rt.Field(0).Tag.Get("json"); tag != "label" {
+		t.Errorf("expected json tag label, got %q", tag)
+	}
+	for i := 1; i < rt.NumField(); i++ {
+		if tag := rt.Field(i).Tag.Get("json"); tag != "-" {
+			t.Errorf("field %s: expected json tag \"-\", got %q", rt.Field(i).Name, tag)
+		}
+	}
+}
+
+func TestStructFieldOrder(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+
+	names, _ := fn.GetParameterInfo()
+	rt := fn.GetStructType()
+	for i, name := range names {
+		if tag := rt.Field(i).Tag.Get("param"); tag != name {
+			t.Errorf("field %d: expected parameter %q, got %q", i, name, tag)
+		}
+	}
+	if layout := fn.StructLayout(); !reflect.DeepEqual(layout, []int{0, 1, 2}) {
+		t.Errorf("expected identity layout, got %v", layout)
+	}
+
+	opts := StructOptions{SortFields: true}
+	sorted := fn.GetStructTypeWithOptions(opts)
+	for i, expected := range []string{"Active", "Name", "Scores"} {
+		if sorted.Field(i).Name != expected {
+			t.Errorf("sorted field %d: expected %s, got %s", i, expected, sorted.Field(i).Name)
+		}
+	}
+
+	layout := fn.StructLayout(opts)
+	if !reflect.DeepEqual(layout, []int{1, 0, 2}) {
+		t.Fatalf("unexpected sorted layout: %v", layout)
+	}
+	for i, name := range names {
+		if field := sorted.Field(layout[i]).Name; field != capitalizeFirst(name) {
+			t.Errorf("parameter %q mapped to field %s", name, field)
+		}
+	}
+
+	data, err := fn.MarshalParams(map[string]any{"name": "Alice", "active": true, "scores": []int{1}}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"Active":true,"Name":"Alice","Scores":[1]}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
 // mustNewFunctionB mirrors mustNewFunction but works with testing.B to
 // simplify benchmarks.
 func mustNewFunctionB(b *testing.B, fn any) *Function {
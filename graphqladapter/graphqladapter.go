@@ -0,0 +1,325 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package graphqladapter exposes wrapped functions as graphql-go fields.
+//
+// Argument definitions come from the real parameter names and types, the
+// field type from the results, context.Context parameters are injected from
+// the resolve context, and a trailing error is surfaced as a GraphQL error.
+//
+// Example:
+//
+//	reg := dwarfreflect.NewRegistry()
+//	reg.Register("user", GetUser) // func GetUser(ctx context.Context, id int) (*User, error)
+//
+//	fields, err := graphqladapter.Fields(reg)
+//	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+//	    Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+//	})
+package graphqladapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// Builder converts functions to GraphQL fields, sharing the generated
+// object types so the same Go type always maps to the same GraphQL type.
+type Builder struct {
+	outputs map[reflect.Type]graphql.Output
+	inputs  map[reflect.Type]graphql.Input
+}
+
+// NewBuilder creates a Builder with empty type caches.
+func NewBuilder() *Builder {
+	return &Builder{
+		outputs: make(map[reflect.Type]graphql.Output),
+		inputs:  make(map[reflect.Type]graphql.Input),
+	}
+}
+
+// Field builds a field for fn using a new Builder.
+func Field(name string, fn *dwarfreflect.Function) (*graphql.Field, error) {
+	return NewBuilder().Field(name, fn)
+}
+
+// Fields builds a field for every function in reg using a new Builder.
+func Fields(reg *dwarfreflect.Registry) (graphql.Fields, error) {
+	return NewBuilder().Fields(reg)
+}
+
+// Fields builds a field for every function in reg, keyed by registered name.
+func (b *Builder) Fields(reg *dwarfreflect.Registry) (graphql.Fields, error) {
+	fields := make(graphql.Fields)
+	for _, name := range reg.Names() {
+		fn, _ := reg.Get(name)
+		field, err := b.Field(name, fn)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// Field builds a field named name that resolves by calling fn.
+//
+// Non-pointer parameters become non-null arguments. A single non-error result
+// becomes the field type; multiple results become an object named after the
+// function. Paginated functions (see dwarfreflect.Paginated) resolve to a page
+// object with items, nextCursor and hasMore fields, named after the item type,
+// e.g. UserPage. Functions without results resolve to true.
+func (b *Builder) Field(name string, fn *dwarfreflect.Function) (*graphql.Field, error) {
+	args := make(graphql.FieldConfigArgument)
+	names, types := fn.GetNonContextParameters()
+	for i, paramName := range names {
+		input, err := b.input(types[i])
+		if err != nil {
+			return nil, fmt.Errorf("graphqladapter: parameter %q of %s: %w", paramName, fn.GetFunctionName(), err)
+		}
+		if types[i].Kind() != reflect.Pointer {
+			input = graphql.NewNonNull(input)
+		}
+		args[paramName] = &graphql.ArgumentConfig{Type: input}
+	}
+
+	output, err := b.resultOutput(name, fn)
+	if err != nil {
+		return nil, fmt.Errorf("graphqladapter: results of %s: %w", fn.GetFunctionName(), err)
+	}
+
+	return &graphql.Field{
+		Name:    name,
+		Type:    output,
+		Args:    args,
+		Resolve: Resolver(fn),
+	}, nil
+}
+
+// Resolver returns a resolve function that binds GraphQL arguments to fn
+// parameters by name, injects the resolve context and calls fn.
+func Resolver(fn *dwarfreflect.Function) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		data, err := json.Marshal(p.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		results, err := fn.CallWithEncodedContext(ctx, "json", data)
+		if err != nil {
+			dwarfreflect.Logger().Debug("graphqladapter: call failed", "function", fn.GetFunctionName(), "error", err)
+			return nil, err
+		}
+
+		if fn.IsPaginated() {
+			page, err := fn.ResultsToPage(results)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"items": page.Items, "nextCursor": page.NextCursor, "hasMore": page.HasMore}, nil
+		}
+
+		out, err := fn.ResultsToStruct(results)
+		if err != nil {
+			return nil, err
+		}
+
+		outValue := reflect.ValueOf(out).Elem()
+		switch outValue.NumField() {
+		case 0:
+			return true, nil
+		case 1:
+			return outValue.Field(0).Interface(), nil
+		default:
+			return out, nil
+		}
+	}
+}
+
+func (b *Builder) resultOutput(name string, fn *dwarfreflect.Function) (graphql.Output, error) {
+	if itemsType := fn.PageItemsType(); itemsType != nil {
+		return b.page(name, itemsType), nil
+	}
+
+	resultType := fn.GetResultStructType()
+	switch resultType.NumField() {
+	case 0:
+		return graphql.Boolean, nil
+	case 1:
+		return b.output(resultType.Field(0).Type)
+	default:
+		return b.object(capitalizeFirst(name)+"Result", resultType), nil
+	}
+}
+
+func (b *Builder) output(t reflect.Type) (graphql.Output, error) {
+	if scalar := scalarFor(t); scalar != nil {
+		return scalar, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.output(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem, err := b.output(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(elem), nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return nil, fmt.Errorf("anonymous struct %v cannot be named in the schema", t)
+		}
+		return b.object(t.Name(), t), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %v", t)
+	}
+}
+
+func (b *Builder) object(name string, t reflect.Type) graphql.Output {
+	if cached, ok := b.outputs[t]; ok {
+		return cached
+	}
+
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		// A thunk lets recursive types reference themselves
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := make(graphql.Fields)
+			for _, f := range exportedFields(t) {
+				if output, err := b.output(f.Type); err == nil {
+					fields[fieldName(f)] = &graphql.Field{Type: output}
+				}
+			}
+			return fields
+		}),
+	})
+	b.outputs[t] = object
+
+	return object
+}
+
+// page returns the object type of the pages of items of itemsType, named
+// after the item type when it has a name: UserPage for []User.
+func (b *Builder) page(name string, itemsType reflect.Type) graphql.Output {
+	pageType := reflect.StructOf([]reflect.StructField{
+		{Name: "Items", Type: itemsType, Tag: `json:"items"`},
+		{Name: "NextCursor", Type: reflect.TypeFor[string](), Tag: `json:"nextCursor"`},
+		{Name: "HasMore", Type: reflect.TypeFor[bool](), Tag: `json:"hasMore"`},
+	})
+
+	itemType := itemsType.Elem()
+	for itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+	if itemType.Name() != "" {
+		return b.object(capitalizeFirst(itemType.Name())+"Page", pageType)
+	}
+	return b.object(capitalizeFirst(name)+"Page", pageType)
+}
+
+func (b *Builder) input(t reflect.Type) (graphql.Input, error) {
+	if scalar := scalarFor(t); scalar != nil {
+		return scalar, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.input(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem, err := b.input(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(elem), nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return nil, fmt.Errorf("anonymous struct %v cannot be named in the schema", t)
+		}
+		if cached, ok := b.inputs[t]; ok {
+			return cached, nil
+		}
+		object := graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: t.Name() + "Input",
+			Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+				fields := make(graphql.InputObjectConfigFieldMap)
+				for _, f := range exportedFields(t) {
+					if input, err := b.input(f.Type); err == nil {
+						fields[fieldName(f)] = &graphql.InputObjectFieldConfig{Type: input}
+					}
+				}
+				return fields
+			}),
+		})
+		b.inputs[t] = object
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %v", t)
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// scalarFor maps Go types to built-in GraphQL scalars, or returns nil.
+func scalarFor(t reflect.Type) *graphql.Scalar {
+	if t == timeType {
+		return graphql.DateTime
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16:
+		return graphql.Int
+	case reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		// Int is a signed 32-bit integer: unsigned values past it would be
+		// rejected, so they are Floats, exact up to 2^53
+		return graphql.Float
+	case reflect.String:
+		return graphql.String
+	default:
+		return nil
+	}
+}
+
+func exportedFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Tag.Get("json") != "-" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func capitalizeFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// fieldName follows encoding/json naming, which graphql-go also uses to
+// resolve struct fields.
+func fieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	return f.Name
+}
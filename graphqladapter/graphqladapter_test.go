@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package graphqladapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+)
+
+type user struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Friends []user `json:"friends"`
+}
+
+type ctxKey struct{}
+
+func getUser(ctx context.Context, id int) (*user, error) {
+	if id <= 0 {
+		return nil, errors.New("user not found")
+	}
+	name, _ := ctx.Value(ctxKey{}).(string)
+	return &user{ID: id, Name: name, Friends: []user{{ID: id + 1, Name: "friend"}}}, nil
+}
+
+func greet(name string, times *int) string {
+	n := 1
+	if times != nil {
+		n = *times
+	}
+	return strings.Repeat("hi "+name+" ", n)
+}
+
+func divmod(a, b int) (quotient, remainder int) {
+	return a / b, a % b
+}
+
+func newTestSchema(t *testing.T) graphql.Schema {
+	t.Helper()
+	reg := dwarfreflect.NewRegistry()
+	for name, fn := range map[string]any{"user": getUser, "greet": greet, "divmod": divmod} {
+		dwarfreflecttest.Register(t, reg, name, fn)
+	}
+
+	fields, err := Fields(reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected schema error: %v", err)
+	}
+	return schema
+}
+
+func query(t *testing.T, schema graphql.Schema, q string) *graphql.Result {
+	t.Helper()
+	return graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: q,
+		Context:       context.WithValue(context.Background(), ctxKey{}, "alice"),
+	})
+}
+
+func resultJSON(t *testing.T, r *graphql.Result) string {
+	t.Helper()
+	data, err := json.Marshal(r.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func TestFields_ContextAndObjects(t *testing.T) {
+	schema := newTestSchema(t)
+
+	r := query(t, schema, `{ user(id: 7) { id name friends { id name } } }`)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := `{"user":{"friends":[{"id":8,"name":"friend"}],"id":7,"name":"alice"}}`
+	if got := resultJSON(t, r); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFields_ErrorSurfaced(t *testing.T) {
+	schema := newTestSchema(t)
+
+	r := query(t, schema, `{ user(id: 0) { id } }`)
+	if len(r.Errors) != 1 || r.Errors[0].Message != "user not found" {
+		t.Errorf("expected user not found error, got %v", r.Errors)
+	}
+}
+
+func TestFields_OptionalArgument(t *testing.T) {
+	schema := newTestSchema(t)
+
+	r := query(t, schema, `{ once: greet(name: "bob") twice: greet(name: "bob", times: 2) }`)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := `{"once":"hi bob ","twice":"hi bob hi bob "}`
+	if got := resultJSON(t, r); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	r = query(t, schema, `{ greet(times: 2) }`)
+	if len(r.Errors) == 0 {
+		t.Error("expected error for missing non-null argument")
+	}
+}
+
+func TestFields_MultipleResults(t *testing.T) {
+	schema := newTestSchema(t)
+
+	r := query(t, schema, `{ divmod(a: 7, b: 2) { quotient remainder } }`)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := `{"divmod":{"quotient":3,"remainder":1}}`
+	if got := resultJSON(t, r); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func doubleSize(bytes uint64) uint64 {
+	return 2 * bytes
+}
+
+func TestFields_Uint64(t *testing.T) {
+	reg := dwarfreflect.NewRegistry()
+	dwarfreflecttest.Register(t, reg, "doubleSize", doubleSize)
+	fields, err := Fields(reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if typ := fields["doubleSize"].Type; typ != graphql.Float {
+		t.Errorf("expected uint64 results as Float, got %v", typ)
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected schema error: %v", err)
+	}
+	r := query(t, schema, `{ doubleSize(bytes: 4294967296) }`)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	if got := resultJSON(t, r); got != `{"doubleSize":8589934592}` {
+		t.Errorf("expected values past 32 bits, got %s", got)
+	}
+}
+
+func TestField_UnsupportedType(t *testing.T) {
+	fn := dwarfreflecttest.NewFunction(t, func(ch chan int) {})
+
+	if _, err := Field("bad", fn); err == nil {
+		t.Error("expected error for unsupported parameter type")
+	}
+}
+
+func listUsers(cursor string, limit int) (items []user, nextCursor string, err error) {
+	all := []user{{ID: 1, Name: "ann"}, {ID: 2, Name: "bob"}, {ID: 3, Name: "cy"}}
+	if cursor == "2" {
+		return all[2:], "", nil
+	}
+	return all[:limit], "2", nil
+}
+
+func TestFields_Paginated(t *testing.T) {
+	reg := dwarfreflect.NewRegistry()
+	dwarfreflecttest.Register(t, reg, "users", listUsers)
+	fields, err := Fields(reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := fields["users"].Type.Name(); name != "UserPage" {
+		t.Errorf("expected a page type named after the items, got %s", name)
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected schema error: %v", err)
+	}
+
+	r := query(t, schema, `{ users(cursor: "", limit: 2) { items { name } nextCursor hasMore } }`)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	expected := `{"users":{"hasMore":true,"items":[{"name":"ann"},{"name":"bob"}],"nextCursor":"2"}}`
+	if got := resultJSON(t, r); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// RequestSource selects where in an HTTP request a parameter is read from.
+type RequestSource int
+
+const (
+	FromAny    RequestSource = iota // header, then cookie, then form or query value
+	FromForm                        // URL query or form body value, as in http.Request.Form
+	FromHeader                      // request header
+	FromCookie                      // cookie
+)
+
+// String returns a human-readable name for the request source
+func (s RequestSource) String() string {
+	switch s {
+	case FromAny:
+		return "any"
+	case FromForm:
+		return "form"
+	case FromHeader:
+		return "header"
+	case FromCookie:
+		return "cookie"
+	default:
+		return "unknown"
+	}
+}
+
+// ParamSource overrides where a parameter is read from. Name is the form key,
+// header or cookie name; when empty, names are matched to the parameter
+// ignoring case, dashes, underscores and an X- header prefix, so X-Request-ID
+// and request_id both bind requestID. A name equal to the parameter's wins
+// over matching ones, which are tried in sorted order.
+type ParamSource struct {
+	From RequestSource
+	Name string
+}
+
+// RequestOptions configures binding from HTTP requests.
+type RequestOptions struct {
+	// Sources pins parameters to a source, by parameter name. The others are
+	// read from any source.
+	Sources map[string]ParamSource
+}
+
+// RequestValues collects the values of the non-context parameters from r,
+// as CallWithRequest binds them: from headers, cookies and the form and
+// query, in this order unless opts pins the parameter to a source, so that
+// a query parameter cannot override a header or cookie of the same name,
+// such as a session. Parameters found nowhere are left out.
+//
+// Example:
+//
+//	values := fn.RequestValues(r, dwarfreflect.RequestOptions{
+//	    Sources: map[string]dwarfreflect.ParamSource{"token": {From: dwarfreflect.FromHeader, Name: "Authorization"}},
+//	})
+func (t *Function) RequestValues(r *http.Request, opts ...RequestOptions) map[string][]string {
+	var options RequestOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	r.ParseForm() // a malformed body or query leaves the values parsed so far
+
+	values := make(map[string][]string)
+	names, _ := t.GetNonContextParameters()
+	for _, name := range names {
+		source := options.Sources[name]
+		for _, from := range []RequestSource{FromHeader, FromCookie, FromForm} {
+			if source.From != FromAny && source.From != from {
+				continue
+			}
+			if found := requestValues(r, from, name, source.Name); len(found) > 0 {
+				values[name] = found
+				break
+			}
+		}
+	}
+	return values
+}
+
+// CallWithRequest invokes the function with arguments bound from the HTTP
+// request by parameter name: headers, cookies and form and query values, as
+// collected by RequestValues, are converted to the parameter types as in
+// CallWithForm. context.Context parameters receive the request context.
+// Functions taking auth tokens or trace IDs as parameters need no extraction
+// code.
+//
+// Example:
+//
+//	func GetOrder(ctx context.Context, requestID string, session string, orderID int) (*Order, error)
+//	// GET /orders?orderID=7 with an X-Request-ID header and a session cookie
+//	results, err := fn.CallWithRequest(r)
+func (t *Function) CallWithRequest(r *http.Request, opts ...RequestOptions) ([]reflect.Value, error) {
+	return t.CallWithFormContext(r.Context(), t.RequestValues(r, opts...))
+}
+
+// requestValues returns the values of r from source for the parameter, by
+// the given name or else the parameter name or, in sorted order, a name
+// matching the parameter.
+func requestValues(r *http.Request, from RequestSource, param, name string) []string {
+	switch from {
+	case FromForm:
+		if name != "" {
+			return r.Form[name]
+		}
+		if values, ok := r.Form[param]; ok {
+			return values
+		}
+		if key, ok := matchingKey(r.Form, func(key string) bool { return matchesParam(key, param) }); ok {
+			return r.Form[key]
+		}
+	case FromHeader:
+		if name != "" {
+			return r.Header.Values(name)
+		}
+		if values := r.Header.Values(param); len(values) > 0 {
+			return values
+		}
+		if key, ok := matchingKey(r.Header, func(key string) bool {
+			return matchesParam(strings.TrimPrefix(strings.ToLower(key), "x-"), param) || matchesParam(key, param)
+		}); ok {
+			return r.Header[key]
+		}
+	case FromCookie:
+		if name != "" {
+			param = name
+		}
+		if cookie, err := r.Cookie(param); err == nil {
+			return []string{cookie.Value}
+		}
+		if name != "" {
+			return nil
+		}
+		cookies := make(map[string][]string)
+		for _, cookie := range r.Cookies() {
+			if _, seen := cookies[cookie.Name]; !seen {
+				cookies[cookie.Name] = []string{cookie.Value}
+			}
+		}
+		if key, ok := matchingKey(cookies, func(key string) bool { return matchesParam(key, param) }); ok {
+			return cookies[key]
+		}
+	}
+	return nil
+}
+
+// matchingKey returns the first key of m in sorted order satisfying match,
+// so that requests with several matching names bind deterministically.
+func matchingKey(m map[string][]string, match func(key string) bool) (string, bool) {
+	var keys []string
+	for key := range m {
+		if match(key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	return slices.Min(keys), true
+}
+
+// separators are ignored when matching request names to parameters.
+var separators = strings.NewReplacer("-", "", "_", "")
+
+// matchesParam reports whether key names param, ignoring case, dashes and
+// underscores.
+func matchesParam(key, param string) bool {
+	return strings.EqualFold(separators.Replace(key), separators.Replace(param))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type httpTestKey struct{}
+
+//go:noinline
+func httpTestGetOrder(ctx context.Context, requestID string, session string, orderID int, verbose bool) string {
+	return fmt.Sprintf("%v %s %s %d %v", ctx.Value(httpTestKey{}), requestID, session, orderID, verbose)
+}
+
+func TestCallWithRequest(t *testing.T) {
+	fn := mustNewFunction(t, httpTestGetOrder)
+
+	r := httptest.NewRequest("GET", "/orders?order_id=7", nil)
+	r = r.WithContext(context.WithValue(r.Context(), httpTestKey{}, "request-context"))
+	r.Header.Set("X-Request-ID", "req-1")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+
+	results, err := fn.CallWithRequest(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "request-context req-1 s3cr3t 7 false" {
+		t.Errorf("unexpected result %q", got)
+	}
+
+	// Pinned sources ignore the others
+	r = httptest.NewRequest("POST", "/orders?session=from-query", strings.NewReader("orderID=8&verbose=true"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Trace", "req-2")
+	r.AddCookie(&http.Cookie{Name: "sid", Value: "from-cookie"})
+	values := fn.RequestValues(r, RequestOptions{Sources: map[string]ParamSource{
+		"requestID": {From: FromHeader, Name: "Trace"},
+		"session":   {From: FromCookie, Name: "sid"},
+	}})
+	want := map[string][]string{
+		"requestID": {"req-2"},
+		"session":   {"from-cookie"},
+		"orderID":   {"8"},
+		"verbose":   {"true"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+
+	r = httptest.NewRequest("GET", "/orders?orderID=9&requestID=req-3", nil)
+	r.Header.Set("Session", "from-header")
+	values = fn.RequestValues(r, RequestOptions{Sources: map[string]ParamSource{"session": {From: FromCookie}}})
+	if _, ok := values["session"]; ok || values["requestID"][0] != "req-3" {
+		t.Errorf("unexpected values %v", values)
+	}
+	if _, err := fn.CallWithRequest(r, RequestOptions{Sources: map[string]ParamSource{"session": {From: FromCookie}}}); err == nil ||
+		!strings.Contains(err.Error(), `missing required form value "session"`) {
+		t.Errorf("expected a missing session, got %v", err)
+	}
+}
+
+func TestRequestValues_Precedence(t *testing.T) {
+	fn := mustNewFunction(t, httpTestGetOrder)
+
+	// Headers and cookies win over query parameters of the same name
+	r := httptest.NewRequest("GET", "/orders?session=forged&requestID=forged&orderID=1", nil)
+	r.Header.Set("X-Request-ID", "req-1")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	values := fn.RequestValues(r)
+	if values["session"][0] != "s3cr3t" || values["requestID"][0] != "req-1" || values["orderID"][0] != "1" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	// The exact name wins, then matching names in sorted order
+	for range 20 {
+		r = httptest.NewRequest("GET", "/orders?order_id=1&order-id=2&orderId=3", nil)
+		if got := fn.RequestValues(r)["orderID"][0]; got != "2" {
+			t.Fatalf("expected order-id to bind, got %s", got)
+		}
+		r = httptest.NewRequest("GET", "/orders?order_id=1&orderID=4&orderId=3", nil)
+		if got := fn.RequestValues(r)["orderID"][0]; got != "4" {
+			t.Fatalf("expected orderID to bind, got %s", got)
+		}
+	}
+}
+
+func TestMatchesParam(t *testing.T) {
+	tests := []struct {
+		key, param string
+		want       bool
+	}{
+		{"request_id", "requestID", true},
+		{"Request-Id", "requestID", true},
+		{"requestid", "requestID", true},
+		{"request", "requestID", false},
+	}
+	for _, tt := range tests {
+		if got := matchesParam(tt.key, tt.param); got != tt.want {
+			t.Errorf("matchesParam(%q, %q) = %v, want %v", tt.key, tt.param, got, tt.want)
+		}
+	}
+	if s := FromHeader.String(); s != "header" {
+		t.Errorf("unexpected name %q", s)
+	}
+}
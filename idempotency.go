@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the HTTP header carrying the idempotency key of a
+// request, as read by handlers calling Router.DispatchIdempotent.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// ErrIdempotencyKeyReused is returned by Router.DispatchIdempotent when a key
+// is presented again with other arguments, the equivalent of an HTTP 422.
+var ErrIdempotencyKeyReused = errors.New("dwarfreflect: idempotency key reused with other arguments")
+
+// IdempotentResponse is the response of a call stored under its idempotency
+// key.
+type IdempotentResponse struct {
+	// Fingerprint identifies the function and arguments of the call, as
+	// hashed by Function.ArgsHash.
+	Fingerprint string `json:"fingerprint"`
+
+	// Response is the JSON encoded response of the call.
+	Response json.RawMessage `json:"response"`
+}
+
+// IdempotencyStore stores the responses of calls made with an idempotency
+// key, for Router.WithIdempotency. Stores shared between processes, such as
+// Redis or a database table, make retries land on any replica.
+type IdempotencyStore interface {
+	// Get returns the response stored under key, reporting false when there
+	// is none or it expired.
+	Get(ctx context.Context, key string) (IdempotentResponse, bool, error)
+
+	// Set stores response under key for ttl.
+	Set(ctx context.Context, key string, response IdempotentResponse, ttl time.Duration) error
+}
+
+// WithIdempotency returns a copy of the Router storing the responses of
+// DispatchIdempotent calls in store for ttl, so that retried requests are
+// answered without calling their function again.
+//
+// Example:
+//
+//	router = router.WithIdempotency(dwarfreflect.NewMemoryIdempotencyStore(), 24*time.Hour)
+func (rt *Router) WithIdempotency(store IdempotencyStore, ttl time.Duration) *Router {
+	clone := *rt
+	clone.idempotency, clone.idempotencyTTL = store, ttl
+	return &clone
+}
+
+// DispatchIdempotent is like DispatchResponse, returning the response encoded
+// as JSON, for requests carrying an idempotency key, such as the
+// Idempotency-Key header of HTTP requests. The response of the first call
+// with a key is stored (see WithIdempotency), and calls repeating the key
+// with identical arguments, as CanonicalArgs compares them, are answered with
+// it, reporting replayed, without calling the function. Calls repeating the
+// key with other arguments fail with ErrIdempotencyKeyReused.
+//
+// Keys are scoped by method, by CallMeta.Caller, when the context carries
+// one (see WithCallMeta), and by the values of scoped parameters (see
+// WithScopedParams). Calls failing, with a binding error or the trailing
+// error of the function, are not stored, so that clients can retry them. Two
+// calls with a new key in flight at once both run. Without a key or a store
+// it dispatches the call unconditionally.
+//
+// Example:
+//
+//	response, replayed, err := router.DispatchIdempotent(r.Context(), method,
+//	    r.Header.Get(dwarfreflect.IdempotencyKeyHeader), body)
+//	if replayed {
+//	    w.Header().Set("Idempotent-Replayed", "true")
+//	}
+func (rt *Router) DispatchIdempotent(ctx context.Context, method, key string, payload []byte) (response json.RawMessage, replayed bool, err error) {
+	if key == "" || rt.idempotency == nil {
+		response, err := rt.DispatchResponse(ctx, method, payload)
+		if err != nil {
+			return nil, false, err
+		}
+		encoded, err := json.Marshal(response)
+		return encoded, false, err
+	}
+
+	named := &boundArgs{}
+	fn, args, err := rt.bind(method, payload, named)
+	if err != nil {
+		return nil, false, err
+	}
+	canonical, err := fn.canonicalValues(named.names, named.values)
+	if err != nil {
+		return nil, false, &BindingError{Method: method, Err: err}
+	}
+	fingerprint := fn.hashArgs(canonical)
+
+	storeKey, err := idempotencyStoreKey(ctx, fn, method, key)
+	if err != nil {
+		return nil, false, &BindingError{Method: method, Err: err}
+	}
+	stored, found, err := rt.idempotency.Get(ctx, storeKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("idempotency store: %w", err)
+	}
+	if found {
+		if stored.Fingerprint != fingerprint {
+			return nil, false, fmt.Errorf("%w: %q", ErrIdempotencyKeyReused, key)
+		}
+		return stored.Response, true, nil
+	}
+
+	results, err := fn.CallWithContext(ctx, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	result, err := responseOf(fn, results)
+	if err != nil {
+		return nil, false, err
+	}
+	if response, err = json.Marshal(result); err != nil {
+		return nil, false, fmt.Errorf("cannot encode response of method %s: %w", method, err)
+	}
+	if err := rt.idempotency.Set(ctx, storeKey, IdempotentResponse{Fingerprint: fingerprint, Response: response}, rt.idempotencyTTL); err != nil {
+		// The function was called: its response is returned along the error
+		return response, false, fmt.Errorf("idempotency store: %w", err)
+	}
+	return response, false, nil
+}
+
+// idempotencyStoreKey returns the key storing the response of method for the
+// idempotency key of the caller of ctx, within the scope values of ctx when
+// fn has scoped parameters: the callers of tenants sharing a key never see
+// each other's responses.
+func idempotencyStoreKey(ctx context.Context, fn *Function, method, key string) (string, error) {
+	meta, _ := CallMetaFrom(ctx)
+	storeKey := meta.Caller + "\x00" + method + "\x00" + key
+	if len(fn.scoped) == 0 {
+		return storeKey, nil
+	}
+
+	scope := ScopeValues(ctx)
+	values := make([]reflect.Value, len(fn.scoped))
+	for i, name := range fn.scoped {
+		values[i] = reflect.ValueOf(scope[name]) // invalid, encoded as null, when absent
+	}
+	canonical, err := fn.canonicalValues(fn.scoped, values)
+	if err != nil {
+		return "", err
+	}
+	return storeKey + "\x00" + string(canonical), nil
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore keeping responses in memory,
+// for single-process services and tests. It is safe for concurrent use.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+	sweepAt int
+}
+
+type memoryIdempotencyEntry struct {
+	response IdempotentResponse
+	expires  time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty MemoryIdempotencyStore.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: make(map[string]memoryIdempotencyEntry), sweepAt: 64}
+}
+
+// Get returns the unexpired response stored under key.
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (IdempotentResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || !time.Now().Before(entry.expires) {
+		return IdempotentResponse{}, false, nil
+	}
+	return entry.response, true, nil
+}
+
+// Set stores response under key for ttl. Expired responses are dropped as the
+// store grows.
+func (s *MemoryIdempotencyStore) Set(_ context.Context, key string, response IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.entries[key] = memoryIdempotencyEntry{response: response, expires: now.Add(ttl)}
+	if len(s.entries) >= s.sweepAt {
+		for key, entry := range s.entries {
+			if !now.Before(entry.expires) {
+				delete(s.entries, key)
+			}
+		}
+		s.sweepAt = max(2*len(s.entries), 64)
+	}
+	return nil
+}
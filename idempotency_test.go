@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func idempotencyTestCharge(ctx context.Context, account string, cents int, memo string) (chargeID int, err error) {
+	if cents <= 0 {
+		return 0, errors.New("nothing to charge")
+	}
+	idempotencyCharges++
+	return idempotencyCharges, nil
+}
+
+var idempotencyCharges int
+
+func newIdempotentRouter(t *testing.T) *Router {
+	t.Helper()
+	fn := mustNewFunction(t, idempotencyTestCharge).WithParamMeta("memo", ParamMeta{Default: "none"})
+	reg := NewRegistry()
+	if _, err := reg.Register("charge", fn); err != nil {
+		t.Fatal(err)
+	}
+	return NewRouter(reg).WithIdempotency(NewMemoryIdempotencyStore(), time.Minute)
+}
+
+func TestDispatchIdempotent(t *testing.T) {
+	router := newIdempotentRouter(t)
+	ctx := context.Background()
+	before := idempotencyCharges
+
+	first, replayed, err := router.DispatchIdempotent(ctx, "charge", "k1", []byte(`{"account":"acme","cents":500}`))
+	if want := fmt.Sprintf(`{"chargeID":%d}`, before+1); err != nil || replayed || string(first) != want {
+		t.Fatalf("unexpected response %s, %v, %v", first, replayed, err)
+	}
+
+	// Identical arguments, in another order and with the default spelled out
+	again, replayed, err := router.DispatchIdempotent(ctx, "charge", "k1", []byte(`{"memo":"none", "cents": 500, "account":"acme"}`))
+	if err != nil || !replayed || string(again) != string(first) {
+		t.Errorf("expected %s replayed, got %s, %v, %v", first, again, replayed, err)
+	}
+	if calls := idempotencyCharges - before; calls != 1 {
+		t.Errorf("expected one call, got %d", calls)
+	}
+
+	_, _, err = router.DispatchIdempotent(ctx, "charge", "k1", []byte(`{"account":"acme","cents":700}`))
+	if !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+	}
+
+	// Keys are scoped by caller and method
+	other := WithCallMeta(ctx, CallMeta{Caller: "bob"})
+	if _, replayed, err := router.DispatchIdempotent(other, "charge", "k1", []byte(`{"account":"acme","cents":700}`)); err != nil || replayed {
+		t.Errorf("expected a new call for another caller, got %v, %v", replayed, err)
+	}
+
+	// Failed calls are not stored, and without a key every call runs
+	for range 2 {
+		if _, replayed, err := router.DispatchIdempotent(ctx, "charge", "k2", []byte(`{"account":"acme","cents":0}`)); err == nil || replayed {
+			t.Errorf("expected the function error, got %v, %v", replayed, err)
+		}
+		if _, replayed, err := router.DispatchIdempotent(ctx, "charge", "", []byte(`{"account":"acme","cents":1}`)); err != nil || replayed {
+			t.Errorf("expected a call, got %v, %v", replayed, err)
+		}
+	}
+	if calls := idempotencyCharges - before; calls != 4 {
+		t.Errorf("expected four calls, got %d", calls)
+	}
+}
+
+func TestDispatchIdempotent_Scoped(t *testing.T) {
+	reg := NewRegistry()
+	reg.Scope("account")
+	if _, err := reg.Register("charge", mustNewFunction(t, idempotencyTestCharge)); err != nil {
+		t.Fatal(err)
+	}
+	router := NewRouter(reg).WithIdempotency(NewMemoryIdempotencyStore(), time.Minute)
+	before := idempotencyCharges
+
+	// Tenants sharing a key and arguments each get their own response
+	calls := []struct {
+		tenant   string
+		replayed bool
+	}{{"acme", false}, {"globex", false}, {"acme", true}}
+	for _, call := range calls {
+		ctx := WithScopeValues(context.Background(), map[string]any{"account": call.tenant})
+		_, replayed, err := router.DispatchIdempotent(ctx, "charge", "k1", []byte(`{"cents":500,"memo":"x"}`))
+		if err != nil || replayed != call.replayed {
+			t.Errorf("%s: expected replayed %v, got %v, %v", call.tenant, call.replayed, replayed, err)
+		}
+	}
+	if calls := idempotencyCharges - before; calls != 2 {
+		t.Errorf("expected one call per tenant, got %d", calls)
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore()
+	store.Set(ctx, "expired", IdempotentResponse{Fingerprint: "a"}, -time.Second)
+	store.Set(ctx, "live", IdempotentResponse{Fingerprint: "b"}, time.Minute)
+
+	if _, found, _ := store.Get(ctx, "expired"); found {
+		t.Error("expected the expired response to be gone")
+	}
+	if response, found, _ := store.Get(ctx, "live"); !found || response.Fingerprint != "b" {
+		t.Errorf("unexpected response %+v, %v", response, found)
+	}
+
+	for i := range 100 {
+		store.Set(ctx, string(rune('a'+i)), IdempotentResponse{}, -time.Second)
+	}
+	if len(store.entries) > 64 {
+		t.Errorf("expected expired responses to be swept, got %d", len(store.entries))
+	}
+}
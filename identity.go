@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ID returns an identifier for the function that is stable across builds and
+// restarts, unlike its PC: the full runtime name (package path and name within
+// the package, including any receiver), followed by a hash of the named
+// non-context signature. It is suitable for job queues and routing tables.
+// Changing a parameter's name or type, or a result type, changes the ID.
+//
+// Example:
+//
+//	fn.ID() // "github.com/user/repo/pkg.(*Service).CreateUser@3f9a0c21b4d7e815"
+func (t *Function) ID() string {
+	sum := sha256.Sum256([]byte(t.Signature()))
+	return t.funcName + "@" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFunction_ID(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	id := fn.ID()
+	pattern := regexp.MustCompile(`^github\.com/matteo-grella/dwarfreflect\.testFunc1@[0-9a-f]{16}$`)
+	if !pattern.MatchString(id) {
+		t.Errorf("unexpected ID %q", id)
+	}
+	if again := mustNewFunction(t, testFunc1).ID(); again != id {
+		t.Errorf("expected a stable ID, got %q and %q", id, again)
+	}
+	if other := mustNewFunction(t, testFunc2).ID(); other == id {
+		t.Error("expected different functions to have different IDs")
+	}
+}
+
+func TestFunction_ID_SignatureSensitive(t *testing.T) {
+	impl := func(args []reflect.Value) []reflect.Value { return nil }
+	a, err := NewFunctionFromSignature([]string{"id"}, []reflect.Type{reflect.TypeFor[int]()}, impl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := NewFunctionFromSignature([]string{"key"}, []reflect.Type{reflect.TypeFor[int]()}, impl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.ID() == b.ID() {
+		t.Errorf("expected renamed parameters to change the ID, both %q", a.ID())
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"iter"
+	"maps"
+	"strings"
+	"unsafe"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
+)
+
+// arenaChunk is the size of the byte chunks function names are packed into.
+const arenaChunk = 64 << 10
+
+// functionIndex maps function names to their DWARF parameter names. Huge
+// binaries list millions of parameters over a few thousand distinct names,
+// so rather than a []string per function it stores parameter names once, in
+// a table, and the parameters of all functions back to back as indexes into
+// it. Function names are packed into large byte chunks. The zero value is an
+// empty index; it is not safe for concurrent writes.
+//
+// Methods are also indexed by receiver type name, as parsed from their
+// function names, for MethodsOf.
+type functionIndex struct {
+	entries map[string]uint32   // entry number by function name
+	names   []string            // interned parameter names
+	nameIDs map[string]uint32   // index in names by parameter name
+	params  []uint32            // parameter name indexes of all entries, in order
+	offsets []uint32            // entry i has params[offsets[i]:offsets[i+1]]
+	results []uint16            // number of result parameters of entry i, listed last
+	methods map[string][]string // method function names by receiver type name
+	arena   []byte              // current chunk of packed function names
+	chunks  int                 // number of chunks allocated
+}
+
+// IndexStats reports the size of the index of DWARF functions of a resolver.
+type IndexStats struct {
+	// Functions is the number of indexed function names, including the
+	// bracket-free aliases of generic instantiations.
+	Functions int
+
+	// Parameters is the number of parameter slots stored, shared by aliases.
+	Parameters int
+
+	// UniqueNames is the number of distinct parameter names, stored once.
+	UniqueNames int
+
+	// Methods is the number of indexed methods, excluding generic
+	// instantiations, closures and wrappers.
+	Methods int
+
+	// Bytes estimates the memory held by the index.
+	Bytes int
+}
+
+// add indexes the parameters of funcName, the last results of which are
+// result parameters, replacing those of a previous entry of the same name.
+func (ix *functionIndex) add(funcName string, params []string, results int) {
+	if ix.entries == nil {
+		ix.entries = make(map[string]uint32)
+		ix.nameIDs = make(map[string]uint32)
+		ix.methods = make(map[string][]string)
+		ix.offsets = []uint32{0}
+	}
+
+	for _, name := range params {
+		id, ok := ix.nameIDs[name]
+		if !ok {
+			id = uint32(len(ix.names))
+			ix.names = append(ix.names, name)
+			ix.nameIDs[name] = id
+		}
+		ix.params = append(ix.params, id)
+	}
+	ix.offsets = append(ix.offsets, uint32(len(ix.params)))
+	ix.results = append(ix.results, uint16(results))
+
+	entry := uint32(len(ix.offsets) - 2)
+	if _, exists := ix.entries[funcName]; exists {
+		ix.entries[funcName] = entry // keep the existing key
+		return
+	}
+	ix.insert(ix.pack(funcName), entry)
+}
+
+// alias indexes alias with the parameters of the existing entry funcName.
+func (ix *functionIndex) alias(alias, funcName string) {
+	if entry, ok := ix.entries[funcName]; ok {
+		ix.insert(ix.pack(alias), entry)
+	}
+}
+
+// insert adds the new key funcName, and indexes it by receiver if it names a
+// method. Generic instantiations are only indexed by their bracket-free
+// alias.
+func (ix *functionIndex) insert(funcName string, entry uint32) {
+	ix.entries[funcName] = entry
+	if !maybeMethod(funcName) {
+		return
+	}
+	sym := symbols.Parse(funcName)
+	if sym.IsMethod() && !sym.IsGeneric() && !sym.IsClosure() && sym.Wrapper == "" && !sym.MethodValue {
+		// Receiver is a substring of the packed name
+		ix.methods[sym.Receiver] = append(ix.methods[sym.Receiver], funcName)
+	}
+}
+
+// maybeMethod cheaply rules out names that cannot be methods: those with
+// fewer than two dots after the package path.
+func maybeMethod(funcName string) bool {
+	return strings.Count(funcName[strings.LastIndexByte(funcName, '/')+1:], ".") >= 2
+}
+
+// pack copies s into the current chunk of function names.
+func (ix *functionIndex) pack(s string) string {
+	if s == "" {
+		return s
+	}
+	if len(ix.arena)+len(s) > cap(ix.arena) {
+		ix.arena = make([]byte, 0, max(arenaChunk, len(s)))
+		ix.chunks++
+	}
+	start := len(ix.arena)
+	ix.arena = append(ix.arena, s...)
+	// Chunks are never written again once filled, nor reallocated
+	return unsafe.String(&ix.arena[start], len(s))
+}
+
+// lookup returns the parameter names of funcName. The slice is newly
+// allocated and may be modified by the caller.
+func (ix *functionIndex) lookup(funcName string) ([]string, bool) {
+	entry, ok := ix.entries[funcName]
+	if !ok {
+		return nil, false
+	}
+	return ix.paramsOf(entry), true
+}
+
+// has reports whether funcName is indexed.
+func (ix *functionIndex) has(funcName string) bool {
+	_, ok := ix.entries[funcName]
+	return ok
+}
+
+// paramCount returns the number of parameters of funcName, or -1 if it is
+// not indexed.
+func (ix *functionIndex) paramCount(funcName string) int {
+	entry, ok := ix.entries[funcName]
+	if !ok {
+		return -1
+	}
+	return int(ix.offsets[entry+1] - ix.offsets[entry])
+}
+
+// resultCount returns the number of result parameters of funcName, which is
+// assumed to be indexed.
+func (ix *functionIndex) resultCount(funcName string) int {
+	return int(ix.results[ix.entries[funcName]])
+}
+
+// methodsOf returns the function names of the methods of receiver types
+// named receiver, in any package.
+func (ix *functionIndex) methodsOf(receiver string) []string {
+	return ix.methods[receiver]
+}
+
+func (ix *functionIndex) paramsOf(entry uint32) []string {
+	ids := ix.params[ix.offsets[entry]:ix.offsets[entry+1]]
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = ix.names[id]
+	}
+	return names
+}
+
+// len returns the number of indexed function names.
+func (ix *functionIndex) len() int {
+	return len(ix.entries)
+}
+
+// all iterates over the indexed functions and their parameter names, in no
+// particular order.
+func (ix *functionIndex) all() iter.Seq2[string, []string] {
+	return func(yield func(string, []string) bool) {
+		for funcName, entry := range ix.entries {
+			if !yield(funcName, ix.paramsOf(entry)) {
+				return
+			}
+		}
+	}
+}
+
+// functionNames iterates over the indexed function names, in no particular
+// order.
+func (ix *functionIndex) functionNames() iter.Seq[string] {
+	return maps.Keys(ix.entries)
+}
+
+// stats reports the size of the index.
+func (ix *functionIndex) stats() IndexStats {
+	stats := IndexStats{
+		Functions:   len(ix.entries),
+		Parameters:  len(ix.params),
+		UniqueNames: len(ix.names),
+	}
+
+	const stringHeader, mapEntry = int(unsafe.Sizeof("")), 8 // map entry overhead, roughly
+	stats.Bytes = ix.chunks*arenaChunk +
+		len(ix.entries)*(stringHeader+4+mapEntry) +
+		len(ix.nameIDs)*(stringHeader+4+mapEntry) +
+		cap(ix.names)*stringHeader +
+		4*(cap(ix.params)+cap(ix.offsets)) + 2*cap(ix.results)
+	for _, name := range ix.names {
+		stats.Bytes += len(name)
+	}
+	for _, methods := range ix.methods {
+		stats.Methods += len(methods)
+		stats.Bytes += stringHeader + 3*int(unsafe.Sizeof(0)) + mapEntry + cap(methods)*stringHeader
+	}
+	return stats
+}
+
+// IndexStats reports the size of the resolver's function index.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewDWARFResolver("./server")
+//	stats := dr.IndexStats()
+//	fmt.Printf("%d functions in %d bytes\n", stats.Functions, stats.Bytes)
+func (dr *DWARFResolver) IndexStats() IndexStats {
+	return dr.functions.stats()
+}
+
+// GetIndexStats reports the size of the function index of the running
+// binary, loading DWARF data if needed.
+func GetIndexStats() (IndexStats, error) {
+	if err := loadResolver(); err != nil {
+		return IndexStats{}, err
+	}
+	return globalResolver.IndexStats(), nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// indexOf builds an index holding the given functions.
+func indexOf(functions map[string][]string) functionIndex {
+	var ix functionIndex
+	for funcName, params := range functions {
+		ix.add(funcName, params, 0)
+	}
+	return ix
+}
+
+func TestFunctionIndex(t *testing.T) {
+	var ix functionIndex
+	if _, ok := ix.lookup("pkg.F"); ok || ix.len() != 0 || ix.paramCount("pkg.F") != -1 {
+		t.Error("expected an empty zero value")
+	}
+
+	ix.add("pkg.F", []string{"ctx", "id", "~r0"}, 1)
+	ix.add("pkg.G", []string{"id", "name"}, 0)
+	ix.add("pkg.H", nil, 0)
+	ix.add("pkg.Map[go.shape.int]", []string{"s", "f"}, 0)
+	ix.alias("pkg.Map", "pkg.Map[go.shape.int]")
+	ix.alias("pkg.Missing", "pkg.Nope")
+
+	want := map[string][]string{
+		"pkg.F":                 {"ctx", "id", "~r0"},
+		"pkg.G":                 {"id", "name"},
+		"pkg.H":                 {},
+		"pkg.Map[go.shape.int]": {"s", "f"},
+		"pkg.Map":               {"s", "f"},
+	}
+	if got := maps.Collect(ix.all()); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if n := ix.paramCount("pkg.G"); n != 2 {
+		t.Errorf("expected 2 parameters, got %d", n)
+	}
+	if ix.has("pkg.Missing") {
+		t.Error("expected no alias of a missing function")
+	}
+
+	// Lookups hand out copies
+	params, _ := ix.lookup("pkg.G")
+	params[0] = "changed"
+	if params, _ := ix.lookup("pkg.G"); params[0] != "id" {
+		t.Errorf("expected the index to be unchanged, got %v", params)
+	}
+
+	// Adding a function again replaces its parameters
+	ix.add("pkg.G", []string{"name"}, 0)
+	if params, _ := ix.lookup("pkg.G"); !reflect.DeepEqual(params, []string{"name"}) || ix.len() != 5 {
+		t.Errorf("expected [name] among 5 functions, got %v among %d", params, ix.len())
+	}
+
+	stats := ix.stats()
+	if stats.Functions != 5 || stats.UniqueNames != 6 || stats.Parameters != 8 || stats.Bytes == 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestFunctionIndex_LongNames(t *testing.T) {
+	var ix functionIndex
+	long := "pkg." + strings.Repeat("F", 2*arenaChunk)
+	for _, funcName := range []string{"pkg.A", long, "pkg.B"} {
+		ix.add(funcName, []string{"x"}, 0)
+	}
+	for _, funcName := range []string{"pkg.A", long, "pkg.B"} {
+		if !ix.has(funcName) {
+			t.Errorf("expected %.10s... to be indexed", funcName)
+		}
+	}
+}
+
+func TestGetIndexStats(t *testing.T) {
+	stats, err := GetIndexStats()
+	if err != nil {
+		t.Skipf("DWARF not available: %v", err)
+	}
+	if stats.Functions == 0 || stats.UniqueNames == 0 || stats.UniqueNames > stats.Parameters || stats.Bytes == 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
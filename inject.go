@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// injector provides a value for parameters of its type.
+type injector func(ctx context.Context) (reflect.Value, error)
+
+// Global injector registry used by CallInjected
+var (
+	injectorsMu sync.RWMutex
+	injectors   = make(map[reflect.Type]injector)
+)
+
+// scopeKey is the context key of a request-scoped value of type typ.
+type scopeKey struct {
+	typ reflect.Type
+}
+
+// RegisterInjector registers a global provider for parameters of type T.
+// CallInjected calls it for every T parameter missing from the argument map,
+// unless the call's context carries a scoped T (see WithScoped).
+// Registering a type again replaces its provider.
+//
+// Example:
+//
+//	dwarfreflect.RegisterInjector(func(ctx context.Context) (*sql.DB, error) { return db, nil })
+//	fn.CallInjected(ctx, map[string]any{"userID": 42}) // func Get(db *sql.DB, userID int)
+func RegisterInjector[T any](provider func(ctx context.Context) (T, error)) {
+	injectorsMu.Lock()
+	defer injectorsMu.Unlock()
+
+	injectors[reflect.TypeFor[T]()] = func(ctx context.Context) (reflect.Value, error) {
+		v, err := provider(ctx)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&v).Elem(), nil
+	}
+}
+
+// WithScoped returns a context carrying value as the request-scoped T.
+// CallInjected injects it into T parameters in preference to a global
+// provider, which makes per-request dependencies such as the authenticated
+// principal or the current transaction injectable.
+//
+// Example:
+//
+//	ctx = dwarfreflect.WithScoped[*Principal](ctx, principal)
+//	fn.CallInjected(ctx, args) // func Delete(p *Principal, id int)
+func WithScoped[T any](ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, scopeKey{reflect.TypeFor[T]()}, value)
+}
+
+// injectValue resolves a value for typ, from the context scope first and
+// then from the global providers. Reports false when neither has one.
+func injectValue(ctx context.Context, typ reflect.Type) (reflect.Value, bool, error) {
+	if scoped := ctx.Value(scopeKey{typ}); scoped != nil {
+		v := reflect.New(typ).Elem()
+		v.Set(reflect.ValueOf(scoped))
+		return v, true, nil
+	}
+
+	injectorsMu.RLock()
+	provide, ok := injectors[typ]
+	injectorsMu.RUnlock()
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	v, err := provide(ctx)
+	return v, true, err
+}
+
+// CallInjected invokes the function using a map of parameter names to values,
+// injecting ctx into context.Context parameters and a scoped or provided value
+// into every other parameter missing from the map whose type has one.
+// Explicit map values always win; remaining parameters bind as in CallWithMap.
+//
+// Example:
+//
+//	ctx = dwarfreflect.WithScoped[*sql.Tx](ctx, tx)
+//	results, err := fn.CallInjected(ctx, map[string]any{"userID": 42})
+func (t *Function) CallInjected(ctx context.Context, argMap map[string]any, opts ...CallOptions) ([]reflect.Value, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	full, _, err := t.injectArgs(ctx, argMap)
+	if err != nil {
+		return nil, t.bindFailed(err)
+	}
+	callArgs := make([]reflect.Value, len(t.paramTypes))
+	if err := t.bindMap(full, options, nil, callArgs, nil); err != nil {
+		return nil, t.bindFailed(err)
+	}
+
+	if len(t.GetContextPositions()) > 0 {
+		return t.invoke(callArgs) // Already carries the decorated context
+	}
+	return t.invokeContext(ctx, callArgs)
+}
+
+// ExplainBindContext is like ExplainBind but binds as CallInjected does,
+// reporting injected parameters with BindInjected.
+func (t *Function) ExplainBindContext(ctx context.Context, argMap map[string]any, opts ...CallOptions) (*BindReport, error) {
+	full, injected, err := t.injectArgs(ctx, argMap)
+	if err != nil {
+		return &BindReport{Function: t.funcName}, err
+	}
+
+	report, err := t.ExplainBind(full, opts...)
+	for i, binding := range report.Params {
+		if slices.Contains(injected, binding.Param) {
+			report.Params[i].Source, report.Params[i].Key = BindInjected, ""
+		}
+	}
+	return report, err
+}
+
+// injectArgs returns a copy of argMap completed with the decorated context and
+// injected values, along with the names of the injected parameters.
+func (t *Function) injectArgs(ctx context.Context, argMap map[string]any) (map[string]any, []string, error) {
+	full := maps.Clone(argMap)
+	if full == nil {
+		full = make(map[string]any, len(t.paramNames))
+	}
+
+	contextPositions := t.GetContextPositions()
+	if len(contextPositions) > 0 {
+		ctx = t.decorateContext(ctx)
+	}
+
+	var injected []string
+	for i, name := range t.paramNames {
+		if _, exists := full[name]; exists {
+			continue
+		}
+		if slices.Contains(contextPositions, i) {
+			full[name] = ctx
+			injected = append(injected, name)
+			continue
+		}
+
+		v, found, err := injectValue(ctx, t.paramTypes[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("parameter %q: injecting %v: %w", name, t.paramTypes[i], err)
+		}
+		if found {
+			full[name] = v.Interface()
+			injected = append(injected, name)
+		}
+	}
+
+	return full, injected, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testPrincipal struct{ name string }
+
+type testStore struct{ name string }
+
+type testUnavailable struct{}
+
+func testFuncInjected(ctx context.Context, p *testPrincipal, store *testStore, id int) string {
+	return p.name + "@" + store.name
+}
+
+func testFuncUnavailable(dep *testUnavailable) {}
+
+func init() {
+	RegisterInjector(func(ctx context.Context) (*testPrincipal, error) {
+		return &testPrincipal{name: "anonymous"}, nil
+	})
+	RegisterInjector(func(ctx context.Context) (*testStore, error) {
+		return &testStore{name: "global"}, nil
+	})
+	RegisterInjector(func(ctx context.Context) (*testUnavailable, error) {
+		return nil, errors.New("unavailable")
+	})
+}
+
+func TestCallInjected(t *testing.T) {
+	fn := mustNewFunction(t, testFuncInjected)
+
+	results, err := fn.CallInjected(context.Background(), map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "anonymous@global" {
+		t.Errorf("expected global providers, got %s", got)
+	}
+
+	ctx := WithScoped(context.Background(), &testPrincipal{name: "alice"})
+	results, err = fn.CallInjected(ctx, map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "alice@global" {
+		t.Errorf("expected scoped principal to win, got %s", got)
+	}
+
+	results, err = fn.CallInjected(ctx, map[string]any{"id": 1, "store": &testStore{name: "explicit"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "alice@explicit" {
+		t.Errorf("expected explicit argument to win, got %s", got)
+	}
+
+	if _, err := fn.CallInjected(ctx, map[string]any{}); err == nil {
+		t.Error("expected missing id error")
+	}
+}
+
+func TestCallInjected_ProviderError(t *testing.T) {
+	_, err := mustNewFunction(t, testFuncUnavailable).CallInjected(context.Background(), nil)
+	if err == nil || err.Error() != `parameter "dep": injecting *dwarfreflect.testUnavailable: unavailable` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExplainBindContext(t *testing.T) {
+	fn := mustNewFunction(t, testFuncInjected)
+
+	report, err := fn.ExplainBindContext(context.Background(), map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range report.Params {
+		want := BindInjected
+		if p.Param == "id" {
+			want = BindExact
+		}
+		if p.Source != want {
+			t.Errorf("%s: expected %s, got %s", p.Param, want, p.Source)
+		}
+	}
+}
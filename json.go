@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// UnmarshalParams decodes JSON into a new generated parameter struct.
+// Returns interface{} containing *struct, ready for CallWithStruct when no
+// StructOptions are given.
+//
+// Example:
+//
+//	params, err := fn.UnmarshalParams([]byte(`{"name":"Alice","age":30}`))
+//	results, err := fn.CallWithStruct(params)
+func (t *Function) UnmarshalParams(data []byte, opts ...StructOptions) (any, error) {
+	structType := t.structType
+	if len(opts) > 0 {
+		var err error
+		if structType, err = t.StructVariant(opts[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	params := reflect.New(structType).Interface()
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters of function %s: %w", t.funcName, err)
+	}
+	return params, nil
+}
+
+// MarshalParams encodes parameters as JSON through the generated struct, so
+// field names and tags follow the function signature and StructOptions.
+// Accepts a generated struct (value or pointer), another struct holding the
+// parameters as CallWithStruct accepts, or a map of parameter names to values.
+//
+// Example:
+//
+//	data, err := fn.MarshalParams(map[string]any{"name": "Alice", "age": 30})
+//	// {"name":"Alice","age":30}
+func (t *Function) MarshalParams(params any, opts ...StructOptions) ([]byte, error) {
+	structType := t.structType
+	if len(opts) > 0 {
+		var err error
+		if structType, err = t.StructVariant(opts[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	if argMap, ok := params.(map[string]any); ok {
+		args, err := t.MapToArgs(argMap)
+		if err != nil {
+			return nil, err
+		}
+		layout := t.StructLayout(opts...)
+		structValue := reflect.New(structType).Elem()
+		for i, arg := range args {
+			if arg != nil { // nil leaves optional interface parameters zero
+				structValue.Field(layout[i]).Set(reflect.ValueOf(arg))
+			}
+		}
+		return json.Marshal(structValue.Interface())
+	}
+
+	structValue := reflect.ValueOf(params)
+	if structValue.Kind() == reflect.Ptr {
+		structValue = structValue.Elem()
+	}
+
+	if structTypesCompatible(structValue.Type(), structType) {
+		return json.Marshal(structValue.Interface())
+	}
+
+	// Other structs are re-encoded through the generated struct
+	args, err := structArgs(structValue, t.paramNames, t.paramTypes)
+	if err != nil {
+		return nil, err
+	}
+	layout := t.StructLayout(opts...)
+	generated := reflect.New(structType).Elem()
+	for i, arg := range args {
+		generated.Field(layout[i]).Set(arg)
+	}
+	return json.Marshal(generated.Interface())
+}
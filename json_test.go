@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalParams(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	params, err := fn.UnmarshalParams([]byte(`{"name":"Alice","age":30}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results, err := fn.CallWithStruct(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "Alice is 30 years old" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestUnmarshalParams_InvalidJSON(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	if _, err := fn.UnmarshalParams([]byte(`{"age":"thirty"}`)); err == nil {
+		t.Error("expected error for mistyped JSON value")
+	}
+}
+
+func TestMarshalParams(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	data, err := fn.MarshalParams(map[string]any{"name": "Bob", "age": 25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"name":"Bob","age":25}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	params := fn.NewParamsPtr()
+	rv := reflect.ValueOf(params).Elem()
+	rv.FieldByName("Name").SetString("Carol")
+	rv.FieldByName("Age").SetInt(41)
+
+	data, err = fn.MarshalParams(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"name":"Carol","age":41}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestMarshalParams_WithOptions(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	opts := StructOptions{
+		TagBuilder: func(name string, typ reflect.Type) string {
+			return fmt.Sprintf(`json:"%s"`, strings.ToUpper(name))
+		},
+	}
+
+	data, err := fn.MarshalParams(map[string]any{"name": "Dan", "age": 52}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"NAME":"Dan","AGE":52}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	params, err := fn.UnmarshalParams(data, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := reflect.ValueOf(params).Elem().Field(0).String(); name != "Dan" {
+		t.Errorf("expected round-tripped name Dan, got %s", name)
+	}
+}
+
+func TestMarshalParams_OtherStruct(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	data, err := fn.MarshalParams(structArgsRequest{User: "Ada", Age: 36})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"name":"Ada","age":36}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestMarshalParams_TypeMismatch(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	if _, err := fn.MarshalParams(struct{ X int }{X: 1}); err == nil {
+		t.Error("expected error for struct type mismatch")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build goexperiment.jsonv2 && go1.27
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CallWithJSON decodes a JSON object of named arguments from r with
+// encoding/json/v2 and invokes the function, in a single streaming pass:
+// each member is decoded straight into its parameter, with no intermediate
+// map or struct. It is only built with Go 1.27 and GOEXPERIMENT=jsonv2.
+//
+// Member names match parameters exactly, by alias (see Aliases) or else
+// ignoring case, unless opts set json.MatchCaseInsensitiveNames(false).
+// Unknown members are skipped, as CallWithMap ignores extra keys, unless
+// opts set json.RejectUnknownMembers(true). Missing parameters take their
+// ParamMeta.Default or, if optional, their zero value. opts also apply to
+// decoding the values, so custom unmarshalers (json.WithUnmarshalers) reach
+// the parameter types. context.Context parameters receive
+// context.Background(); use CallWithJSONContext to provide one.
+//
+// Example:
+//
+//	GOEXPERIMENT=jsonv2 go build ./...
+//
+//	results, err := fn.CallWithJSON(r.Body, json.WithUnmarshalers(
+//	    json.UnmarshalFromFunc(func(dec *jsontext.Decoder, d *time.Duration) error { ... }),
+//	))
+func (t *Function) CallWithJSON(r io.Reader, opts ...jsonv2.Options) ([]reflect.Value, error) {
+	return t.CallWithJSONContext(context.Background(), r, opts...)
+}
+
+// CallWithJSONContext is like CallWithJSON but injects ctx into
+// context.Context parameters.
+func (t *Function) CallWithJSONContext(ctx context.Context, r io.Reader, opts ...jsonv2.Options) ([]reflect.Value, error) {
+	args, param, err := t.decodeJSONArgs(jsontext.NewDecoder(r), jsonv2.JoinOptions(opts...), Limits{})
+	if err != nil {
+		if param != "" {
+			err = fmt.Errorf("parameter %q: %w", param, err)
+		}
+		return nil, t.bindFailed(err)
+	}
+	return t.CallWithContext(ctx, args...)
+}
+
+// DispatchJSON is like Dispatch, decoding the payload from r with
+// encoding/json/v2 in a single streaming pass as Function.CallWithJSON does,
+// instead of decoding it once into raw members and again per parameter.
+// Unknown members are rejected, as by Dispatch, unless opts set
+// json.RejectUnknownMembers(false). The Router limits apply to the decoded
+// arguments. It is only built with Go 1.27 and GOEXPERIMENT=jsonv2.
+//
+// Example:
+//
+//	results, err := router.DispatchJSON(r.Context(), method, r.Body, json.MatchCaseInsensitiveNames(false))
+func (rt *Router) DispatchJSON(ctx context.Context, method string, r io.Reader, opts ...jsonv2.Options) ([]reflect.Value, error) {
+	fn, ok := rt.registry.Get(method)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, method)
+	}
+	if len(fn.options) > 0 {
+		return nil, &BindingError{Method: method, Err: errors.New("functional options are bound by Dispatch only")}
+	}
+
+	options := jsonv2.JoinOptions(append([]jsonv2.Options{jsonv2.RejectUnknownMembers(true)}, opts...)...)
+	args, param, err := fn.decodeJSONArgs(jsontext.NewDecoder(r), options, rt.limits)
+	if err != nil {
+		return nil, &BindingError{Method: method, Param: param, Err: err}
+	}
+	return fn.CallWithContext(ctx, args...)
+}
+
+// decodeJSONArgs decodes the non-context arguments of the function from the
+// JSON object read by dec, reporting the parameter an error is about, if any.
+func (t *Function) decodeJSONArgs(dec *jsontext.Decoder, opts jsonv2.Options, limits Limits) ([]any, string, error) {
+	foldNames, set := jsonv2.GetOption(opts, jsonv2.MatchCaseInsensitiveNames)
+	foldNames = foldNames || !set
+	rejectUnknown, _ := jsonv2.GetOption(opts, jsonv2.RejectUnknownMembers)
+
+	names, types := t.GetNonContextParameters()
+	for i, name := range names {
+		if isUnsafeType(types[i]) {
+			return nil, name, &UnsafeParameterError{Function: t.funcName, Param: name, Type: types[i]}
+		}
+	}
+
+	if kind := dec.PeekKind(); kind != '{' {
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("payload must be a JSON object, got %v", kind)
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return nil, "", err
+	}
+
+	values := make([]reflect.Value, len(names))
+	var unknown []string
+	for dec.PeekKind() != '}' {
+		key, err := dec.ReadValue()
+		if err != nil {
+			return nil, "", err
+		}
+		var member string
+		if err := jsonv2.Unmarshal(key, &member); err != nil {
+			return nil, "", err
+		}
+
+		i := t.jsonParamIndex(names, member, foldNames)
+		if i < 0 {
+			if rejectUnknown {
+				unknown = append(unknown, member)
+			}
+			if err := dec.SkipValue(); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		if t.isScoped(names[i]) {
+			return nil, names[i], fmt.Errorf("%w cannot be set by the payload", ErrScopedParameter)
+		}
+		if values[i].IsValid() {
+			return nil, names[i], fmt.Errorf("given twice, the second time as %q", member)
+		}
+
+		v := reflect.New(types[i])
+		if err := jsonv2.UnmarshalDecode(dec, v.Interface(), opts); err != nil {
+			return nil, names[i], err
+		}
+		if limits.enabled() {
+			if err := limits.check(t.funcName, names[i], v.Elem()); err != nil {
+				return nil, names[i], err
+			}
+		}
+		values[i] = v.Elem()
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return nil, "", err
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, "", fmt.Errorf("unknown parameters %v (expected %v)", unknown, names)
+	}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		switch meta := t.paramMeta[name]; {
+		case values[i].IsValid():
+			args[i] = values[i].Interface()
+		case meta.Default != nil:
+			args[i] = meta.Default
+		case t.isOptional(name, types[i]):
+			args[i] = reflect.Zero(types[i]).Interface()
+		default:
+			return nil, name, errors.New("missing required parameter")
+		}
+	}
+	return args, "", nil
+}
+
+// jsonParamIndex returns the index in names of the parameter a JSON member
+// binds to, or -1.
+func (t *Function) jsonParamIndex(names []string, member string, foldNames bool) int {
+	if param, ok := t.aliases[member]; ok {
+		member = param
+	}
+	for i, name := range names {
+		if name == member {
+			return i
+		}
+	}
+	if foldNames {
+		for i, name := range names {
+			if strings.EqualFold(name, member) {
+				return i
+			}
+		}
+	}
+	return -1
+}
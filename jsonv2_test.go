@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build goexperiment.jsonv2 && go1.27
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func testFuncSchedule(ctx context.Context, jobName string, every time.Duration, retries *int) string {
+	n := 0
+	if retries != nil {
+		n = *retries
+	}
+	return fmt.Sprintf("%s every %v, %d retries", jobName, every, n)
+}
+
+func TestCallWithJSON(t *testing.T) {
+	fn := mustNewFunction(t, testFuncSchedule)
+	durations := jsonv2.WithUnmarshalers(jsonv2.UnmarshalFromFunc(func(dec *jsontext.Decoder, d *time.Duration) error {
+		var s string
+		if err := jsonv2.UnmarshalDecode(dec, &s); err != nil {
+			return err
+		}
+		parsed, err := time.ParseDuration(s)
+		*d = parsed
+		return err
+	}))
+
+	tests := []struct {
+		input string
+		opts  []jsonv2.Options
+		want  string
+	}{
+		{`{"jobName": "backup", "every": "1h", "retries": 3}`, []jsonv2.Options{durations}, "backup every 1h0m0s, 3 retries"},
+		{`{"JOBNAME": "sync", "every": "5m", "extra": [1, 2]}`, []jsonv2.Options{durations}, "sync every 5m0s, 0 retries"},
+		{`{"JOBNAME": "sync", "every": "5m"}`, []jsonv2.Options{durations, jsonv2.MatchCaseInsensitiveNames(false)}, "missing required parameter"},
+		{`{"jobName": "sync", "every": "5m", "extra": 1}`, []jsonv2.Options{durations, jsonv2.RejectUnknownMembers(true)}, "unknown parameters [extra]"},
+		{`{"jobName": "a", "JobName": "b", "every": "5m"}`, []jsonv2.Options{durations}, "given twice"},
+		{`{"jobName": "a", "every": "soon"}`, []jsonv2.Options{durations}, `parameter "every"`},
+		{`["backup"]`, nil, "must be a JSON object"},
+	}
+	for _, tt := range tests {
+		results, err := fn.CallWithJSON(strings.NewReader(tt.input), tt.opts...)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = results[0].String()
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRouter_DispatchJSON(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "users.Greet", testFuncPlanGreet)
+	router := NewRouter(reg).WithLimits(Limits{MaxStringLength: 8})
+	ctx := context.Background()
+
+	results, err := router.DispatchJSON(ctx, "users.Greet", strings.NewReader(`{"name": "alice", "age": 30}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "alice is 30" {
+		t.Errorf("unexpected result: %q", got)
+	}
+
+	var bindErr *BindingError
+	payload := `{"name": "bob", "age": 1, "extra": 1}`
+	if _, err := router.DispatchJSON(ctx, "users.Greet", strings.NewReader(payload)); !errors.As(err, &bindErr) ||
+		!strings.Contains(err.Error(), "unknown parameters [extra]") {
+		t.Errorf("expected unknown members to be rejected, got %v", err)
+	}
+	if _, err := router.DispatchJSON(ctx, "users.Greet", strings.NewReader(payload), jsonv2.RejectUnknownMembers(false)); err != nil {
+		t.Errorf("expected options to override the defaults, got %v", err)
+	}
+	if _, err := router.DispatchJSON(ctx, "users.Greet", strings.NewReader(`{"name": "much too long", "age": 1}`)); !errors.As(err, &bindErr) ||
+		bindErr.Param != "name" {
+		t.Errorf("expected the limits to apply to name, got %v", err)
+	}
+	if _, err := router.DispatchJSON(ctx, "users.Missing", strings.NewReader(`{}`)); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("expected ErrUnknownMethod, got %v", err)
+	}
+}
+
+func TestRouter_DispatchJSON_Scoped(t *testing.T) {
+	router := NewRouter(newScopedRegistry(t))
+	ctx := WithScopeValues(context.Background(), map[string]any{"tenantID": "acme"})
+
+	results, err := router.DispatchJSON(ctx, "invoices.List", strings.NewReader(`{"status": "open"}`))
+	if err != nil || results[0].String() != "acme:open" {
+		t.Errorf("expected the scoped tenant, got %v, %v", results, err)
+	}
+	_, err = router.DispatchJSON(ctx, "invoices.List", strings.NewReader(`{"status": "open", "tenantID": "acme"}`))
+	if !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected a scoped parameter error, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FunctionKind classifies how a wrapped function was implemented.
+type FunctionKind int
+
+const (
+	KindGo        FunctionKind = iota // regular Go function
+	KindAssembly                      // implemented in Go assembly (.s)
+	KindCgo                           // cgo export or C function wrapper
+	KindSynthetic                     // built by NewFunctionFromSignature
+)
+
+// String returns a human-readable name for the function kind
+func (k FunctionKind) String() string {
+	switch k {
+	case KindGo:
+		return "Go"
+	case KindAssembly:
+		return "Assembly"
+	case KindCgo:
+		return "Cgo"
+	case KindSynthetic:
+		return "Synthetic"
+	default:
+		return "Unknown"
+	}
+}
+
+// Kind returns how the function was implemented. Assembly and cgo functions
+// often carry no DWARF parameter names; for those, NewFunction falls back to
+// positional names (arg0, arg1, ...) instead of failing.
+func (t *Function) Kind() FunctionKind {
+	return t.kind
+}
+
+// classifyFunction determines the function kind from its runtime name and the
+// source file of its entry point.
+func classifyFunction(funcName, file string) FunctionKind {
+	baseName := funcName[strings.LastIndex(funcName, "/")+1:]
+
+	switch {
+	case strings.Contains(baseName, "_cgoexp_"),
+		strings.Contains(baseName, "._Cfunc_"),
+		strings.Contains(baseName, "._cgo_"):
+		return KindCgo
+	case strings.HasSuffix(file, ".s"):
+		return KindAssembly
+	default:
+		return KindGo
+	}
+}
+
+// positionalNames generates fallback parameter names for functions without DWARF names.
+func positionalNames(count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("arg%d", i)
+	}
+	return names
+}
+
+// positionalResultNames generates names for unnamed results: r0, r1, ...
+func positionalResultNames(count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("r%d", i)
+	}
+	return names
+}
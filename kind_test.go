@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFunctionKind_String(t *testing.T) {
+	tests := []struct {
+		kind     FunctionKind
+		expected string
+	}{
+		{KindGo, "Go"},
+		{KindAssembly, "Assembly"},
+		{KindCgo, "Cgo"},
+		{FunctionKind(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.expected {
+			t.Errorf("String() = %v, want %v", got, tt.expected)
+		}
+	}
+}
+
+func TestClassifyFunction(t *testing.T) {
+	tests := []struct {
+		funcName string
+		file     string
+		expected FunctionKind
+	}{
+		{"main.process", "/src/main.go", KindGo},
+		{"github.com/user/repo/pkg.(*T).Method", "/src/pkg/t.go", KindGo},
+		{"github.com/user/repo/pkg.addVectors", "/src/pkg/add_amd64.s", KindAssembly},
+		{"main._cgoexp_1f2e3d_goCallback", "_cgo_gotypes.go", KindCgo},
+		{"github.com/user/repo/pkg._Cfunc_sqlite3_open", "_cgo_gotypes.go", KindCgo},
+		{"main._cgo_cmalloc", "_cgo_gotypes.go", KindCgo},
+		{"github.com/user/repo/cgo_helpers.Process", "/src/cgo_helpers/p.go", KindGo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.funcName, func(t *testing.T) {
+			if got := classifyFunction(tt.funcName, tt.file); got != tt.expected {
+				t.Errorf("classifyFunction(%q, %q) = %v, want %v", tt.funcName, tt.file, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPositionalNames(t *testing.T) {
+	if names := positionalNames(3); !reflect.DeepEqual(names, []string{"arg0", "arg1", "arg2"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+func TestKind_GoFunction(t *testing.T) {
+	if kind := mustNewFunction(t, testFunc1).Kind(); kind != KindGo {
+		t.Errorf("expected KindGo, got %v", kind)
+	}
+}
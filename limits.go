@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Limits bounds the size of arguments accepted from untrusted input, to
+// protect services from memory-exhaustion abuse. Zero fields are unlimited.
+type Limits struct {
+	// MaxStringLength bounds the length in bytes of strings, at any depth.
+	MaxStringLength int
+
+	// MaxSliceLength bounds the length of slices and arrays, at any depth.
+	MaxSliceLength int
+
+	// MaxMapKeys bounds the number of keys of maps, at any depth.
+	MaxMapKeys int
+
+	// MaxDepth bounds the nesting of slices, arrays, maps and structs within a
+	// single argument: []int has depth 1, map[string][]int depth 2. JSON
+	// payloads of named arguments may nest one level more, for the
+	// enclosing object.
+	MaxDepth int
+}
+
+// LimitError reports an argument exceeding one of the configured Limits.
+// Param is empty when the limit applies to a whole payload.
+type LimitError struct {
+	Function string
+	Param    string
+	Limit    string // name of the exceeded Limits field, e.g. "MaxStringLength"
+	Max      int
+	Actual   int
+}
+
+func (e *LimitError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("arguments of function %s exceed %s: %d > %d", e.Function, e.Limit, e.Actual, e.Max)
+	}
+	return fmt.Sprintf("parameter %q of function %s exceeds %s: %d > %d", e.Param, e.Function, e.Limit, e.Actual, e.Max)
+}
+
+// enabled reports whether any limit is set.
+func (l Limits) enabled() bool {
+	return l != Limits{}
+}
+
+// check validates an argument value, returning a *LimitError for param.
+func (l Limits) check(function, param string, v reflect.Value) error {
+	if err := l.checkValue(v, 0, make(map[uintptr]bool)); err != nil {
+		err.Function, err.Param = function, param
+		return err
+	}
+	return nil
+}
+
+func (l Limits) checkValue(v reflect.Value, depth int, seen map[uintptr]bool) *LimitError {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return exceeds("MaxStringLength", l.MaxStringLength, v.Len())
+
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			if seen[v.Pointer()] {
+				return nil // Already checked: cycles must not recurse forever
+			}
+			seen[v.Pointer()] = true
+		}
+		return l.checkValue(v.Elem(), depth, seen)
+
+	case reflect.Slice, reflect.Array:
+		if err := exceeds("MaxDepth", l.MaxDepth, depth+1); err != nil {
+			return err
+		}
+		if err := exceeds("MaxSliceLength", l.MaxSliceLength, v.Len()); err != nil {
+			return err
+		}
+		if !mayExceedLimits(v.Type().Elem()) {
+			return nil // e.g. []byte or []int: nothing to check per element
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := l.checkValue(v.Index(i), depth+1, seen); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		if err := exceeds("MaxDepth", l.MaxDepth, depth+1); err != nil {
+			return err
+		}
+		if err := exceeds("MaxMapKeys", l.MaxMapKeys, v.Len()); err != nil {
+			return err
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := l.checkValue(iter.Key(), depth+1, seen); err != nil {
+				return err
+			}
+			if err := l.checkValue(iter.Value(), depth+1, seen); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		if err := exceeds("MaxDepth", l.MaxDepth, depth+1); err != nil {
+			return err
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if err := l.checkValue(v.Field(i), depth+1, seen); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkJSON validates the nesting depth of a raw JSON payload before it is
+// decoded, so deeply nested input is rejected without building it in memory.
+func (l Limits) checkJSON(function string, data []byte) error {
+	if l.MaxDepth <= 0 {
+		return nil
+	}
+
+	depth, maxDepth := 0, l.MaxDepth+1 // one level for the enclosing object
+	inString, escaped := false, false
+	for _, c := range data {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > maxDepth {
+				return &LimitError{Function: function, Limit: "MaxDepth", Max: l.MaxDepth, Actual: depth - 1}
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// exceeds returns a *LimitError when max is set and actual is above it.
+func exceeds(limit string, max, actual int) *LimitError {
+	if max > 0 && actual > max {
+		return &LimitError{Limit: limit, Max: max, Actual: actual}
+	}
+	return nil
+}
+
+// mayExceedLimits reports whether values of typ can contain strings or
+// containers, which must be checked one by one.
+func mayExceedLimits(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	default:
+		return true
+	}
+}
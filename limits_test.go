@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLimits_Check(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	cyclic := &node{Name: "a"}
+	cyclic.Next = cyclic
+
+	tests := []struct {
+		name   string
+		limits Limits
+		value  any
+		limit  string
+	}{
+		{"string ok", Limits{MaxStringLength: 3}, "abc", ""},
+		{"string", Limits{MaxStringLength: 3}, "abcd", "MaxStringLength"},
+		{"nested string", Limits{MaxStringLength: 3}, map[string][]string{"k": {"long"}}, "MaxStringLength"},
+		{"map key", Limits{MaxStringLength: 3}, map[string]int{"long": 1}, "MaxStringLength"},
+		{"slice", Limits{MaxSliceLength: 2}, []int{1, 2, 3}, "MaxSliceLength"},
+		{"array", Limits{MaxSliceLength: 2}, [3]int{}, "MaxSliceLength"},
+		{"map", Limits{MaxMapKeys: 1}, map[string]int{"a": 1, "b": 2}, "MaxMapKeys"},
+		{"depth ok", Limits{MaxDepth: 2}, [][]int{{1}}, ""},
+		{"depth", Limits{MaxDepth: 2}, [][][]int{{{1}}}, "MaxDepth"},
+		{"depth any", Limits{MaxDepth: 2}, []any{map[string]any{"x": []any{1}}}, "MaxDepth"},
+		{"pointer", Limits{MaxStringLength: 1}, &node{Name: "ab"}, "MaxStringLength"},
+		{"cycle", Limits{MaxStringLength: 1}, cyclic, ""},
+		{"scalar", Limits{MaxDepth: 1}, 42, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.check("pkg.F", "p", reflect.ValueOf(tt.value))
+			if tt.limit == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != tt.limit || limitErr.Param != "p" || limitErr.Function != "pkg.F" {
+				t.Errorf("expected %s violation, got %v", tt.limit, err)
+			}
+		})
+	}
+}
+
+func TestLimits_CheckJSON(t *testing.T) {
+	limits := Limits{MaxDepth: 1}
+	if err := limits.checkJSON("pkg.F", []byte(`{"a":[1,2],"b":"[[[["}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := limits.checkJSON("pkg.F", []byte(`{"a":[[1]]}`)); err == nil {
+		t.Error("expected depth violation")
+	}
+	if err := limits.checkJSON("pkg.F", []byte(`{"a":"\"[","b":[{}]}`)); err == nil {
+		t.Error("expected depth violation after escaped quote")
+	}
+}
+
+func TestCallWithMap_Limits(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+	args := map[string]any{"name": "Alice", "active": true, "scores": []int{1, 2, 3}}
+
+	if _, err := fn.CallWithMap(args, CallOptions{Limits: Limits{MaxSliceLength: 3}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := fn.CallWithMap(args, CallOptions{Limits: Limits{MaxSliceLength: 2}})
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Param != "scores" || limitErr.Actual != 3 {
+		t.Errorf("expected scores to exceed MaxSliceLength, got %v", err)
+	}
+}
+
+func TestBinder_FromJSONLimits(t *testing.T) {
+	fn := mustNewFunction(t, testFunc5)
+	b := fn.Binder(BinderOptions{CallOptions: CallOptions{Limits: Limits{MaxStringLength: 5, MaxDepth: 1}}})
+
+	if _, err := b.FromJSON([]byte(`{"name":"Bob","active":true,"scores":[1]}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := b.FromJSON([]byte(`{"name":"Robert","active":true,"scores":[1]}`)); err == nil || !strings.Contains(err.Error(), "MaxStringLength") {
+		t.Errorf("expected MaxStringLength violation, got %v", err)
+	}
+	if _, err := b.FromJSON([]byte(`{"name":"Bob","active":true,"scores":[[1]]}`)); err == nil || !strings.Contains(err.Error(), "MaxDepth") {
+		t.Errorf("expected MaxDepth violation, got %v", err)
+	}
+}
+
+func TestRouter_WithLimits(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "greet", testFunc1)
+	router := NewRouter(reg).WithLimits(Limits{MaxStringLength: 4})
+
+	if _, err := router.Dispatch(context.Background(), "greet", []byte(`{"name":"Bob","age":3}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := router.Dispatch(context.Background(), "greet", []byte(`{"name":"Robert","age":3}`))
+	var bindErr *BindingError
+	var limitErr *LimitError
+	if !errors.As(err, &bindErr) || !errors.As(err, &limitErr) || bindErr.Param != "name" {
+		t.Errorf("expected binding error wrapping a limit error, got %v", err)
+	}
+}
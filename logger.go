@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+var (
+	discardLogger = slog.New(slog.DiscardHandler)
+	packageLogger atomic.Pointer[slog.Logger]
+)
+
+// SetLogger sets the logger receiving the package's diagnostics at debug
+// level: resolver initialization timing, DWARF lookup misses, matches through
+// name candidates other than the runtime name, positional fallbacks and the
+// call errors of adapters. Logging is off by default; nil turns it off again.
+// Set it before the first NewFunction to observe resolver initialization.
+//
+// Example:
+//
+//	dwarfreflect.SetLogger(slog.New(slog.NewTextHandler(os.Stderr,
+//	    &slog.HandlerOptions{Level: slog.LevelDebug})))
+func SetLogger(logger *slog.Logger) {
+	packageLogger.Store(logger)
+}
+
+// Logger returns the logger set with SetLogger, or a logger discarding
+// everything. Adapters built on this package log through it.
+func Logger() *slog.Logger {
+	if logger := packageLogger.Load(); logger != nil {
+		return logger
+	}
+	return discardLogger
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	if Logger() == nil || Logger().Enabled(t.Context(), slog.LevelError) {
+		t.Error("expected a discarding logger by default")
+	}
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetLogger(nil) })
+
+	dr := &DWARFResolver{
+		functions:   indexOf(map[string][]string{"pkg.Greet": {"name", "~r0"}}),
+		normalizers: defaultNormalizers,
+	}
+	if _, _, err := dr.lookupParameterNames("example.com/mod/pkg.Greet", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := dr.lookupParameterNames("pkg.Missing", 1); err == nil {
+		t.Fatal("expected a lookup miss")
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="dwarfreflect: DWARF entry matched by candidate name" function=example.com/mod/pkg.Greet entry=pkg.Greet`,
+		`msg="dwarfreflect: no DWARF entry matches" function=pkg.Missing parameters=1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	SetLogger(nil)
+	if Logger().Enabled(t.Context(), slog.LevelError) {
+		t.Error("expected nil to turn logging off")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"maps"
+	"reflect"
+)
+
+type callMetaKey struct{}
+
+// WithCallMeta returns a context carrying meta, the caller, transport and
+// request details of the calls made with it. Every Call variant taking a
+// context, or a context.Context argument, passes them on to the authorizers
+// and MetaObservers. Function and Args of meta are ignored.
+//
+// Example:
+//
+//	ctx := dwarfreflect.WithCallMeta(r.Context(), dwarfreflect.CallMeta{
+//	    Caller:    claims.Subject,
+//	    Transport: "http",
+//	    RequestID: r.Header.Get("X-Request-ID"),
+//	})
+//	results, err := router.Dispatch(ctx, method, body)
+func WithCallMeta(ctx context.Context, meta CallMeta) context.Context {
+	meta.Function, meta.Args = nil, nil
+	return context.WithValue(ctx, callMetaKey{}, meta)
+}
+
+// CallMetaFrom returns the metadata carried by ctx, as set by WithCallMeta or
+// CallWithMeta, so functions and context decorators can read it too.
+func CallMetaFrom(ctx context.Context) (CallMeta, bool) {
+	meta, ok := ctx.Value(callMetaKey{}).(CallMeta)
+	return meta, ok
+}
+
+// CallWithMeta invokes the function using a map of parameter names to values
+// like CallWithMap, injecting ctx into context.Context parameters absent from
+// the map and passing meta to the authorizers and MetaObservers. The called
+// function finds meta in its context with CallMetaFrom.
+//
+// Example:
+//
+//	results, err := fn.CallWithMeta(ctx, dwarfreflect.CallMeta{
+//	    Caller:    "admin-cli",
+//	    Transport: "cli",
+//	}, map[string]any{"userID": 42})
+func (t *Function) CallWithMeta(ctx context.Context, meta CallMeta, argMap map[string]any, opts ...CallOptions) ([]reflect.Value, error) {
+	var options CallOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ctx = WithCallMeta(ctx, meta)
+	if positions := t.GetContextPositions(); len(positions) > 0 {
+		// Decorate once so every context position receives the same context
+		decorated := t.decorateContext(ctx)
+		argMap = maps.Clone(argMap)
+		if argMap == nil {
+			argMap = make(map[string]any, len(positions))
+		}
+		for _, pos := range positions {
+			if _, exists := argMap[t.paramNames[pos]]; !exists {
+				argMap[t.paramNames[pos]] = decorated
+			}
+		}
+	}
+
+	frame := t.getFrame()
+	defer t.putFrame(frame)
+	if err := t.bindMap(argMap, options, nil, *frame, nil); err != nil {
+		return nil, t.bindFailed(err)
+	}
+	return t.invokeContext(ctx, *frame)
+}
+
+// callMeta returns the metadata of a call with prepared arguments: that
+// carried by ctx, with the Function and its non-context arguments.
+func (t *Function) callMeta(ctx context.Context, args []reflect.Value) CallMeta {
+	meta, _ := CallMetaFrom(ctx)
+	meta.Function = t
+	meta.Args = make(map[string]any, len(args))
+	for i, arg := range args {
+		if t.paramTypes[i] != contextType {
+			meta.Args[t.paramNames[i]] = arg.Interface()
+		}
+	}
+	return meta
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type auditObserver struct {
+	recordingObserver
+	metas []CallMeta
+}
+
+func (o *auditObserver) ObserveCallMeta(meta CallMeta, duration time.Duration, err error) {
+	o.metas = append(o.metas, meta)
+}
+
+func testFuncMeta(ctx context.Context, id int) string {
+	meta, _ := CallMetaFrom(ctx)
+	return meta.Caller
+}
+
+func TestCallWithMeta(t *testing.T) {
+	audit := &auditObserver{}
+	var authorized CallMeta
+	fn := mustNewFunction(t, testFuncMeta).With(
+		Observers(audit),
+		Authorizers(func(ctx context.Context, meta CallMeta) error {
+			authorized = meta
+			return nil
+		}),
+	)
+
+	meta := CallMeta{Caller: "alice", Transport: "cli", RequestID: "req-1", Attributes: map[string]any{"tenant": "acme"}}
+	results, err := fn.CallWithMeta(context.Background(), meta, map[string]any{"id": 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "alice" {
+		t.Errorf("expected the function to see the caller, got %q", got)
+	}
+
+	if authorized.Caller != "alice" || authorized.RequestID != "req-1" || authorized.Function == nil {
+		t.Errorf("unexpected authorizer meta: %+v", authorized)
+	}
+	if !reflect.DeepEqual(authorized.Args, map[string]any{"id": 7}) {
+		t.Errorf("unexpected authorizer args: %v", authorized.Args)
+	}
+
+	if len(audit.metas) != 1 || len(audit.calls) != 0 {
+		t.Fatalf("expected ObserveCallMeta instead of ObserveCall, got %d and %d", len(audit.metas), len(audit.calls))
+	}
+	if got := audit.metas[0]; got.Transport != "cli" || got.Attributes["tenant"] != "acme" || got.Args["id"] != 7 {
+		t.Errorf("unexpected observed meta: %+v", got)
+	}
+}
+
+func TestWithCallMeta(t *testing.T) {
+	audit := &auditObserver{}
+	fn := mustNewFunction(t, testFunc1).WithObserver(audit)
+
+	ctx := WithCallMeta(context.Background(), CallMeta{Transport: "http", Function: fn})
+	if _, err := fn.CallWithContext(ctx, "Ada", 36); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(audit.metas) != 1 || audit.metas[0].Transport != "http" || audit.metas[0].Args["name"] != "Ada" {
+		t.Errorf("expected the context metadata to reach observers, got %+v", audit.metas)
+	}
+
+	if _, ok := CallMetaFrom(context.Background()); ok {
+		t.Error("expected no metadata in a plain context")
+	}
+	if meta, _ := CallMetaFrom(ctx); meta.Function != nil {
+		t.Error("expected WithCallMeta to drop the Function")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
+)
+
+// Method is a method found in DWARF data, with the names of its receiver,
+// parameters and results.
+type Method struct {
+	// FuncName is the DWARF name, e.g. example.com/app.(*UserService).Create.
+	FuncName string
+
+	// Package is the import path of the package declaring the method.
+	Package string
+
+	// Receiver is the receiver type name, without pointer or type arguments.
+	Receiver string
+
+	// PointerReceiver reports a method declared on *Receiver.
+	PointerReceiver bool
+
+	// Name is the method name.
+	Name string
+
+	// ReceiverName is the name of the receiver parameter.
+	ReceiverName string
+
+	// Params and Results are the parameter and result names as DWARF lists
+	// them; unnamed ones are ~p0, ~r0, ...
+	Params  []string
+	Results []string
+}
+
+// MethodsOf returns the methods of the receiver type, sorted by package and
+// name, enabling reflection over a whole service. Like a Go method set, the
+// methods of "(*UserService)" or "*UserService" include those declared on
+// the value receiver, while "UserService" only has value receiver methods.
+// The receiver may be qualified by its package ("example.com/app.(*UserService)");
+// otherwise types of that name in every package match. Generic receivers
+// are matched without type arguments.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewDWARFResolver("./server")
+//	for _, m := range dr.MethodsOf("(*UserService)") {
+//		fmt.Println(m.Name, m.Params, m.Results)
+//	}
+func (dr *DWARFResolver) MethodsOf(receiver string) []Method {
+	pkg, typeName, pointer := parseReceiver(receiver)
+
+	var methods []Method
+	for _, funcName := range dr.functions.methodsOf(typeName) {
+		sym := symbols.Parse(funcName)
+		if (pkg != "" && sym.Package != pkg) || (sym.PointerReceiver && !pointer) {
+			continue
+		}
+
+		params, _ := dr.functions.lookup(funcName)
+		if len(params) == 0 {
+			continue // no receiver parameter, not a usable entry
+		}
+		results := min(dr.functions.resultCount(funcName), len(params)-1)
+		methods = append(methods, Method{
+			FuncName:        funcName,
+			Package:         sym.Package,
+			Receiver:        sym.Receiver,
+			PointerReceiver: sym.PointerReceiver,
+			Name:            sym.Name,
+			ReceiverName:    params[0],
+			Params:          params[1 : len(params)-results],
+			Results:         params[len(params)-results:],
+		})
+	}
+
+	slices.SortFunc(methods, func(a, b Method) int {
+		if c := strings.Compare(a.Package, b.Package); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return methods
+}
+
+// MethodsOf returns the methods of the receiver type in the running binary,
+// as DWARFResolver.MethodsOf does.
+func MethodsOf(receiver string) ([]Method, error) {
+	if err := loadResolver(); err != nil {
+		return nil, err
+	}
+	return globalResolver.MethodsOf(receiver), nil
+}
+
+// parseReceiver splits a receiver such as "example.com/app.(*T[K])" into its
+// package, type name and pointer parts.
+func parseReceiver(receiver string) (pkg, typeName string, pointer bool) {
+	rest := receiver
+	if i, j := strings.IndexByte(rest, '['), strings.LastIndexByte(rest, ']'); i >= 0 && j > i {
+		rest = rest[:i] + rest[j+1:] // type arguments may hold any qualified name
+	}
+	if i := strings.LastIndexByte(rest, '.'); i > strings.LastIndexByte(rest, '/') {
+		pkg, rest = rest[:i], rest[i+1:]
+	}
+	if inner, ok := strings.CutPrefix(rest, "("); ok {
+		rest = strings.TrimSuffix(inner, ")")
+	}
+	typeName, pointer = strings.CutPrefix(rest, "*")
+	return pkg, typeName, pointer
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type methodsTestService struct{ prefix string }
+
+//go:noinline
+func (s *methodsTestService) Create(ctx context.Context, name string) (id int, err error) {
+	return len(s.prefix + name), ctx.Err()
+}
+
+//go:noinline
+func (s methodsTestService) Describe(verbose bool) string {
+	if verbose {
+		return "service " + s.prefix
+	}
+	return s.prefix
+}
+
+func TestMethodsOf(t *testing.T) {
+	svc := &methodsTestService{prefix: "user-"}
+	svc.Create(context.Background(), "ada")
+	svc.Describe(true)
+
+	methods, err := MethodsOf("(*methodsTestService)")
+	if err != nil {
+		t.Skipf("DWARF not available: %v", err)
+	}
+
+	want := []Method{
+		{
+			FuncName:        "github.com/matteo-grella/dwarfreflect.(*methodsTestService).Create",
+			Package:         "github.com/matteo-grella/dwarfreflect",
+			Receiver:        "methodsTestService",
+			PointerReceiver: true,
+			Name:            "Create",
+			ReceiverName:    "s",
+			Params:          []string{"ctx", "name"},
+			Results:         []string{"id", "err"},
+		},
+		{
+			FuncName:     "github.com/matteo-grella/dwarfreflect.methodsTestService.Describe",
+			Package:      "github.com/matteo-grella/dwarfreflect",
+			Receiver:     "methodsTestService",
+			Name:         "Describe",
+			ReceiverName: "s",
+			Params:       []string{"verbose"},
+			Results:      []string{"~r0"},
+		},
+	}
+	if !reflect.DeepEqual(methods, want) {
+		t.Errorf("expected %+v, got %+v", want, methods)
+	}
+
+	values, _ := MethodsOf("github.com/matteo-grella/dwarfreflect.methodsTestService")
+	if len(values) != 1 || values[0].Name != "Describe" {
+		t.Errorf("expected only the value receiver method, got %+v", values)
+	}
+	if other, _ := MethodsOf("other/pkg.(*methodsTestService)"); len(other) != 0 {
+		t.Errorf("expected no methods in another package, got %+v", other)
+	}
+}
+
+func TestDWARFResolver_MethodsOf(t *testing.T) {
+	dr := &DWARFResolver{}
+	dr.functions.add("app/users.(*Service).Get", []string{"s", "id", "~r0", "~r1"}, 2)
+	dr.functions.add("app/users.(*Service).Get.func1", []string{"x"}, 0)
+	dr.functions.add("app/users.(*Service).Get-fm", []string{"id", "~r0", "~r1"}, 2)
+	dr.functions.add("app/users.Service.String", []string{"s", "~r0"}, 1)
+	dr.functions.add("app/admin.(*Service).Ban", []string{"~p0", "id"}, 0)
+	dr.functions.add("app/users.NewService", []string{"~r0"}, 1)
+	dr.functions.add("app/cache.(*Cache[go.shape.int]).Put", []string{"c", "key"}, 0)
+	dr.functions.alias("app/cache.(*Cache).Put", "app/cache.(*Cache[go.shape.int]).Put")
+
+	var names []string
+	for _, m := range dr.MethodsOf("*Service") {
+		names = append(names, m.FuncName)
+	}
+	want := []string{"app/admin.(*Service).Ban", "app/users.(*Service).Get", "app/users.Service.String"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+
+	if ban := dr.MethodsOf("app/admin.(*Service)"); len(ban) != 1 || ban[0].ReceiverName != "~p0" || !reflect.DeepEqual(ban[0].Params, []string{"id"}) {
+		t.Errorf("unexpected methods %+v", ban)
+	}
+	if put := dr.MethodsOf("app/cache.(*Cache[app/keys.Key])"); len(put) != 1 || put[0].Name != "Put" {
+		t.Errorf("expected the generic method once, got %+v", put)
+	}
+	if stats := dr.functions.stats(); stats.Methods != 4 {
+		t.Errorf("expected 4 indexed methods, got %d", stats.Methods)
+	}
+}
+
+func TestParseReceiver(t *testing.T) {
+	tests := []struct {
+		receiver, pkg, typeName string
+		pointer                 bool
+	}{
+		{"(*UserService)", "", "UserService", true},
+		{"*UserService", "", "UserService", true},
+		{"UserService", "", "UserService", false},
+		{"example.com/app.(*UserService)", "example.com/app", "UserService", true},
+		{"app.Cache[example.com/k.Key]", "app", "Cache", false},
+	}
+	for _, tt := range tests {
+		pkg, typeName, pointer := parseReceiver(tt.receiver)
+		if pkg != tt.pkg || typeName != tt.typeName || pointer != tt.pointer {
+			t.Errorf("parseReceiver(%q) = %q, %q, %v", tt.receiver, pkg, typeName, pointer)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// NameNormalizer maps a runtime function name to extra DWARF lookup keys.
+// Normalizers are tried after the built-in candidates, in registration order.
+type NameNormalizer func(runtimeName string) []string
+
+// defaultNormalizers are installed on every resolver.
+var defaultNormalizers = []NameNormalizer{VendorNormalizer, GenericsNormalizer}
+
+// AddNameNormalizer adds a normalizer to the global resolver used by NewFunction.
+func AddNameNormalizer(normalizer NameNormalizer) error {
+	resolverOnce.Do(initResolver)
+	if resolverInitErr != nil {
+		return resolverInitErr
+	}
+	globalResolver.AddNameNormalizer(normalizer)
+	resetFunctionInfos() // functions may now resolve differently
+	return nil
+}
+
+// AddNameNormalizer adds a normalizer used to match runtime names to DWARF entries.
+//
+// Example:
+//
+//	resolver.AddNameNormalizer(dwarfreflect.MajorVersionNormalizer)
+func (dr *DWARFResolver) AddNameNormalizer(normalizer NameNormalizer) {
+	// Copy on write, so that lookups read the list without locking
+	for {
+		old := dr.added.Load()
+		var added []NameNormalizer
+		if old != nil {
+			added = append(added, *old...)
+		}
+		added = append(added, normalizer)
+		if dr.added.CompareAndSwap(old, &added) {
+			return
+		}
+	}
+}
+
+// candidates returns the lookup keys for a runtime name: the built-in candidates
+// followed by those of each normalizer, without duplicates.
+func (dr *DWARFResolver) candidates(runtimeName string) []string {
+	candidates := generateFunctionKeyCandidates(runtimeName)
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		seen[candidate] = true
+	}
+
+	normalizers := dr.normalizers
+	if added := dr.added.Load(); added != nil {
+		normalizers = append(slices.Clip(normalizers), *added...)
+	}
+	for _, normalize := range normalizers {
+		for _, candidate := range normalize(runtimeName) {
+			if !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// VendorNormalizer strips vendor directory prefixes, so that
+// "myapp/vendor/github.com/x/y.F" is also looked up as "github.com/x/y.F".
+// Installed by default.
+func VendorNormalizer(runtimeName string) []string {
+	if i := strings.LastIndex(runtimeName, "/vendor/"); i >= 0 {
+		return []string{runtimeName[i+len("/vendor/"):]}
+	}
+	if strings.HasPrefix(runtimeName, "vendor/") {
+		return []string{strings.TrimPrefix(runtimeName, "vendor/")}
+	}
+	return nil
+}
+
+// GenericsNormalizer strips type parameter brackets, so that the runtime name
+// "pkg.Map[...]" matches the DWARF entry of any instantiation such as
+// "pkg.Map[int,string]". Parameter names are the same for every instantiation.
+// Installed by default.
+func GenericsNormalizer(runtimeName string) []string {
+	if stripped := stripTypeParams(runtimeName); stripped != runtimeName {
+		return []string{stripped}
+	}
+	return nil
+}
+
+var majorVersionPattern = regexp.MustCompile(`/v[2-9][0-9]*([./]|$)`)
+
+// MajorVersionNormalizer strips module major-version path elements, so that
+// "example.com/lib/v2.Do" is also looked up as "example.com/lib.Do".
+// Not installed by default: a binary linking several major versions of the
+// same module would match the wrong one.
+func MajorVersionNormalizer(runtimeName string) []string {
+	if !strings.Contains(runtimeName, "/") {
+		return nil
+	}
+
+	// Only rewrite the import path, never the function part
+	importPath, rest := splitImportPath(runtimeName)
+	stripped := majorVersionPattern.ReplaceAllString(importPath, "$1")
+	if stripped == importPath {
+		return nil
+	}
+	return []string{stripped + rest}
+}
+
+// ImportPathRewriter returns a normalizer that rewrites import path prefixes,
+// so that lookups survive module replace directives and forks whose DWARF
+// entries were produced under a different path. A prefix matches whole path
+// elements only and the longest matching prefix wins. Vendor directory
+// prefixes are stripped before matching.
+//
+// Example:
+//
+//	dwarfreflect.AddNameNormalizer(dwarfreflect.ImportPathRewriter(map[string]string{
+//	    "github.com/me/fork": "github.com/upstream/lib",
+//	}))
+func ImportPathRewriter(rewrites map[string]string) NameNormalizer {
+	prefixes := make([]string, 0, len(rewrites))
+	for from := range rewrites {
+		prefixes = append(prefixes, from)
+	}
+	// Longest first, so that nested prefixes take precedence
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	return func(runtimeName string) []string {
+		names := append([]string{runtimeName}, VendorNormalizer(runtimeName)...)
+		for _, name := range names {
+			importPath, rest := splitImportPath(name)
+			for _, from := range prefixes {
+				if importPath == from || strings.HasPrefix(importPath, from+"/") {
+					return []string{rewrites[from] + importPath[len(from):] + rest}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// splitImportPath splits a runtime function name into its import path and the
+// remainder starting at the dot that separates the package from the function:
+// "github.com/x/y.(*T).M" -> "github.com/x/y", ".(*T).M".
+func splitImportPath(runtimeName string) (string, string) {
+	lastSlash := max(strings.LastIndex(runtimeName, "/"), 0)
+	pathEnd := strings.Index(runtimeName[lastSlash:], ".")
+	if pathEnd < 0 {
+		return runtimeName, ""
+	}
+	pathEnd += lastSlash
+	return runtimeName[:pathEnd], runtimeName[pathEnd:]
+}
+
+// stripTypeParams removes bracketed type parameter lists from a function name:
+// "pkg.(*List[...]).Push" -> "pkg.(*List).Push".
+func stripTypeParams(name string) string {
+	if !strings.Contains(name, "[") {
+		return name
+	}
+
+	var b strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizers(t *testing.T) {
+	rewriter := ImportPathRewriter(map[string]string{
+		"github.com/me/fork":          "github.com/upstream/lib",
+		"github.com/me/fork/internal": "example.com/internal",
+	})
+
+	tests := []struct {
+		name       string
+		normalizer NameNormalizer
+		input      string
+		expected   []string
+	}{
+		{"vendor", VendorNormalizer, "myapp/vendor/github.com/x/y.F", []string{"github.com/x/y.F"}},
+		{"vendor root", VendorNormalizer, "vendor/github.com/x/y.F", []string{"github.com/x/y.F"}},
+		{"vendor none", VendorNormalizer, "github.com/x/y.F", nil},
+		{"generic func", GenericsNormalizer, "pkg.Map[...]", []string{"pkg.Map"}},
+		{"generic method", GenericsNormalizer, "github.com/x/pkg.(*List[...]).Push", []string{"github.com/x/pkg.(*List).Push"}},
+		{"generic none", GenericsNormalizer, "pkg.F", nil},
+		{"major version", MajorVersionNormalizer, "example.com/lib/v2.Do", []string{"example.com/lib.Do"}},
+		{"major version subpackage", MajorVersionNormalizer, "example.com/lib/v3/sub.Do", []string{"example.com/lib/sub.Do"}},
+		{"major version method", MajorVersionNormalizer, "example.com/lib/v2.(*T).v2", []string{"example.com/lib.(*T).v2"}},
+		{"no major version", MajorVersionNormalizer, "example.com/lib/v1.Do", nil},
+		{"version-like name", MajorVersionNormalizer, "example.com/lib/v2x.Do", nil},
+		{"rewrite", rewriter, "github.com/me/fork.Do", []string{"github.com/upstream/lib.Do"}},
+		{"rewrite subpackage", rewriter, "github.com/me/fork/sub.(*T).M", []string{"github.com/upstream/lib/sub.(*T).M"}},
+		{"rewrite longest prefix", rewriter, "github.com/me/fork/internal.Do", []string{"example.com/internal.Do"}},
+		{"rewrite vendored", rewriter, "myapp/vendor/github.com/me/fork.Do", []string{"github.com/upstream/lib.Do"}},
+		{"rewrite whole elements", rewriter, "github.com/me/forked.Do", nil},
+		{"rewrite no slash", ImportPathRewriter(map[string]string{"main": "cmd/tool"}), "main.run", []string{"cmd/tool.run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.normalizer(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("normalizer(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDWARFResolver_AddNameNormalizer(t *testing.T) {
+	resolver := &DWARFResolver{
+		functions: indexOf(map[string][]string{"real/pkg.Handler": {"id", "name"}}),
+	}
+
+	if _, err := resolver.discoverParameterNames("alias/pkg.Handler", 2); err == nil {
+		t.Fatal("expected lookup to fail without a normalizer")
+	}
+
+	resolver.AddNameNormalizer(func(runtimeName string) []string {
+		return []string{"real/pkg.Handler"}
+	})
+
+	names, err := resolver.discoverParameterNames("alias/pkg.Handler", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"id", "name"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+func TestImportPathRewriter_Resolver(t *testing.T) {
+	resolver := &DWARFResolver{
+		functions:   indexOf(map[string][]string{"github.com/upstream/lib.Handler": {"id", "name"}}),
+		normalizers: defaultNormalizers,
+	}
+	resolver.AddNameNormalizer(ImportPathRewriter(map[string]string{"github.com/me/fork": "github.com/upstream/lib"}))
+
+	for _, name := range []string{"github.com/me/fork.Handler", "myapp/vendor/github.com/me/fork.Handler"} {
+		names, ok := resolver.ParameterNames(name)
+		if !ok || !reflect.DeepEqual(names, []string{"id", "name"}) {
+			t.Errorf("%s: unexpected names %v (found %v)", name, names, ok)
+		}
+	}
+}
+
+func testGeneric[T any](value T, count int) []T {
+	out := make([]T, count)
+	for i := range out {
+		out[i] = value
+	}
+	return out
+}
+
+func TestNewFunction_Generic(t *testing.T) {
+	fn := mustNewFunction(t, testGeneric[string])
+
+	names, _ := fn.GetParameterInfo()
+	if !reflect.DeepEqual(names, []string{"value", "count"}) {
+		t.Errorf("expected [value count], got %v", names)
+	}
+
+	results, err := fn.CallWithMap(map[string]any{"value": "x", "count": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].Interface().([]string); len(got) != 2 {
+		t.Errorf("unexpected result: %v", got)
+	}
+}
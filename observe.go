@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Observer is notified of the calls made through a Function, for metrics and
+// tracing integrations. Methods are called synchronously on the calling
+// goroutine and must be safe for concurrent use.
+type Observer interface {
+	// ObserveCall is called after each invocation of the underlying function
+	// with its duration and error: the call's own error (e.g. ErrCircuitOpen)
+	// or the function's trailing error result. Calls rejected by an
+	// authorizer are not observed.
+	ObserveCall(fn *Function, duration time.Duration, err error)
+
+	// ObserveBindingFailure is called when the arguments of a call could not
+	// be bound to the function's parameters, so the function was not called.
+	ObserveBindingFailure(fn *Function, err error)
+}
+
+// MetaObserver is an Observer also told the CallMeta of each call, e.g. for
+// audit logs recording who called what with which arguments. ObserveCallMeta
+// is called instead of ObserveCall.
+type MetaObserver interface {
+	Observer
+
+	// ObserveCallMeta is like ObserveCall, with the metadata of the call.
+	ObserveCallMeta(meta CallMeta, duration time.Duration, err error)
+}
+
+// WithObserver returns a copy of the Function that notifies observer of
+// every call. Observers are notified in the order they were added.
+//
+// Example:
+//
+//	fn = fn.WithObserver(collector)
+func (t *Function) WithObserver(observer Observer) *Function {
+	clone := *t
+	clone.observers = append(slices.Clip(t.observers), observer)
+	return &clone
+}
+
+// observedCall runs guardedCall, reporting it to the observers and recording
+// failures for DumpState. ctx carries the metadata for MetaObservers.
+func (t *Function) observedCall(ctx context.Context, args []reflect.Value) ([]reflect.Value, error) {
+	if len(t.observers) == 0 {
+		results, err := t.guardedCall(args)
+		if callErr := callError(results, err); callErr != nil {
+			recordError(t, false, callErr)
+		}
+		return results, err
+	}
+
+	var meta *CallMeta
+	for _, observer := range t.observers {
+		if _, ok := observer.(MetaObserver); ok {
+			// Taken before the call, which may modify its arguments
+			m := t.callMeta(ctx, args)
+			meta = &m
+			break
+		}
+	}
+
+	start := time.Now()
+	results, err := t.guardedCall(args)
+	duration := time.Since(start)
+
+	callErr := callError(results, err)
+	if callErr != nil {
+		recordError(t, false, callErr)
+	}
+	for _, observer := range t.observers {
+		if metaObserver, ok := observer.(MetaObserver); ok {
+			metaObserver.ObserveCallMeta(*meta, duration, callErr)
+		} else {
+			observer.ObserveCall(t, duration, callErr)
+		}
+	}
+	return results, err
+}
+
+// bindFailed reports a binding error to the observers, records it for
+// DumpState and returns it.
+func (t *Function) bindFailed(err error) error {
+	recordError(t, true, err)
+	for _, observer := range t.observers {
+		observer.ObserveBindingFailure(t, err)
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	calls    []error
+	failures []error
+}
+
+func (o *recordingObserver) ObserveCall(fn *Function, duration time.Duration, err error) {
+	o.calls = append(o.calls, err)
+}
+
+func (o *recordingObserver) ObserveBindingFailure(fn *Function, err error) {
+	o.failures = append(o.failures, err)
+}
+
+func TestWithObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	fn := mustNewFunction(t, testFunc4).WithObserver(observer)
+
+	fn.CallWithContext(context.Background(), 1, "Alice")
+	fn.CallWithContext(context.Background(), -1, "Alice")
+	fn.CallWithContext(context.Background(), "1", "Alice")
+	fn.CallWithMap(map[string]any{"id": 1})
+	fn.CallWithNonContextStructAndContext(context.Background(), struct{ ID int }{1})
+
+	if len(observer.calls) != 2 || observer.calls[0] != nil || observer.calls[1] == nil {
+		t.Errorf("expected a successful and a failed call, got %v", observer.calls)
+	}
+	if len(observer.failures) != 3 {
+		t.Errorf("expected 3 binding failures, got %v", observer.failures)
+	}
+}
+
+func TestWithObserver_CircuitOpen(t *testing.T) {
+	observer := &recordingObserver{}
+	breaker := NewCircuitBreaker(1, time.Hour)
+	fn := mustNewFunction(t, testFunc4).WithCircuitBreaker(breaker).WithObserver(observer)
+
+	fn.CallWithContext(context.Background(), -1, "Alice")
+	fn.CallWithContext(context.Background(), 1, "Alice")
+
+	if len(observer.calls) != 2 || !errors.Is(observer.calls[1], ErrCircuitOpen) {
+		t.Errorf("expected the rejected call to be observed with ErrCircuitOpen, got %v", observer.calls)
+	}
+}
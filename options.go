@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// HasOptionVariadic reports whether the function ends in the functional
+// options pattern: a variadic parameter of a named func or interface type.
+//
+// Example:
+//
+//	type Option func(*config)
+//	func NewServer(addr string, opts ...Option) *Server
+//	fn.HasOptionVariadic() // true
+func (t *Function) HasOptionVariadic() bool {
+	return t.OptionType() != nil
+}
+
+// OptionType returns the element type of the functional options parameter,
+// e.g. Option for opts ...Option, or nil if HasOptionVariadic is false.
+func (t *Function) OptionType() reflect.Type {
+	if !t.functionType.IsVariadic() {
+		return nil
+	}
+	elem := t.paramTypes[len(t.paramTypes)-1].Elem()
+	if elem.Name() == "" || elem == errorType || (elem.Kind() != reflect.Func && elem.Kind() != reflect.Interface) {
+		return nil
+	}
+	return elem
+}
+
+// WithOptionConstructors returns a copy of the Function that accepts named
+// arguments for its functional options: each key of constructors becomes an
+// argument name whose value is passed to the constructor, and the resulting
+// option is appended to the variadic parameter. Constructors take zero or one
+// argument and return the option type. Zero-argument constructors apply when
+// their argument is true. Options are appended in key order, after any given
+// through the variadic parameter itself.
+//
+// Example:
+//
+//	func NewServer(addr string, opts ...Option) *Server
+//	func WithTimeout(d time.Duration) Option
+//	func WithTLS() Option
+//
+//	fn, err = fn.WithOptionConstructors(map[string]any{"timeout": WithTimeout, "tls": WithTLS})
+//	fn.CallWithMap(map[string]any{"addr": ":8080", "timeout": 5 * time.Second, "tls": true})
+//	// NewServer(":8080", WithTimeout(5*time.Second), WithTLS())
+func (t *Function) WithOptionConstructors(constructors map[string]any) (*Function, error) {
+	optionType := t.OptionType()
+	if optionType == nil {
+		return nil, fmt.Errorf("function %s has no functional options parameter", t.funcName)
+	}
+
+	options := maps.Clone(t.options)
+	if options == nil {
+		options = make(map[string]reflect.Value, len(constructors))
+	}
+	for name, constructor := range constructors {
+		if t.hasParam(name) {
+			return nil, fmt.Errorf("option %q of function %s collides with a parameter name", name, t.funcName)
+		}
+		c := reflect.ValueOf(constructor)
+		if c.Kind() != reflect.Func || c.IsNil() || c.Type().IsVariadic() || c.Type().NumIn() > 1 ||
+			c.Type().NumOut() != 1 || !c.Type().Out(0).AssignableTo(optionType) {
+			return nil, fmt.Errorf("option %q: constructor %T must take at most one argument and return %v",
+				name, constructor, optionType)
+		}
+		options[name] = c
+	}
+
+	clone := *t
+	clone.options = options
+	return &clone, nil
+}
+
+// optionArgType returns the type of the argument of the named constructor:
+// bool for zero-argument constructors.
+func (t *Function) optionArgType(name string) reflect.Type {
+	constructor := t.options[name].Type()
+	if constructor.NumIn() == 0 {
+		return reflect.TypeFor[bool]()
+	}
+	return constructor.In(0)
+}
+
+// isVariadicParam reports whether name is the function's variadic parameter.
+func (t *Function) isVariadicParam(name string) bool {
+	return t.functionType.IsVariadic() && t.paramNames[len(t.paramNames)-1] == name
+}
+
+// bindOptions constructs the functional options named in argMap and appends
+// them to the bound variadic slice.
+func (t *Function) bindOptions(argMap map[string]any, variadic reflect.Value) (reflect.Value, error) {
+	names := make([]string, 0, len(t.options))
+	for name := range t.options {
+		if _, ok := argMap[name]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return variadic, nil
+	}
+	slices.Sort(names)
+
+	// Append to a copy: the caller's slice may have spare capacity
+	variadic = reflect.AppendSlice(reflect.Zero(variadic.Type()), variadic)
+	for _, name := range names {
+		option, apply, err := t.constructOption(name, argMap[name])
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if apply {
+			variadic = reflect.Append(variadic, option)
+		}
+	}
+	return variadic, nil
+}
+
+// constructOption calls the named constructor with value. apply is false for
+// zero-argument constructors given false.
+func (t *Function) constructOption(name string, value any) (option reflect.Value, apply bool, err error) {
+	constructor := t.options[name]
+	if constructor.Type().NumIn() == 0 {
+		enabled, ok := value.(bool)
+		if !ok {
+			return reflect.Value{}, false, fmt.Errorf("option %q: expected bool, got %T", name, value)
+		}
+		if !enabled {
+			return reflect.Value{}, false, nil
+		}
+		return constructor.Call(nil)[0], true, nil
+	}
+
+	in := constructor.Type().In(0)
+	rv := reflect.ValueOf(value)
+	if s, ok := value.(string); ok && !reflect.TypeOf(s).AssignableTo(in) {
+		parsed, found, err := parseString(s, in)
+		if err != nil {
+			return reflect.Value{}, false, fmt.Errorf("option %q: cannot parse %q as %v: %w", name, s, in, err)
+		}
+		if found {
+			rv = parsed
+		}
+	}
+	if !rv.IsValid() {
+		if !canBeNil(in) {
+			return reflect.Value{}, false, fmt.Errorf("option %q: cannot assign nil to %v", name, in)
+		}
+		rv = reflect.Zero(in)
+	}
+	if !rv.Type().AssignableTo(in) {
+		return reflect.Value{}, false, fmt.Errorf("option %q: cannot assign %v to %v", name, rv.Type(), in)
+	}
+	return constructor.Call([]reflect.Value{rv})[0], true, nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type serverConfig struct {
+	timeout time.Duration
+	tls     bool
+	tags    []string
+}
+
+type serverOption func(*serverConfig)
+
+func withServerTimeout(d time.Duration) serverOption {
+	return func(c *serverConfig) { c.timeout = d }
+}
+
+func withServerTLS() serverOption {
+	return func(c *serverConfig) { c.tls = true }
+}
+
+func withServerTag(tag string) serverOption {
+	return func(c *serverConfig) { c.tags = append(c.tags, tag) }
+}
+
+func newTestServer(addr string, opts ...serverOption) string {
+	var config serverConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return fmt.Sprintf("%s timeout=%v tls=%v tags=%v", addr, config.timeout, config.tls, config.tags)
+}
+
+func joinTestValues(sep string, values ...int) string {
+	return fmt.Sprint(values)
+}
+
+func TestHasOptionVariadic(t *testing.T) {
+	server := mustNewFunction(t, newTestServer)
+	if !server.HasOptionVariadic() || server.OptionType() != reflect.TypeFor[serverOption]() {
+		t.Errorf("expected functional options, got %v", server.OptionType())
+	}
+
+	for _, fn := range []any{joinTestValues, testFunc4, fmt.Sprintf} {
+		if mustNewFunction(t, fn).HasOptionVariadic() {
+			t.Errorf("%T must not be detected as functional options", fn)
+		}
+	}
+}
+
+func TestCall_Variadic(t *testing.T) {
+	fn := mustNewFunction(t, newTestServer)
+
+	results, err := fn.Call(":80", []serverOption{withServerTLS()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != ":80 timeout=0s tls=true tags=[]" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+
+	// The variadic parameter is optional when calling with a map
+	results, err = fn.CallWithMap(map[string]any{"addr": ":80"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != ":80 timeout=0s tls=false tags=[]" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
+
+func TestWithOptionConstructors(t *testing.T) {
+	fn, err := mustNewFunction(t, newTestServer).WithOptionConstructors(map[string]any{
+		"timeout": withServerTimeout,
+		"tls":     withServerTLS,
+		"tag":     withServerTag,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	given := make([]serverOption, 1, 4)
+	given[0] = withServerTag("first")
+	results, err := fn.CallWithMap(map[string]any{
+		"addr":    ":8080",
+		"opts":    given,
+		"timeout": 5 * time.Second,
+		"tls":     true,
+		"tag":     "second",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != ":8080 timeout=5s tls=true tags=[first second]" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+	if given[:cap(given)][1] != nil {
+		t.Error("options must not be appended into the caller's slice")
+	}
+
+	results, err = fn.CallWithMap(map[string]any{"addr": ":8080", "tls": false})
+	if err != nil || results[0].String() != ":8080 timeout=0s tls=false tags=[]" {
+		t.Errorf("expected disabled flag option, got %v, %v", results, err)
+	}
+
+	if _, err := fn.CallWithMap(map[string]any{"addr": ":8080", "timeout": "soon"}); err == nil || !strings.Contains(err.Error(), `option "timeout"`) {
+		t.Errorf("expected option type error, got %v", err)
+	}
+	if _, err := fn.CallWithMap(map[string]any{"addr": ":8080", "tls": "yes"}); err == nil {
+		t.Error("expected flag option type error")
+	}
+}
+
+func TestWithOptionConstructors_Binders(t *testing.T) {
+	fn, err := mustNewFunction(t, newTestServer).WithOptionConstructors(map[string]any{
+		"timeout": withServerTimeout,
+		"tls":     withServerTLS,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args, err := fn.Binder(BinderOptions{DisallowUnknown: true}).FromJSON([]byte(`{"addr":":1","timeout":1000,"tls":true}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := args.Invoke(context.Background())
+	if err != nil || results[0].String() != ":1 timeout=1µs tls=true tags=[]" {
+		t.Errorf("unexpected result: %v, %v", results, err)
+	}
+
+	reg := NewRegistry()
+	if _, err := reg.Register("serve", fn); err != nil {
+		t.Fatal(err)
+	}
+	results, err = NewRouter(reg).Dispatch(context.Background(), "serve", []byte(`{"addr":":2","tls":true}`))
+	if err != nil || results[0].String() != ":2 timeout=0s tls=true tags=[]" {
+		t.Errorf("unexpected result: %v, %v", results, err)
+	}
+}
+
+func TestWithOptionConstructors_Errors(t *testing.T) {
+	server := mustNewFunction(t, newTestServer)
+
+	tests := map[string]any{
+		"addr":     withServerTLS,
+		"wrong":    withServerTimeout(time.Second),
+		"results":  func() (serverOption, error) { return nil, nil },
+		"two args": func(a, b int) serverOption { return nil },
+		"type":     func() string { return "" },
+	}
+	for name, constructor := range tests {
+		if _, err := server.WithOptionConstructors(map[string]any{name: constructor}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := mustNewFunction(t, testFunc4).WithOptionConstructors(map[string]any{"tls": withServerTLS}); err == nil {
+		t.Error("expected error for functions without options")
+	}
+}
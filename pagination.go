@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// PaginationOptions configures how paginated functions are recognized and
+// bound. Empty fields take their defaults.
+type PaginationOptions struct {
+	// ItemsResult names the slice result holding the page items.
+	// Default: "items".
+	ItemsResult string
+
+	// CursorResult names the string result holding the cursor of the next
+	// page, empty on the last page. Default: "nextCursor" or "cursor".
+	CursorResult string
+
+	// CursorParam names the parameter receiving the cursor of the page to
+	// return, bound from the standard "cursor" key as well. Default: "cursor".
+	CursorParam string
+
+	// LimitParam names the parameter receiving the maximum number of items
+	// to return, bound from the standard "limit" key as well. Default: "limit".
+	LimitParam string
+}
+
+// Page is the standard envelope of a page of results, as returned by
+// ResultsToPage and emitted by adapters for paginated functions.
+type Page struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+// Paginated returns an Option marking the function as paginated: its results
+// are packed into a Page by ResultsToPage and the adapters, and its cursor and
+// limit parameters also bind from the standard "cursor" and "limit" keys.
+// Functions whose results are named as opts expects are recognized as
+// paginated without it; a function with exactly a slice and a string result,
+// besides a trailing error, is paginated with it whatever the names.
+//
+// Example:
+//
+//	func ListUsers(ctx context.Context, after string, first int) (users []User, next string, err error)
+//	fn = fn.With(dwarfreflect.Paginated(dwarfreflect.PaginationOptions{
+//	    ItemsResult: "users", CursorResult: "next", CursorParam: "after", LimitParam: "first",
+//	}))
+//	results, err := fn.CallWithMap(map[string]any{"ctx": ctx, "cursor": "u42", "limit": 20})
+//	page, err := fn.ResultsToPage(results) // {Items: users, NextCursor: next, HasMore: next != ""}
+func Paginated(opts ...PaginationOptions) Option {
+	return func(t *Function) *Function {
+		var options PaginationOptions
+		if len(opts) > 0 {
+			options = opts[0]
+		}
+
+		clone := *t
+		clone.pagination = &options
+		if _, _, ok := clone.pageResults(); !ok {
+			Logger().Debug("dwarfreflect: results of paginated function do not fit a page",
+				"function", t.funcName, "results", t.resultNames)
+		}
+
+		aliases := make(map[string]string)
+		for key, param := range map[string]string{
+			"cursor": cmp.Or(options.CursorParam, "cursor"),
+			"limit":  cmp.Or(options.LimitParam, "limit"),
+		} {
+			if key != param && t.hasParam(param) && !t.hasParam(key) {
+				aliases[key] = param
+			}
+		}
+		if len(aliases) > 0 {
+			return Aliases(aliases)(&clone)
+		}
+		return &clone
+	}
+}
+
+// IsPaginated reports whether the results of the function are packed into a
+// Page: those of functions configured with Paginated, or named items and
+// nextCursor (or cursor), of slice and string types.
+func (t *Function) IsPaginated() bool {
+	_, _, ok := t.pageResults()
+	return ok
+}
+
+// PageItemsType returns the type of the page items of a paginated function,
+// e.g. []User, or nil if the function is not paginated.
+func (t *Function) PageItemsType() reflect.Type {
+	items, _, ok := t.pageResults()
+	if !ok {
+		return nil
+	}
+	return t.resultType.Field(items).Type
+}
+
+// ResultsToPage packs the results of a paginated function into a Page,
+// returning the trailing error of the function, if any, as ResultsToStruct
+// does.
+//
+// Example:
+//
+//	func ListUsers(cursor string, limit int) (items []User, nextCursor string, err error)
+//	results, _ := fn.CallWithMap(map[string]any{"cursor": "", "limit": 10})
+//	page, err := fn.ResultsToPage(results)
+//	json.NewEncoder(w).Encode(page) // {"items":[...],"nextCursor":"u10","hasMore":true}
+func (t *Function) ResultsToPage(results []reflect.Value) (*Page, error) {
+	items, cursor, ok := t.pageResults()
+	if !ok {
+		return nil, fmt.Errorf("results %v of function %s are not paginated", t.resultNames, t.funcName)
+	}
+
+	page := &Page{
+		Items:      results[items].Interface(),
+		NextCursor: results[cursor].String(),
+	}
+	page.HasMore = page.NextCursor != ""
+	return page, trailingError(results)
+}
+
+// pageResults returns the indexes of the items and cursor results.
+func (t *Function) pageResults() (items, cursor int, ok bool) {
+	var options PaginationOptions
+	if t.pagination != nil {
+		options = *t.pagination
+	}
+
+	fields := t.resultType.NumField()
+	items = slices.Index(t.resultNames[:fields], cmp.Or(options.ItemsResult, "items"))
+	if options.CursorResult != "" {
+		cursor = slices.Index(t.resultNames[:fields], options.CursorResult)
+	} else {
+		cursor = slices.Index(t.resultNames[:fields], "nextCursor")
+		if cursor < 0 {
+			cursor = slices.Index(t.resultNames[:fields], "cursor")
+		}
+	}
+
+	// Configured functions may name their results freely
+	if (items < 0 || cursor < 0) && t.pagination != nil && fields == 2 {
+		items, cursor = 0, 1
+		if t.resultType.Field(0).Type.Kind() == reflect.String {
+			items, cursor = 1, 0
+		}
+	}
+
+	if items < 0 || cursor < 0 {
+		return -1, -1, false
+	}
+	ok = t.resultType.Field(items).Type.Kind() == reflect.Slice &&
+		t.resultType.Field(cursor).Type.Kind() == reflect.String
+	return items, cursor, ok
+}
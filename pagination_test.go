@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var pageLetters = []string{"a", "b", "c", "d", "e"}
+
+func testFuncListLetters(cursor string, limit int) (items []string, nextCursor string, err error) {
+	start := 0
+	if cursor != "" {
+		if start, err = strconv.Atoi(cursor); err != nil {
+			return nil, "", errors.New("invalid cursor")
+		}
+	}
+	end := min(start+limit, len(pageLetters))
+	if end < len(pageLetters) {
+		nextCursor = strconv.Itoa(end)
+	}
+	return pageLetters[start:end], nextCursor, nil
+}
+
+func testFuncListAfter(after string, first int) ([]string, string) {
+	items, next, _ := testFuncListLetters(after, first)
+	return items, next
+}
+
+func TestResultsToPage(t *testing.T) {
+	fn := mustNewFunction(t, testFuncListLetters)
+	if !fn.IsPaginated() {
+		t.Fatal("expected results named items and nextCursor to be recognized")
+	}
+	if got := fn.PageItemsType(); got != reflect.TypeFor[[]string]() {
+		t.Errorf("unexpected items type: %v", got)
+	}
+
+	results, err := fn.CallWithMap(map[string]any{"cursor": "", "limit": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page, err := fn.ResultsToPage(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(page)
+	if got := string(data); got != `{"items":["a","b"],"nextCursor":"2","hasMore":true}` {
+		t.Errorf("unexpected page: %s", got)
+	}
+
+	results, _ = fn.CallWithMap(map[string]any{"cursor": "4", "limit": 2})
+	page, _ = fn.ResultsToPage(results)
+	data, _ = json.Marshal(page)
+	if got := string(data); got != `{"items":["e"],"hasMore":false}` {
+		t.Errorf("unexpected last page: %s", got)
+	}
+
+	results, _ = fn.CallWithMap(map[string]any{"cursor": "x", "limit": 2})
+	if _, err := fn.ResultsToPage(results); err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("expected the function error, got %v", err)
+	}
+}
+
+func TestPaginated(t *testing.T) {
+	fn := mustNewFunction(t, testFuncListAfter)
+	if fn.IsPaginated() {
+		t.Error("expected unnamed results not to be recognized without Paginated")
+	}
+	if _, err := fn.ResultsToPage(nil); err == nil {
+		t.Error("expected an error for a function that is not paginated")
+	}
+
+	fn = fn.With(Paginated(PaginationOptions{CursorParam: "after", LimitParam: "first"}))
+	if !fn.IsPaginated() {
+		t.Fatal("expected a slice and a string result to be paginated once configured")
+	}
+
+	results, err := fn.CallWithMap(map[string]any{"cursor": "1", "limit": 3})
+	if err != nil {
+		t.Fatalf("expected the standard keys to bind, got %v", err)
+	}
+	page, _ := fn.ResultsToPage(results)
+	if !reflect.DeepEqual(page, &Page{Items: []string{"b", "c", "d"}, NextCursor: "4", HasMore: true}) {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// stringParser converts a string argument into a value of the parser's type.
+type stringParser func(s string) (reflect.Value, error)
+
+// Global parser registry used to bind string arguments to non-string parameters
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[reflect.Type]stringParser)
+)
+
+func init() {
+	RegisterParser(func(s string) (net.IP, error) {
+		if ip := net.ParseIP(s); ip != nil {
+			return ip, nil
+		}
+		return nil, fmt.Errorf("invalid IP address %q", s)
+	})
+	RegisterParser(netip.ParseAddr)
+	RegisterParser(url.Parse)
+	RegisterParser(func(s string) (url.URL, error) {
+		u, err := url.Parse(s)
+		if err != nil {
+			return url.URL{}, err
+		}
+		return *u, nil
+	})
+	RegisterParser(regexp.Compile)
+	RegisterParser(time.LoadLocation)
+}
+
+// RegisterParser registers a parser used when a string argument is bound to a
+// parameter of type T, e.g. from query strings or JSON string fields.
+// Built-in parsers cover net.IP, netip.Addr, url.URL, *url.URL, *regexp.Regexp
+// and *time.Location (by zone name). Registering a type again replaces its parser.
+//
+// Example:
+//
+//	dwarfreflect.RegisterParser(uuid.Parse)
+//	fn.CallWithMap(map[string]any{"id": "0b6f2c1e-..."}) // func Get(id uuid.UUID)
+func RegisterParser[T any](parse func(s string) (T, error)) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	parsers[reflect.TypeFor[T]()] = func(s string) (reflect.Value, error) {
+		v, err := parse(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&v).Elem(), nil
+	}
+}
+
+// parseString converts s to target using a registered parser.
+// Reports false if no parser is registered for target.
+func parseString(s string, target reflect.Type) (reflect.Value, bool, error) {
+	parsersMu.RLock()
+	parse, ok := parsers[target]
+	parsersMu.RUnlock()
+
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	v, err := parse(s)
+	return v, true, err
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseString_BuiltIns(t *testing.T) {
+	tests := []struct {
+		input  string
+		target reflect.Type
+		check  func(v any) bool
+	}{
+		{"10.0.0.1", reflect.TypeFor[net.IP](), func(v any) bool { return v.(net.IP).Equal(net.IPv4(10, 0, 0, 1)) }},
+		{"::1", reflect.TypeFor[netip.Addr](), func(v any) bool { return v.(netip.Addr).IsLoopback() }},
+		{"https://example.com/a", reflect.TypeFor[*url.URL](), func(v any) bool { return v.(*url.URL).Host == "example.com" }},
+		{"https://example.com/a", reflect.TypeFor[url.URL](), func(v any) bool { u := v.(url.URL); return u.Path == "/a" }},
+		{"^a+$", reflect.TypeFor[*regexp.Regexp](), func(v any) bool { return v.(*regexp.Regexp).MatchString("aaa") }},
+		{"UTC", reflect.TypeFor[*time.Location](), func(v any) bool { return v.(*time.Location).String() == "UTC" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target.String(), func(t *testing.T) {
+			v, found, err := parseString(tt.input, tt.target)
+			if !found || err != nil {
+				t.Fatalf("expected parser for %v, found=%v err=%v", tt.target, found, err)
+			}
+			if v.Type() != tt.target {
+				t.Fatalf("expected %v, got %v", tt.target, v.Type())
+			}
+			if !tt.check(v.Interface()) {
+				t.Errorf("unexpected parsed value: %v", v.Interface())
+			}
+		})
+	}
+}
+
+func TestParseString_Errors(t *testing.T) {
+	if _, found, _ := parseString("x", reflect.TypeFor[int]()); found {
+		t.Error("expected no parser for int")
+	}
+	if _, _, err := parseString("not-an-ip", reflect.TypeFor[net.IP]()); err == nil {
+		t.Error("expected error for invalid IP")
+	}
+}
+
+type testUpperString string
+
+func testFuncStdlibTypes(addr netip.Addr, pattern *regexp.Regexp, loc *time.Location) string {
+	return fmt.Sprintf("%s %v %s", addr, pattern.MatchString("go"), loc)
+}
+
+func testFuncCustomParsed(value testUpperString) string {
+	return string(value)
+}
+
+func TestCallWithMap_ParsedStrings(t *testing.T) {
+	fn := mustNewFunction(t, testFuncStdlibTypes)
+	results, err := fn.CallWithMap(map[string]any{
+		"addr":    "192.168.1.1",
+		"pattern": "^g",
+		"loc":     "UTC",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "192.168.1.1 true UTC" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+
+	if _, err := fn.CallWithMap(map[string]any{
+		"addr":    "bogus",
+		"pattern": "^g",
+		"loc":     "UTC",
+	}); err == nil || !strings.Contains(err.Error(), `parameter "addr"`) {
+		t.Errorf("expected parse error naming the parameter, got %v", err)
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(func(s string) (testUpperString, error) {
+		return testUpperString(strings.ToUpper(s)), nil
+	})
+
+	fn := mustNewFunction(t, testFuncCustomParsed)
+	results, err := fn.CallWithMap(map[string]any{"value": "shout"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "SHOUT" {
+		t.Errorf("unexpected result: %s", results[0].String())
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package pipeexec forwards calls of wrapped functions to a child process
+// running the same binary, over the child's stdin and stdout. Both processes
+// share the DWARF-derived signature contract, so specific functions can be
+// sandboxed or run with different privileges without per-function protocol
+// code.
+//
+// The child side is the same program: call ServeIfChild early in main, with a
+// registry of the functions the parent may forward.
+//
+// Example:
+//
+//	func main() {
+//	    reg := dwarfreflect.NewRegistry()
+//	    reg.Register("Render", Render) // func Render(ctx context.Context, doc string) (string, error)
+//	    if pipeexec.ServeIfChild(reg) {
+//	        return
+//	    }
+//
+//	    child, err := pipeexec.Spawn(pipeexec.Options{})
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    defer child.Close()
+//	    render, _ := reg.Get("Render")
+//	    render, err = child.Forward("Render", render) // now runs in the child
+//	}
+package pipeexec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"os"
+	"os/exec"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// childEnv marks a process spawned by Spawn.
+const childEnv = "DWARFREFLECT_PIPEEXEC_CHILD"
+
+// serviceName is the RPC service the child serves its registry under.
+const serviceName = "pipeexec"
+
+// Options customizes the child process.
+type Options struct {
+	// Path is the executable to run. Default: os.Executable(), the running
+	// binary, which must contain the ServeIfChild call.
+	Path string
+
+	// Args are the command-line arguments of the child, without the program
+	// name.
+	Args []string
+
+	// Env is the environment of the child. Default: the parent's environment.
+	Env []string
+
+	// Stderr receives the child's standard error and anything it prints to
+	// standard output. Default: the parent's standard error.
+	Stderr io.Writer
+
+	// Configure adjusts the command before it is started, e.g. to set
+	// SysProcAttr credentials, a chroot or a working directory for privilege
+	// separation.
+	Configure func(cmd *exec.Cmd)
+}
+
+// Child is a child process serving forwarded calls. It is safe for concurrent
+// use: calls to forwarded functions are multiplexed over the pipes.
+type Child struct {
+	cmd    *exec.Cmd
+	client *rpc.Client
+}
+
+// IsChild reports whether the process was spawned by Spawn.
+func IsChild() bool {
+	return os.Getenv(childEnv) != ""
+}
+
+// ServeIfChild serves the functions of reg over stdin and stdout when the
+// process was spawned by Spawn, returning true once the parent closes the
+// pipe; the caller should then exit. Otherwise it returns false immediately.
+// While serving, os.Stdout is redirected to os.Stderr so that stray output
+// does not corrupt the protocol.
+func ServeIfChild(reg *dwarfreflect.Registry) bool {
+	if !IsChild() {
+		return false
+	}
+
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	dwarfreflect.NewRPCServer(serviceName, reg).ServeConn(&pipe{Reader: os.Stdin, Writer: stdout, closers: []io.Closer{os.Stdin, stdout}})
+	return true
+}
+
+// Spawn starts a child process, by default the running binary, whose
+// ServeIfChild call serves forwarded functions.
+func Spawn(opts ...Options) (*Child, error) {
+	var options Options
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	path := options.Path
+	if path == "" {
+		var err error
+		if path, err = os.Executable(); err != nil {
+			return nil, fmt.Errorf("pipeexec: cannot locate the running binary: %w", err)
+		}
+	}
+
+	cmd := exec.Command(path, options.Args...)
+	cmd.Env = options.Env
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, childEnv+"=1")
+	cmd.Stderr = options.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if options.Configure != nil {
+		options.Configure(cmd)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("pipeexec: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("pipeexec: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("pipeexec: cannot start %s: %w", path, err)
+	}
+
+	conn := &pipe{Reader: stdout, Writer: stdin, closers: []io.Closer{stdin}}
+	return &Child{cmd: cmd, client: rpc.NewClient(conn)}, nil
+}
+
+// Forward returns a Function with the signature of fn whose calls run fn's
+// counterpart registered under name in the child. fn must return a trailing
+// error, which also reports failures of the child process.
+func (c *Child) Forward(name string, fn *dwarfreflect.Function) (*dwarfreflect.Function, error) {
+	return dwarfreflect.NewRPCStub(c.client, serviceName+"."+name, fn)
+}
+
+// Close closes the child's stdin, so ServeIfChild returns, and waits for the
+// child to exit. Calls still in flight fail.
+func (c *Child) Close() error {
+	closeErr := c.client.Close()
+	if errors.Is(closeErr, rpc.ErrShutdown) {
+		closeErr = nil // the child exited first
+	}
+	return errors.Join(closeErr, c.cmd.Wait())
+}
+
+// pipe joins the two ends of a stdio connection into an io.ReadWriteCloser.
+type pipe struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+}
+
+func (p *pipe) Close() error {
+	var errs []error
+	for _, closer := range p.closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package pipeexec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+)
+
+func pid(ctx context.Context, label string) (string, error) {
+	fmt.Println("stray output must not corrupt the protocol")
+	return fmt.Sprintf("%s:%d", label, os.Getpid()), nil
+}
+
+func fail(code int) error {
+	return fmt.Errorf("failed with %d", code)
+}
+
+func testRegistry() (*dwarfreflect.Registry, error) {
+	reg := dwarfreflect.NewRegistry()
+	if _, err := reg.Register("pid", pid); err != nil {
+		return nil, err
+	}
+	if _, err := reg.Register("fail", fail); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// TestMain runs the child side when the test binary is spawned by Spawn.
+func TestMain(m *testing.M) {
+	if IsChild() {
+		reg, err := testRegistry()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		ServeIfChild(reg)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestForward(t *testing.T) {
+	reg, err := testRegistry()
+	if err != nil {
+		if dwarfreflecttest.IsDWARFError(err) {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	child, err := Spawn()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	local, _ := reg.Get("pid")
+	remote, err := child.Forward("pid", local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := remote.CallWithMap(map[string]any{"ctx": context.Background(), "label": "child"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !results[1].IsNil() {
+		t.Fatalf("unexpected error result: %v", results[1])
+	}
+	if got := results[0].String(); !strings.HasPrefix(got, "child:") || got == fmt.Sprintf("child:%d", os.Getpid()) {
+		t.Errorf("expected the call to run in the child, got %q", got)
+	}
+
+	local, _ = reg.Get("fail")
+	remoteFail, err := child.Forward("fail", local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, _ = remoteFail.Call(3)
+	if err, _ := results[0].Interface().(error); err == nil || err.Error() != "failed with 3" {
+		t.Errorf("expected the child's error, got %v", results[0])
+	}
+
+	if err := child.Close(); err != nil {
+		t.Errorf("unexpected close error: %v", err)
+	}
+	results, _ = remote.Call(context.Background(), "closed")
+	if err, _ := results[1].Interface().(error); err == nil {
+		t.Error("expected calls after Close to fail")
+	}
+}
+
+func TestSpawn_Errors(t *testing.T) {
+	if _, err := Spawn(Options{Path: "/nonexistent/binary"}); err == nil {
+		t.Error("expected start error")
+	}
+	if IsChild() {
+		t.Error("the test process is not a child")
+	}
+}
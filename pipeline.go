@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Pipeline composes the functions of a Registry into a directed acyclic graph
+// whose edges carry named results of one function to named parameters of
+// another, running independent functions in parallel.
+//
+// Nodes are named as in the registry and endpoints as "node:name", e.g.
+// "users.Fetch:items" for the items result of users.Fetch. Build the graph
+// with Add and Connect, then Run it as many times as needed; Run is safe for
+// concurrent use, but not concurrently with Add or Connect.
+//
+// Example:
+//
+//	pipe := dwarfreflect.NewPipeline(reg)
+//	pipe.Connect("users.Fetch:items", "report.Build:users")
+//	pipe.Connect("orders.Fetch:items", "report.Build:orders")
+//	out, err := pipe.Run(ctx, map[string]any{
+//	    "users.Fetch:team":  "sales", // users.Fetch and orders.Fetch run in parallel
+//	    "orders.Fetch:since": since,
+//	})
+//	report := out["report.Build:report"]
+type Pipeline struct {
+	registry *Registry
+	nodes    map[string]*Function
+	order    []string // node names, as added
+	edges    []pipeEdge
+}
+
+// pipeEndpoint is a named result or parameter of a node.
+type pipeEndpoint struct {
+	node, name string
+}
+
+func (e pipeEndpoint) String() string {
+	return e.node + ":" + e.name
+}
+
+// pipeEdge carries the result from to the parameter to.
+type pipeEdge struct {
+	from, to pipeEndpoint
+}
+
+// PipelineError reports the failure of a node of a Pipeline.
+type PipelineError struct {
+	Node string
+	Err  error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("pipeline node %s: %v", e.Node, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// NewPipeline creates an empty Pipeline over the functions of reg.
+func NewPipeline(reg *Registry) *Pipeline {
+	return &Pipeline{registry: reg, nodes: make(map[string]*Function)}
+}
+
+// Add adds the functions registered under names as nodes, for nodes without
+// edges; Connect adds the nodes it connects.
+func (p *Pipeline) Add(names ...string) error {
+	for _, name := range names {
+		if _, err := p.node(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// node returns the function of the named node, adding it if needed.
+func (p *Pipeline) node(name string) (*Function, error) {
+	if fn, ok := p.nodes[name]; ok {
+		return fn, nil
+	}
+	fn, ok := p.registry.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+	p.nodes[name] = fn
+	p.order = append(p.order, name)
+	return fn, nil
+}
+
+// Connect adds an edge from a named result, "node:result", to a named
+// parameter, "node:param", adding their nodes. It fails, leaving the pipeline
+// unchanged, if either is unknown, the result is not assignable to the
+// parameter, the parameter is connected already or the edge would close a
+// cycle.
+func (p *Pipeline) Connect(from, to string) error {
+	source, err := parseEndpoint(from)
+	if err != nil {
+		return err
+	}
+	target, err := parseEndpoint(to)
+	if err != nil {
+		return err
+	}
+	if source.node == target.node || p.reaches(target.node, source.node) {
+		return fmt.Errorf("connecting %s to %s would close a cycle", source, target)
+	}
+	for _, edge := range p.edges {
+		if edge.to == target {
+			return fmt.Errorf("parameter %s is connected to %s already", target, edge.from)
+		}
+	}
+
+	order := len(p.order)
+	fromFn, err := p.node(source.node)
+	if err == nil {
+		var toFn *Function
+		if toFn, err = p.node(target.node); err == nil {
+			err = checkEdge(fromFn, source, toFn, target)
+		}
+	}
+	if err != nil {
+		for _, name := range p.order[order:] {
+			delete(p.nodes, name)
+		}
+		p.order = p.order[:order]
+		return err
+	}
+
+	p.edges = append(p.edges, pipeEdge{from: source, to: target})
+	return nil
+}
+
+// parseEndpoint parses a "node:name" endpoint; node names may contain colons.
+func parseEndpoint(s string) (pipeEndpoint, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i <= 0 || i == len(s)-1 {
+		return pipeEndpoint{}, fmt.Errorf("invalid pipeline endpoint %q: want node:name", s)
+	}
+	return pipeEndpoint{node: s[:i], name: s[i+1:]}, nil
+}
+
+// checkEdge checks that the result source of fromFn may bind the parameter
+// target of toFn.
+func checkEdge(fromFn *Function, source pipeEndpoint, toFn *Function, target pipeEndpoint) error {
+	result := slices.Index(fromFn.resultNames[:fromFn.resultType.NumField()], source.name)
+	if result < 0 {
+		return fmt.Errorf("function %s has no result %q (results %v)", source.node, source.name, fromFn.resultNames[:fromFn.resultType.NumField()])
+	}
+	param := toFn.paramPosition(target.name)
+	if param < 0 {
+		return fmt.Errorf("function %s has no parameter %q (parameters %v)", target.node, target.name, toFn.paramNames)
+	}
+	if from, to := fromFn.functionType.Out(result), toFn.paramTypes[param]; !from.AssignableTo(to) {
+		return fmt.Errorf("cannot connect %s of type %v to %s of type %v", source, from, target, to)
+	}
+	return nil
+}
+
+// reaches reports whether node to is downstream of node from.
+func (p *Pipeline) reaches(from, to string) bool {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == to {
+			return true
+		}
+		for _, edge := range p.edges {
+			if edge.from.node == node && !seen[edge.to.node] {
+				seen[edge.to.node] = true
+				queue = append(queue, edge.to.node)
+			}
+		}
+	}
+	return false
+}
+
+// Run calls every node once its upstream nodes have returned, in parallel
+// where independent, binding each node's parameters from the results carried
+// by its incoming edges and from inputs, keyed "node:param". Functions are
+// called as CallInjected calls them, so context.Context parameters receive
+// ctx. Run returns the results of every node, keyed "node:result", without
+// trailing errors.
+//
+// The first node to fail, with a binding error, its trailing error or a
+// panic, cancels the context of the others and is returned as a
+// *PipelineError; nodes downstream of it are not called.
+func (p *Pipeline) Run(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+	nodeInputs := make(map[string]map[string]any, len(p.nodes))
+	for key, value := range inputs {
+		endpoint, err := parseEndpoint(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.nodes[endpoint.node]; !ok {
+			return nil, fmt.Errorf("input %s: %w: %q", key, ErrUnknownMethod, endpoint.node)
+		}
+		if nodeInputs[endpoint.node] == nil {
+			nodeInputs[endpoint.node] = make(map[string]any)
+		}
+		nodeInputs[endpoint.node][endpoint.name] = value
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		outputs  = make(map[string]any)
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	done := make(map[string]chan struct{}, len(p.nodes))
+	for name := range p.nodes {
+		done[name] = make(chan struct{})
+	}
+
+	for _, name := range p.order {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[name])
+
+			for _, upstream := range p.upstream(name) {
+				select {
+				case <-done[upstream]:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			mu.Lock()
+			if firstErr != nil {
+				mu.Unlock()
+				return
+			}
+			args := make(map[string]any, len(nodeInputs[name]))
+			for param, value := range nodeInputs[name] {
+				args[param] = value
+			}
+			for _, edge := range p.edges {
+				if edge.to.node == name {
+					args[edge.to.name] = outputs[edge.from.String()]
+				}
+			}
+			mu.Unlock()
+
+			fn := p.nodes[name]
+			results, err := callNode(ctx, fn, args)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = &PipelineError{Node: name, Err: err}
+					cancel()
+				}
+				return
+			}
+			for i := range fn.resultType.NumField() {
+				outputs[name+":"+fn.resultNames[i]] = results[i].Interface()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil && len(outputs) < p.resultCount() {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// callNode calls fn for a node of Run, returning its trailing error, or its
+// panic, as the error.
+func callNode(ctx context.Context, fn *Function, args map[string]any) (results []reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	results, err = fn.CallInjected(ctx, args)
+	if err == nil {
+		err = trailingError(results)
+	}
+	return results, err
+}
+
+// upstream returns the nodes the named node has incoming edges from.
+func (p *Pipeline) upstream(name string) []string {
+	var nodes []string
+	for _, edge := range p.edges {
+		if edge.to.node == name && !slices.Contains(nodes, edge.from.node) {
+			nodes = append(nodes, edge.from.node)
+		}
+	}
+	return nodes
+}
+
+// resultCount returns the number of results of a complete run.
+func (p *Pipeline) resultCount() int {
+	count := 0
+	for _, fn := range p.nodes {
+		count += fn.resultType.NumField()
+	}
+	return count
+}
+
+// Nodes returns the node names of the pipeline in sorted order.
+func (p *Pipeline) Nodes() []string {
+	names := slices.Clone(p.order)
+	sort.Strings(names)
+	return names
+}
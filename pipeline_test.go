@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func pipeFetchUsers(ctx context.Context, team string) (users []string, err error) {
+	return []string{team + ":ann", team + ":bob"}, nil
+}
+
+//go:noinline
+func pipeFetchOrders(since int) (orders int, err error) {
+	return since * 2, nil
+}
+
+//go:noinline
+func pipeBuildReport(users []string, orders int) (report string) {
+	return strings.Join(users, ",") + " orders=" + strconv.Itoa(orders)
+}
+
+//go:noinline
+func pipeFail(team string) (users []string, err error) {
+	return nil, errors.New("boom")
+}
+
+//go:noinline
+func pipePanic(team string) (users []string) {
+	panic("kaboom")
+}
+
+func newTestPipeline(t *testing.T) (*Registry, *Pipeline) {
+	t.Helper()
+	reg := NewRegistry()
+	mustRegister(t, reg, "users.Fetch", pipeFetchUsers)
+	mustRegister(t, reg, "orders.Fetch", pipeFetchOrders)
+	mustRegister(t, reg, "report.Build", pipeBuildReport)
+	mustRegister(t, reg, "users.Fail", pipeFail)
+	mustRegister(t, reg, "users.Panic", pipePanic)
+	return reg, NewPipeline(reg)
+}
+
+func TestPipeline_Run(t *testing.T) {
+	_, pipe := newTestPipeline(t)
+	if err := pipe.Connect("users.Fetch:users", "report.Build:users"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pipe.Connect("orders.Fetch:orders", "report.Build:orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := pipe.Run(context.Background(), map[string]any{
+		"users.Fetch:team":   "sales",
+		"orders.Fetch:since": 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["report.Build:report"]; got != "sales:ann,sales:bob orders=6" {
+		t.Errorf("unexpected report %v", got)
+	}
+	if got := out["orders.Fetch:orders"]; got != 6 {
+		t.Errorf("expected intermediate result 6, got %v", got)
+	}
+	if _, ok := out["orders.Fetch:err"]; ok {
+		t.Error("expected trailing errors to be left out")
+	}
+	if got := strings.Join(pipe.Nodes(), " "); got != "orders.Fetch report.Build users.Fetch" {
+		t.Errorf("unexpected nodes %s", got)
+	}
+}
+
+var pipeRunning, pipePeak atomic.Int32
+
+//go:noinline
+func pipeSlow(n int) (out int) {
+	if r := pipeRunning.Add(1); r > pipePeak.Load() {
+		pipePeak.Store(r)
+	}
+	time.Sleep(50 * time.Millisecond)
+	pipeRunning.Add(-1)
+	return n
+}
+
+func TestPipeline_Parallel(t *testing.T) {
+	reg := NewRegistry()
+	fn := mustRegister(t, reg, "a", pipeSlow)
+	if _, err := reg.Register("b", fn); err != nil {
+		t.Fatal(err)
+	}
+	pipe := NewPipeline(reg)
+	if err := pipe.Add("a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := pipe.Run(context.Background(), map[string]any{"a:n": 1, "b:n": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["a:out"] != 1 || out["b:out"] != 2 {
+		t.Errorf("unexpected outputs %v", out)
+	}
+	if pipePeak.Load() != 2 {
+		t.Errorf("expected independent nodes to run in parallel, peak %d", pipePeak.Load())
+	}
+}
+
+func TestPipeline_RunError(t *testing.T) {
+	_, pipe := newTestPipeline(t)
+	if err := pipe.Connect("users.Fail:users", "report.Build:users"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := pipe.Run(context.Background(), map[string]any{"users.Fail:team": "x", "report.Build:orders": 1})
+	var pipeErr *PipelineError
+	if !errors.As(err, &pipeErr) || pipeErr.Node != "users.Fail" || pipeErr.Err.Error() != "boom" {
+		t.Fatalf("expected users.Fail error, got %v", err)
+	}
+	if out != nil {
+		t.Errorf("expected no outputs, got %v", out)
+	}
+
+	_, err = pipe.Run(context.Background(), map[string]any{"users.Fail:team": "x"})
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("expected a node error, got %v", err)
+	}
+
+	if _, err := pipe.Run(context.Background(), map[string]any{"nope:x": 1}); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("expected ErrUnknownMethod for input of unknown node, got %v", err)
+	}
+}
+
+func TestPipeline_RunPanic(t *testing.T) {
+	_, pipe := newTestPipeline(t)
+	if err := pipe.Connect("users.Panic:users", "report.Build:users"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := pipe.Run(context.Background(), map[string]any{"users.Panic:team": "x", "report.Build:orders": 1})
+	var pipeErr *PipelineError
+	if !errors.As(err, &pipeErr) || pipeErr.Node != "users.Panic" || !strings.Contains(pipeErr.Err.Error(), "panic: kaboom") {
+		t.Errorf("expected the panic as the users.Panic error, got %v", err)
+	}
+}
+
+func TestPipeline_ConnectErrors(t *testing.T) {
+	_, pipe := newTestPipeline(t)
+
+	tests := []struct {
+		from, to, want string
+	}{
+		{"users.Fetch", "report.Build:users", "invalid pipeline endpoint"},
+		{"missing:x", "report.Build:users", "unknown method"},
+		{"users.Fetch:nope", "report.Build:users", "has no result"},
+		{"users.Fetch:err", "report.Build:users", "has no result"},
+		{"users.Fetch:users", "report.Build:nope", "has no parameter"},
+		{"users.Fetch:users", "report.Build:orders", "cannot connect"},
+		{"orders.Fetch:orders", "orders.Fetch:since", "cycle"},
+	}
+	for _, tt := range tests {
+		err := pipe.Connect(tt.from, tt.to)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Connect(%s, %s): expected %q error, got %v", tt.from, tt.to, tt.want, err)
+		}
+	}
+	if nodes := pipe.Nodes(); len(nodes) != 0 {
+		t.Errorf("expected failed connections to add no nodes, got %v", nodes)
+	}
+
+	if err := pipe.Connect("users.Fetch:users", "report.Build:users"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pipe.Connect("users.Fail:users", "report.Build:users"); err == nil || !strings.Contains(err.Error(), "connected to users.Fetch:users already") {
+		t.Errorf("expected double connection error, got %v", err)
+	}
+}
+
+func TestPipeline_Cycle(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "a", pipeFetchOrders)
+	mustRegister(t, reg, "b", pipeFetchOrders)
+	pipe := NewPipeline(reg)
+	if err := pipe.Connect("a:orders", "b:since"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pipe.Connect("b:orders", "a:since"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Plan binds named arguments of a known shape to the parameters of a
+// Function, validated and compiled once by Function.Plan, like a prepared
+// statement: each call only converts values along the compiled steps. A Plan
+// is safe for concurrent use.
+type Plan struct {
+	fn    *Function
+	shape map[string]reflect.Type
+	steps []planStep // in parameter order
+}
+
+// planStep binds one parameter.
+type planStep struct {
+	param    string
+	key      string        // input key, empty when the parameter is not in the shape
+	input    reflect.Type  // type of the input value
+	convert  converter     // nil when input is assignable to the parameter
+	fallback reflect.Value // value when the key is absent; invalid when required
+	context  bool          // context.Context parameter outside the shape
+}
+
+// converter converts a value of a planned input type to a parameter type.
+type converter func(v reflect.Value) (reflect.Value, error)
+
+// Plan compiles a Plan binding arguments of the given types, by parameter
+// name or alias, such as the float64, string, bool, []any and
+// map[string]any values of decoded JSON. Keys matching no parameter, types
+// that cannot be converted, unsafe parameters and required parameters
+// missing from the shape are reported here rather than on every call.
+// context.Context parameters outside the shape receive the context of
+// Plan.CallContext.
+//
+// Values are assigned as is when their type allows it. Otherwise numbers
+// convert to other numeric types when exact (3.0 binds to an int, 3.5 does
+// not), strings parse as form values do (see CallWithForm), []any converts
+// element by element, and other
+// values, such as map[string]any to a struct, go through encoding/json.
+//
+// Example:
+//
+//	func Transfer(ctx context.Context, from, to string, amount int) error
+//	plan, err := fn.Plan(map[string]reflect.Type{
+//	    "from": reflect.TypeFor[string](), "to": reflect.TypeFor[string](), "amount": reflect.TypeFor[float64](),
+//	})
+//	// on every request
+//	var body map[string]any
+//	json.NewDecoder(r.Body).Decode(&body)
+//	results, err := plan.CallContext(r.Context(), body)
+func (t *Function) Plan(inputShape map[string]reflect.Type) (*Plan, error) {
+	if len(t.options) > 0 {
+		return nil, fmt.Errorf("cannot plan function %s: functional options are bound by CallWithMap only", t.funcName)
+	}
+
+	keys := make(map[string]string, len(inputShape)) // parameter to key
+	var unknown []string
+	for key := range inputShape {
+		param := key
+		if alias, ok := t.aliases[key]; ok {
+			param = alias
+		}
+		if !t.hasParam(param) {
+			unknown = append(unknown, key)
+			continue
+		}
+		if other, ok := keys[param]; ok {
+			return nil, fmt.Errorf("cannot plan function %s: parameter %q given both as %q and %q", t.funcName, param, other, key)
+		}
+		keys[param] = key
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("cannot plan function %s: unknown parameters %v (expected %v)", t.funcName, unknown, t.paramNames)
+	}
+
+	plan := &Plan{fn: t, shape: inputShape, steps: make([]planStep, len(t.paramNames))}
+	for i, param := range t.paramNames {
+		typ := t.paramTypes[i]
+		step := planStep{param: param, key: keys[param]}
+
+		if step.key != "" {
+			if isUnsafeType(typ) {
+				return nil, &UnsafeParameterError{Function: t.funcName, Param: param, Type: typ}
+			}
+			step.input = inputShape[step.key]
+			if step.input == nil {
+				return nil, fmt.Errorf("cannot plan function %s: nil type for parameter %q", t.funcName, param)
+			}
+			if !step.input.AssignableTo(typ) {
+				convert, err := compileConverter(step.input, typ)
+				if err != nil {
+					return nil, fmt.Errorf("cannot plan function %s: parameter %q: %w", t.funcName, param, err)
+				}
+				step.convert = convert
+			}
+		}
+
+		switch meta := t.paramMeta[param]; {
+		case typ == contextType && step.key == "":
+			step.context = true
+		case meta.Default != nil:
+			if defaultType := reflect.TypeOf(meta.Default); !defaultType.AssignableTo(typ) {
+				return nil, fmt.Errorf("cannot plan function %s: default %v of parameter %q is not assignable to %v",
+					t.funcName, defaultType, param, typ)
+			}
+			step.fallback = reflect.New(typ).Elem()
+			step.fallback.Set(reflect.ValueOf(meta.Default))
+		case t.isOptional(param, typ):
+			step.fallback = reflect.Zero(typ)
+		case step.key == "":
+			return nil, fmt.Errorf("cannot plan function %s: missing required parameter %q", t.funcName, param)
+		}
+		plan.steps[i] = step
+	}
+	return plan, nil
+}
+
+// Call binds values, shaped as the Plan expects, and invokes the function.
+// Keys of the shape may be absent for parameters with a default or that are
+// optional, and nil values bind the zero value of parameters that can be nil.
+// context.Context parameters outside the shape receive context.Background().
+func (p *Plan) Call(values map[string]any) ([]reflect.Value, error) {
+	return p.CallContext(context.Background(), values)
+}
+
+// CallContext is like Call but injects ctx, decorated as by CallWithContext,
+// into context.Context parameters outside the shape. ctx is the context of
+// the call for every function, as with CallWithContext.
+func (p *Plan) CallContext(ctx context.Context, values map[string]any) ([]reflect.Value, error) {
+	t := p.fn
+	frame := t.getFrame()
+	defer t.putFrame(frame)
+
+	args := *frame
+	var ctxValue reflect.Value
+	for i := range p.steps {
+		step := &p.steps[i]
+		if step.context {
+			if !ctxValue.IsValid() {
+				// Decorate once so every context position receives the same context
+				ctx = t.decorateContext(ctx)
+				ctxValue = reflect.ValueOf(&ctx).Elem()
+			}
+			args[i] = ctxValue
+			continue
+		}
+
+		value, present := values[step.key]
+		switch {
+		case step.key == "" || !present:
+			if !step.fallback.IsValid() {
+				return nil, t.bindFailed(fmt.Errorf("missing required parameter %q (function %s)", step.param, t.funcName))
+			}
+			args[i] = step.fallback
+		case value == nil:
+			if !canBeNil(t.paramTypes[i]) {
+				return nil, t.bindFailed(fmt.Errorf("parameter %q: nil for %v", step.param, t.paramTypes[i]))
+			}
+			args[i] = reflect.Zero(t.paramTypes[i])
+		default:
+			rv := reflect.ValueOf(value)
+			if rv.Type() != step.input {
+				return nil, t.bindFailed(fmt.Errorf("parameter %q: got %v, planned for %v", step.param, rv.Type(), step.input))
+			}
+			if step.convert != nil {
+				var err error
+				if rv, err = step.convert(rv); err != nil {
+					return nil, t.bindFailed(fmt.Errorf("parameter %q: %w", step.param, err))
+				}
+			}
+			args[i] = rv
+		}
+	}
+	return t.invokeContext(ctx, args)
+}
+
+// Shape returns the input shape the Plan was compiled for.
+func (p *Plan) Shape() map[string]reflect.Type {
+	return p.shape
+}
+
+// compileConverter returns a converter from values of type from to type to.
+func compileConverter(from, to reflect.Type) (converter, error) {
+	switch {
+	case isNumeric(from) && isNumeric(to):
+		return numberConverter(to), nil
+
+	case from.Kind() == reflect.String:
+		return func(v reflect.Value) (reflect.Value, error) {
+			parsed, err := parseStringValue(v.String(), to)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot parse %q as %v: %w", v.String(), to, err)
+			}
+			return parsed, nil
+		}, nil
+
+	case from.Kind() == to.Kind() && from.ConvertibleTo(to) && from.Kind() != reflect.Slice:
+		return func(v reflect.Value) (reflect.Value, error) {
+			return v.Convert(to), nil
+		}, nil
+
+	case from.Kind() == reflect.Slice && to.Kind() == reflect.Slice && to.Elem().Kind() != reflect.Interface:
+		elem, err := compileElemConverter(from.Elem(), to.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) (reflect.Value, error) {
+			if v.IsNil() {
+				return reflect.Zero(to), nil
+			}
+			out := reflect.MakeSlice(to, v.Len(), v.Len())
+			for i := range v.Len() {
+				item, err := elem(v.Index(i))
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+				}
+				out.Index(i).Set(item)
+			}
+			return out, nil
+		}, nil
+
+	case from.Kind() == reflect.Map || from.Kind() == reflect.Slice || from.Kind() == reflect.Interface:
+		return jsonConverter(to), nil
+	}
+	return nil, fmt.Errorf("cannot bind %v to %v", from, to)
+}
+
+// compileElemConverter converts elements of a slice, which are dynamically
+// typed when from is an interface type such as any in []any.
+func compileElemConverter(from, to reflect.Type) (converter, error) {
+	if from.Kind() != reflect.Interface {
+		if from.AssignableTo(to) {
+			return func(v reflect.Value) (reflect.Value, error) { return v, nil }, nil
+		}
+		return compileConverter(from, to)
+	}
+
+	return func(v reflect.Value) (reflect.Value, error) {
+		if v.IsNil() {
+			if canBeNil(to) {
+				return reflect.Zero(to), nil
+			}
+			return reflect.Value{}, fmt.Errorf("nil for %v", to)
+		}
+		v = v.Elem()
+		if v.Type().AssignableTo(to) {
+			return v, nil
+		}
+		convert, err := compileConverter(v.Type(), to)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return convert(v)
+	}, nil
+}
+
+// numberConverter converts numbers to the numeric type to, failing unless
+// the value is represented exactly.
+func numberConverter(to reflect.Type) converter {
+	return func(v reflect.Value) (reflect.Value, error) {
+		out := reflect.New(to).Elem()
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			switch {
+			case to.Kind() == reflect.Float32 || to.Kind() == reflect.Float64:
+				out.SetFloat(f)
+			case f != math.Trunc(f) || math.IsInf(f, 0):
+				return reflect.Value{}, fmt.Errorf("%v is not an integer", f)
+			case isUnsigned(to):
+				if f < 0 || f >= math.Ldexp(1, to.Bits()) {
+					return reflect.Value{}, fmt.Errorf("%v overflows %v", f, to)
+				}
+				out.SetUint(uint64(f))
+			default:
+				if f < -math.Ldexp(1, to.Bits()-1) || f >= math.Ldexp(1, to.Bits()-1) {
+					return reflect.Value{}, fmt.Errorf("%v overflows %v", f, to)
+				}
+				out.SetInt(int64(f))
+			}
+		default:
+			converted := v.Convert(to)
+			if !converted.Convert(v.Type()).Equal(v) || (v.CanInt() && converted.CanUint() && v.Int() < 0) ||
+				(v.CanUint() && converted.CanInt() && converted.Int() < 0) {
+				return reflect.Value{}, fmt.Errorf("%v overflows %v", v, to)
+			}
+			out.Set(converted)
+		}
+		return out, nil
+	}
+}
+
+// jsonConverter converts values through their JSON encoding.
+func jsonConverter(to reflect.Type) converter {
+	return func(v reflect.Value) (reflect.Value, error) {
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(to)
+		if err := json.Unmarshal(data, out.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot bind %v to %v: %w", v.Type(), to, err)
+		}
+		return out.Elem(), nil
+	}
+}
+
+func isNumeric(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isUnsigned(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
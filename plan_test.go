@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPlanAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+//go:noinline
+func testFuncShip(ctx context.Context, orderID int64, quantity uint8, tags []string, address testPlanAddress, delay time.Duration, note *string) string {
+	_, hasDeadline := ctx.Deadline()
+	return fmt.Sprintf("%d x%d %s %s %v deadline=%v note=%v", orderID, quantity, strings.Join(tags, "+"), address.City, delay, hasDeadline, note != nil)
+}
+
+//go:noinline
+func testFuncPlanGreet(name string, age int) string {
+	return fmt.Sprintf("%s is %d", name, age)
+}
+
+//go:noinline
+func testFuncPlanScoped(tenantID string, status string) string {
+	return tenantID + ":" + status
+}
+
+var jsonShape = map[string]reflect.Type{
+	"orderID":  reflect.TypeFor[float64](),
+	"quantity": reflect.TypeFor[float64](),
+	"tags":     reflect.TypeFor[[]any](),
+	"address":  reflect.TypeFor[map[string]any](),
+	"delay":    reflect.TypeFor[string](),
+}
+
+func decodeJSON(t testing.TB, data string) map[string]any {
+	t.Helper()
+	var values map[string]any
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return values
+}
+
+func TestPlan(t *testing.T) {
+	fn := mustNewFunction(t, testFuncShip)
+	plan, err := fn.Plan(jsonShape)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	values := decodeJSON(t, `{"orderID": 42, "quantity": 3, "tags": ["gift", "fragile"],
+		"address": {"city": "Turin", "zip": "10100"}, "delay": "1h"}`)
+	results, err := plan.CallContext(ctx, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := results[0].String(), "42 x3 gift+fragile Turin 1h0m0s deadline=true note=false"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	tests := map[string]string{
+		`{"orderID": 4.5, "quantity": 1, "tags": [], "address": {}, "delay": "1s"}`:        "4.5 is not an integer",
+		`{"orderID": 1, "quantity": 300, "tags": [], "address": {}, "delay": "1s"}`:        "300 overflows uint8",
+		`{"orderID": 1, "quantity": 1, "tags": [1], "address": {}, "delay": "1s"}`:         "element 0",
+		`{"orderID": 1, "quantity": 1, "tags": [], "address": {}, "delay": "soon"}`:        "cannot parse",
+		`{"orderID": "1", "quantity": 1, "tags": [], "address": {}, "delay": "1s"}`:        "planned for float64",
+		`{"quantity": 1, "tags": [], "address": {}, "delay": "1s"}`:                        "missing required parameter",
+		`{"orderID": 1, "quantity": 1, "tags": null, "address": {}, "delay": "1s"}`:        "",
+		`{"orderID": 1, "quantity": 1, "tags": [], "address": {"city": 1}, "delay": "1s"}`: "cannot bind",
+	}
+	for input, want := range tests {
+		_, err := plan.Call(decodeJSON(t, input))
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", input, err)
+		case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+			t.Errorf("%s: got error %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestPlan_Invalid(t *testing.T) {
+	fn := mustNewFunction(t, testFuncShip)
+	tests := map[string]map[string]reflect.Type{
+		"unknown parameters [weight]":           {"orderID": reflect.TypeFor[float64](), "weight": reflect.TypeFor[float64]()},
+		`missing required parameter "quantity"`: {"orderID": reflect.TypeFor[float64]()},
+		`parameter "orderID": cannot bind bool to int64`: {
+			"orderID": reflect.TypeFor[bool](), "quantity": reflect.TypeFor[float64](), "tags": reflect.TypeFor[[]any](),
+			"address": reflect.TypeFor[map[string]any](), "delay": reflect.TypeFor[string](),
+		},
+	}
+	for want, shape := range tests {
+		if _, err := fn.Plan(shape); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("got error %v, want %q", err, want)
+		}
+	}
+}
+
+func TestPlan_Context(t *testing.T) {
+	fn := mustNewFunction(t, testFuncContextValues).
+		WithContextDecorator(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, testCtxKey("tenant"), "acme")
+		}).
+		WithContextDecorator(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, testCtxKey("request"), "req-1")
+		})
+	plan, err := fn.Plan(map[string]reflect.Type{"name": reflect.TypeFor[string]()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := plan.CallContext(context.Background(), map[string]any{"name": "x"})
+	if err != nil || results[0].String() != "acme/req-1/x" {
+		t.Errorf("expected the decorated context, got %v, %v", results, err)
+	}
+
+	// Functions without a context parameter still get the scope of ctx
+	scoped := mustNewFunction(t, testFuncPlanScoped).WithScopedParams("tenantID")
+	plan, err = scoped.Plan(map[string]reflect.Type{"status": reflect.TypeFor[string]()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := WithScopeValues(context.Background(), map[string]any{"tenantID": "acme"})
+	results, err = plan.CallContext(ctx, map[string]any{"status": "open"})
+	if err != nil || results[0].String() != "acme:open" {
+		t.Errorf("expected the scoped tenant, got %v, %v", results, err)
+	}
+	if _, err := plan.Call(map[string]any{"status": "open"}); !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected ErrScopedParameter without a scope, got %v", err)
+	}
+}
+
+func BenchmarkPlanCall(b *testing.B) {
+	fn, err := NewFunction(testFuncPlanGreet)
+	if err != nil {
+		b.Skip(err)
+	}
+	plan, err := fn.Plan(map[string]reflect.Type{"name": reflect.TypeFor[string](), "age": reflect.TypeFor[float64]()})
+	if err != nil {
+		b.Fatal(err)
+	}
+	values := map[string]any{"name": "alice", "age": 30.0}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := plan.Call(values); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Policy configures timeout and retry behavior applied by every Call variant.
+type Policy struct {
+	// Timeout bounds each attempt. It is enforced through the context passed to
+	// context.Context parameters, so it only affects functions that accept and
+	// honor a context.
+	Timeout time.Duration
+
+	// Retries is the number of additional attempts made after the first one
+	// when the function's trailing error result is non-nil.
+	Retries int
+
+	// Backoff returns the delay before the given retry (starting at 1).
+	// A nil Backoff retries immediately.
+	Backoff func(retry int) time.Duration
+
+	// RetryIf reports whether an error is worth retrying.
+	// A nil RetryIf retries every error.
+	RetryIf func(err error) bool
+}
+
+// ExponentialBackoff returns a Backoff doubling base on each retry, capped at max.
+//
+// Example:
+//
+//	policy := dwarfreflect.Policy{Retries: 3, Backoff: dwarfreflect.ExponentialBackoff(100*time.Millisecond, time.Second)}
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		delay := base
+		for i := 1; i < retry && delay < max; i++ {
+			delay *= 2
+		}
+		return min(delay, max)
+	}
+}
+
+// WithPolicy returns a copy of the Function that applies policy on every call.
+// Retries require the function to return a trailing error; the results of the
+// last attempt are returned. Waiting between retries stops early when the
+// caller's context is done.
+//
+// Example:
+//
+//	resilient := fn.WithPolicy(dwarfreflect.Policy{
+//	    Timeout: 2 * time.Second,
+//	    Retries: 3,
+//	    RetryIf: func(err error) bool { return errors.Is(err, ErrUnavailable) },
+//	})
+func (t *Function) WithPolicy(policy Policy) *Function {
+	clone := *t
+	clone.policy = &policy
+	return &clone
+}
+
+// GetPolicy returns the Policy set with WithPolicy, if any.
+func (t *Function) GetPolicy() (Policy, bool) {
+	if t.policy == nil {
+		return Policy{}, false
+	}
+	return *t.policy, true
+}
+
+// callWithPolicy calls the underlying function with fully prepared arguments,
+// applying the Function's timeout and retry policy.
+func (t *Function) callWithPolicy(args []reflect.Value) []reflect.Value {
+	if t.policy == nil {
+		return t.call(args)
+	}
+
+	contextPositions := t.GetContextPositions()
+	parent := t.argContext(args)
+
+	for attempt := 0; ; attempt++ {
+		results := t.attempt(parent, args, contextPositions)
+
+		err := trailingError(results)
+		if err == nil || attempt >= t.policy.Retries {
+			return results
+		}
+		if t.policy.RetryIf != nil && !t.policy.RetryIf(err) {
+			return results
+		}
+
+		if t.policy.Backoff != nil {
+			timer := time.NewTimer(t.policy.Backoff(attempt + 1))
+			select {
+			case <-timer.C:
+			case <-parent.Done():
+				timer.Stop()
+				return results
+			}
+		} else if parent.Err() != nil {
+			return results
+		}
+	}
+}
+
+// attempt performs a single call, deriving a per-attempt timeout context when
+// the policy sets one.
+func (t *Function) attempt(parent context.Context, args []reflect.Value, contextPositions []int) []reflect.Value {
+	if t.policy.Timeout <= 0 || len(contextPositions) == 0 {
+		return t.call(args)
+	}
+
+	ctx, cancel := context.WithTimeout(parent, t.policy.Timeout)
+	defer cancel()
+
+	attemptArgs := make([]reflect.Value, len(args))
+	copy(attemptArgs, args)
+	for _, pos := range contextPositions {
+		attemptArgs[pos] = reflect.ValueOf(ctx)
+	}
+	return t.call(attemptArgs)
+}
+
+// trailingError returns the non-nil error in the last result, if any.
+func trailingError(results []reflect.Value) error {
+	if len(results) == 0 {
+		return nil
+	}
+	last := results[len(results)-1]
+	if last.Type() != errorType || last.IsNil() {
+		return nil
+	}
+	return last.Interface().(error)
+}
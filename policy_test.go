@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+type flaky struct {
+	calls    int
+	failures int
+	err      error
+}
+
+func (f *flaky) Fetch(id int) (string, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return "", f.err
+	}
+	return "ok", nil
+}
+
+func testFuncSlow(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestWithPolicy_Retries(t *testing.T) {
+	f := &flaky{failures: 2, err: errTransient}
+	fn := mustNewFunction(t, f.Fetch).WithPolicy(Policy{Retries: 3})
+
+	results, err := fn.CallWithMap(map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].String() != "ok" || !results[1].IsNil() {
+		t.Errorf("expected success after retries, got %v, %v", results[0], results[1])
+	}
+	if f.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", f.calls)
+	}
+}
+
+func TestWithPolicy_RetriesExhausted(t *testing.T) {
+	f := &flaky{failures: 10, err: errTransient}
+	fn := mustNewFunction(t, f.Fetch).WithPolicy(Policy{
+		Retries: 2,
+		Backoff: ExponentialBackoff(time.Millisecond, 2*time.Millisecond),
+	})
+
+	results, _ := fn.Call(1)
+	if results[1].IsNil() {
+		t.Error("expected error after retries are exhausted")
+	}
+	if f.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", f.calls)
+	}
+}
+
+func TestWithPolicy_RetryIf(t *testing.T) {
+	f := &flaky{failures: 10, err: errors.New("permanent")}
+	fn := mustNewFunction(t, f.Fetch).WithPolicy(Policy{
+		Retries: 5,
+		RetryIf: func(err error) bool { return errors.Is(err, errTransient) },
+	})
+
+	fn.Call(1)
+	if f.calls != 1 {
+		t.Errorf("expected non-matching error not to be retried, got %d calls", f.calls)
+	}
+}
+
+func TestWithPolicy_Timeout(t *testing.T) {
+	fn := mustNewFunction(t, testFuncSlow).WithPolicy(Policy{Timeout: 10 * time.Millisecond})
+
+	results, err := fn.CallWithContext(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if callErr, _ := results[0].Interface().(error); !errors.Is(callErr, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", callErr)
+	}
+
+	results, _ = fn.CallWithContext(context.Background(), time.Duration(0))
+	if !results[0].IsNil() {
+		t.Errorf("expected fast call to succeed, got %v", results[0])
+	}
+}
+
+func TestWithPolicy_DoesNotMutateOriginal(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	if _, ok := fn.WithPolicy(Policy{Retries: 1}).GetPolicy(); !ok {
+		t.Error("expected policy on copy")
+	}
+	if _, ok := fn.GetPolicy(); ok {
+		t.Error("expected original to have no policy")
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	expected := []time.Duration{10, 20, 40, 50, 50}
+	for i, want := range expected {
+		if got := backoff(i + 1); got != want*time.Millisecond {
+			t.Errorf("retry %d: expected %v, got %v", i+1, want*time.Millisecond, got)
+		}
+	}
+}
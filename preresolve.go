@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"debug/dwarf"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// PreResolve resolves the parameter names of fns, typically every handler of
+// a service, at startup: failures are reported all at once, joined, instead
+// of surfacing one by one as requests reach NewFunction, and later
+// NewFunction calls for fns are served from the cache. Functions whose
+// runtime name matches no DWARF entry are looked up by code address, in the
+// DWARF compile unit whose PC ranges hold it.
+//
+// Example:
+//
+//	if err := dwarfreflect.PreResolve(CreateUser, DeleteUser, svc.Rename); err != nil {
+//	    log.Fatal(err) // lists every function without names
+//	}
+func PreResolve(fns ...any) error {
+	resolverOnce.Do(initResolver)
+	if resolverInitErr != nil && !hasSourceNames() {
+		return resolverInitErr
+	}
+
+	var errs []error
+	for i, fn := range fns {
+		fnValue := reflect.ValueOf(fn)
+		if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+			errs = append(errs, fmt.Errorf("argument %d: %T is not a function", i, fn))
+			continue
+		}
+		if _, err := lookupFunctionInfo(fnValue.Pointer(), fnValue.Type()); err != nil {
+			funcName := runtime.FuncForPC(fnValue.Pointer()).Name()
+			errs = append(errs, fmt.Errorf("%s: %w", funcName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookupPC returns the DWARF name and formal parameter names of the function
+// whose code starts at the run-time address pc.
+func (dr *DWARFResolver) lookupPC(pc uintptr) (string, []string, bool) {
+	if dr.dwarfData == nil {
+		return "", nil, false
+	}
+	offset, err := dr.loadOffset()
+	if err != nil {
+		Logger().Debug("dwarfreflect: no lookup by address", "error", err)
+		return "", nil, false
+	}
+	reader, entry, ok := dr.subprogramAt(uint64(pc-offset), true)
+	if !ok {
+		return "", nil, false
+	}
+	funcName, _ := entryName(entry)
+	var params []string
+	if entry.Children {
+		params, _ = dr.extractParametersFromDWARF(reader)
+	}
+	return funcName, params, true
+}
+
+// subprogramAt returns the subprogram entry whose code holds the DWARF
+// address pc, or starts at it when entry is set, with a reader positioned on
+// its children. Only the compile unit whose ranges hold pc is read.
+// Out-of-line copies of inlined functions are replaced by their abstract
+// entry, which has the names and types.
+func (dr *DWARFResolver) subprogramAt(pc uint64, entry bool) (*dwarf.Reader, *dwarf.Entry, bool) {
+	reader := dr.dwarfData.Reader()
+	if _, err := reader.SeekPC(pc); err != nil {
+		return nil, nil, false
+	}
+	for {
+		e, err := reader.Next()
+		if err != nil || e == nil || e.Tag == 0 {
+			return nil, nil, false
+		}
+		if e.Tag != dwarf.TagSubprogram || !dr.holdsPC(e, pc, entry) {
+			if e.Children {
+				reader.SkipChildren()
+			}
+			continue
+		}
+
+		if origin, ok := e.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset); ok {
+			reader.Seek(origin)
+			if e, err = reader.Next(); err != nil || e == nil {
+				return nil, nil, false
+			}
+		}
+		if _, ok := entryName(e); !ok {
+			return nil, nil, false
+		}
+		return reader, e, true
+	}
+}
+
+// holdsPC reports whether the code of the subprogram e starts at pc, or holds
+// it when entry is false.
+func (dr *DWARFResolver) holdsPC(e *dwarf.Entry, pc uint64, entry bool) bool {
+	if entry {
+		low, ok := e.Val(dwarf.AttrLowpc).(uint64)
+		return ok && low == pc
+	}
+	ranges, err := dr.dwarfData.Ranges(e)
+	if err != nil {
+		return false
+	}
+	for _, r := range ranges {
+		if r[0] <= pc && pc < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// loadOffset returns the difference between the run-time and DWARF addresses
+// of code, which is non-zero for position-independent executables. It is
+// computed once, from the address of loadAnchor.
+func (dr *DWARFResolver) loadOffset() (uintptr, error) {
+	dr.offset.once.Do(func() {
+		// Fast path: the binary is loaded at its link address
+		reader := dr.dwarfData.Reader()
+		if _, err := reader.SeekPC(uint64(anchorPC)); err == nil {
+			for {
+				entry, err := reader.Next()
+				if err != nil || entry == nil || entry.Tag == 0 {
+					break
+				}
+				if name, _ := entryName(entry); name == anchorName && entry.Tag == dwarf.TagSubprogram {
+					if low, _ := entry.Val(dwarf.AttrLowpc).(uint64); low == uint64(anchorPC) {
+						return
+					}
+				}
+				if entry.Children {
+					reader.SkipChildren()
+				}
+			}
+		}
+
+		lowPCs, err := dr.lowPCs(anchorName)
+		if err != nil {
+			dr.offset.err = err
+			return
+		}
+		lowPC, ok := lowPCs[anchorName]
+		if !ok {
+			dr.offset.err = fmt.Errorf("cannot compute the load offset of %s: no DWARF entry for %s", dr.source(), anchorName)
+			return
+		}
+		dr.offset.value = anchorPC - uintptr(lowPC)
+	})
+	return dr.offset.value, dr.offset.err
+}
+
+// lowPCs returns the DWARF low PCs of the named subprograms that have one.
+func (dr *DWARFResolver) lowPCs(funcNames ...string) (map[string]uint64, error) {
+	lowPCs := make(map[string]uint64, len(funcNames))
+	reader := dr.dwarfData.Reader()
+	for {
+		entry, err := reader.Next()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read DWARF of %s: %w", dr.source(), err)
+		}
+		if entry == nil {
+			return lowPCs, nil
+		}
+		if entry.Tag != dwarf.TagSubprogram {
+			continue
+		}
+		if name, ok := entryName(entry); ok {
+			if lowPC, ok := entry.Val(dwarf.AttrLowpc).(uint64); ok {
+				for _, funcName := range funcNames {
+					if name == funcName {
+						lowPCs[name] = lowPC
+					}
+				}
+			}
+		}
+		if entry.Children {
+			reader.SkipChildren()
+		}
+	}
+}
+
+// loadOffsetState caches the result of DWARFResolver.loadOffset.
+type loadOffsetState struct {
+	once  sync.Once
+	value uintptr
+	err   error
+}
+
+// loadAnchor is a function of known run-time address, to compute the load
+// offset of the binary.
+//
+//go:noinline
+func loadAnchor() {}
+
+var (
+	anchorPC   = reflect.ValueOf(loadAnchor).Pointer()
+	anchorName = runtime.FuncForPC(anchorPC).Name()
+)
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func preResolveTestHandler(accountID int, verbose bool) (summary string, err error) {
+	if verbose {
+		return strings.Repeat("account ", accountID), nil
+	}
+	return "", nil
+}
+
+func TestPreResolve(t *testing.T) {
+	if err := PreResolve(testFunc1, testFunc2, preResolveTestHandler); err != nil {
+		if resolverInitErr != nil {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var nilFunc func()
+	err := PreResolve(testFunc1, 42, nilFunc)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{"argument 1: int is not a function", "argument 2: func() is not a function"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestDWARFResolver_lookupPC(t *testing.T) {
+	initResolver()
+	if resolverInitErr != nil {
+		t.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+	preResolveTestHandler(1, true)
+
+	funcName, params, ok := globalResolver.lookupPC(reflect.ValueOf(preResolveTestHandler).Pointer())
+	if !ok || funcName != "github.com/matteo-grella/dwarfreflect.preResolveTestHandler" ||
+		!reflect.DeepEqual(params, []string{"accountID", "verbose", "summary", "err"}) {
+		t.Errorf("unexpected entry %q %v (%v)", funcName, params, ok)
+	}
+
+	if _, _, ok := globalResolver.lookupPC(reflect.ValueOf(preResolveTestHandler).Pointer() + 1); ok {
+		t.Error("expected no entry for an address inside the function")
+	}
+	if _, _, ok := (&DWARFResolver{}).lookupPC(anchorPC); ok {
+		t.Error("expected no entry without DWARF data")
+	}
+}
+
+func TestNewFunction_PCMatch(t *testing.T) {
+	initResolver()
+	if resolverInitErr != nil {
+		t.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+
+	// Hide every DWARF name, as if the runtime name matched none of them
+	functions := globalResolver.functions
+	globalResolver.functions = functionIndex{}
+	t.Cleanup(func() { globalResolver.functions = functions })
+
+	fnValue := reflect.ValueOf(preResolveTestHandler)
+	info, err := newFunctionInfo(fnValue.Pointer(), fnValue.Type())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(info.paramNames, []string{"accountID", "verbose"}) || !reflect.DeepEqual(info.resultNames, []string{"summary", "err"}) {
+		t.Errorf("unexpected names %v %v", info.paramNames, info.resultNames)
+	}
+	if info.provenance.Source != SourcePCMatch || info.provenance.String() != "address match (github.com/matteo-grella/dwarfreflect.preResolveTestHandler)" {
+		t.Errorf("unexpected provenance %v", info.provenance)
+	}
+}
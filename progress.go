@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ProgressReporter receives the incremental updates of a long-running
+// function. Functions declare a parameter of this type, or of a func(T) type
+// with no results, to report progress through adapters; see WithProgress.
+type ProgressReporter interface {
+	Report(progress any)
+}
+
+// ProgressFunc adapts an ordinary function to a ProgressReporter.
+type ProgressFunc func(progress any)
+
+// Report calls f(progress).
+func (f ProgressFunc) Report(progress any) {
+	f(progress)
+}
+
+var progressReporterType = reflect.TypeFor[ProgressReporter]()
+
+// ProgressParameters returns the names of the parameters through which the
+// function reports progress: those of type ProgressReporter, and those of a
+// func(T) type with no results.
+//
+// Example:
+//
+//	func Export(rows int, progress func(percent int)) (path string, err error)
+//	fn.ProgressParameters() // ["progress"]
+func (t *Function) ProgressParameters() []string {
+	var names []string
+	for i, typ := range t.paramTypes {
+		if isProgressType(typ) && !(t.functionType.IsVariadic() && i == len(t.paramTypes)-1) {
+			names = append(names, t.paramNames[i])
+		}
+	}
+	return names
+}
+
+func isProgressType(typ reflect.Type) bool {
+	return typ == progressReporterType ||
+		(typ.Kind() == reflect.Func && typ.NumIn() == 1 && typ.NumOut() == 0 && !typ.IsVariadic())
+}
+
+// WithProgress returns a copy of the Function whose progress parameters (see
+// ProgressParameters) bind to reporter when not supplied, keeping their other
+// metadata: ProgressReporter parameters receive reporter itself, func(T)
+// parameters a function passing each T to it. Adapters clone the Function
+// this way for every call to forward its updates.
+//
+// Example:
+//
+//	func Export(rows int, progress func(percent int)) (path string, err error)
+//	fn = fn.WithProgress(dwarfreflect.ProgressFunc(func(p any) { log.Printf("%v%%", p) }))
+//	results, err := fn.CallWithMap(map[string]any{"rows": 1000})
+func (t *Function) WithProgress(reporter ProgressReporter) *Function {
+	names := t.ProgressParameters()
+	if len(names) == 0 {
+		return t
+	}
+
+	clone := *t
+	clone.paramMeta = maps.Clone(t.paramMeta)
+	if clone.paramMeta == nil {
+		clone.paramMeta = make(map[string]ParamMeta)
+	}
+	for i, name := range t.paramNames {
+		if !slices.Contains(names, name) {
+			continue
+		}
+		meta := clone.paramMeta[name]
+		if t.paramTypes[i] == progressReporterType {
+			meta.Default = reporter
+		} else {
+			meta.Default = reflect.MakeFunc(t.paramTypes[i], func(args []reflect.Value) []reflect.Value {
+				reporter.Report(args[0].Interface())
+				return nil
+			}).Interface()
+		}
+		clone.paramMeta[name] = meta
+	}
+	return &clone
+}
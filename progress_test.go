@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"slices"
+	"testing"
+)
+
+//go:noinline
+func testFuncExport(rows int, progress func(percent int)) (path string) {
+	for done := 1; done <= rows; done++ {
+		progress(done * 100 / rows)
+	}
+	return "export.csv"
+}
+
+//go:noinline
+func testFuncReindex(reporter ProgressReporter, shards ...string) int {
+	for _, shard := range shards {
+		reporter.Report(shard)
+	}
+	return len(shards)
+}
+
+func TestWithProgress(t *testing.T) {
+	fn := mustNewFunction(t, testFuncExport)
+	if got := fn.ProgressParameters(); !slices.Equal(got, []string{"progress"}) {
+		t.Errorf("unexpected progress parameters: %v", got)
+	}
+
+	var updates []any
+	withProgress := fn.WithProgress(ProgressFunc(func(progress any) { updates = append(updates, progress) }))
+	results, err := withProgress.CallWithMap(map[string]any{"rows": 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "export.csv" {
+		t.Errorf("unexpected result: %s", got)
+	}
+	if !slices.Equal(updates, []any{25, 50, 75, 100}) {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+
+	if _, err := fn.CallWithMap(map[string]any{"rows": 4}); err == nil {
+		t.Error("expected the progress parameter to stay required without WithProgress")
+	}
+	if _, ok := fn.GetParamMeta("progress"); ok {
+		t.Error("expected WithProgress not to modify the original Function")
+	}
+}
+
+func TestWithProgress_Reporter(t *testing.T) {
+	fn := mustNewFunction(t, testFuncReindex)
+	if got := fn.ProgressParameters(); !slices.Equal(got, []string{"reporter"}) {
+		t.Errorf("unexpected progress parameters: %v", got)
+	}
+
+	var updates []any
+	fn = fn.WithProgress(ProgressFunc(func(progress any) { updates = append(updates, progress) }))
+	if _, err := fn.CallWithMap(map[string]any{"shards": []string{"a", "b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(updates, []any{"a", "b"}) {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+
+	explicit := 0
+	reporter := ProgressFunc(func(any) { explicit++ })
+	if _, err := fn.CallWithMap(map[string]any{"reporter": reporter, "shards": []string{"c"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if explicit != 1 || len(updates) != 2 {
+		t.Errorf("expected a supplied reporter to win, got %d explicit and %v", explicit, updates)
+	}
+}
+
+func TestWithProgress_None(t *testing.T) {
+	fn := mustNewFunction(t, testFuncListLetters)
+	if fn.ProgressParameters() != nil || fn.WithProgress(ProgressFunc(func(any) {})) != fn {
+		t.Error("expected functions without progress parameters to be left as they are")
+	}
+}
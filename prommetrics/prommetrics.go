@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package prommetrics exports per-function Prometheus metrics for wrapped
+// functions: calls, errors, call duration and binding failures, labeled by
+// the function's base name.
+//
+// Example:
+//
+//	collector, err := prommetrics.New(prometheus.DefaultRegisterer)
+//	fn = collector.Instrument(fn)
+//	reg.Register("CreateUser", fn)
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options customizes the exported metrics.
+type Options struct {
+	// Namespace prefixes every metric name. Default: "dwarfreflect".
+	Namespace string
+
+	// Buckets are the call duration histogram buckets, in seconds.
+	// Default: prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector records the metrics of instrumented functions. It implements
+// dwarfreflect.Observer and is safe for concurrent use.
+type Collector struct {
+	calls           *prometheus.CounterVec
+	errors          *prometheus.CounterVec
+	duration        *prometheus.HistogramVec
+	bindingFailures *prometheus.CounterVec
+}
+
+// New creates a Collector and registers its metrics with registerer:
+//
+//	<namespace>_calls_total{function}
+//	<namespace>_errors_total{function}
+//	<namespace>_call_duration_seconds{function}
+//	<namespace>_binding_failures_total{function}
+func New(registerer prometheus.Registerer, opts ...Options) (*Collector, error) {
+	var options Options
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Namespace == "" {
+		options.Namespace = "dwarfreflect"
+	}
+	if options.Buckets == nil {
+		options.Buckets = prometheus.DefBuckets
+	}
+
+	labels := []string{"function"}
+	c := &Collector{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Name:      "calls_total",
+			Help:      "Number of calls of the function.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Name:      "errors_total",
+			Help:      "Number of calls of the function returning an error.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: options.Namespace,
+			Name:      "call_duration_seconds",
+			Help:      "Duration of calls of the function.",
+			Buckets:   options.Buckets,
+		}, labels),
+		bindingFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Name:      "binding_failures_total",
+			Help:      "Number of calls whose arguments could not be bound to the function's parameters.",
+		}, labels),
+	}
+
+	for _, collector := range []prometheus.Collector{c.calls, c.errors, c.duration, c.bindingFailures} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Instrument returns a copy of fn whose calls are recorded by the Collector.
+func (c *Collector) Instrument(fn *dwarfreflect.Function) *dwarfreflect.Function {
+	return fn.WithObserver(c)
+}
+
+// ObserveCall implements dwarfreflect.Observer.
+func (c *Collector) ObserveCall(fn *dwarfreflect.Function, duration time.Duration, err error) {
+	name := fn.GetBaseFunctionName()
+	c.calls.WithLabelValues(name).Inc()
+	c.duration.WithLabelValues(name).Observe(duration.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(name).Inc()
+	}
+}
+
+// ObserveBindingFailure implements dwarfreflect.Observer.
+func (c *Collector) ObserveBindingFailure(fn *dwarfreflect.Function, err error) {
+	c.bindingFailures.WithLabelValues(fn.GetBaseFunctionName()).Inc()
+}
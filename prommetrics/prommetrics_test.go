@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package prommetrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func TestCollector(t *testing.T) {
+	fn := dwarfreflecttest.NewFunction(t, divide)
+
+	registry := prometheus.NewRegistry()
+	collector, err := New(registry, Options{Namespace: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fn = collector.Instrument(fn)
+
+	fn.CallWithMap(map[string]any{"a": 6, "b": 3})
+	fn.CallWithMap(map[string]any{"a": 6, "b": 0})
+	fn.CallWithMap(map[string]any{"a": 6})
+	fn.Call("6", 3)
+
+	if got := testutil.ToFloat64(collector.calls.WithLabelValues("divide")); got != 2 {
+		t.Errorf("expected 2 calls, got %v", got)
+	}
+	if got := testutil.ToFloat64(collector.errors.WithLabelValues("divide")); got != 1 {
+		t.Errorf("expected 1 error, got %v", got)
+	}
+	if got := testutil.ToFloat64(collector.bindingFailures.WithLabelValues("divide")); got != 2 {
+		t.Errorf("expected 2 binding failures, got %v", got)
+	}
+	if got := testutil.CollectAndCount(collector.duration); got != 1 {
+		t.Errorf("expected one duration series, got %d", got)
+	}
+
+	if _, err := New(registry, Options{Namespace: "test"}); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+}
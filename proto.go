@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// protoField is a field of a generated protobuf message struct.
+type protoField struct {
+	name     string // Proto field name, e.g. "user_id"
+	jsonName string // JSON name, e.g. "userId"
+	value    reflect.Value
+}
+
+// CallWithProto invokes the function with the fields of a generated protobuf
+// message, so gRPC handlers can delegate to plain Go functions. Parameters are
+// matched to fields by proto name, JSON name or Go field name, ignoring case
+// and underscores (userID matches user_id). Values are converted to parameter
+// types: int32 fields bind to int parameters, enums to their integer type,
+// repeated fields element by element, and unset proto2 optional fields bind
+// the zero value. Oneof members are matched like regular fields. Parameters
+// without a matching field take their ParamMeta.Default or fail.
+// context.Context parameters receive context.Background(); use
+// CallWithProtoContext to provide one.
+//
+// The message is read through its protobuf struct tags, so any generated
+// message (a pointer to a struct implementing proto.Message) is accepted
+// without this package depending on the protobuf runtime.
+//
+// Example:
+//
+//	func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+//	    results, err := getUser.CallWithProtoContext(ctx, req) // func GetUser(ctx, userID int64, fields []string)
+//	    ...
+//	}
+func (t *Function) CallWithProto(msg any) ([]reflect.Value, error) {
+	return t.CallWithProtoContext(context.Background(), msg)
+}
+
+// CallWithProtoContext is like CallWithProto but injects ctx into context.Context parameters.
+func (t *Function) CallWithProtoContext(ctx context.Context, msg any) ([]reflect.Value, error) {
+	fields, err := protoFields(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	names, types := t.GetNonContextParameters()
+	args := make([]any, len(names))
+	for i, name := range names {
+		field, ok := matchProtoField(fields, name)
+		if !ok {
+			if meta, hasMeta := t.paramMeta[name]; hasMeta && meta.Default != nil {
+				args[i] = meta.Default
+				continue
+			}
+			if t.isOptional(name, types[i]) {
+				args[i] = reflect.Zero(types[i]).Interface()
+				continue
+			}
+			return nil, fmt.Errorf("parameter %q of function %s has no matching field in %T", name, t.funcName, msg)
+		}
+
+		v, err := convertProtoValue(field.value, types[i])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: field %s: %w", name, field.name, err)
+		}
+		args[i] = v.Interface()
+	}
+
+	return t.CallWithContext(ctx, args...)
+}
+
+// protoFields lists the fields of a generated message struct, flattening oneofs.
+func protoFields(msg any) ([]protoField, error) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a non-nil pointer to a protobuf message struct, got %T", msg)
+	}
+	v = v.Elem()
+
+	var fields []protoField
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		if _, isOneof := sf.Tag.Lookup("protobuf_oneof"); isOneof {
+			// The interface holds a pointer to a wrapper struct with a single tagged field
+			wrapper := v.Field(i)
+			if wrapper.IsNil() {
+				continue
+			}
+			inner := wrapper.Elem().Elem()
+			if inner.Kind() == reflect.Struct && inner.NumField() == 1 {
+				if field, ok := newProtoField(inner.Type().Field(0), inner.Field(0)); ok {
+					fields = append(fields, field)
+				}
+			}
+			continue
+		}
+
+		if field, ok := newProtoField(sf, v.Field(i)); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%T has no protobuf fields", msg)
+	}
+	return fields, nil
+}
+
+// newProtoField parses a `protobuf:"varint,1,opt,name=user_id,json=userId,proto3"` tag.
+func newProtoField(sf reflect.StructField, value reflect.Value) (protoField, bool) {
+	tag, ok := sf.Tag.Lookup("protobuf")
+	if !ok {
+		return protoField{}, false
+	}
+
+	field := protoField{value: value}
+	for _, part := range strings.Split(tag, ",") {
+		if name, found := strings.CutPrefix(part, "name="); found {
+			field.name = name
+		} else if jsonName, found := strings.CutPrefix(part, "json="); found {
+			field.jsonName = jsonName
+		}
+	}
+	if field.name == "" {
+		field.name = sf.Name
+	}
+	if field.jsonName == "" {
+		field.jsonName = field.name
+	}
+	return field, true
+}
+
+// matchProtoField finds the field for a parameter, preferring exact name matches.
+func matchProtoField(fields []protoField, param string) (protoField, bool) {
+	for _, f := range fields {
+		if f.name == param || f.jsonName == param {
+			return f, true
+		}
+	}
+	folded := foldProtoName(param)
+	for _, f := range fields {
+		if foldProtoName(f.name) == folded {
+			return f, true
+		}
+	}
+	return protoField{}, false
+}
+
+func foldProtoName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+}
+
+// convertProtoValue converts a message field value to typ.
+func convertProtoValue(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	if v.Type().AssignableTo(typ) {
+		return v, nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Pointer && typ.Kind() != reflect.Pointer:
+		// Unset proto2 optional scalars bind the zero value
+		if v.IsNil() {
+			return reflect.Zero(typ), nil
+		}
+		return convertProtoValue(v.Elem(), typ)
+
+	case v.Kind() == reflect.Slice && typ.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(typ, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := convertProtoValue(v.Index(i), typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(elem)
+		}
+		return slice, nil
+
+	case isNumericKind(v.Kind()) && isNumericKind(typ.Kind()):
+		// Numbers convert only when represented exactly: an int64 field
+		// holding 300 does not bind to a uint8 parameter
+		return numberConverter(typ)(v)
+
+	case v.Kind() == reflect.String && typ.Kind() == reflect.String,
+		v.Kind() == reflect.Bool && typ.Kind() == reflect.Bool:
+		return v.Convert(typ), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", v.Type(), typ)
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Hand-written equivalents of protoc-gen-go output
+
+type testStatus int32
+
+type testGetUserRequest struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	UserId  int64      `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fields  []string   `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Limits  []int32    `protobuf:"varint,3,rep,packed,name=limits,proto3" json:"limits,omitempty"`
+	Status  testStatus `protobuf:"varint,4,opt,name=status,proto3,enum=test.Status" json:"status,omitempty"`
+	Comment *string    `protobuf:"bytes,5,opt,name=comment" json:"comment,omitempty"`
+	// Types that are assignable to Lookup:
+	//
+	//	*testGetUserRequest_Email
+	Lookup isTestGetUserRequest_Lookup `protobuf_oneof:"lookup"`
+}
+
+type isTestGetUserRequest_Lookup interface{ isTestGetUserRequest_Lookup() }
+
+type testGetUserRequest_Email struct {
+	Email string `protobuf:"bytes,6,opt,name=email,proto3,oneof"`
+}
+
+func (*testGetUserRequest_Email) isTestGetUserRequest_Lookup() {}
+
+func testFuncGetUser(ctx context.Context, userID int, fields []string, limits []int, status int, comment string, email string) string {
+	return strings.Join([]string{
+		strings.Join(fields, "+"),
+		strconv.Itoa(userID),
+		comment, email,
+	}, "|")
+}
+
+func testFuncGetUserSimple(userID int, limits []int, status int) []int {
+	return append(limits, userID, status)
+}
+
+func TestCallWithProto(t *testing.T) {
+	fn := mustNewFunction(t, testFuncGetUserSimple)
+
+	results, err := fn.CallWithProto(&testGetUserRequest{UserId: 7, Limits: []int32{1, 2}, Status: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].Interface().([]int); !reflect.DeepEqual(got, []int{1, 2, 7, 3}) {
+		t.Errorf("unexpected result: %v", got)
+	}
+}
+
+func TestCallWithProtoContext_OneofAndOptional(t *testing.T) {
+	fn := mustNewFunction(t, testFuncGetUser)
+
+	msg := &testGetUserRequest{
+		UserId: 1,
+		Fields: []string{"a", "b"},
+		Lookup: &testGetUserRequest_Email{Email: "x@example.com"},
+	}
+	results, err := fn.CallWithProtoContext(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); !strings.HasPrefix(got, "a+b|1|") || !strings.HasSuffix(got, "||x@example.com") {
+		t.Errorf("unexpected result: %q", got)
+	}
+
+	// Without the oneof set, email has no matching field
+	msg.Lookup = nil
+	if _, err := fn.CallWithProto(msg); err == nil || !strings.Contains(err.Error(), `"email"`) {
+		t.Errorf("expected missing email error, got %v", err)
+	}
+
+	// A default fills the missing parameter
+	results, err = fn.WithParamMeta("email", ParamMeta{Default: "none"}).CallWithProto(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); !strings.HasSuffix(got, "|none") {
+		t.Errorf("expected default email, got %q", got)
+	}
+}
+
+func testFuncGetUserCursor(userID int, cursor *string) string {
+	if cursor == nil {
+		return strconv.Itoa(userID) + "|start"
+	}
+	return strconv.Itoa(userID) + "|" + *cursor
+}
+
+func TestCallWithProto_OptionalParams(t *testing.T) {
+	// Pointer parameters without a matching field bind nil
+	results, err := mustNewFunction(t, testFuncGetUserCursor).CallWithProto(&testGetUserRequest{UserId: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "4|start" {
+		t.Errorf("unexpected result: %q", got)
+	}
+
+	// WithRequired makes other parameters optional
+	fn := mustNewFunction(t, testFuncGetUser).WithRequired("email", false)
+	results, err = fn.CallWithProto(&testGetUserRequest{UserId: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "|2||" {
+		t.Errorf("expected empty email, got %q", got)
+	}
+}
+
+func TestCallWithProto_Errors(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	if _, err := fn.CallWithProto(testGetUserRequest{}); err == nil {
+		t.Error("expected error for non-pointer message")
+	}
+	if _, err := fn.CallWithProto(&struct{ Name string }{}); err == nil {
+		t.Error("expected error for struct without protobuf fields")
+	}
+}
+
+func TestConvertProtoValue(t *testing.T) {
+	comment := "c"
+	tests := []struct {
+		value any
+		typ   reflect.Type
+		want  any
+	}{
+		{int32(5), reflect.TypeOf(0), 5},
+		{testStatus(2), reflect.TypeOf(int64(0)), int64(2)},
+		{&comment, reflect.TypeOf(""), "c"},
+		{(*string)(nil), reflect.TypeOf(""), ""},
+		{[]uint32{1}, reflect.TypeOf([]uint64{}), []uint64{1}},
+	}
+	for _, tt := range tests {
+		got, err := convertProtoValue(reflect.ValueOf(tt.value), tt.typ)
+		if err != nil {
+			t.Errorf("%T to %v: unexpected error: %v", tt.value, tt.typ, err)
+			continue
+		}
+		if !reflect.DeepEqual(got.Interface(), tt.want) {
+			t.Errorf("%T to %v: expected %v, got %v", tt.value, tt.typ, tt.want, got.Interface())
+		}
+	}
+
+	if _, err := convertProtoValue(reflect.ValueOf(int32(1)), reflect.TypeOf("")); err == nil {
+		t.Error("expected error converting int32 to string")
+	}
+
+	// Numbers that do not fit are rejected rather than truncated
+	lossy := []struct {
+		value any
+		typ   reflect.Type
+	}{
+		{int64(300), reflect.TypeOf(uint8(0))},
+		{int32(-1), reflect.TypeOf(uint32(0))},
+		{uint64(math.MaxUint64), reflect.TypeOf(int64(0))},
+		{2.5, reflect.TypeOf(0)},
+		{[]int64{1, 1 << 40}, reflect.TypeOf([]int32{})},
+	}
+	for _, tt := range lossy {
+		if _, err := convertProtoValue(reflect.ValueOf(tt.value), tt.typ); err == nil {
+			t.Errorf("%T %v to %v: expected a conversion error, got %v", tt.value, tt.value, tt.typ, err)
+		}
+	}
+}
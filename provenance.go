@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NameSource identifies the mechanism that produced a function's parameter names.
+type NameSource int
+
+const (
+	SourceExactMatch         NameSource = iota // DWARF entry found under the runtime name itself
+	SourceCandidateMatch                       // DWARF entry found under a derived candidate name
+	SourceNormalizerMatch                      // DWARF entry found under a name from a NameNormalizer
+	SourcePositionalFallback                   // no DWARF entry; names are arg0, arg1, ...
+	SourceMethodValue                          // DWARF entry of the method behind a -fm method value wrapper
+	SourceSignature                            // names supplied to NewFunctionFromSignature
+	SourceGoFile                               // names parsed from source registered with RegisterSourceFS
+	SourcePCMatch                              // DWARF entry found by code address
+	SourceCache                                // names from the Cache of InitOptions
+)
+
+// String returns a human-readable name for the name source
+func (s NameSource) String() string {
+	switch s {
+	case SourceExactMatch:
+		return "exact DWARF match"
+	case SourceCandidateMatch:
+		return "candidate match"
+	case SourceNormalizerMatch:
+		return "normalizer match"
+	case SourcePositionalFallback:
+		return "positional fallback"
+	case SourceMethodValue:
+		return "method value unwrap"
+	case SourceSignature:
+		return "explicit signature"
+	case SourceGoFile:
+		return "registered source"
+	case SourcePCMatch:
+		return "address match"
+	case SourceCache:
+		return "cache"
+	default:
+		return "unknown"
+	}
+}
+
+// Provenance describes how a Function's parameter names were resolved.
+type Provenance struct {
+	Source   NameSource
+	DWARFKey string // matched DWARF entry name, empty for positional fallback
+}
+
+// String formats the provenance for logs and diagnostics
+func (p Provenance) String() string {
+	if p.DWARFKey == "" {
+		return p.Source.String()
+	}
+	return fmt.Sprintf("%s (%s)", p.Source, p.DWARFKey)
+}
+
+// Provenance reports which mechanism produced the parameter names and the DWARF
+// key that matched. Useful when debugging wrong names across build configurations.
+//
+// Example:
+//
+//	fmt.Println(fn.Provenance()) // candidate match (pkg.(*Service).Handle)
+func (t *Function) Provenance() Provenance {
+	return t.provenance
+}
+
+// newProvenance classifies how dwarfKey was derived from the runtime name.
+func newProvenance(runtimeName, dwarfKey string) Provenance {
+	switch {
+	case dwarfKey == runtimeName:
+		return Provenance{Source: SourceExactMatch, DWARFKey: dwarfKey}
+	case strings.HasSuffix(runtimeName, "-fm") && !strings.HasSuffix(dwarfKey, "-fm"):
+		return Provenance{Source: SourceMethodValue, DWARFKey: dwarfKey}
+	case slices.Contains(generateFunctionKeyCandidates(runtimeName), dwarfKey):
+		return Provenance{Source: SourceCandidateMatch, DWARFKey: dwarfKey}
+	default:
+		return Provenance{Source: SourceNormalizerMatch, DWARFKey: dwarfKey}
+	}
+}
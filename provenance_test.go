@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"testing"
+)
+
+func TestNewProvenance(t *testing.T) {
+	tests := []struct {
+		runtimeName string
+		dwarfKey    string
+		expected    NameSource
+	}{
+		{"main.process", "main.process", SourceExactMatch},
+		{"github.com/user/repo/pkg.F", "pkg.F", SourceCandidateMatch},
+		{"pkg.Map[...]", "pkg.Map", SourceNormalizerMatch},
+		{"pkg.(*T).Method-fm", "pkg.(*T).Method", SourceMethodValue},
+		{"pkg.(*T).Method-fm", "pkg.(*T).Method-fm", SourceExactMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtimeName, func(t *testing.T) {
+			p := newProvenance(tt.runtimeName, tt.dwarfKey)
+			if p.Source != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, p.Source)
+			}
+			if p.DWARFKey != tt.dwarfKey {
+				t.Errorf("expected key %q, got %q", tt.dwarfKey, p.DWARFKey)
+			}
+		})
+	}
+}
+
+func TestProvenance_String(t *testing.T) {
+	p := Provenance{Source: SourceCandidateMatch, DWARFKey: "pkg.F"}
+	if got := p.String(); got != "candidate match (pkg.F)" {
+		t.Errorf("unexpected string: %s", got)
+	}
+
+	p = Provenance{Source: SourcePositionalFallback}
+	if got := p.String(); got != "positional fallback" {
+		t.Errorf("unexpected string: %s", got)
+	}
+}
+
+func TestFunction_Provenance(t *testing.T) {
+	p := mustNewFunction(t, testFunc1).Provenance()
+	if p.Source != SourceExactMatch || p.DWARFKey != "github.com/matteo-grella/dwarfreflect.testFunc1" {
+		t.Errorf("unexpected provenance: %v", p)
+	}
+
+	p = mustNewFunction(t, testGeneric[int]).Provenance()
+	if p.Source != SourceNormalizerMatch {
+		t.Errorf("expected normalizer match for generic function, got %v", p)
+	}
+
+	p = mustNewFunction(t, (&testStruct{}).Method).Provenance()
+	if p.Source != SourceMethodValue || p.DWARFKey != "github.com/matteo-grella/dwarfreflect.(*testStruct).Method" {
+		t.Errorf("expected method value unwrap, got %v", p)
+	}
+}
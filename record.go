@@ -0,0 +1,249 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// maxRecordSize bounds the size of a single recorded call read by
+// ReplayAgainst.
+const maxRecordSize = 64 << 20
+
+// recordedCall is a call written by Record, one JSON object per line.
+type recordedCall struct {
+	Function string                     `json:"function"` // ID of the function
+	Args     map[string]json.RawMessage `json:"args"`
+	Results  map[string]json.RawMessage `json:"results"`
+	Error    string                     `json:"error,omitempty"`
+}
+
+// recorder holds the destination set with Record.
+type recorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// ReplayMismatch reports a replayed call whose results differ from the
+// recorded ones. Trailing errors are compared by message.
+type ReplayMismatch struct {
+	Call    int             // 1-based position of the call in the recording
+	Args    json.RawMessage // recorded non-context arguments, by parameter name
+	Want    json.RawMessage // recorded results, by result name
+	Got     json.RawMessage // replayed results, by result name
+	WantErr string          // recorded trailing error message, if any
+	GotErr  string          // replayed trailing error message, if any
+}
+
+func (m *ReplayMismatch) Error() string {
+	if m.WantErr != m.GotErr {
+		return fmt.Sprintf("call %d with %s: error %q, recorded %q", m.Call, m.Args, m.GotErr, m.WantErr)
+	}
+	return fmt.Sprintf("call %d with %s: results %s, recorded %s", m.Call, m.Args, m.Got, m.Want)
+}
+
+// Record returns a copy of the Function that writes every call it makes to w,
+// one JSON object per line holding the function's ID, the non-context
+// arguments and the results by name, and the trailing error message. The
+// recording can be replayed with ReplayAgainst, turning the traffic of a
+// dynamic dispatch layer into golden tests.
+//
+// Arguments are encoded before the call, so functions modifying them do not
+// change what was recorded. Calls that fail before reaching the function
+// (binding errors, authorization, open circuits) are not recorded; calls whose
+// arguments or results cannot be encoded as JSON are logged and skipped, and
+// so are write errors. Writes are serialized, so w needs no locking. Argument
+// values are recorded as given, redacted parameters included.
+//
+// Example:
+//
+//	golden, _ := os.Create("testdata/create_user.jsonl")
+//	reg.Register("users.Create", fn.Record(golden))
+//	// ... exercise the router
+//
+//	// in the test:
+//	f, _ := os.Open("testdata/create_user.jsonl")
+//	if err := dwarfreflect.ReplayAgainst(fn, f); err != nil {
+//	    t.Fatal(err)
+//	}
+func (t *Function) Record(w io.Writer) *Function {
+	clone := *t
+	clone.recorder = &recorder{w: w}
+	return &clone
+}
+
+// prepareRecord encodes the arguments of a call before it runs, returning
+// the function writing the call once its results are known, or nil when the
+// call is not recorded.
+func (t *Function) prepareRecord(args []reflect.Value) func(results []reflect.Value) {
+	if t.recorder == nil {
+		return nil
+	}
+
+	encoded, err := t.encodeArgs(args)
+	if err != nil {
+		Logger().Warn("cannot record call", "function", t.funcName, "error", err)
+		return nil
+	}
+
+	return func(results []reflect.Value) {
+		call := recordedCall{Function: t.ID(), Args: encoded}
+		if call.Results, err = t.encodeResults(results); err == nil {
+			if callErr := trailingError(results); callErr != nil {
+				call.Error = callErr.Error()
+			}
+			err = t.recorder.write(call)
+		}
+		if err != nil {
+			Logger().Warn("cannot record call", "function", t.funcName, "error", err)
+		}
+	}
+}
+
+// encodeArgs encodes the non-context arguments of a call by parameter name.
+func (t *Function) encodeArgs(args []reflect.Value) (map[string]json.RawMessage, error) {
+	contextPositions := t.GetContextPositions()
+	encoded := make(map[string]json.RawMessage, len(args))
+	for i, arg := range args {
+		if slices.Contains(contextPositions, i) {
+			continue
+		}
+		data, err := json.Marshal(arg.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", t.paramNames[i], err)
+		}
+		encoded[t.paramNames[i]] = data
+	}
+	return encoded, nil
+}
+
+// encodeResults encodes the results of a call by result name, without the
+// trailing error.
+func (t *Function) encodeResults(results []reflect.Value) (map[string]json.RawMessage, error) {
+	encoded := make(map[string]json.RawMessage, t.resultType.NumField())
+	for i := range t.resultType.NumField() {
+		data, err := json.Marshal(results[i].Interface())
+		if err != nil {
+			return nil, fmt.Errorf("result %q: %w", t.resultNames[i], err)
+		}
+		encoded[t.resultNames[i]] = data
+	}
+	return encoded, nil
+}
+
+// write writes call as a single line.
+func (r *recorder) write(call recordedCall) error {
+	line, err := json.Marshal(call)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(line)
+	return err
+}
+
+// ReplayAgainst replays the calls recorded by Record from r against fn, in
+// order, and returns an error joining a *ReplayMismatch for every call whose
+// results or trailing error differ from the recorded ones. Results are
+// compared as JSON values, so the recording's formatting does not matter.
+//
+// Calls recorded for a different function ID, i.e. another function or a
+// changed signature, fail without being replayed, and so do arguments that
+// no longer bind. context.Context parameters receive context.Background().
+//
+// Example:
+//
+//	f, _ := os.Open("testdata/create_user.jsonl")
+//	defer f.Close()
+//	if err := dwarfreflect.ReplayAgainst(fn, f); err != nil {
+//	    t.Fatal(err) // call 3 with {"age":30,"name":"ann"}: results {"id":8}, recorded {"id":7}
+//	}
+func ReplayAgainst(fn *Function, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxRecordSize)
+
+	var errs []error
+	n := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n++
+		var call recordedCall
+		if err := json.Unmarshal(line, &call); err != nil {
+			return fmt.Errorf("call %d: invalid recording: %w", n, err)
+		}
+		if err := fn.replay(n, call); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("reading recording: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// replay makes the nth recorded call and compares its results, see
+// ReplayAgainst.
+func (t *Function) replay(n int, call recordedCall) error {
+	args, _ := json.Marshal(call.Args) // raw messages always marshal
+	if call.Function != t.ID() {
+		return fmt.Errorf("call %d with %s: recorded for %s, replaying against %s", n, args, call.Function, t.ID())
+	}
+
+	argMap := make(map[string]any, len(call.Args))
+	for i, name := range t.paramNames {
+		data, ok := call.Args[name]
+		if !ok {
+			continue
+		}
+		v := reflect.New(t.paramTypes[i])
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return fmt.Errorf("call %d: parameter %q: %w", n, name, err)
+		}
+		argMap[name] = v.Elem().Interface()
+	}
+
+	results, err := t.CallInjected(context.Background(), argMap)
+	if err != nil {
+		return fmt.Errorf("call %d with %s: %w", n, args, err)
+	}
+	encoded, err := t.encodeResults(results)
+	if err != nil {
+		return fmt.Errorf("call %d with %s: %w", n, args, err)
+	}
+
+	mismatch := &ReplayMismatch{Call: n, Args: args, WantErr: call.Error}
+	if callErr := trailingError(results); callErr != nil {
+		mismatch.GotErr = callErr.Error()
+	}
+	mismatch.Want, _ = json.Marshal(call.Results)
+	mismatch.Got, _ = json.Marshal(encoded)
+	if mismatch.WantErr != mismatch.GotErr || !jsonEqual(mismatch.Want, mismatch.Got) {
+		return mismatch
+	}
+	return nil
+}
+
+// jsonEqual reports whether a and b encode the same JSON value.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//go:noinline
+func recordDivide(ctx context.Context, a, b int) (quotient int, err error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+//go:noinline
+func recordAppend(items []string, item string) (count int) {
+	items[0] = "changed"
+	return len(items) + 1
+}
+
+//go:noinline
+func recordSend(ch chan int) {}
+
+func TestRecord_Replay(t *testing.T) {
+	fn := mustNewFunction(t, recordDivide)
+	var buf bytes.Buffer
+	recorded := fn.Record(&buf)
+
+	if _, err := recorded.CallInjected(context.Background(), map[string]any{"a": 10, "b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorded.CallInjected(context.Background(), map[string]any{"a": 1, "b": 0}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorded.CallInjected(context.Background(), map[string]any{"a": "x", "b": 1}); err == nil {
+		t.Fatal("expected binding error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 recorded calls, got %d:\n%s", len(lines), buf.String())
+	}
+	want := `{"function":"` + fn.ID() + `","args":{"a":10,"b":2},"results":{"quotient":5}}`
+	if lines[0] != want {
+		t.Errorf("unexpected record\n got %s\nwant %s", lines[0], want)
+	}
+	if !strings.Contains(lines[1], `"error":"division by zero"`) {
+		t.Errorf("expected trailing error to be recorded, got %s", lines[1])
+	}
+
+	if err := ReplayAgainst(fn, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Errorf("unexpected replay error: %v", err)
+	}
+}
+
+func TestReplayAgainst_Mismatch(t *testing.T) {
+	fn := mustNewFunction(t, recordDivide)
+	id := fn.ID()
+	recording := `{"function":"` + id + `","args":{"a":10,"b":2},"results":{"quotient":4}}
+
+{"function":"` + id + `","args":{"a":9,"b":3},"results":{"quotient":3}}
+{"function":"` + id + `","args":{"a":1,"b":0},"results":{"quotient":0}}
+`
+	err := ReplayAgainst(fn, strings.NewReader(recording))
+
+	var mismatch *ReplayMismatch
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected a mismatch, got %v", err)
+	}
+	if mismatch.Call != 1 || string(mismatch.Got) != `{"quotient":5}` || string(mismatch.Want) != `{"quotient":4}` {
+		t.Errorf("unexpected mismatch %+v", mismatch)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `call 1 with {"a":10,"b":2}: results {"quotient":5}, recorded {"quotient":4}`) {
+		t.Errorf("unexpected message %s", msg)
+	}
+	if !strings.Contains(msg, `call 3 with {"a":1,"b":0}: error "division by zero", recorded ""`) {
+		t.Errorf("expected error mismatch of call 3, got %s", msg)
+	}
+	if strings.Contains(msg, "call 2") {
+		t.Errorf("expected call 2 to match, got %s", msg)
+	}
+}
+
+func TestReplayAgainst_Incompatible(t *testing.T) {
+	fn := mustNewFunction(t, recordDivide)
+
+	err := ReplayAgainst(fn, strings.NewReader(`{"function":"other@0","args":{},"results":{}}`))
+	if err == nil || !strings.Contains(err.Error(), "recorded for other@0") {
+		t.Errorf("expected function mismatch, got %v", err)
+	}
+
+	err = ReplayAgainst(fn, strings.NewReader(`{"function":"`+fn.ID()+`","args":{"a":"x","b":1},"results":{}}`))
+	if err == nil || !strings.Contains(err.Error(), `parameter "a"`) {
+		t.Errorf("expected decoding error, got %v", err)
+	}
+
+	if err := ReplayAgainst(fn, strings.NewReader("not json\n")); err == nil || !strings.Contains(err.Error(), "invalid recording") {
+		t.Errorf("expected invalid recording error, got %v", err)
+	}
+}
+
+func TestRecord_ArgsBeforeCall(t *testing.T) {
+	fn := mustNewFunction(t, recordAppend)
+	var buf bytes.Buffer
+	if _, err := fn.Record(&buf).Call([]string{"a"}, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"items":["a"]`) {
+		t.Errorf("expected arguments as given, got %s", buf.String())
+	}
+}
+
+func TestRecord_Unencodable(t *testing.T) {
+	fn := mustNewFunction(t, recordSend)
+	var buf bytes.Buffer
+	if _, err := fn.Record(&buf).Call(make(chan int)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected unencodable call to be skipped, got %s", buf.String())
+	}
+}
+
+func TestRecord_Concurrent(t *testing.T) {
+	fn := mustNewFunction(t, recordDivide)
+	var buf bytes.Buffer
+	recorded := fn.Record(&buf)
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := recorded.CallInjected(context.Background(), map[string]any{"a": i, "b": 1}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ReplayAgainst(fn, &buf); err != nil {
+		t.Errorf("unexpected replay error: %v", err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 0 {
+		t.Errorf("expected replay to consume the recording, %d lines left", n)
+	}
+}
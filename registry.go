@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Registry maps names to wrapped functions for name-based dispatch.
+// It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	functions map[string]*Function
+	ids       map[string]string // names by function ID, the first registered, see ByID
+	scoped    []string          // scoping parameter names, see Scope
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		functions: make(map[string]*Function),
+		ids:       make(map[string]string),
+	}
+}
+
+// Register wraps fn and stores it under name. fn may be a plain function or
+// an already wrapped *Function. Registering the same name twice is an error.
+//
+// Example:
+//
+//	reg := dwarfreflect.NewRegistry()
+//	fn, err := reg.Register("CreateUser", CreateUser)
+func (r *Registry) Register(name string, fn any) (*Function, error) {
+	function, ok := fn.(*Function)
+	if !ok {
+		var err error
+		if function, err = NewFunction(fn); err != nil {
+			return nil, err
+		}
+	}
+	id := function.ID()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.functions[name]; exists {
+		return nil, fmt.Errorf("function %q already registered", name)
+	}
+	if len(r.scoped) > 0 {
+		function = function.WithScopedParams(r.scoped...)
+	}
+	r.add(name, id, function)
+
+	return function, nil
+}
+
+// RegisterAll registers every function of fns under prefix + key, e.g. a
+// "users." prefix with a "Create" key registers "users.Create". Either all
+// functions are registered or, on error, none are.
+//
+// Example:
+//
+//	err := reg.RegisterAll("users.", map[string]any{
+//	    "Create": CreateUser,
+//	    "Delete": DeleteUser,
+//	})
+func (r *Registry) RegisterAll(prefix string, fns map[string]any) error {
+	keys := make([]string, 0, len(fns))
+	for key := range fns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	wrapped := make(map[string]*Function, len(fns))
+	ids := make(map[string]string, len(fns))
+	for _, key := range keys {
+		function, ok := fns[key].(*Function)
+		if !ok {
+			var err error
+			if function, err = NewFunction(fns[key]); err != nil {
+				return fmt.Errorf("function %q: %w", prefix+key, err)
+			}
+		}
+		wrapped[prefix+key] = function
+		ids[prefix+key] = function.ID()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		if _, exists := r.functions[prefix+key]; exists {
+			return fmt.Errorf("function %q already registered", prefix+key)
+		}
+	}
+	for _, key := range keys {
+		function := wrapped[prefix+key]
+		if len(r.scoped) > 0 {
+			function = function.WithScopedParams(r.scoped...)
+		}
+		r.add(prefix+key, ids[prefix+key], function)
+	}
+
+	return nil
+}
+
+// add stores function under name, indexing its id. r.mu must be held.
+func (r *Registry) add(name, id string, function *Function) {
+	r.functions[name] = function
+	if _, exists := r.ids[id]; !exists {
+		r.ids[id] = name
+	}
+}
+
+// Get returns the function registered under name.
+func (r *Registry) Get(name string) (*Function, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.functions[name]
+	return fn, ok
+}
+
+// Names returns the registered names in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.functions))
+	for name := range r.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ByID returns the registered function whose ID is id, for routing persisted
+// references that outlive the process. See Function.ID. A function registered
+// under several names is returned as registered first.
+//
+// Example:
+//
+//	fn, ok := reg.ByID("github.com/user/repo/pkg.CreateUser@3f9a0c21b4d7e815")
+func (r *Registry) ByID(id string) (*Function, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, ok := r.ids[id]
+	if !ok {
+		return nil, false
+	}
+	return r.functions[name], true
+}
+
+// FindCallable returns the registered functions, in name order, that can be
+// called with arguments of argTypes and whose results can be assigned to
+// retTypes, for wiring functions by shape, e.g. finding a converter from A
+// to B. argTypes cover either every parameter or only the non-context ones,
+// which CallWithContext injects. A trailing error result may be left out of
+// retTypes.
+//
+// Example:
+//
+//	// func UserToDTO(u *User) (UserDTO, error)
+//	converters := reg.FindCallable(
+//	    []reflect.Type{reflect.TypeFor[*User]()},
+//	    []reflect.Type{reflect.TypeFor[UserDTO]()},
+//	)
+func (r *Registry) FindCallable(argTypes []reflect.Type, retTypes []reflect.Type) []*Function {
+	var matches []*Function
+	for _, name := range r.Names() {
+		fn, ok := r.Get(name)
+		if ok && fn.acceptsArgs(argTypes) && fn.returnsResults(retTypes) {
+			matches = append(matches, fn)
+		}
+	}
+	return matches
+}
+
+// acceptsArgs reports whether arguments of argTypes can be passed to every
+// parameter of the function, or to its non-context parameters.
+func (t *Function) acceptsArgs(argTypes []reflect.Type) bool {
+	if assignableAll(argTypes, t.paramTypes) {
+		return true
+	}
+	_, nonContextTypes := t.GetNonContextParameters()
+	return len(nonContextTypes) < len(t.paramTypes) && assignableAll(argTypes, nonContextTypes)
+}
+
+// returnsResults reports whether the results of the function can be
+// assigned to retTypes, with or without a trailing error.
+func (t *Function) returnsResults(retTypes []reflect.Type) bool {
+	results := t.GetReturnTypes()
+	if assignableAll(results, retTypes) {
+		return true
+	}
+	n := len(results)
+	return n > 0 && results[n-1] == errorType && assignableAll(results[:n-1], retTypes)
+}
+
+// assignableAll reports whether each of from is assignable to the type of
+// to at the same position.
+func assignableAll(from, to []reflect.Type) bool {
+	if len(from) != len(to) {
+		return false
+	}
+	for i := range from {
+		if !from[i].AssignableTo(to[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustRegister(t *testing.T, reg *Registry, name string, fn any) *Function {
+	t.Helper()
+	f, err := reg.Register(name, fn)
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return f
+}
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "greet", testFunc1)
+	mustRegister(t, reg, "add", testFunc2)
+
+	fn, ok := reg.Get("greet")
+	if !ok {
+		t.Fatal("expected greet to be registered")
+	}
+	if fn.GetBaseFunctionName() != "testFunc1" {
+		t.Errorf("unexpected function: %s", fn.GetFunctionName())
+	}
+
+	if _, ok := reg.Get("missing"); ok {
+		t.Error("expected missing to be absent")
+	}
+
+	names := reg.Names()
+	if len(names) != 2 || names[0] != "add" || names[1] != "greet" {
+		t.Errorf("expected sorted names [add greet], got %v", names)
+	}
+}
+
+func TestRegistry_Duplicate(t *testing.T) {
+	reg := NewRegistry()
+	fn := mustRegister(t, reg, "greet", testFunc1)
+
+	if _, err := reg.Register("greet", fn); err == nil {
+		t.Error("expected error for duplicate name")
+	}
+}
+
+func TestRegistry_RegisterFunction(t *testing.T) {
+	reg := NewRegistry()
+	fn := mustNewFunction(t, testFunc1)
+
+	registered := mustRegister(t, reg, "greet", fn)
+	if registered != fn {
+		t.Error("expected wrapped function to be stored as-is")
+	}
+}
+
+func TestRegistry_NotAFunction(t *testing.T) {
+	if _, err := NewRegistry().Register("bad", 42); err == nil {
+		t.Error("expected error for non-function input")
+	}
+}
+
+func TestRegistry_RegisterAll(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "math.Sub", testFunc1)
+
+	err := reg.RegisterAll("math.", map[string]any{"Add": testFunc2, "Greet": testFunc1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := reg.Names(); len(names) != 3 || names[0] != "math.Add" || names[1] != "math.Greet" {
+		t.Errorf("unexpected names: %v", names)
+	}
+
+	err = reg.RegisterAll("math.", map[string]any{"Mul": testFunc2, "Sub": testFunc2})
+	if err == nil || !strings.Contains(err.Error(), "math.Sub") {
+		t.Errorf("expected duplicate error for math.Sub, got %v", err)
+	}
+	if _, ok := reg.Get("math.Mul"); ok {
+		t.Error("expected no function registered after a failed RegisterAll")
+	}
+
+	if err := reg.RegisterAll("bad.", map[string]any{"X": 42}); err == nil {
+		t.Error("expected error for non-function input")
+	}
+}
+
+func TestRegistry_ByID(t *testing.T) {
+	reg := NewRegistry()
+	greet := mustRegister(t, reg, "greet", testFunc1)
+	mustRegister(t, reg, "add", testFunc2)
+
+	fn, ok := reg.ByID(greet.ID())
+	if !ok || fn != greet {
+		t.Errorf("expected greet for ID %q", greet.ID())
+	}
+	if _, ok := reg.ByID("missing@0000000000000000"); ok {
+		t.Error("expected unknown ID to be absent")
+	}
+
+	// Functions registered under several names resolve to the first, also
+	// when registered with RegisterAll
+	if _, err := reg.Register("greet2", greet); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterAll("math.", map[string]any{"a": testFunc2, "b": testFunc2}); err != nil {
+		t.Fatal(err)
+	}
+	if fn, _ := reg.ByID(greet.ID()); fn != greet {
+		t.Error("expected greet to be found under its first name")
+	}
+	add, _ := reg.Get("add")
+	if fn, ok := reg.ByID(add.ID()); !ok || fn != add {
+		t.Error("expected add to be found under its first name")
+	}
+}
+
+type findUser struct{ Name string }
+
+type findUserDTO struct{ Name string }
+
+func findUserToDTO(u *findUser) findUserDTO { return findUserDTO(*u) }
+
+func findUserToDTOChecked(ctx context.Context, u *findUser) (findUserDTO, error) {
+	return findUserDTO(*u), nil
+}
+
+func findStringer(v fmt.Stringer) string { return v.String() }
+
+func TestRegistry_FindCallable(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "plain", findUserToDTO)
+	mustRegister(t, reg, "checked", findUserToDTOChecked)
+	mustRegister(t, reg, "stringer", findStringer)
+	mustRegister(t, reg, "greet", testFunc1)
+
+	names := func(fns []*Function) []string {
+		var names []string
+		for _, fn := range fns {
+			names = append(names, fn.GetBaseFunctionName())
+		}
+		return names
+	}
+
+	userType, dtoType := reflect.TypeFor[*findUser](), reflect.TypeFor[findUserDTO]()
+	got := names(reg.FindCallable([]reflect.Type{userType}, []reflect.Type{dtoType}))
+	if !slices.Equal(got, []string{"findUserToDTOChecked", "findUserToDTO"}) {
+		t.Errorf("unexpected converters: %v", got)
+	}
+
+	got = names(reg.FindCallable([]reflect.Type{reflect.TypeFor[context.Context](), userType}, []reflect.Type{dtoType, errorType}))
+	if !slices.Equal(got, []string{"findUserToDTOChecked"}) {
+		t.Errorf("unexpected converters with context and error: %v", got)
+	}
+
+	got = names(reg.FindCallable([]reflect.Type{reflect.TypeFor[time.Duration]()}, []reflect.Type{reflect.TypeFor[string]()}))
+	if !slices.Equal(got, []string{"findStringer"}) {
+		t.Errorf("expected a concrete type to match an interface parameter, got %v", got)
+	}
+
+	if got := reg.FindCallable([]reflect.Type{reflect.TypeFor[string]()}, nil); len(got) != 0 {
+		t.Errorf("expected no match, got %v", names(got))
+	}
+}
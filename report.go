@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Report is a machine-readable inventory of functions exposed for dynamic
+// invocation, meant for security review of an application's dynamic surface.
+type Report struct {
+	// Source is "registry" for Registry.Report, or the binary the resolver
+	// read for DWARFResolver.Report.
+	Source    string           `json:"source"`
+	Functions []FunctionReport `json:"functions"`
+}
+
+// FunctionReport describes one function of a Report.
+type FunctionReport struct {
+	Name      string        `json:"name"`                // registered name, or runtime name for resolver reports
+	Function  string        `json:"function"`            // full runtime name
+	ID        string        `json:"id,omitempty"`        // see Function.ID
+	Package   string        `json:"package,omitempty"`   // package path
+	Module    string        `json:"module,omitempty"`    // module path
+	Kind      string        `json:"kind,omitempty"`      // see FunctionKind
+	Signature string        `json:"signature,omitempty"` // named non-context signature
+	Params    []ReportParam `json:"params"`
+	Results   []ReportParam `json:"results"`
+}
+
+// ReportParam is a parameter or result of a FunctionReport.
+type ReportParam struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Unsafe bool   `json:"unsafe,omitempty"` // unsafe.Pointer or uintptr
+}
+
+// JSON encodes the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Report returns an inventory of every registered function with its full
+// signature, sorted by registered name.
+//
+// Example:
+//
+//	data, _ := reg.Report().JSON()
+//	os.WriteFile("dynamic-surface.json", data, 0o644)
+func (r *Registry) Report() *Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := &Report{Source: "registry", Functions: make([]FunctionReport, 0, len(r.functions))}
+	for name, fn := range r.functions {
+		report.Functions = append(report.Functions, fn.report(name))
+	}
+	sort.Slice(report.Functions, func(i, j int) bool {
+		return report.Functions[i].Name < report.Functions[j].Name
+	})
+
+	return report
+}
+
+// report describes the function registered under name.
+func (t *Function) report(name string) FunctionReport {
+	params := make([]ReportParam, len(t.paramNames))
+	for i, paramName := range t.paramNames {
+		params[i] = ReportParam{Name: paramName, Type: t.paramTypes[i].String(), Unsafe: isUnsafeType(t.paramTypes[i])}
+	}
+
+	results := make([]ReportParam, t.functionType.NumOut())
+	for i := range results {
+		resultName := ""
+		if i < len(t.resultNames) {
+			resultName = t.resultNames[i]
+		}
+		results[i] = ReportParam{Name: resultName, Type: t.functionType.Out(i).String()}
+	}
+
+	return FunctionReport{
+		Name:      name,
+		Function:  t.funcName,
+		ID:        t.ID(),
+		Package:   t.packagePath,
+		Module:    t.ModulePath(),
+		Kind:      t.Kind().String(),
+		Signature: t.Signature(),
+		Params:    params,
+		Results:   results,
+	}
+}
+
+// Report returns an inventory of every function in the binary whose name
+// starts with packagePrefix, with parameter and result types as recorded in
+// DWARF, sorted by name. Unlike Registry.Report it covers functions whether
+// or not they are registered.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewDWARFResolver("bin/server")
+//	report, err := dr.Report("github.com/org/server/")
+func (dr *DWARFResolver) Report(packagePrefix string) (*Report, error) {
+	if dr.dwarfData == nil {
+		return nil, fmt.Errorf("DWARF debug information not available")
+	}
+
+	signatures, err := dr.collectSignatures(packagePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signatures from %s: %w", dr.source(), err)
+	}
+
+	report := &Report{Source: dr.source(), Functions: make([]FunctionReport, 0, len(signatures))}
+	for name, dwarfParams := range signatures {
+		function := FunctionReport{
+			Name:     name,
+			Function: name,
+			Package:  extractPackagePath(name),
+			Params:   []ReportParam{},
+			Results:  []ReportParam{},
+		}
+		for _, p := range dwarfParams {
+			param := ReportParam{Name: p.Name, Type: p.Type, Unsafe: p.Type == "unsafe.Pointer" || p.Type == "uintptr"}
+			if p.Result {
+				function.Results = append(function.Results, param)
+			} else {
+				function.Params = append(function.Params, param)
+			}
+		}
+		report.Functions = append(report.Functions, function)
+	}
+	sort.Slice(report.Functions, func(i, j int) bool {
+		return report.Functions[i].Name < report.Functions[j].Name
+	})
+
+	return report, nil
+}
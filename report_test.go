@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_Report(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "greet", testFunc1)
+	mustRegister(t, reg, "unsafe", testFuncUnsafe)
+
+	report := reg.Report()
+	if report.Source != "registry" || len(report.Functions) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	greet := report.Functions[0]
+	if greet.Name != "greet" || greet.Function != "github.com/matteo-grella/dwarfreflect.testFunc1" {
+		t.Errorf("unexpected function: %+v", greet)
+	}
+	if greet.Signature != "func(name string, age int) string" || greet.ID == "" || greet.Kind == "" {
+		t.Errorf("unexpected signature details: %+v", greet)
+	}
+	expected := []ReportParam{{Name: "name", Type: "string"}, {Name: "age", Type: "int"}}
+	if !reflect.DeepEqual(greet.Params, expected) {
+		t.Errorf("expected params %v, got %v", expected, greet.Params)
+	}
+	if len(greet.Results) != 1 || greet.Results[0].Type != "string" {
+		t.Errorf("unexpected results: %v", greet.Results)
+	}
+
+	if unsafe := report.Functions[1]; !unsafe.Params[1].Unsafe {
+		t.Errorf("expected unsafe parameter to be flagged: %+v", unsafe.Params)
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(&decoded, report) {
+		t.Errorf("expected JSON round trip, got %+v, %v", decoded, err)
+	}
+}
+
+func TestDWARFResolver_Report(t *testing.T) {
+	execPath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dr, err := NewDWARFResolver(execPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := dr.Report("github.com/matteo-grella/dwarfreflect.testFunc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Source != execPath {
+		t.Errorf("unexpected source %q", report.Source)
+	}
+
+	for _, fn := range report.Functions {
+		if fn.Name != "github.com/matteo-grella/dwarfreflect.testFunc1" {
+			continue
+		}
+		expected := []ReportParam{{Name: "name", Type: "string"}, {Name: "age", Type: "int"}}
+		if !reflect.DeepEqual(fn.Params, expected) || fn.Package != "github.com/matteo-grella/dwarfreflect" {
+			t.Errorf("unexpected testFunc1 report: %+v", fn)
+		}
+		return
+	}
+	t.Errorf("testFunc1 missing from report of %d functions", len(report.Functions))
+}
@@ -8,11 +8,18 @@ import (
 	"debug/elf"
 	"debug/macho"
 	"debug/pe"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
 )
 
 // Global DWARF resolver for parameter name discovery from binary debug info
@@ -46,25 +53,68 @@ func (f ExecutableFormat) String() string {
 	}
 }
 
-// DWARFResolver extracts parameter names from DWARF debug information in the binary
+// DWARFResolver extracts parameter names from DWARF debug information in the binary.
+// Its index is frozen once built, so lookups take no lock and scale with the
+// number of goroutines wrapping functions concurrently.
 type DWARFResolver struct {
-	mu             sync.RWMutex
-	functionMap    map[string][]string // maps function names to parameter names
+	functions      functionIndex // maps function names to parameter names, read-only once indexed
 	dwarfData      *dwarf.Data
 	executablePath string
+	normalizers    []NameNormalizer                 // installed at construction
+	added          atomic.Pointer[[]NameNormalizer] // by AddNameNormalizer, replaced on write
+	suggestions    int                              // nearest names listed by lookup errors
+	offset         loadOffsetState
+	deferred       deferredLoadState // global resolver only, see InitOptions.Cache
 }
 
 // initResolver initializes the global DWARF resolver
 func initResolver() {
+	initResolverWith(InitOptions{})
+}
+
+// initResolverWith initializes the global DWARF resolver with options
+func initResolverWith(options InitOptions) {
 	globalResolver = &DWARFResolver{
-		functionMap: make(map[string][]string),
+		normalizers: defaultNormalizers,
+		suggestions: options.Suggestions,
 	}
 
-	// Try to initialize DWARF data from current executable
-	if err := globalResolver.loadDWARFData(); err != nil {
+	resolverInitErr = nil
+
+	// Functions found in the cache need no DWARF: load it on the first miss
+	if options.Cache != nil {
+		globalResolver.deferred.cache = options.Cache
+		globalResolver.deferred.path = options.ExecutablePath
+		globalResolver.deferred.pending = true
+		Logger().Debug("dwarfreflect: resolver initialization deferred to the first cache miss")
+		return
+	}
+	loadGlobalResolver(options.ExecutablePath)
+}
+
+// loadGlobalResolver loads the DWARF data of the global resolver from the
+// current executable, or from explicitPath when it is set.
+func loadGlobalResolver(explicitPath string) {
+	start := time.Now()
+	if err := globalResolver.loadDWARFData(explicitPath); err != nil {
 		resolverInitErr = err
+		Logger().Debug("dwarfreflect: resolver initialization failed", "error", err, "duration", time.Since(start))
 		return
 	}
+	Logger().Debug("dwarfreflect: resolver initialized", "executable", globalResolver.executablePath,
+		"functions", globalResolver.functions.len(), "duration", time.Since(start))
+}
+
+// loadResolver initializes the global resolver, loading the DWARF data its
+// Cache deferred, and returns the initialization error.
+func loadResolver() error {
+	resolverOnce.Do(initResolver)
+	globalResolver.deferred.once.Do(func() {
+		if globalResolver.deferred.pending {
+			loadGlobalResolver(globalResolver.deferred.path)
+		}
+	})
+	return resolverInitErr
 }
 
 // DetectExecutableFormat determines the executable format by examining magic bytes
@@ -75,9 +125,15 @@ func DetectExecutableFormat(filename string) (ExecutableFormat, error) {
 	}
 	defer file.Close()
 
+	return DetectExecutableFormatFromReader(file)
+}
+
+// DetectExecutableFormatFromReader is like DetectExecutableFormat but examines
+// the magic bytes at the start of ra.
+func DetectExecutableFormatFromReader(ra io.ReaderAt) (ExecutableFormat, error) {
 	// Read first 4 bytes to check magic numbers
 	magic := make([]byte, 4)
-	if _, err := file.Read(magic); err != nil {
+	if _, err := ra.ReadAt(magic, 0); err != nil {
 		return FormatUnknown, err
 	}
 
@@ -90,59 +146,158 @@ func DetectExecutableFormat(filename string) (ExecutableFormat, error) {
 	case (magic[0] == 0xfe && magic[1] == 0xed && magic[2] == 0xfa && magic[3] == 0xce) || // Mach-O 32-bit big endian
 		(magic[0] == 0xce && magic[1] == 0xfa && magic[2] == 0xed && magic[3] == 0xfe) || // Mach-O 32-bit little endian
 		(magic[0] == 0xfe && magic[1] == 0xed && magic[2] == 0xfa && magic[3] == 0xcf) || // Mach-O 64-bit big endian
-		(magic[0] == 0xcf && magic[1] == 0xfa && magic[2] == 0xed && magic[3] == 0xfe): // Mach-O 64-bit little endian
+		(magic[0] == 0xcf && magic[1] == 0xfa && magic[2] == 0xed && magic[3] == 0xfe) || // Mach-O 64-bit little endian
+		(magic[0] == 0xca && magic[1] == 0xfe && magic[2] == 0xba && magic[3] == 0xbe): // Mach-O universal (fat)
 		return FormatMachO, nil
 	default:
 		return FormatUnknown, fmt.Errorf("unknown executable format, magic bytes: %x", magic)
 	}
 }
 
-// loadDWARFData loads DWARF debugging information from the current executable (cross-platform)
-func (dr *DWARFResolver) loadDWARFData() error {
+// NewDWARFResolver creates a resolver for the executable at path, which need not be
+// the running binary. Useful for offline analysis such as DiffBinaries.
+func NewDWARFResolver(path string) (*DWARFResolver, error) {
+	dr := &DWARFResolver{
+		normalizers: defaultNormalizers,
+	}
+
+	if err := dr.loadDWARFDataFromPath(path); err != nil {
+		return nil, err
+	}
+
+	return dr, nil
+}
+
+// loadDWARFData loads DWARF debugging information from the current executable (cross-platform),
+// or from explicitPath when it is set
+func (dr *DWARFResolver) loadDWARFData(explicitPath string) error {
+	capturedPath, capturedFile := takeCapturedExecutable()
+	if capturedFile != nil {
+		defer capturedFile.Close()
+	}
+
+	if explicitPath != "" {
+		return dr.loadDWARFDataFromPath(explicitPath)
+	}
+
+	// Prefer the binary captured at package initialization: it may have been
+	// removed since, e.g. by go run or test cache cleanup
+	if capturedFile != nil {
+		dr.executablePath = capturedPath
+		return dr.loadDWARFDataFromReader(capturedFile, FormatUnknown)
+	}
+
 	executablePath, err := os.Executable() // get current executable path
 	if err != nil {
 		return fmt.Errorf("failed to get executable path: %v", err)
 	}
 
+	file, err := openExecutable(executablePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
 	dr.executablePath = executablePath
+	return dr.loadDWARFDataFromReader(file, FormatUnknown)
+}
+
+// NewResolverFromReader creates a resolver for an executable read through ra,
+// so DWARF can be loaded from embedded files, object stores or memory rather
+// than a path on disk. Pass FormatUnknown to detect the format from ra.
+//
+// Example:
+//
+//	data, _ := io.ReadAll(download)
+//	dr, err := dwarfreflect.NewResolverFromReader(bytes.NewReader(data), dwarfreflect.FormatUnknown)
+func NewResolverFromReader(ra io.ReaderAt, format ExecutableFormat) (*DWARFResolver, error) {
+	dr := &DWARFResolver{
+		normalizers: defaultNormalizers,
+	}
+
+	if err := dr.loadDWARFDataFromReader(ra, format); err != nil {
+		return nil, err
+	}
 
-	format, err := DetectExecutableFormat(executablePath)
+	return dr, nil
+}
+
+// ParameterNames returns the formal parameter names DWARF lists for funcName,
+// inputs first and then results, trying the same name candidates and
+// normalizers as NewFunction. Reports false if no entry matches.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewResolverFromReader(bytes.NewReader(binary), dwarfreflect.FormatUnknown)
+//	names, ok := dr.ParameterNames("main.handleRequest")
+func (dr *DWARFResolver) ParameterNames(funcName string) ([]string, bool) {
+	for _, candidate := range dr.candidates(funcName) {
+		if names, exists := dr.functions.lookup(candidate); exists {
+			return names, true
+		}
+	}
+	return nil, false
+}
+
+// source describes where the resolver's DWARF data was loaded from.
+func (dr *DWARFResolver) source() string {
+	if dr.executablePath == "" {
+		return "reader"
+	}
+	return dr.executablePath
+}
+
+// loadDWARFDataFromPath loads DWARF debugging information from the executable at executablePath
+func (dr *DWARFResolver) loadDWARFDataFromPath(executablePath string) error {
+	dr.executablePath = executablePath
+
+	file, err := os.Open(executablePath)
 	if err != nil {
-		return fmt.Errorf("failed to detect executable format: %v", err)
+		return fmt.Errorf("failed to open executable: %v", err)
+	}
+	defer file.Close()
+
+	return dr.loadDWARFDataFromReader(file, FormatUnknown)
+}
+
+// loadDWARFDataFromReader loads DWARF debugging information from an executable
+// of the given format read through ra, detecting the format if it is FormatUnknown
+func (dr *DWARFResolver) loadDWARFDataFromReader(ra io.ReaderAt, format ExecutableFormat) error {
+	if format == FormatUnknown {
+		var err error
+		if format, err = DetectExecutableFormatFromReader(ra); err != nil {
+			return fmt.Errorf("failed to detect executable format: %v", err)
+		}
 	}
 
 	// Extract DWARF data based on format
 	var dwarfData *dwarf.Data
 	switch format {
 	case FormatELF:
-		elfFile, err := elf.Open(executablePath)
+		elfFile, err := elf.NewFile(ra)
 		if err != nil {
 			return fmt.Errorf("failed to open ELF file: %v", err)
 		}
-		defer elfFile.Close()
 		dwarfData, err = elfFile.DWARF()
 		if err != nil {
 			return fmt.Errorf("failed to extract DWARF from ELF file: %v", err)
 		}
 
 	case FormatPE:
-		peFile, err := pe.Open(executablePath)
+		peFile, err := pe.NewFile(ra)
 		if err != nil {
 			return fmt.Errorf("failed to open PE file: %v", err)
 		}
-		defer peFile.Close()
 		dwarfData, err = peFile.DWARF()
 		if err != nil {
 			return fmt.Errorf("failed to extract DWARF from PE file: %v", err)
 		}
 
 	case FormatMachO:
-
-		machoFile, err := macho.Open(executablePath)
+		machoFile, err := openMachO(ra)
 		if err != nil {
 			return fmt.Errorf("failed to open Mach-O file: %v", err)
 		}
-		defer machoFile.Close()
 		dwarfData, err = machoFile.DWARF()
 		if err != nil {
 			return fmt.Errorf("failed to extract DWARF from Mach-O file: %v", err)
@@ -156,6 +311,35 @@ func (dr *DWARFResolver) loadDWARFData() error {
 	return dr.indexFunctions()
 }
 
+// machoCPUs maps GOARCH values to Mach-O CPU types, to pick the host's slice
+// of universal binaries.
+var machoCPUs = map[string]macho.Cpu{
+	"386":   macho.Cpu386,
+	"amd64": macho.CpuAmd64,
+	"arm":   macho.CpuArm,
+	"arm64": macho.CpuArm64,
+	"ppc64": macho.CpuPpc64,
+}
+
+// openMachO opens a thin or universal (fat) Mach-O file. For universal
+// binaries it returns the slice matching the host architecture, or the first
+// slice if there is none, assuming all slices are built from the same source.
+func openMachO(ra io.ReaderAt) (*macho.File, error) {
+	fat, err := macho.NewFatFile(ra)
+	if errors.Is(err, macho.ErrNotFat) {
+		return macho.NewFile(ra)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, arch := range fat.Arches {
+		if cpu, ok := machoCPUs[runtime.GOARCH]; ok && arch.Cpu == cpu {
+			return arch.File, nil
+		}
+	}
+	return fat.Arches[0].File, nil
+}
+
 // indexFunctions parses DWARF info and builds function parameter index
 func (dr *DWARFResolver) indexFunctions() error {
 	reader := dr.dwarfData.Reader()
@@ -168,14 +352,27 @@ func (dr *DWARFResolver) indexFunctions() error {
 
 		// Look for function/subprogram entries
 		if entry.Tag == dwarf.TagSubprogram {
-			funcName := ""
-			if nameField := entry.AttrField(dwarf.AttrName); nameField != nil {
-				funcName = nameField.Val.(string)
+			funcName, _ := entryName(entry)
+
+			// Concrete instances of inlined functions refer to their
+			// abstract entry instead of naming themselves: skip their
+			// children, which would otherwise be read as top-level entries
+			if funcName == "" {
+				reader.SkipChildren()
+				continue
 			}
 
-			if funcName != "" && entry.Children {
-				paramNames := dr.extractParametersFromDWARF(reader)
-				dr.functionMap[funcName] = paramNames
+			if entry.Children {
+				paramNames, results := dr.extractParametersFromDWARF(reader)
+				dr.functions.add(funcName, paramNames, results)
+
+				// Also index generic instantiations under their bracket-free
+				// name, which is what GenericsNormalizer looks up
+				if stripped := stripTypeParams(funcName); stripped != funcName {
+					if !dr.functions.has(stripped) {
+						dr.functions.alias(stripped, funcName)
+					}
+				}
 			}
 		}
 	}
@@ -186,9 +383,12 @@ func (dr *DWARFResolver) indexFunctions() error {
 // extractParametersFromDWARF extracts parameter names from DWARF child entries
 // Note: This includes both input parameters AND return value parameters (~r0, ~r1, etc.)
 // Filtering happens later in discoverParameterNames()
-func (dr *DWARFResolver) extractParametersFromDWARF(reader *dwarf.Reader) []string {
-	var paramNames []string
-
+// Only direct children are parameters of the function: the parameters of
+// inlined calls and the variables of lexical blocks are nested deeper and
+// skipped. Parameters without a usable name keep their position as ~p0,
+// ~p1, ..., as the Go compiler names unnamed parameters. It also counts the
+// result parameters, which DWARF lists last.
+func (dr *DWARFResolver) extractParametersFromDWARF(reader *dwarf.Reader) (paramNames []string, results int) {
 	for {
 		entry, err := reader.Next()
 		if err != nil || entry == nil {
@@ -202,26 +402,51 @@ func (dr *DWARFResolver) extractParametersFromDWARF(reader *dwarf.Reader) []stri
 
 		// Look for formal parameters (includes both input and return value parameters)
 		if entry.Tag == dwarf.TagFormalParameter {
-			if nameField := entry.AttrField(dwarf.AttrName); nameField != nil {
-				paramName := nameField.Val.(string)
-				paramNames = append(paramNames, paramName)
+			paramName, ok := entryName(entry)
+			if !ok {
+				paramName = fmt.Sprintf("~p%d", len(paramNames))
+			}
+			paramNames = append(paramNames, paramName)
+			if result, _ := entry.Val(dwarf.AttrVarParam).(bool); result {
+				results++
 			}
 		}
+
+		if entry.Children {
+			reader.SkipChildren()
+		}
 	}
 
-	return paramNames
+	return paramNames, results
+}
+
+// entryName returns the DW_AT_name of entry. debug/dwarf resolves the string
+// forms of every DWARF version (string, strp, line_strp, strx) to a string;
+// it reports false when the name is missing, empty or of any other form.
+func entryName(entry *dwarf.Entry) (string, bool) {
+	name, ok := entry.Val(dwarf.AttrName).(string)
+	return name, ok && name != ""
 }
 
 // discoverParameterNames tries to find parameter names in DWARF debug info
 func (dr *DWARFResolver) discoverParameterNames(funcName string, paramCount int) ([]string, error) {
-	dr.mu.RLock()
-	defer dr.mu.RUnlock()
+	paramNames, _, err := dr.lookupParameterNames(funcName, paramCount)
+	return paramNames, err
+}
+
+// lookupParameterNames is like discoverParameterNames but also returns the DWARF key that matched
+func (dr *DWARFResolver) lookupParameterNames(funcName string, paramCount int) ([]string, string, error) {
+	// Method values resolve through their -fm wrapper to the method itself
+	if allParams, key, ok := dr.lookupMethodValue(funcName, paramCount); ok {
+		Logger().Debug("dwarfreflect: method value resolved through its method", "function", funcName, "entry", key)
+		return allParams[1 : paramCount+1], key, nil
+	}
 
 	// Try various function name formats to match runtime names with DWARF
-	candidates := generateFunctionKeyCandidates(funcName)
+	candidates := dr.candidates(funcName)
 
 	for _, candidate := range candidates {
-		if allParams, exists := dr.functionMap[candidate]; exists {
+		if allParams, exists := dr.functions.lookup(candidate); exists {
 			// Filter out return value parameters - only take the first paramCount parameters
 			// Go DWARF includes both input parameters AND return value parameters (like ~r0, ~r1)
 			// Input parameters come first, return values come after
@@ -243,21 +468,33 @@ func (dr *DWARFResolver) discoverParameterNames(funcName string, paramCount int)
 
 				// Return the filtered parameters if we got the expected count
 				if len(validParams) == paramCount {
-					return validParams, nil
+					logCandidateMatch(funcName, candidate)
+					return validParams, candidate, nil
 				}
 				// If validation filtered too many, return the first paramCount as-is
 				if len(inputParams) == paramCount {
-					return inputParams, nil
+					Logger().Debug("dwarfreflect: parameters that look like results taken as inputs",
+						"function", funcName, "entry", candidate, "parameters", inputParams)
+					logCandidateMatch(funcName, candidate)
+					return inputParams, candidate, nil
 				}
 			}
 		}
 	}
 
+	Logger().Debug("dwarfreflect: no DWARF entry matches", "function", funcName, "parameters", paramCount,
+		"candidates", candidates)
+
 	// Get executable format for better error message
 	format, execPath, _ := GetExecutableInfo()
 
+	var didYouMean string
+	if nearest := dr.nearest(funcName, dr.suggestions); len(nearest) > 0 {
+		didYouMean = fmt.Sprintf("\nDid you mean %s?\n", strings.Join(nearest, ", "))
+	}
+
 	// Return detailed error explaining why parameter names couldn't be extracted
-	return nil, fmt.Errorf(`dwarfreflect: Cannot extract real parameter names for function %q
+	return nil, "", fmt.Errorf(`dwarfreflect: Cannot extract real parameter names for function %q
 
 Possible causes:
 • Binary built with -ldflags="-w" (strips DWARF debug info)
@@ -267,13 +504,73 @@ Possible causes:
 
 Current executable: %s (format: %s)
 Available DWARF functions: %d
-
+%s
 Solutions:
 • Build with debug info: go build (default)
 • For tests: use -ldflags=""
 
 Function: %s | Expected parameters: %d`,
-		funcName, execPath, format, len(dr.functionMap), funcName, paramCount)
+		funcName, execPath, format, dr.functions.len(), didYouMean, funcName, paramCount)
+}
+
+// logCandidateMatch logs lookups matched by a name other than the runtime
+// name: the candidates of generateFunctionKeyCandidates and the normalizers
+// may pick the entry of another function.
+func logCandidateMatch(funcName, candidate string) {
+	if candidate != funcName {
+		Logger().Debug("dwarfreflect: DWARF entry matched by candidate name", "function", funcName, "entry", candidate)
+	}
+}
+
+// lookupMethodValue finds the DWARF entry of the method behind a method value
+// wrapper ("pkg.(*T).Method-fm"). The wrapper's own entry may lack the real
+// parameter names, while the method's entry lists the receiver first and then
+// the same parameters. Reports false for other functions or when the method
+// has no entry with at least the receiver and paramCount parameters.
+func (dr *DWARFResolver) lookupMethodValue(funcName string, paramCount int) ([]string, string, bool) {
+	method, ok := strings.CutSuffix(funcName, "-fm")
+	if !ok {
+		return nil, "", false
+	}
+
+	for _, candidate := range dr.candidates(method) {
+		if allParams, exists := dr.functions.lookup(candidate); exists && len(allParams) > paramCount {
+			return allParams, candidate, true
+		}
+	}
+	return nil, "", false
+}
+
+// discoverResultNames returns result parameter names, which DWARF lists right after
+// the input parameters. Unnamed results (~r0, ~r1, ...) are reported as r0, r1, ...
+func (dr *DWARFResolver) discoverResultNames(funcName string, paramCount, resultCount int) []string {
+	names := positionalResultNames(resultCount)
+
+	if allParams, _, ok := dr.lookupMethodValue(funcName, paramCount); ok {
+		if len(allParams) == 1+paramCount+resultCount {
+			for i, name := range allParams[1+paramCount:] {
+				if !strings.HasPrefix(name, "~") {
+					names[i] = name
+				}
+			}
+		}
+		return names
+	}
+
+	for _, candidate := range dr.candidates(funcName) {
+		if allParams, exists := dr.functions.lookup(candidate); exists {
+			if len(allParams) == paramCount+resultCount {
+				for i, name := range allParams[paramCount:] {
+					if !strings.HasPrefix(name, "~") {
+						names[i] = name
+					}
+				}
+			}
+			break
+		}
+	}
+
+	return names
 }
 
 // generateFunctionKeyCandidates creates possible lookup keys from runtime function name
@@ -309,46 +606,119 @@ func generateFunctionKeyCandidates(runtimeName string) []string {
 func extractPackagePath(funcName string) string {
 	// Handle function names like:
 	// "main.funcName" -> "main"
-	// "github.com/user/repo/pkg.funcName" -> "github.com/user/repo/pkg"
 	// "github.com/user/repo/pkg.(*Type).Method" -> "github.com/user/repo/pkg"
+	// "example.com/lib/v2.Do[...]" -> "example.com/lib/v2"
+	// "pkg.Map[example.com/x.T]" -> "pkg"
+	// "gopkg.in/yaml%2ev3.Marshal" -> "gopkg.in/yaml.v3"
+	if pkg := symbols.Parse(funcName).Package; pkg != "" {
+		return pkg
+	}
+	return "main" // No package qualifier, probably "funcName"
+}
 
-	lastSlash := strings.LastIndex(funcName, "/")
-	if lastSlash == -1 {
-		// No slashes, probably "main.funcName"
-		parts := strings.Split(funcName, ".")
-		if len(parts) > 1 {
-			return parts[0]
+// buildModules lists the module paths of the running binary, main module first.
+var buildModules = sync.OnceValue(func() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	modules := []string{info.Main.Path}
+	for _, dep := range info.Deps {
+		modules = append(modules, dep.Path)
+	}
+	return modules
+})
+
+// testedPackage returns the import path of the package tested by the external
+// test package pkgPath, "example.com/x" for "example.com/x_test", and reports
+// whether pkgPath is one. Other paths are returned unchanged.
+func testedPackage(pkgPath string) (string, bool) {
+	tested, ok := strings.CutSuffix(pkgPath, "_test")
+	if !ok || tested == "" || strings.HasSuffix(tested, "/") {
+		return pkgPath, false
+	}
+	return tested, true
+}
+
+// modulePathFor returns the module providing pkgPath: the longest matching
+// module path among modules (main module first), or a guess from the path
+// shape when none matches, e.g. in binaries built without module support.
+// External test packages belong to the module of the package they test.
+func modulePathFor(pkgPath string, modules []string) string {
+	pkgPath, _ = testedPackage(pkgPath)
+	if pkgPath == "main" {
+		if len(modules) > 0 && modules[0] != "" {
+			return modules[0]
 		}
 		return "main"
 	}
 
-	// Find the first dot after the last slash
-	remaining := funcName[lastSlash+1:]
-	firstDot := strings.Index(remaining, ".")
-	if firstDot == -1 {
-		return funcName // Fallback
+	best := ""
+	for _, mod := range modules {
+		if mod != "" && len(mod) > len(best) && (pkgPath == mod || strings.HasPrefix(pkgPath, mod+"/")) {
+			best = mod
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	elems := strings.Split(pkgPath, "/")
+	if !strings.Contains(elems[0], ".") {
+		return "std" // Standard library packages have no domain
+	}
+
+	switch elems[0] {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		// Module roots are host/owner/repo, optionally with a major version suffix
+		if len(elems) >= 3 {
+			n := 3
+			if len(elems) > 3 && isMajorVersion(elems[3]) {
+				n = 4
+			}
+			return strings.Join(elems[:n], "/")
+		}
+	case "gopkg.in":
+		// gopkg.in/pkg.v3 or gopkg.in/user/pkg.v3
+		for i, elem := range elems {
+			if strings.Contains(elem, ".v") {
+				return strings.Join(elems[:i+1], "/")
+			}
+		}
+	}
+
+	for i := len(elems) - 1; i > 0; i-- {
+		if isMajorVersion(elems[i]) {
+			return strings.Join(elems[:i+1], "/")
+		}
 	}
+	return pkgPath
+}
 
-	return funcName[:lastSlash+1+firstDot]
+// isMajorVersion reports whether elem is a major version suffix such as "v2".
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' || elem[1] == '0' {
+		return false
+	}
+	for _, r := range elem[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return elem != "v1"
 }
 
 // GetDWARFStatus returns information about DWARF debug info availability
 func GetDWARFStatus() (available bool, funcCount int, err error) {
-	resolverOnce.Do(initResolver)
-
-	if resolverInitErr != nil {
-		return false, 0, resolverInitErr
+	if err := loadResolver(); err != nil {
+		return false, 0, err
 	}
 
 	if globalResolver.dwarfData == nil {
 		return false, 0, fmt.Errorf("DWARF debug information not available")
 	}
 
-	globalResolver.mu.RLock()
-	funcCount = len(globalResolver.functionMap)
-	globalResolver.mu.RUnlock()
-
-	return true, funcCount, nil
+	return true, globalResolver.functions.len(), nil
 }
 
 // GetExecutableInfo returns information about the current executable
@@ -422,11 +792,9 @@ func TestDWARFExtraction() (int, error) {
 	}
 
 	// Create a test resolver
-	resolver := &DWARFResolver{
-		functionMap: make(map[string][]string),
-	}
+	resolver := &DWARFResolver{}
 
-	if err := resolver.loadDWARFData(); err != nil {
+	if err := resolver.loadDWARFData(""); err != nil {
 		return 0, fmt.Errorf("DWARF extraction failed (%s format, %s): %v", format, execPath, err)
 	}
 
@@ -445,24 +813,19 @@ func TestDWARFExtraction() (int, error) {
 		return 0, fmt.Errorf("no DWARF entries found")
 	}
 
-	return len(resolver.functionMap), nil
+	return resolver.functions.len(), nil
 }
 
 // DebugDWARFParameters helps debug parameter extraction issues by showing all DWARF parameters
 func DebugDWARFParameters(funcName string) (inputParams []string, allParams []string, err error) {
-	resolverOnce.Do(initResolver)
-
-	if resolverInitErr != nil {
-		return nil, nil, resolverInitErr
+	if err := loadResolver(); err != nil {
+		return nil, nil, err
 	}
 
-	globalResolver.mu.RLock()
-	defer globalResolver.mu.RUnlock()
-
-	candidates := generateFunctionKeyCandidates(funcName)
+	candidates := globalResolver.candidates(funcName)
 
 	for _, candidate := range candidates {
-		if params, exists := globalResolver.functionMap[candidate]; exists {
+		if params, exists := globalResolver.functions.lookup(candidate); exists {
 			allParams = params
 			break
 		}
@@ -492,21 +855,14 @@ func DebugDWARFParameters(funcName string) (inputParams []string, allParams []st
 
 // GetAllDWARFFunctions returns all functions found in DWARF data for debugging
 func GetAllDWARFFunctions() map[string][]string {
-	resolverOnce.Do(initResolver)
-
-	if resolverInitErr != nil {
+	if loadResolver() != nil {
 		return map[string][]string{}
 	}
 
-	globalResolver.mu.RLock()
-	defer globalResolver.mu.RUnlock()
-
-	// Return a copy to avoid concurrent access issues
-	result := make(map[string][]string)
-	for k, v := range globalResolver.functionMap {
-		paramsCopy := make([]string, len(v))
-		copy(paramsCopy, v)
-		result[k] = paramsCopy
+	// The index hands out copies
+	result := make(map[string][]string, globalResolver.functions.len())
+	for k, v := range globalResolver.functions.all() {
+		result[k] = v
 	}
 
 	return result
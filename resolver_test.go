@@ -4,8 +4,10 @@
 package dwarfreflect
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
@@ -153,6 +155,36 @@ func TestGenerateFunctionKeyCandidates(t *testing.T) {
 	}
 }
 
+func TestDWARFResolver_MethodValue(t *testing.T) {
+	resolver := &DWARFResolver{
+		functions: indexOf(map[string][]string{
+			// Wrapper entries may carry placeholder names instead of the real ones
+			"pkg.(*T).Method-fm": {"~p0", "~p1", "~r0"},
+			"pkg.(*T).Method":    {"t", "prefix", "num", "out"},
+		}),
+		normalizers: defaultNormalizers,
+	}
+
+	names, key, err := resolver.lookupParameterNames("pkg.(*T).Method-fm", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key != "pkg.(*T).Method" || len(names) != 2 || names[0] != "prefix" || names[1] != "num" {
+		t.Errorf("expected [prefix num] from the method entry, got %v from %q", names, key)
+	}
+
+	results := resolver.discoverResultNames("pkg.(*T).Method-fm", 2, 1)
+	if len(results) != 1 || results[0] != "out" {
+		t.Errorf("expected result [out], got %v", results)
+	}
+
+	// Without the method entry, the wrapper entry is still used
+	resolver.functions = indexOf(map[string][]string{"pkg.(*T).Method-fm": {"~p0", "~p1", "~r0"}})
+	if _, key, err := resolver.lookupParameterNames("pkg.(*T).Method-fm", 2); err != nil || key != "pkg.(*T).Method-fm" {
+		t.Errorf("expected wrapper fallback, got %q, %v", key, err)
+	}
+}
+
 func TestExtractPackagePath(t *testing.T) {
 	tests := []struct {
 		funcName string
@@ -165,6 +197,12 @@ func TestExtractPackagePath(t *testing.T) {
 		{"github.com/user/repo/pkg.Type.Method", "github.com/user/repo/pkg"},
 		{"funcNameOnly", "main"},
 		{"github.com/org/project/internal/sub/pkg.Function", "github.com/org/project/internal/sub/pkg"},
+		{"example.com/lib/v2.Do[...]", "example.com/lib/v2"},
+		{"example.com/lib/v2.(*Cache[...]).Get", "example.com/lib/v2"},
+		{"pkg.Map[example.com/x.T]", "pkg"},
+		{"example.com/lib.Map[example.com/x/y.T,int].func1", "example.com/lib"},
+		{"gopkg.in/yaml%2ev3.Marshal", "gopkg.in/yaml.v3"},
+		{"example.com/lib/v2.Do.func1.2", "example.com/lib/v2"},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +215,50 @@ func TestExtractPackagePath(t *testing.T) {
 	}
 }
 
+func TestModulePathFor(t *testing.T) {
+	modules := []string{"example.com/app", "example.com/lib/v2", "example.com/lib/v2/contrib", "github.com/user/repo"}
+
+	tests := []struct {
+		pkgPath  string
+		modules  []string
+		expected string
+	}{
+		{"main", modules, "example.com/app"},
+		{"main", nil, "main"},
+		{"example.com/app/internal/db", modules, "example.com/app"},
+		{"example.com/lib/v2", modules, "example.com/lib/v2"},
+		{"example.com/lib/v2/codec", modules, "example.com/lib/v2"},
+		{"example.com/app_test", modules, "example.com/app"},
+		{"github.com/user/repo_test", nil, "github.com/user/repo"},
+		{"example.com/lib/v2/contrib/otel", modules, "example.com/lib/v2/contrib"},
+		{"example.com/application", modules, "example.com/application"},
+		{"fmt", modules, "std"},
+		{"encoding/json", nil, "std"},
+		{"github.com/user/repo/pkg", nil, "github.com/user/repo"},
+		{"github.com/user/repo/v3/pkg", nil, "github.com/user/repo/v3"},
+		{"github.com/user/repo/v1/pkg", nil, "github.com/user/repo"},
+		{"gopkg.in/yaml.v3", nil, "gopkg.in/yaml.v3"},
+		{"gopkg.in/user/pkg.v2/sub", nil, "gopkg.in/user/pkg.v2"},
+		{"example.com/other/v4/sub", nil, "example.com/other/v4"},
+		{"example.com/other/sub", nil, "example.com/other/sub"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pkgPath, func(t *testing.T) {
+			if got := modulePathFor(tt.pkgPath, tt.modules); got != tt.expected {
+				t.Errorf("modulePathFor(%q) = %q, want %q", tt.pkgPath, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+	if got := fn.ModulePath(); got != "github.com/matteo-grella/dwarfreflect" {
+		t.Errorf("expected module github.com/matteo-grella/dwarfreflect, got %q", got)
+	}
+}
+
 func TestDWARFResolver_extractParametersFromDWARF(t *testing.T) {
 	// This test would require mocking dwarf.Reader, which is complex
 	// Instead, we'll test the integration with a real function
@@ -302,6 +384,148 @@ func TestDebugDWARFParameters(t *testing.T) {
 	}
 }
 
+func TestNewResolverFromReader(t *testing.T) {
+	execPath, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(execPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dr, err := NewResolverFromReader(bytes.NewReader(data), FormatUnknown)
+	if err != nil {
+		if strings.Contains(err.Error(), "DWARF") {
+			t.Skipf("DWARF not available: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, ok := dr.ParameterNames("github.com/matteo-grella/dwarfreflect.testFunc1")
+	if !ok || len(names) < 2 || names[0] != "name" || names[1] != "age" {
+		t.Errorf("expected [name age ...], got %v", names)
+	}
+	if _, ok := dr.ParameterNames("missing.function"); ok {
+		t.Error("expected missing function not to be found")
+	}
+
+	diff, err := DiffResolvers(dr, dr, "github.com/matteo-grella/dwarfreflect.")
+	if err != nil || !diff.Empty() {
+		t.Errorf("expected empty diff against itself, got %+v, %v", diff, err)
+	}
+}
+
+//go:generate go run ./testdata/genfixtures
+
+// TestResolverFixtures resolves tiny prebuilt executables of every format,
+// so format handling is tested on any host. See testdata/genfixtures.
+func TestResolverFixtures(t *testing.T) {
+	tests := []struct {
+		file   string
+		format ExecutableFormat
+	}{
+		{"greet.elf", FormatELF},
+		{"greet.exe", FormatPE},
+		{"greet.macho", FormatMachO},
+		{"greet.fat", FormatMachO},
+	}
+	want := map[string][]string{
+		"main.Greet":            {"name", "times", "~r0"},
+		"main.(*Server).Handle": {"s", "path", "code", "~r0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", "fixtures", tt.file)
+			format, err := DetectExecutableFormat(path)
+			if err != nil || format != tt.format {
+				t.Fatalf("expected %v, got %v, %v", tt.format, format, err)
+			}
+
+			fromPath, err := NewDWARFResolver(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fromReader, err := NewResolverFromReader(bytes.NewReader(data), tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, dr := range []*DWARFResolver{fromPath, fromReader} {
+				for funcName, expected := range want {
+					if names, ok := dr.ParameterNames(funcName); !ok || !reflect.DeepEqual(names, expected) {
+						t.Errorf("%s: expected %v, got %v", funcName, expected, names)
+					}
+				}
+				if _, ok := dr.ParameterNames("main.main"); ok {
+					t.Error("expected main.main not to be found")
+				}
+			}
+		})
+	}
+}
+
+// TestResolverFixtures_DWARF5 resolves fixtures with DWARF 5 string forms,
+// nested entries and names of unexpected forms.
+func TestResolverFixtures_DWARF5(t *testing.T) {
+	want := map[string][]string{
+		"main.Greet":            {"name", "times", "~r0"},
+		"main.(*Server).Handle": {"s", "path", "~p2", "~r0"},
+	}
+
+	for _, file := range []string{"greet5.elf", "greet5.exe", "greet5.macho"} {
+		t.Run(file, func(t *testing.T) {
+			dr, err := NewDWARFResolver(filepath.Join("testdata", "fixtures", file))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for funcName, expected := range want {
+				if names, ok := dr.ParameterNames(funcName); !ok || !reflect.DeepEqual(names, expected) {
+					t.Errorf("%s: expected %v, got %v", funcName, expected, names)
+				}
+			}
+			if dr.functions.len() != len(want) {
+				t.Errorf("expected only %d functions, got %d", len(want), dr.functions.len())
+			}
+		})
+	}
+}
+
+func TestNewResolverFromReader_Errors(t *testing.T) {
+	if _, err := NewResolverFromReader(bytes.NewReader([]byte("not a binary")), FormatUnknown); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := NewResolverFromReader(bytes.NewReader([]byte("\x7fELF garbage")), FormatELF); err == nil {
+		t.Error("expected error for malformed ELF")
+	}
+}
+
+func TestDetectExecutableFormatFromReader(t *testing.T) {
+	tests := []struct {
+		magic    []byte
+		expected ExecutableFormat
+	}{
+		{[]byte{0x7f, 'E', 'L', 'F'}, FormatELF},
+		{[]byte{'M', 'Z', 0, 0}, FormatPE},
+		{[]byte{0xcf, 0xfa, 0xed, 0xfe}, FormatMachO},
+		{[]byte{0xca, 0xfe, 0xba, 0xbe}, FormatMachO},
+	}
+	for _, tt := range tests {
+		got, err := DetectExecutableFormatFromReader(bytes.NewReader(tt.magic))
+		if err != nil || got != tt.expected {
+			t.Errorf("%x: expected %v, got %v, %v", tt.magic, tt.expected, got, err)
+		}
+	}
+	if _, err := DetectExecutableFormatFromReader(bytes.NewReader([]byte{1})); err == nil {
+		t.Error("expected error for short input")
+	}
+}
+
 func TestGetAllDWARFFunctions(t *testing.T) {
 	functions := GetAllDWARFFunctions()
 
@@ -326,11 +550,9 @@ func TestGetAllDWARFFunctions(t *testing.T) {
 }
 
 func TestDWARFResolver_loadDWARFData(t *testing.T) {
-	resolver := &DWARFResolver{
-		functionMap: make(map[string][]string),
-	}
+	resolver := &DWARFResolver{}
 
-	err := resolver.loadDWARFData()
+	err := resolver.loadDWARFData("")
 
 	// This might fail if test binary has no DWARF
 	if err != nil {
@@ -418,8 +640,8 @@ func TestResolverInitialization(t *testing.T) {
 	}
 
 	// Verify resolver has required fields
-	if globalResolver.functionMap == nil {
-		t.Error("functionMap should be initialized")
+	if globalResolver.functions.len() == 0 {
+		t.Error("functions should be indexed")
 	}
 
 	// Test that multiple calls don't panic (sync.Once ensures only first call executes)
@@ -461,3 +683,58 @@ func BenchmarkExtractPackagePath(b *testing.B) {
 		}
 	}
 }
+
+// Benchmark for indexing the functions of a binary, the test binary itself
+// unless DWARFREFLECT_BENCH_BINARY names another one, e.g. a large service.
+// retained-B/op is the heap the resolver keeps alive.
+func BenchmarkIndexFunctions(b *testing.B) {
+	path := os.Getenv("DWARFREFLECT_BENCH_BINARY")
+	if path == "" {
+		path = os.Args[0]
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := NewResolverFromReader(bytes.NewReader(data), FormatUnknown); err != nil {
+		b.Skipf("no DWARF in %s: %v", path, err)
+	}
+
+	var retained uint64
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		dr, _ := NewResolverFromReader(bytes.NewReader(data), FormatUnknown)
+		dr.dwarfData = nil // drop what the resolver only needs for later lookups
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		retained += after.HeapAlloc - before.HeapAlloc
+		runtime.KeepAlive(dr)
+	}
+	b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+}
+
+// BenchmarkLookupParameterNames_Parallel measures lookups from many
+// goroutines, as when functions are wrapped per request. Run with -cpu 1,4,16
+// to see how it scales.
+func BenchmarkLookupParameterNames_Parallel(b *testing.B) {
+	initResolver()
+	if resolverInitErr != nil {
+		b.Skipf("DWARF not available: %v", resolverInitErr)
+	}
+	funcName := runtime.FuncForPC(reflect.ValueOf(testFunc1).Pointer()).Name()
+	if _, _, err := globalResolver.lookupParameterNames(funcName, 2); err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			globalResolver.lookupParameterNames(funcName, 2)
+		}
+	})
+}
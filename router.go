@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ErrUnknownMethod is returned by Router.Dispatch for methods not in the
+// registry, the equivalent of an HTTP 404.
+var ErrUnknownMethod = errors.New("dwarfreflect: unknown method")
+
+// BindingError reports a payload that does not fit the target function's
+// parameters, the equivalent of an HTTP 422. Param is empty when the error is
+// not specific to a parameter.
+type BindingError struct {
+	Method string
+	Param  string
+	Err    error
+}
+
+func (e *BindingError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("method %s: %v", e.Method, e.Err)
+	}
+	return fmt.Sprintf("method %s: parameter %q: %v", e.Method, e.Param, e.Err)
+}
+
+func (e *BindingError) Unwrap() error {
+	return e.Err
+}
+
+// Router dispatches calls by method name to the functions of a Registry,
+// validating named-argument payloads against the target signature first.
+type Router struct {
+	registry       *Registry
+	limits         Limits
+	idempotency    IdempotencyStore
+	idempotencyTTL time.Duration
+}
+
+// NewRouter creates a Router over the functions registered in registry.
+//
+// Example:
+//
+//	router := dwarfreflect.NewRouter(reg)
+//	results, err := router.Dispatch(ctx, "users.Create", body)
+//	switch {
+//	case errors.Is(err, dwarfreflect.ErrUnknownMethod):
+//	    http.Error(w, err.Error(), http.StatusNotFound)
+//	case errors.As(err, &bindErr):
+//	    http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+//	}
+func NewRouter(registry *Registry) *Router {
+	return &Router{registry: registry}
+}
+
+// WithLimits returns a copy of the Router enforcing limits on payloads and
+// decoded arguments. Violations are reported as a *BindingError wrapping a
+// *LimitError.
+//
+// Example:
+//
+//	router = router.WithLimits(dwarfreflect.Limits{MaxStringLength: 1 << 16, MaxDepth: 8})
+func (rt *Router) WithLimits(limits Limits) *Router {
+	clone := *rt
+	clone.limits = limits
+	return &clone
+}
+
+// Dispatch decodes a JSON object of named arguments and calls the function
+// registered under method. Errors wrapping ErrUnknownMethod mean the method
+// does not exist; a *BindingError means the payload has unknown, missing
+// (required and without a ParamMeta.Default) or mistyped parameters. In both cases the
+// function is not called. context.Context parameters receive ctx.
+func (rt *Router) Dispatch(ctx context.Context, method string, payload []byte) ([]reflect.Value, error) {
+	fn, args, err := rt.bind(method, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	return fn.CallWithContext(ctx, args...)
+}
+
+// boundArgs collects the named arguments decoded by Router.bind, functional
+// options included by their own name.
+type boundArgs struct {
+	names  []string
+	values []reflect.Value
+}
+
+func (b *boundArgs) add(name string, value reflect.Value) {
+	if b != nil {
+		b.names, b.values = append(b.names, name), append(b.values, value)
+	}
+}
+
+// bind implements Dispatch up to the call, returning the function registered
+// under method and its arguments. The named arguments are also collected into
+// named when it is non-nil.
+func (rt *Router) bind(method string, payload []byte, named *boundArgs) (*Function, []any, error) {
+	fn, ok := rt.registry.Get(method)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownMethod, method)
+	}
+
+	if err := rt.limits.checkJSON(fn.funcName, payload); err != nil {
+		return nil, nil, &BindingError{Method: method, Err: err}
+	}
+
+	var raw map[string]json.RawMessage
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &raw); err != nil {
+			return nil, nil, &BindingError{Method: method, Err: fmt.Errorf("payload must be a JSON object: %w", err)}
+		}
+	}
+
+	for alias, param := range fn.aliases {
+		data, present := raw[alias]
+		if !present {
+			continue
+		}
+		if _, exists := raw[param]; exists {
+			return nil, nil, &BindingError{Method: method, Param: param, Err: fmt.Errorf("given both by name and as %q", alias)}
+		}
+		delete(raw, alias)
+		raw[param] = data
+	}
+
+	for _, name := range fn.scoped {
+		if _, present := raw[name]; present {
+			return nil, nil, &BindingError{Method: method, Param: name, Err: fmt.Errorf("%w cannot be set by the payload", ErrScopedParameter)}
+		}
+	}
+
+	names, types := fn.GetNonContextParameters()
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	for name := range fn.options {
+		known[name] = true
+	}
+	var unknown []string
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, nil, &BindingError{Method: method, Err: fmt.Errorf("unknown parameters %v (expected %v)", unknown, names)}
+	}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		data, present := raw[name]
+		if !present {
+			meta := fn.paramMeta[name]
+			switch {
+			case meta.Default != nil:
+				args[i] = meta.Default
+				named.add(name, reflect.ValueOf(meta.Default))
+			case fn.isOptional(name, types[i]):
+				args[i] = reflect.Zero(types[i]).Interface()
+				named.add(name, reflect.Zero(types[i]))
+			default:
+				return nil, nil, &BindingError{Method: method, Param: name, Err: errors.New("missing required parameter")}
+			}
+			continue
+		}
+
+		if isUnsafeType(types[i]) {
+			return nil, nil, &BindingError{Method: method, Param: name, Err: &UnsafeParameterError{Function: fn.funcName, Param: name, Type: types[i]}}
+		}
+
+		v := reflect.New(types[i])
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, nil, &BindingError{Method: method, Param: name, Err: err}
+		}
+		if rt.limits.enabled() {
+			if err := rt.limits.check(fn.funcName, name, v.Elem()); err != nil {
+				return nil, nil, &BindingError{Method: method, Param: name, Err: err}
+			}
+		}
+		args[i] = v.Elem().Interface()
+		if !fn.isVariadicParam(name) || len(fn.options) == 0 {
+			named.add(name, v.Elem())
+		}
+	}
+
+	if len(fn.options) > 0 {
+		options := make(map[string]any)
+		for name := range fn.options {
+			if data, present := raw[name]; present {
+				v := reflect.New(fn.optionArgType(name))
+				if err := json.Unmarshal(data, v.Interface()); err != nil {
+					return nil, nil, &BindingError{Method: method, Param: name, Err: err}
+				}
+				options[name] = v.Elem().Interface()
+				named.add(name, v.Elem())
+			}
+		}
+		last := len(args) - 1
+		variadic, err := fn.bindOptions(options, reflect.ValueOf(args[last]))
+		if err != nil {
+			return nil, nil, &BindingError{Method: method, Err: err}
+		}
+		args[last] = variadic.Interface()
+	}
+
+	return fn, args, nil
+}
+
+// DispatchResponse is like Dispatch, returning the results ready to be
+// encoded as the response: a *Page for paginated functions (see Paginated)
+// and the result struct of ResultsToStruct for the others. The trailing error
+// of the function is returned as is.
+//
+// Example:
+//
+//	response, err := router.DispatchResponse(ctx, "users.List", body)
+//	json.NewEncoder(w).Encode(response) // {"items":[...],"nextCursor":"u20","hasMore":true}
+func (rt *Router) DispatchResponse(ctx context.Context, method string, payload []byte) (any, error) {
+	results, err := rt.Dispatch(ctx, method, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, _ := rt.registry.Get(method)
+	return responseOf(fn, results)
+}
+
+// responseOf returns the response of DispatchResponse for the results of fn.
+func responseOf(fn *Function, results []reflect.Value) (any, error) {
+	if fn.IsPaginated() {
+		return fn.ResultsToPage(results)
+	}
+	return fn.ResultsToStruct(results)
+}
+
+// Methods returns the method names the Router dispatches to, in sorted order.
+func (rt *Router) Methods() []string {
+	return rt.registry.Names()
+}
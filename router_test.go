@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newTestRouter(t *testing.T) *Router {
+	t.Helper()
+	reg := NewRegistry()
+	mustRegister(t, reg, "greet", testFunc1)
+	mustRegister(t, reg, "lookup", testFunc4)
+	return NewRouter(reg)
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	router := newTestRouter(t)
+
+	results, err := router.Dispatch(context.Background(), "greet", []byte(`{"name":"Ann","age":4}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "Ann is 4 years old" {
+		t.Errorf("unexpected result: %s", got)
+	}
+
+	results, err = router.Dispatch(context.Background(), "lookup", []byte(`{"id":1,"name":"x"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(results))
+	}
+}
+
+func TestRouter_UnknownMethod(t *testing.T) {
+	_, err := newTestRouter(t).Dispatch(context.Background(), "missing", nil)
+	if !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("expected ErrUnknownMethod, got %v", err)
+	}
+}
+
+func TestRouter_BindingErrors(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		payload string
+		param   string
+	}{
+		{`[1, 2]`, ""},
+		{`{"name":"Ann","age":4,"extra":true}`, ""},
+		{`{"name":"Ann"}`, "age"},
+		{`{"name":"Ann","age":"four"}`, "age"},
+	}
+	for _, tt := range tests {
+		_, err := router.Dispatch(context.Background(), "greet", []byte(tt.payload))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Errorf("%s: expected *BindingError, got %v", tt.payload, err)
+			continue
+		}
+		if bindErr.Method != "greet" || bindErr.Param != tt.param {
+			t.Errorf("%s: unexpected binding error: %+v", tt.payload, bindErr)
+		}
+	}
+}
+
+func TestRouter_Defaults(t *testing.T) {
+	reg := NewRegistry()
+	fn := mustNewFunction(t, testFunc1).WithParamMeta("age", ParamMeta{Default: 18})
+	mustRegister(t, reg, "greet", fn)
+
+	results, err := NewRouter(reg).Dispatch(context.Background(), "greet", []byte(`{"name":"Ann"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "Ann is 18 years old" {
+		t.Errorf("unexpected result: %s", got)
+	}
+}
+
+func TestRouter_DispatchResponse(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "letters", mustNewFunction(t, testFuncListAfter).With(
+		Paginated(PaginationOptions{CursorParam: "after", LimitParam: "first"}),
+	))
+	mustRegister(t, reg, "greet", testFunc1)
+	router := NewRouter(reg)
+
+	response, err := router.DispatchResponse(context.Background(), "letters", []byte(`{"cursor":"3","limit":5}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(response)
+	if got := string(data); got != `{"items":["d","e"],"hasMore":false}` {
+		t.Errorf("unexpected response: %s", got)
+	}
+
+	_, err = router.DispatchResponse(context.Background(), "letters", []byte(`{"cursor":"3","after":"1","limit":5}`))
+	var bindErr *BindingError
+	if !errors.As(err, &bindErr) || bindErr.Param != "after" {
+		t.Errorf("expected a binding error for a parameter given twice, got %v", err)
+	}
+
+	response, err = router.DispatchResponse(context.Background(), "greet", []byte(`{"name":"Ann","age":3}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := json.Marshal(response); string(data) != `{"r0":"Ann is 3 years old"}` {
+		t.Errorf("unexpected response: %s", data)
+	}
+}
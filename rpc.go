@@ -0,0 +1,284 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"reflect"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RPCServer exposes a Registry as a net/rpc service.
+// Requests are decoded into the generated non-context parameter structs and
+// replies are the generated result structs, so existing net/rpc clients calling
+// "Service.Method" with args/reply structs of matching field names keep working.
+// A trailing error returned by the function is sent as the RPC error, and so
+// is a panic. Functions with unsafe parameters (see GetUnsafePositions) are
+// not served.
+type RPCServer struct {
+	serviceName string
+	registry    *Registry
+}
+
+// NewRPCServer creates a net/rpc compatible server for the functions in registry.
+// Clients address functions as serviceName + "." + registered name.
+//
+// Example:
+//
+//	reg := dwarfreflect.NewRegistry()
+//	reg.Register("Add", Add) // func Add(a, b int) (sum int)
+//	srv := dwarfreflect.NewRPCServer("Math", reg)
+//	go srv.Accept(listener)
+//
+//	// client side, unchanged net/rpc code:
+//	var reply struct{ Sum int }
+//	client.Call("Math.Add", struct{ A, B int }{1, 2}, &reply)
+func NewRPCServer(serviceName string, registry *Registry) *RPCServer {
+	return &RPCServer{
+		serviceName: serviceName,
+		registry:    registry,
+	}
+}
+
+// Accept accepts connections on the listener and serves requests for each
+// incoming connection. Accept blocks until the listener returns an error.
+func (s *RPCServer) Accept(lis net.Listener) error {
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn runs the server on a single connection using the gob codec,
+// like rpc.ServeConn. It blocks until the client hangs up.
+func (s *RPCServer) ServeConn(conn io.ReadWriteCloser) {
+	buf := bufio.NewWriter(conn)
+	s.ServeCodec(&gobServerCodec{
+		rwc:    conn,
+		dec:    gob.NewDecoder(conn),
+		enc:    gob.NewEncoder(buf),
+		encBuf: buf,
+	})
+}
+
+// ServeCodec is like ServeConn but uses the specified codec to decode
+// requests and encode responses.
+func (s *RPCServer) ServeCodec(codec rpc.ServerCodec) {
+	sending := new(sync.Mutex)
+	wg := new(sync.WaitGroup)
+
+	for {
+		var req rpc.Request
+		if err := codec.ReadRequestHeader(&req); err != nil {
+			break
+		}
+
+		fn, err := s.lookup(req.ServiceMethod)
+		if err != nil {
+			codec.ReadRequestBody(nil) // discard the body
+			s.sendResponse(sending, &req, struct{}{}, codec, err.Error())
+			continue
+		}
+
+		// gob ignores the json:"-" tags keeping unsafe parameters out of the
+		// generated struct, so functions taking them are not served
+		if positions := fn.GetUnsafePositions(); len(positions) > 0 {
+			codec.ReadRequestBody(nil) // discard the body
+			unsafeErr := &UnsafeParameterError{Function: fn.funcName, Param: fn.paramNames[positions[0]], Type: fn.paramTypes[positions[0]]}
+			s.sendResponse(sending, &req, struct{}{}, codec, unsafeErr.Error())
+			continue
+		}
+
+		params := fn.NewNonContextParamsPtr()
+		if err := codec.ReadRequestBody(params); err != nil {
+			s.sendResponse(sending, &req, struct{}{}, codec, err.Error())
+			continue
+		}
+
+		wg.Add(1)
+		go func(req rpc.Request) {
+			defer wg.Done()
+			s.call(sending, &req, fn, params, codec)
+		}(req)
+	}
+
+	wg.Wait()
+	codec.Close()
+}
+
+func (s *RPCServer) lookup(serviceMethod string) (*Function, error) {
+	dot := strings.LastIndex(serviceMethod, ".")
+	if dot < 0 || serviceMethod[:dot] != s.serviceName {
+		return nil, fmt.Errorf("rpc: can't find service %s", serviceMethod)
+	}
+
+	fn, ok := s.registry.Get(serviceMethod[dot+1:])
+	if !ok {
+		return nil, fmt.Errorf("rpc: can't find method %s", serviceMethod)
+	}
+	return fn, nil
+}
+
+func (s *RPCServer) call(sending *sync.Mutex, req *rpc.Request, fn *Function, params any, codec rpc.ServerCodec) {
+	ctx := WithCallMeta(context.Background(), CallMeta{
+		Transport: "net/rpc",
+		RequestID: strconv.FormatUint(req.Seq, 10),
+	})
+	defer func() {
+		if r := recover(); r != nil {
+			Logger().Error("rpc call panicked", "method", req.ServiceMethod, "seq", req.Seq, "panic", r, "stack", string(debug.Stack()))
+			s.sendResponse(sending, req, struct{}{}, codec, fmt.Sprintf("rpc: method %s panicked", req.ServiceMethod))
+		}
+	}()
+
+	results, err := fn.CallWithNonContextStructAndContext(ctx, params)
+	if err != nil {
+		s.sendResponse(sending, req, struct{}{}, codec, err.Error())
+		return
+	}
+
+	reply, err := fn.ResultsToStruct(results)
+	if err != nil {
+		s.sendResponse(sending, req, struct{}{}, codec, err.Error())
+		return
+	}
+
+	s.sendResponse(sending, req, reply, codec, "")
+}
+
+func (s *RPCServer) sendResponse(sending *sync.Mutex, req *rpc.Request, reply any, codec rpc.ServerCodec, errmsg string) {
+	resp := &rpc.Response{
+		ServiceMethod: req.ServiceMethod,
+		Seq:           req.Seq,
+		Error:         errmsg,
+	}
+
+	sending.Lock()
+	defer sending.Unlock()
+	codec.WriteResponse(resp, reply)
+}
+
+// gobServerCodec mirrors the unexported gob codec used by net/rpc.
+type gobServerCodec struct {
+	rwc    io.ReadWriteCloser
+	dec    *gob.Decoder
+	enc    *gob.Encoder
+	encBuf *bufio.Writer
+	closed bool
+}
+
+func (c *gobServerCodec) ReadRequestHeader(r *rpc.Request) error {
+	return c.dec.Decode(r)
+}
+
+func (c *gobServerCodec) ReadRequestBody(body any) error {
+	return c.dec.Decode(body)
+}
+
+func (c *gobServerCodec) WriteResponse(r *rpc.Response, body any) (err error) {
+	if err = c.enc.Encode(r); err != nil {
+		if c.encBuf.Flush() == nil {
+			// Gob couldn't encode the header. Should not happen, so if it does,
+			// shut down the connection to signal that the connection is broken.
+			c.Close()
+		}
+		return
+	}
+	if err = c.enc.Encode(body); err != nil {
+		if c.encBuf.Flush() == nil {
+			// Was a gob problem encoding the body but the header has been written.
+			// Shut down the connection to signal that the connection is broken.
+			c.Close()
+		}
+		return
+	}
+	return c.encBuf.Flush()
+}
+
+func (c *gobServerCodec) Close() error {
+	if c.closed {
+		// Only call c.rwc.Close once; otherwise the semantics are undefined.
+		return nil
+	}
+	c.closed = true
+	return c.rwc.Close()
+}
+
+// NewRPCStub returns a Function with the signature of contract whose calls are
+// sent to serviceMethod of an RPCServer through client, for trusted processes
+// sharing the binary and thus the DWARF-derived contract, e.g. a parent and
+// its child process. Named arguments are encoded with gob through the
+// generated parameter struct and replies are decoded into the result types.
+// contract must return a trailing error, which reports transport errors as
+// well as the remote function's error. A context.Context argument cancels the
+// wait for the reply. Interface values must be registered with gob.Register.
+//
+// Example:
+//
+//	client := rpc.NewClient(conn)
+//	contract, _ := dwarfreflect.NewFunction(Divide) // func Divide(ctx context.Context, a, b int) (int, error)
+//	stub, err := dwarfreflect.NewRPCStub(client, "Math.Divide", contract)
+//	results, err := stub.CallWithMap(map[string]any{"ctx": ctx, "a": 10, "b": 2})
+func NewRPCStub(client *rpc.Client, serviceMethod string, contract *Function) (*Function, error) {
+	if _, hasError := contract.GetReturnInfo(); !hasError {
+		return nil, fmt.Errorf("rpc stub for %s: function %s must return a trailing error", serviceMethod, contract.funcName)
+	}
+
+	paramStruct := contract.GetNonContextStructType()
+	resultStruct := contract.GetResultStructType()
+	returnTypes := contract.GetReturnTypes()
+	contextPositions := contract.GetContextPositions()
+
+	impl := func(args []reflect.Value) []reflect.Value {
+		ctx := context.Background()
+		params := reflect.New(paramStruct)
+		field := 0
+		for i, arg := range args {
+			if slices.Contains(contextPositions, i) {
+				if !arg.IsNil() {
+					ctx = arg.Interface().(context.Context)
+				}
+				continue
+			}
+			params.Elem().Field(field).Set(arg)
+			field++
+		}
+
+		reply := reflect.New(resultStruct)
+		var err error
+		select {
+		case call := <-client.Go(serviceMethod, params.Interface(), reply.Interface(), make(chan *rpc.Call, 1)).Done:
+			err = call.Error
+		case <-ctx.Done():
+			// The reply may still be decoded later: leave it alone
+			err, reply = ctx.Err(), reflect.New(resultStruct)
+		}
+
+		results := make([]reflect.Value, len(returnTypes))
+		for i := range resultStruct.NumField() {
+			results[i] = reply.Elem().Field(i)
+		}
+		results[len(results)-1] = reflect.Zero(errorType)
+		if err != nil {
+			results[len(results)-1] = reflect.ValueOf(&err).Elem()
+		}
+		return results
+	}
+
+	names, types := contract.GetParameterInfo()
+	return NewFunctionFromSignature(names, types, impl, returnTypes...)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rpcAdd(a, b int) (sum int) {
+	return a + b
+}
+
+func rpcDivide(ctx context.Context, a, b int) (quotient int, err error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func rpcPanic(a int) (sum int) {
+	panic("boom")
+}
+
+func newTestRPCClient(t *testing.T) *rpc.Client {
+	t.Helper()
+	reg := NewRegistry()
+	mustRegister(t, reg, "Add", rpcAdd)
+	mustRegister(t, reg, "Divide", rpcDivide)
+	mustRegister(t, reg, "Panic", rpcPanic)
+	mustRegister(t, reg, "Unsafe", testFuncUnsafe)
+
+	serverConn, clientConn := net.Pipe()
+	go NewRPCServer("Math", reg).ServeConn(serverConn)
+
+	client := rpc.NewClient(clientConn)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRPCServer(t *testing.T) {
+	client := newTestRPCClient(t)
+
+	var reply struct{ Sum int }
+	if err := client.Call("Math.Add", struct{ A, B int }{2, 3}, &reply); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.Sum != 5 {
+		t.Errorf("expected sum 5, got %d", reply.Sum)
+	}
+}
+
+func TestRPCServer_ContextAndError(t *testing.T) {
+	client := newTestRPCClient(t)
+
+	var reply struct{ Quotient int }
+	if err := client.Call("Math.Divide", struct{ A, B int }{10, 2}, &reply); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.Quotient != 5 {
+		t.Errorf("expected quotient 5, got %d", reply.Quotient)
+	}
+
+	err := client.Call("Math.Divide", struct{ A, B int }{1, 0}, &reply)
+	if err == nil || err.Error() != "division by zero" {
+		t.Errorf("expected division by zero error, got %v", err)
+	}
+}
+
+func TestRPCServer_UnknownMethod(t *testing.T) {
+	client := newTestRPCClient(t)
+
+	var reply struct{}
+	if err := client.Call("Math.Missing", struct{ A int }{1}, &reply); err == nil {
+		t.Error("expected error for unknown method")
+	}
+	if err := client.Call("Other.Add", struct{ A int }{1}, &reply); err == nil {
+		t.Error("expected error for unknown service")
+	}
+}
+
+func TestRPCServer_Unsafe(t *testing.T) {
+	client := newTestRPCClient(t)
+
+	var reply struct{ R0 string }
+	err := client.Call("Math.Unsafe", struct {
+		Label string
+		Addr  uintptr
+	}{"x", 12345}, &reply)
+	if err == nil || !strings.Contains(err.Error(), "unsafe type uintptr") {
+		t.Errorf("expected unsafe parameter error, got %v", err)
+	}
+}
+
+func TestRPCServer_Panic(t *testing.T) {
+	client := newTestRPCClient(t)
+
+	var reply struct{ Sum int }
+	if err := client.Call("Math.Panic", struct{ A int }{1}, &reply); err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Errorf("expected panic error, got %v", err)
+	}
+	if err := client.Call("Math.Add", struct{ A, B int }{1, 2}, &reply); err != nil || reply.Sum != 3 {
+		t.Errorf("expected the connection to keep serving, got %v, %d", err, reply.Sum)
+	}
+}
+
+type rpcUser struct {
+	Name  string
+	Roles []string
+}
+
+func rpcRename(user rpcUser, name string) (renamed rpcUser, changed bool, err error) {
+	if name == "" {
+		return user, false, errors.New("empty name")
+	}
+	user.Name = name
+	return user, true, nil
+}
+
+func rpcBlock(ctx context.Context, id int) (int, error) {
+	time.Sleep(time.Second) // the server calls with context.Background()
+	return id, nil
+}
+
+func rpcPing(name string) error {
+	return nil
+}
+
+func TestNewRPCStub(t *testing.T) {
+	client := newTestRPCClient(t)
+	contract := mustNewFunction(t, rpcDivide)
+
+	stub, err := NewRPCStub(client, "Math.Divide", contract)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, _ := stub.GetParameterInfo()
+	if !slices.Equal(names, []string{"ctx", "a", "b"}) {
+		t.Errorf("expected the contract's parameters, got %v", names)
+	}
+
+	results, err := stub.CallWithMap(map[string]any{"ctx": context.Background(), "a": 10, "b": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Int() != 5 || !results[1].IsNil() {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	results, _ = stub.Call(context.Background(), 1, 0)
+	var serverErr rpc.ServerError
+	if err, _ := results[1].Interface().(error); !errors.As(err, &serverErr) || serverErr != "division by zero" {
+		t.Errorf("expected the remote error, got %v", results[1])
+	}
+}
+
+func TestNewRPCStub_Structs(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "Rename", rpcRename)
+	mustRegister(t, reg, "Block", rpcBlock)
+	mustRegister(t, reg, "Ping", rpcPing)
+	serverConn, clientConn := net.Pipe()
+	go NewRPCServer("Users", reg).ServeConn(serverConn)
+	client := rpc.NewClient(clientConn)
+	defer client.Close()
+
+	stub, err := NewRPCStub(client, "Users.Rename", mustNewFunction(t, rpcRename))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := stub.Call(rpcUser{Name: "a", Roles: []string{"admin"}}, "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	renamed := results[0].Interface().(rpcUser)
+	if renamed.Name != "b" || !slices.Equal(renamed.Roles, []string{"admin"}) || !results[1].Bool() {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	ping, err := NewRPCStub(client, "Users.Ping", mustNewFunction(t, rpcPing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results, err := ping.Call("x"); err != nil || !results[0].IsNil() {
+		t.Errorf("unexpected ping results %v, %v", results, err)
+	}
+
+	// Cancelling the context stops waiting for the reply
+	block, err := NewRPCStub(client, "Users.Block", mustNewFunction(t, rpcBlock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	results, _ = block.Call(ctx, 1)
+	if err, _ := results[1].Interface().(error); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", results[1])
+	}
+}
+
+func TestNewRPCStub_RequiresError(t *testing.T) {
+	if _, err := NewRPCStub(nil, "Math.Add", mustNewFunction(t, rpcAdd)); err == nil {
+		t.Error("expected error for a contract without a trailing error")
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema (draft 2020-12) document, as generated by
+// Function.JSONSchema. It marshals to standard JSON Schema.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+
+	// Ref references a schema in the Defs of the root schema, e.g.
+	// "#/$defs/Node". Recursive types are described once in Defs and
+	// referenced wherever they occur.
+	Ref  string             `json:"$ref,omitempty"`
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+
+	// Nullable reports that null is accepted besides Type, as for pointers.
+	// It is encoded in the type, e.g. "type": ["string", "null"], or as an
+	// anyOf alternative for references.
+	Nullable bool `json:"-"`
+}
+
+// MarshalJSON encodes the schema, listing "null" among the types of nullable
+// schemas.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	switch {
+	case !s.Nullable:
+		return json.Marshal((*plain)(s))
+	case s.Ref != "":
+		rest := *s
+		rest.Ref, rest.Nullable = "", false
+		return json.Marshal(struct {
+			AnyOf []*Schema `json:"anyOf"`
+			*plain
+		}{[]*Schema{{Ref: s.Ref}, {Type: "null"}}, (*plain)(&rest)})
+	case s.Type != "":
+		return json.Marshal(struct {
+			Type []string `json:"type"`
+			*plain
+		}{[]string{s.Type, "null"}, (*plain)(s)})
+	default:
+		return json.Marshal((*plain)(s))
+	}
+}
+
+// JSONSchema returns the JSON Schema of the function's named arguments: an
+// object with one property per parameter, leaving out context.Context and
+// unsafe parameters. Descriptions, enums, defaults and examples come from
+// ParamMeta. Pointer parameters are nullable and optional; other parameters
+// are required unless they have a default. Use WithRequired to override.
+// Deprecated functions (see Deprecate) have a deprecated schema, described by
+// the deprecation message.
+// Recursive types such as linked lists and trees are described once in the
+// root's Defs and referenced with $ref.
+//
+// Example:
+//
+//	func CreateUser(ctx context.Context, userName string, age int) error
+//	data, _ := json.Marshal(fn.JSONSchema())
+//	// {"type":"object","properties":{"userName":{"type":"string"},"age":{"type":"integer"}},"required":["userName","age"]}
+func (t *Function) JSONSchema() *Schema {
+	names, types := t.GetNonContextParameters()
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(names))}
+	gen := newSchemaGenerator()
+
+	for i, name := range names {
+		if isUnsafeType(types[i]) {
+			continue
+		}
+
+		meta := t.paramMeta[name]
+		property := gen.schemaFor(types[i])
+		property.Description = meta.Description
+		property.Enum = meta.Enum
+		property.Default = meta.Default
+		property.Examples = meta.Examples
+		schema.Properties[name] = property
+
+		if meta.Default == nil && !t.isOptional(name, types[i]) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	if len(gen.defs) > 0 {
+		schema.Defs = gen.defs
+	}
+	if message, ok := t.Deprecation(); ok {
+		schema.Deprecated, schema.Description = true, "Deprecated: "+message
+	}
+
+	return schema
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// schemaGenerator builds schemas sharing the definitions of recursive
+// types, so linked lists and trees are described with $ref instead of being
+// expanded forever.
+type schemaGenerator struct {
+	defs      map[string]*Schema      // definitions of recursive types by name
+	refs      map[reflect.Type]string // definition names of recursive types
+	expanding map[reflect.Type]bool   // structs being described, to detect cycles
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		defs:      make(map[string]*Schema),
+		refs:      make(map[reflect.Type]string),
+		expanding: make(map[reflect.Type]bool),
+	}
+}
+
+// schemaFor returns the schema of values of typ as encoded by encoding/json:
+// pointers are nullable.
+func (g *schemaGenerator) schemaFor(typ reflect.Type) *Schema {
+	if typ.Kind() != reflect.Pointer {
+		return g.valueSchemaFor(typ)
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	schema := g.valueSchemaFor(typ)
+	schema.Nullable = schema.Type != "" || schema.Ref != ""
+	return schema
+}
+
+// valueSchemaFor returns the schema of values of the non-pointer type typ.
+func (g *schemaGenerator) valueSchemaFor(typ reflect.Type) *Schema {
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonUnmarshalerType):
+		return &Schema{} // Custom JSON encoding: anything goes
+	case typ.Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"} // base64
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(typ.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(typ.Elem())}
+	case reflect.Struct:
+		return g.structRef(typ)
+	default:
+		return &Schema{} // interfaces and anything else
+	}
+}
+
+// structRef describes a struct, returning a $ref when the struct is
+// recursive. A struct met again while it is being described is a cycle: it
+// gets a definition, which the outermost occurrence fills in.
+func (g *schemaGenerator) structRef(typ reflect.Type) *Schema {
+	if name, ok := g.refs[typ]; ok {
+		return &Schema{Ref: "#/$defs/" + name}
+	}
+	if g.expanding[typ] {
+		name := g.defName(typ)
+		g.refs[typ] = name
+		g.defs[name] = nil // reserved until the definition is complete
+		return &Schema{Ref: "#/$defs/" + name}
+	}
+
+	g.expanding[typ] = true
+	schema := g.structSchema(typ)
+	delete(g.expanding, typ)
+
+	name, recursive := g.refs[typ]
+	if !recursive {
+		return schema
+	}
+	g.defs[name] = schema
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// defName returns a definition name for typ unused by other types.
+func (g *schemaGenerator) defName(typ reflect.Type) string {
+	base := typ.Name()
+	if base == "" {
+		base = "Type"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := g.defs[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// structSchema describes the exported fields of a struct following their
+// json tags. Fields without omitempty are required.
+func (g *schemaGenerator) structSchema(typ reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+
+		// Untagged embedded structs are flattened by encoding/json
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if g.expanding[fieldType] {
+				continue // encoding/json ignores embedding cycles too
+			}
+			g.expanding[fieldType] = true
+			embedded := g.structSchema(fieldType)
+			delete(g.expanding, fieldType)
+			if ref, ok := g.refs[fieldType]; ok && g.defs[ref] == nil {
+				g.defs[ref] = embedded // also referenced by one of its fields
+			}
+			for key, property := range embedded.Properties {
+				schema.Properties[key] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
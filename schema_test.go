@@ -0,0 +1,235 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaBase struct {
+	ID int `json:"id"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Tags     []string          `json:"tags"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Manager  *schemaUser       `json:"manager,omitempty"`
+	Ignored  string            `json:"-"`
+	internal int
+}
+
+func TestSchemaFor(t *testing.T) {
+	tests := []struct {
+		typ      reflect.Type
+		expected string
+	}{
+		{reflect.TypeFor[bool](), `{"type":"boolean"}`},
+		{reflect.TypeFor[uint16](), `{"type":"integer"}`},
+		{reflect.TypeFor[*float64](), `{"type":["number","null"]}`},
+		{reflect.TypeFor[[]*string](), `{"type":"array","items":{"type":["string","null"]}}`},
+		{reflect.TypeFor[*any](), `{}`},
+		{reflect.TypeFor[[]byte](), `{"type":"string","format":"byte"}`},
+		{reflect.TypeFor[[][]int](), `{"type":"array","items":{"type":"array","items":{"type":"integer"}}}`},
+		{reflect.TypeFor[map[string]bool](), `{"type":"object","additionalProperties":{"type":"boolean"}}`},
+		{reflect.TypeFor[time.Time](), `{"type":"string","format":"date-time"}`},
+		{reflect.TypeFor[any](), `{}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(newSchemaGenerator().schemaFor(tt.typ))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("%v: expected %s, got %s", tt.typ, tt.expected, data)
+		}
+	}
+}
+
+func TestSchemaFor_Struct(t *testing.T) {
+	gen := newSchemaGenerator()
+	if ref := gen.schemaFor(reflect.TypeFor[schemaUser]()); ref.Ref != "#/$defs/schemaUser" {
+		t.Fatalf("expected recursive struct to be referenced, got %+v", ref)
+	}
+	schema := gen.defs["schemaUser"]
+
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	if len(names) != 7 {
+		t.Errorf("expected 7 properties, got %v", names)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"id", "name", "tags", "created"}) {
+		t.Errorf("unexpected required fields: %v", schema.Required)
+	}
+	data, err := json.Marshal(schema.Properties["manager"])
+	if err != nil || string(data) != `{"anyOf":[{"$ref":"#/$defs/schemaUser"},{"type":"null"}]}` {
+		t.Errorf("expected nullable reference to the definition, got %s, %v", data, err)
+	}
+}
+
+type schemaNode struct {
+	Value int         `json:"value"`
+	Next  *schemaNode `json:"next"`
+}
+
+type schemaTree struct {
+	Label    string        `json:"label"`
+	Children []schemaTree  `json:"children"`
+	Meta     schemaTreeTag `json:"meta"`
+}
+
+type schemaTreeTag struct {
+	Owner *schemaTree `json:"owner"`
+}
+
+type schemaEmbedded struct {
+	*schemaEmbedded
+	Name string `json:"name"`
+}
+
+func TestSchemaFor_Recursive(t *testing.T) {
+	gen := newSchemaGenerator()
+	list := gen.schemaFor(reflect.TypeFor[[]schemaNode]())
+	tree := gen.schemaFor(reflect.TypeFor[*schemaTree]())
+	gen.schemaFor(reflect.TypeFor[schemaEmbedded]())
+
+	data, _ := json.Marshal(list)
+	if string(data) != `{"type":"array","items":{"$ref":"#/$defs/schemaNode"}}` {
+		t.Errorf("unexpected list schema: %s", data)
+	}
+	data, _ = json.Marshal(gen.defs["schemaNode"])
+	if string(data) != `{"type":"object","properties":{"next":{"anyOf":[{"$ref":"#/$defs/schemaNode"},{"type":"null"}]},"value":{"type":"integer"}},"required":["value","next"]}` {
+		t.Errorf("unexpected node definition: %s", data)
+	}
+
+	// Mutually recursive types: only the outermost one is defined
+	if tree.Ref != "#/$defs/schemaTree" || !tree.Nullable {
+		t.Errorf("unexpected tree schema: %+v", tree)
+	}
+	if children := gen.defs["schemaTree"].Properties["children"]; children.Items.Ref != "#/$defs/schemaTree" {
+		t.Errorf("unexpected children schema: %+v", children)
+	}
+	if owner := gen.defs["schemaTree"].Properties["meta"].Properties["owner"]; owner.Ref != "#/$defs/schemaTree" {
+		t.Errorf("unexpected owner schema: %+v", owner)
+	}
+	if _, ok := gen.defs["schemaTreeTag"]; ok {
+		t.Error("non-recursive types must be inlined")
+	}
+
+	for name, def := range gen.defs {
+		if def == nil {
+			t.Errorf("definition %s left empty", name)
+		}
+	}
+	if len(gen.defs) != 2 {
+		t.Errorf("expected 2 definitions, got %d", len(gen.defs))
+	}
+}
+
+func recursiveSchemaFunc(head *schemaNode, root schemaTree) int {
+	return 0
+}
+
+func TestJSONSchema_Recursive(t *testing.T) {
+	fn := mustNewFunction(t, recursiveSchemaFunc)
+
+	schema := fn.JSONSchema()
+	if schema.Properties["head"].Ref != "#/$defs/schemaNode" || schema.Properties["root"].Ref != "#/$defs/schemaTree" {
+		t.Errorf("expected references to definitions, got %+v", schema.Properties)
+	}
+	if len(schema.Defs) != 2 {
+		t.Errorf("expected definitions at the root, got %v", schema.Defs)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"root"}) {
+		t.Errorf("unexpected required parameters: %v", schema.Required)
+	}
+	if _, err := json.Marshal(schema); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	fn := mustNewFunction(t, testFuncForm).
+		WithParamMeta("role", ParamMeta{Description: "Account role", Enum: []any{"admin", "user"}, Default: "user"}).
+		WithExamples(map[string]any{"userName": "alice", "maxItems": 10})
+	fn = fn.WithExamples(map[string]any{"userName": "bob"})
+
+	schema := fn.JSONSchema()
+	if schema.Type != "object" || len(schema.Properties) != 6 {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+	if _, ok := schema.Properties["ctx"]; ok {
+		t.Error("context parameters must be left out")
+	}
+
+	userName := schema.Properties["userName"]
+	if userName.Type != "string" || !reflect.DeepEqual(userName.Examples, []any{"alice", "bob"}) {
+		t.Errorf("unexpected userName schema: %+v", userName)
+	}
+	role := schema.Properties["role"]
+	if role.Description != "Account role" || role.Default != "user" || len(role.Enum) != 2 {
+		t.Errorf("unexpected role schema: %+v", role)
+	}
+	for _, name := range schema.Required {
+		if name == "role" {
+			t.Error("parameters with a default must not be required")
+		}
+	}
+
+	data, err := json.Marshal(schema.Properties["maxItems"])
+	if err != nil || string(data) != `{"type":"integer","examples":[10]}` {
+		t.Errorf("unexpected maxItems JSON: %s, %v", data, err)
+	}
+}
+
+func TestJSONSchema_Optional(t *testing.T) {
+	fn := mustNewFunction(t, testFuncForm)
+
+	schema := fn.JSONSchema()
+	if !reflect.DeepEqual(schema.Required, []string{"userName", "maxItems", "admin", "tags", "role"}) {
+		t.Errorf("expected pointer parameters to be optional, got required %v", schema.Required)
+	}
+	data, err := json.Marshal(schema.Properties["timeout"])
+	if err != nil || string(data) != `{"type":["integer","null"]}` {
+		t.Errorf("expected nullable timeout, got %s, %v", data, err)
+	}
+
+	overridden := fn.WithRequired("timeout", true).WithRequired("admin", false).JSONSchema()
+	if !reflect.DeepEqual(overridden.Required, []string{"userName", "maxItems", "tags", "role", "timeout"}) {
+		t.Errorf("unexpected required parameters with overrides: %v", overridden.Required)
+	}
+	if !overridden.Properties["timeout"].Nullable {
+		t.Error("required pointer parameters must stay nullable")
+	}
+}
+
+func TestCallWithMap_OptionalParameters(t *testing.T) {
+	fn := mustNewFunction(t, testFuncForm)
+	args := map[string]any{"ctx": context.Background(), "userName": "u", "maxItems": 1, "admin": true, "tags": []string{}, "role": "r"}
+
+	// Absent pointer parameters bind nil
+	if _, err := fn.CallWithMap(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := fn.WithRequired("timeout", true).CallWithMap(args); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected missing timeout error, got %v", err)
+	}
+
+	delete(args, "maxItems")
+	if _, err := fn.WithRequired("maxItems", false).CallWithMap(args); err != nil {
+		t.Errorf("expected optional maxItems to bind zero, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ErrScopedParameter is wrapped by the errors of calls setting a scoped
+// parameter themselves, or made without a value for it in their context.
+var ErrScopedParameter = errors.New("dwarfreflect: scoped parameter")
+
+type scopeValuesKey struct{}
+
+// WithScopeValues returns a context carrying the values of scoped parameters by
+// name, such as the tenant of the authenticated caller, added to those ctx
+// already carries. Typically called by authentication middleware.
+//
+// Example:
+//
+//	ctx := dwarfreflect.WithScopeValues(r.Context(), map[string]any{"tenantID": claims.TenantID})
+func WithScopeValues(ctx context.Context, values map[string]any) context.Context {
+	scope := maps.Clone(ScopeValues(ctx))
+	if scope == nil {
+		scope = make(map[string]any, len(values))
+	}
+	maps.Copy(scope, values)
+	return context.WithValue(ctx, scopeValuesKey{}, scope)
+}
+
+// ScopeValues returns the values of scoped parameters carried by ctx, as set
+// by WithScopeValues. The map must not be modified.
+func ScopeValues(ctx context.Context) map[string]any {
+	scope, _ := ctx.Value(scopeValuesKey{}).(map[string]any)
+	return scope
+}
+
+// WithScopedParams returns a copy of the Function whose named parameters are
+// scoping parameters, e.g. the tenant of multi-tenant services: their values
+// come from the context of the call, as set by WithScopeValues from
+// authenticated credentials, never from the caller's arguments. Name-based
+// binding treats them as optional; every Call variant then binds the value of
+// the context, rejecting calls without one and calls whose argument is set to
+// another value, with errors wrapping ErrScopedParameter. Router payloads may
+// not name them at all. Names the function has no parameter for are ignored.
+//
+// Example:
+//
+//	func ListInvoices(ctx context.Context, tenantID string, status string) ([]Invoice, error)
+//	fn = fn.WithScopedParams("tenantID")
+//	ctx = dwarfreflect.WithScopeValues(ctx, map[string]any{"tenantID": "acme"})
+//	fn.CallWithMeta(ctx, meta, map[string]any{"status": "open"}) // tenantID is "acme"
+func (t *Function) WithScopedParams(names ...string) *Function {
+	clone := *t
+	clone.scoped = slices.Clip(t.scoped)
+	for _, name := range names {
+		if t.hasParam(name) && !slices.Contains(clone.scoped, name) {
+			clone.scoped = append(clone.scoped, name)
+		}
+	}
+	return &clone
+}
+
+// ScopedParams returns the names of the scoping parameters of the function,
+// see WithScopedParams.
+func (t *Function) ScopedParams() []string {
+	return slices.Clone(t.scoped)
+}
+
+// isScoped reports whether the named parameter is a scoping parameter.
+func (t *Function) isScoped(name string) bool {
+	return slices.Contains(t.scoped, name)
+}
+
+// applyScope returns a copy of args with the scoping parameters bound to the
+// values carried by ctx, see WithScopedParams. args itself, which may be the
+// caller's, is left unchanged.
+func (t *Function) applyScope(ctx context.Context, args []reflect.Value) ([]reflect.Value, error) {
+	scope := ScopeValues(ctx)
+	args = slices.Clone(args)
+	for _, name := range t.scoped {
+		i := t.paramPosition(name)
+		value, ok := scope[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q of function %s has no value in the call context (see WithScopeValues)", ErrScopedParameter, name, t.funcName)
+		}
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || !rv.Type().AssignableTo(t.paramTypes[i]) {
+			return nil, fmt.Errorf("%w %q of function %s: cannot assign scope value of type %T to %v", ErrScopedParameter, name, t.funcName, value, t.paramTypes[i])
+		}
+		if arg := args[i]; arg.IsValid() && !arg.IsZero() && !equalArgs(arg, rv) {
+			return nil, fmt.Errorf("%w %q of function %s cannot be set by the caller", ErrScopedParameter, name, t.funcName)
+		}
+		args[i] = rv
+	}
+	return args, nil
+}
+
+// Scope declares scoping parameters for every function of the registry,
+// registered before or after, as Function.WithScopedParams does, so that
+// adapters serving it bind them from the authenticated context and never from
+// payloads.
+//
+// Example:
+//
+//	reg.Scope("tenantID")
+//	http.Handle("/ws", authenticate(wsadapter.New(reg))) // authenticate calls WithScopeValues
+func (r *Registry) Scope(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scoped = append(r.scoped, names...)
+	for name, fn := range r.functions {
+		r.functions[name] = fn.WithScopedParams(names...)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+//go:noinline
+func scopeTestListInvoices(ctx context.Context, tenantID string, status string) string {
+	return tenantID + ":" + status
+}
+
+func newScopedRegistry(t *testing.T) *Registry {
+	t.Helper()
+	mustNewFunction(t, scopeTestListInvoices)
+	reg := NewRegistry()
+	reg.Scope("tenantID")
+	if _, err := reg.Register("invoices.List", scopeTestListInvoices); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+func TestScopedParams_Router(t *testing.T) {
+	router := NewRouter(newScopedRegistry(t))
+	ctx := WithScopeValues(context.Background(), map[string]any{"tenantID": "acme"})
+
+	results, err := router.Dispatch(ctx, "invoices.List", []byte(`{"status":"open"}`))
+	if err != nil || results[0].String() != "acme:open" {
+		t.Errorf("expected the scoped tenant, got %v, %v", results, err)
+	}
+
+	// Payloads cannot name the scoped parameter, whatever its value
+	for _, payload := range []string{`{"status":"open","tenantID":"globex"}`, `{"status":"open","tenantID":"acme"}`} {
+		_, err := router.Dispatch(ctx, "invoices.List", []byte(payload))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) || bindErr.Param != "tenantID" || !errors.Is(err, ErrScopedParameter) {
+			t.Errorf("%s: expected a scoped parameter binding error, got %v", payload, err)
+		}
+	}
+
+	if _, err := router.Dispatch(context.Background(), "invoices.List", []byte(`{"status":"open"}`)); !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected a missing scope error, got %v", err)
+	}
+}
+
+func TestScopedParams_Function(t *testing.T) {
+	reg := newScopedRegistry(t)
+	fn, _ := reg.Get("invoices.List")
+	if got := fn.ScopedParams(); len(got) != 1 || got[0] != "tenantID" {
+		t.Errorf("unexpected scoped params %v", got)
+	}
+	ctx := WithScopeValues(context.Background(), map[string]any{"tenantID": "acme"})
+
+	results, err := fn.CallWithMap(map[string]any{"ctx": ctx, "status": "paid"})
+	if err != nil || results[0].String() != "acme:paid" {
+		t.Errorf("expected the scoped tenant, got %v, %v", results, err)
+	}
+
+	// Other transports cannot override it either
+	if _, err := fn.CallWithMap(map[string]any{"ctx": ctx, "status": "paid", "tenantID": "globex"}); !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected an override error, got %v", err)
+	}
+	r := httptest.NewRequestWithContext(ctx, "GET", "/invoices?status=paid&tenantID=globex", nil)
+	if _, err := fn.CallWithRequest(r); !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected an override error from the request, got %v", err)
+	}
+	if _, err := fn.CallWithContext(WithScopeValues(ctx, map[string]any{"tenantID": 7}), "", "paid"); !errors.Is(err, ErrScopedParameter) {
+		t.Errorf("expected a scope type error, got %v", err)
+	}
+
+	// Functions registered before the declaration are scoped too
+	reg = NewRegistry()
+	reg.Register("invoices.List", scopeTestListInvoices)
+	reg.Scope("tenantID", "accountID")
+	if fn, _ := reg.Get("invoices.List"); len(fn.ScopedParams()) != 1 {
+		t.Errorf("expected tenantID scoped, got %v", fn.ScopedParams())
+	}
+}
+
+func TestScopedParams_ArgsUnchanged(t *testing.T) {
+	reg := newScopedRegistry(t)
+	fn, _ := reg.Get("invoices.List")
+	ctx := WithScopeValues(context.Background(), map[string]any{"tenantID": "acme"})
+
+	args := []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(""), reflect.ValueOf("open")}
+	results, err := fn.CallWithReflect(args)
+	if err != nil || results[0].String() != "acme:open" {
+		t.Fatalf("expected the scoped tenant, got %v, %v", results, err)
+	}
+	if args[1].String() != "" {
+		t.Errorf("expected the caller's args to be left unchanged, got tenantID %q", args[1].String())
+	}
+}
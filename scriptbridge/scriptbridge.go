@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package scriptbridge exposes wrapped functions to gopher-lua scripts.
+//
+// Scripts call functions with a single table of named arguments, mapped to the
+// real parameter names. Go results are returned as Lua values (multiple results
+// become multiple return values) and a trailing error is raised as a Lua error.
+//
+// Example:
+//
+//	func Greet(name string, times int) string
+//
+//	L := lua.NewState()
+//	scriptbridge.Register(L, "greet", fn)
+//	L.DoString(`print(greet{name = "alice", times = 2})`)
+package scriptbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/matteo-grella/dwarfreflect"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Register exposes fn as the global Lua function name.
+func Register(L *lua.LState, name string, fn *dwarfreflect.Function) {
+	L.SetGlobal(name, LuaFunction(L, fn))
+}
+
+// RegisterAll exposes every function of reg as a global named after its registered name.
+func RegisterAll(L *lua.LState, reg *dwarfreflect.Registry) {
+	for _, name := range reg.Names() {
+		fn, _ := reg.Get(name)
+		Register(L, name, fn)
+	}
+}
+
+// LuaFunction returns a Lua function that binds a table of named arguments to
+// fn parameters and calls it. context.Context parameters receive the state's
+// context (see LState.SetContext), or context.Background() if none is set.
+func LuaFunction(L *lua.LState, fn *dwarfreflect.Function) *lua.LFunction {
+	return L.NewFunction(func(L *lua.LState) int {
+		args := map[string]any{}
+		if L.GetTop() > 0 {
+			converted, err := toGo(L.CheckTable(1))
+			if err != nil {
+				L.RaiseError("%s", err.Error())
+				return 0
+			}
+			if m, ok := converted.(map[string]any); ok {
+				args = m
+			} else if table := L.CheckTable(1); table.Len() > 0 {
+				L.RaiseError("%s expects a table of named arguments", fn.GetBaseFunctionName())
+				return 0
+			}
+		}
+
+		data, err := json.Marshal(args)
+		if err != nil {
+			L.RaiseError("%s", err.Error())
+			return 0
+		}
+
+		ctx := L.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		results, err := fn.CallWithEncodedContext(ctx, "json", data)
+		if err != nil {
+			dwarfreflect.Logger().Debug("scriptbridge: call failed", "function", fn.GetFunctionName(), "error", err)
+			L.RaiseError("%s", err.Error())
+			return 0
+		}
+
+		out, err := fn.ResultsToStruct(results)
+		if err != nil {
+			L.RaiseError("%s", err.Error())
+			return 0
+		}
+
+		outValue := reflect.ValueOf(out).Elem()
+		for i := 0; i < outValue.NumField(); i++ {
+			lv, err := fromGoValue(L, outValue.Field(i).Interface())
+			if err != nil {
+				L.RaiseError("%s", err.Error())
+				return 0
+			}
+			L.Push(lv)
+		}
+		return outValue.NumField()
+	})
+}
+
+// toGo converts a Lua value to JSON-compatible Go values. Tables with only
+// consecutive integer keys from 1 become slices, other tables become maps.
+func toGo(lv lua.LValue) (any, error) {
+	switch v := lv.(type) {
+	case *lua.LNilType:
+		return nil, nil
+	case lua.LBool:
+		return bool(v), nil
+	case lua.LNumber:
+		return float64(v), nil
+	case lua.LString:
+		return string(v), nil
+	case *lua.LTable:
+		if n := v.MaxN(); n > 0 && countKeys(v) == n {
+			slice := make([]any, n)
+			for i := 1; i <= n; i++ {
+				elem, err := toGo(v.RawGetInt(i))
+				if err != nil {
+					return nil, err
+				}
+				slice[i-1] = elem
+			}
+			return slice, nil
+		}
+
+		m := make(map[string]any)
+		var err error
+		v.ForEach(func(key, value lua.LValue) {
+			if err != nil {
+				return
+			}
+			var elem any
+			if elem, err = toGo(value); err == nil {
+				m[key.String()] = elem
+			}
+		})
+		return m, err
+	default:
+		return nil, fmt.Errorf("unsupported Lua value of type %s", lv.Type())
+	}
+}
+
+func countKeys(table *lua.LTable) int {
+	count := 0
+	table.ForEach(func(lua.LValue, lua.LValue) { count++ })
+	return count
+}
+
+// fromGoValue converts a Go result to a Lua value through its JSON form.
+func fromGoValue(L *lua.LState, v any) (lua.LValue, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return fromGo(L, generic), nil
+}
+
+func fromGo(L *lua.LState, v any) lua.LValue {
+	switch v := v.(type) {
+	case nil:
+		return lua.LNil
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []any:
+		table := L.NewTable()
+		for _, elem := range v {
+			table.Append(fromGo(L, elem))
+		}
+		return table
+	case map[string]any:
+		table := L.NewTable()
+		for key, elem := range v {
+			table.RawSetString(key, fromGo(L, elem))
+		}
+		return table
+	default:
+		return lua.LString(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package scriptbridge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+	lua "github.com/yuin/gopher-lua"
+)
+
+type point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type ctxKey struct{}
+
+func greet(ctx context.Context, name string, times int) string {
+	prefix, _ := ctx.Value(ctxKey{}).(string)
+	return prefix + strings.Repeat(name, times)
+}
+
+func divmod(a, b int) (quotient, remainder int, err error) {
+	if b == 0 {
+		return 0, 0, errors.New("division by zero")
+	}
+	return a / b, a % b, nil
+}
+
+func centroid(points []point) point {
+	var c point
+	for _, p := range points {
+		c.X += p.X
+		c.Y += p.Y
+	}
+	c.X /= len(points)
+	c.Y /= len(points)
+	return c
+}
+
+func peek(label string, addr uintptr) string {
+	return label
+}
+
+func newTestState(t *testing.T) *lua.LState {
+	t.Helper()
+	reg := dwarfreflect.NewRegistry()
+	for name, fn := range map[string]any{"greet": greet, "divmod": divmod, "centroid": centroid, "peek": peek} {
+		dwarfreflecttest.Register(t, reg, name, fn)
+	}
+
+	L := lua.NewState()
+	t.Cleanup(L.Close)
+	RegisterAll(L, reg)
+	return L
+}
+
+func TestRegisterAll_NamedArguments(t *testing.T) {
+	L := newTestState(t)
+	L.SetContext(context.WithValue(context.Background(), ctxKey{}, ">"))
+
+	if err := L.DoString(`result = greet{name = "ab", times = 2}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := L.GetGlobal("result").String(); got != ">abab" {
+		t.Errorf("unexpected result: %s", got)
+	}
+}
+
+func TestRegisterAll_MultipleResults(t *testing.T) {
+	L := newTestState(t)
+
+	if err := L.DoString(`q, r = divmod{a = 7, b = 2}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q, r := L.GetGlobal("q").String(), L.GetGlobal("r").String(); q != "3" || r != "1" {
+		t.Errorf("expected 3 and 1, got %s and %s", q, r)
+	}
+}
+
+func TestRegisterAll_ErrorRaised(t *testing.T) {
+	L := newTestState(t)
+
+	err := L.DoString(`
+		local ok, msg = pcall(divmod, {a = 1, b = 0})
+		assert(not ok)
+		error(msg)
+	`)
+	if err == nil || !strings.Contains(err.Error(), "division by zero") {
+		t.Errorf("expected division by zero error, got %v", err)
+	}
+}
+
+func TestRegisterAll_Tables(t *testing.T) {
+	L := newTestState(t)
+
+	if err := L.DoString(`c = centroid{points = {{x = 0, y = 0}, {x = 4, y = 2}}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, ok := L.GetGlobal("c").(*lua.LTable)
+	if !ok {
+		t.Fatalf("expected table result, got %v", L.GetGlobal("c"))
+	}
+	if x, y := c.RawGetString("x").String(), c.RawGetString("y").String(); x != "2" || y != "1" {
+		t.Errorf("expected {x=2, y=1}, got {x=%s, y=%s}", x, y)
+	}
+}
+
+func TestRegisterAll_BadArguments(t *testing.T) {
+	L := newTestState(t)
+
+	if err := L.DoString(`greet{name = "x", times = "many"}`); err == nil {
+		t.Error("expected error for mistyped argument")
+	}
+	if err := L.DoString(`greet{"positional"}`); err == nil {
+		t.Error("expected error for positional arguments")
+	}
+}
+
+func TestRegisterAll_UnsafeRejected(t *testing.T) {
+	L := newTestState(t)
+
+	err := L.DoString(`peek{label = "x", addr = 4096}`)
+	if err == nil || !strings.Contains(err.Error(), `parameter "addr"`) || !strings.Contains(err.Error(), "unsafe type") {
+		t.Errorf("expected the unsafe parameter rejected, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+)
+
+// ShadowDivergence describes a shadow call whose results differ from the primary call.
+type ShadowDivergence struct {
+	Function string         // Full name of the primary function
+	Shadow   string         // Full name of the shadow function
+	Args     map[string]any // Non-context arguments, by parameter name
+	Primary  []any          // Results of the primary call
+	Results  []any          // Results of the shadow call, nil if Err is set
+	Err      error          // Set when the shadow call could not be bound or panicked
+}
+
+// shadowConfig holds the configuration set with Shadow.
+type shadowConfig struct {
+	other        *Function
+	sampler      func() bool
+	onDivergence func(ShadowDivergence)
+}
+
+// SampleRate returns a sampler for Shadow selecting roughly the given fraction of calls.
+func SampleRate(rate float64) func() bool {
+	return func() bool {
+		return rand.Float64() < rate
+	}
+}
+
+// Shadow returns a copy of the Function that, on calls selected by sampler,
+// also invokes other asynchronously with the same non-context arguments,
+// matched by parameter name. The caller only ever sees the primary results.
+// When the shadow results differ (errors are compared by message), or the
+// shadow call cannot be bound or panics, onDivergence is called from the
+// shadow goroutine. Arguments are deep-copied before the primary call, and the
+// shadow receives the caller's context values without its cancellation.
+// A nil sampler shadows every call.
+//
+// Example:
+//
+//	migrating := v1.Shadow(v2, dwarfreflect.SampleRate(0.1), func(d dwarfreflect.ShadowDivergence) {
+//	    log.Printf("%s diverged from %s for %v", d.Shadow, d.Function, d.Args)
+//	})
+func (t *Function) Shadow(other *Function, sampler func() bool, onDivergence func(ShadowDivergence)) *Function {
+	clone := *t
+	clone.shadow = &shadowConfig{other: other, sampler: sampler, onDivergence: onDivergence}
+	return &clone
+}
+
+// prepareShadow captures the arguments of a sampled call before the primary
+// call runs, returning nil when the call is not shadowed.
+func (t *Function) prepareShadow(args []reflect.Value) func(primary []reflect.Value) {
+	if t.shadow == nil || (t.shadow.sampler != nil && !t.shadow.sampler()) {
+		return nil
+	}
+
+	ctx := context.Background()
+	contextPositions := t.GetContextPositions()
+	argMap := make(map[string]any, len(args))
+	for i, arg := range args {
+		if slices.Contains(contextPositions, i) {
+			if arg.IsValid() && !arg.IsZero() {
+				ctx = context.WithoutCancel(arg.Interface().(context.Context))
+			}
+			continue
+		}
+		argMap[t.paramNames[i]] = deepCopy(arg).Interface()
+	}
+
+	return func(primary []reflect.Value) {
+		go t.shadow.run(t.funcName, ctx, argMap, valuesToAny(primary))
+	}
+}
+
+// run invokes the shadow function and reports divergence from the primary results.
+func (s *shadowConfig) run(primaryName string, ctx context.Context, argMap map[string]any, primary []any) {
+	divergence := ShadowDivergence{
+		Function: primaryName,
+		Shadow:   s.other.funcName,
+		Args:     argMap,
+		Primary:  primary,
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			divergence.Err = fmt.Errorf("shadow call panicked: %v", r)
+			s.report(divergence)
+		}
+	}()
+
+	names, _ := s.other.GetNonContextParameters()
+	args := make([]any, len(names))
+	for i, name := range names {
+		value, ok := argMap[name]
+		if !ok {
+			divergence.Err = fmt.Errorf("shadow parameter %q has no matching argument", name)
+			s.report(divergence)
+			return
+		}
+		args[i] = value
+	}
+
+	results, err := s.other.CallWithContext(ctx, args...)
+	if err != nil {
+		divergence.Err = err
+		s.report(divergence)
+		return
+	}
+
+	divergence.Results = valuesToAny(results)
+	if !resultsEqual(primary, divergence.Results) {
+		s.report(divergence)
+	}
+}
+
+func (s *shadowConfig) report(divergence ShadowDivergence) {
+	if s.onDivergence != nil {
+		s.onDivergence(divergence)
+	}
+}
+
+func valuesToAny(values []reflect.Value) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v.Interface()
+	}
+	return out
+}
+
+// resultsEqual compares results deeply, comparing errors by message.
+func resultsEqual(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		errA, isErrA := a[i].(error)
+		errB, isErrB := b[i].(error)
+		if isErrA || isErrB {
+			if !isErrA || !isErrB || errA.Error() != errB.Error() {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
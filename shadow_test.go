@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testShadowV1(ctx context.Context, name string, times int) string {
+	return strings.Repeat(name, times)
+}
+
+func testShadowV2(times int, name string) string {
+	if times > 2 {
+		return "capped"
+	}
+	return strings.Repeat(name, times)
+}
+
+func testShadowOther(label string) string {
+	return label
+}
+
+func waitDivergence(t *testing.T, ch <-chan ShadowDivergence) (ShadowDivergence, bool) {
+	t.Helper()
+	select {
+	case d := <-ch:
+		return d, true
+	case <-time.After(100 * time.Millisecond):
+		return ShadowDivergence{}, false
+	}
+}
+
+func TestShadow(t *testing.T) {
+	v2 := mustNewFunction(t, testShadowV2)
+	divergences := make(chan ShadowDivergence, 1)
+	fn := mustNewFunction(t, testShadowV1).Shadow(v2, nil, func(d ShadowDivergence) {
+		divergences <- d
+	})
+
+	results, err := fn.CallWithContext(context.Background(), "ab", 2)
+	if err != nil || results[0].String() != "abab" {
+		t.Fatalf("unexpected primary result: %v, %v", results, err)
+	}
+	if d, ok := waitDivergence(t, divergences); ok {
+		t.Errorf("unexpected divergence: %+v", d)
+	}
+
+	results, _ = fn.CallWithMap(map[string]any{"ctx": context.Background(), "name": "ab", "times": 3})
+	if results[0].String() != "ababab" {
+		t.Errorf("expected primary result, got %v", results[0])
+	}
+	d, ok := waitDivergence(t, divergences)
+	if !ok {
+		t.Fatal("expected divergence")
+	}
+	if d.Primary[0] != "ababab" || d.Results[0] != "capped" || d.Args["times"] != 3 {
+		t.Errorf("unexpected divergence: %+v", d)
+	}
+}
+
+func TestShadow_BindError(t *testing.T) {
+	divergences := make(chan ShadowDivergence, 1)
+	fn := mustNewFunction(t, testShadowV1).Shadow(mustNewFunction(t, testShadowOther), nil, func(d ShadowDivergence) {
+		divergences <- d
+	})
+
+	fn.CallWithContext(context.Background(), "ab", 1)
+	d, ok := waitDivergence(t, divergences)
+	if !ok || d.Err == nil || !strings.Contains(d.Err.Error(), "label") {
+		t.Errorf("expected bind error for label, got %+v", d)
+	}
+}
+
+func TestShadow_Sampler(t *testing.T) {
+	divergences := make(chan ShadowDivergence, 1)
+	fn := mustNewFunction(t, testShadowV1).Shadow(mustNewFunction(t, testShadowV2), SampleRate(0), func(d ShadowDivergence) {
+		divergences <- d
+	})
+
+	fn.CallWithContext(context.Background(), "ab", 3)
+	if d, ok := waitDivergence(t, divergences); ok {
+		t.Errorf("expected unsampled call not to be shadowed, got %+v", d)
+	}
+}
+
+func TestResultsEqual(t *testing.T) {
+	if !resultsEqual([]any{1, errors.New("x")}, []any{1, errors.New("x")}) {
+		t.Error("expected errors with equal messages to match")
+	}
+	if resultsEqual([]any{1, errors.New("x")}, []any{1, nil}) {
+		t.Error("expected error and nil to differ")
+	}
+	if resultsEqual([]any{[]int{1}}, []any{[]int{2}}) {
+		t.Error("expected different slices to differ")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShutdown is returned by calls started after Shutdown, and is the cause
+// of the cancellation of the contexts of calls in flight when it was called.
+var ErrShutdown = errors.New("dwarfreflect: shut down")
+
+// inflight tracks the calls in progress, for Shutdown.
+var inflight struct {
+	calls     atomic.Int64
+	closing   atomic.Bool
+	keepCalls atomic.Bool   // see SetShutdownCancellation
+	drained   chan struct{} // signaled when calls drops to zero while closing
+
+	mu     sync.Mutex // guards ctx and cancel
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func init() {
+	resetShutdown()
+}
+
+// resetShutdown accepts calls again after Shutdown.
+func resetShutdown() {
+	inflight.mu.Lock()
+	defer inflight.mu.Unlock()
+	inflight.ctx, inflight.cancel = context.WithCancelCause(context.Background())
+	inflight.drained = make(chan struct{}, 1)
+	inflight.closing.Store(false)
+}
+
+// Shutdown drains the calls in flight, for services embedding a Router,
+// RPCServer or other dispatcher to stop cleanly: calls started afterwards
+// fail with ErrShutdown, the contexts injected into context.Context
+// parameters of the calls in flight are canceled with cause ErrShutdown (see
+// SetShutdownCancellation), and Shutdown waits for those calls to return. It
+// returns ctx.Err() if ctx is done first. Shutdown is final: the package
+// serves no more calls.
+//
+// Example:
+//
+//	<-sigterm
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := dwarfreflect.Shutdown(ctx); err != nil {
+//	    log.Printf("calls still running: %v", err)
+//	}
+func Shutdown(ctx context.Context) error {
+	inflight.closing.Store(true)
+
+	inflight.mu.Lock()
+	cancel, drained := inflight.cancel, inflight.drained
+	inflight.mu.Unlock()
+	cancel(ErrShutdown)
+
+	for inflight.calls.Load() > 0 {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// SetShutdownCancellation sets whether Shutdown cancels the contexts of the
+// calls in flight, which it does by default so that long-running functions
+// stop early instead of being waited for. Disable it for functions that must
+// run to completion once started. The contexts of streaming functions (see
+// IsStream) outlive their calls and are never canceled.
+//
+// Example:
+//
+//	dwarfreflect.SetShutdownCancellation(false) // Shutdown only waits
+func SetShutdownCancellation(enabled bool) {
+	inflight.keepCalls.Store(!enabled)
+}
+
+// beginCall registers a call in flight, returning the context canceled by
+// Shutdown and the function ending the call, or ErrShutdown.
+func beginCall() (context.Context, func(), error) {
+	inflight.calls.Add(1)
+	if inflight.closing.Load() {
+		endCall()
+		return nil, nil, ErrShutdown
+	}
+	inflight.mu.Lock()
+	shutdownCtx := inflight.ctx
+	inflight.mu.Unlock()
+	return shutdownCtx, endCall, nil
+}
+
+func endCall() {
+	if inflight.calls.Add(-1) == 0 && inflight.closing.Load() {
+		inflight.mu.Lock()
+		drained := inflight.drained
+		inflight.mu.Unlock()
+		select {
+		case drained <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// cancelOnShutdown replaces ctx, and the contexts in args of types that are
+// ctx, with a context derived from it and canceled by Shutdown. args is
+// copied before the contexts are replaced, so the caller's slice is left
+// unchanged. The context must be released once the call returns.
+func cancelOnShutdown(shutdownCtx, ctx context.Context, args []reflect.Value, types []reflect.Type) (*shutdownContext, []reflect.Value) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	callCtx := &shutdownContext{Context: ctx, shutdown: shutdownCtx}
+
+	copied := false
+	for i, typ := range types {
+		if typ != contextType || !args[i].IsValid() {
+			continue
+		}
+		// Contexts of uncomparable types cannot be recognized, and are kept
+		argCtx, ok := args[i].Interface().(context.Context)
+		if !ok || !reflect.TypeOf(argCtx).Comparable() || argCtx != ctx {
+			continue
+		}
+		if !copied {
+			args, copied = slices.Clone(args), true
+		}
+		args[i] = reflect.ValueOf(callCtx)
+	}
+	return callCtx, args
+}
+
+// shutdownContext is the context of a call in flight, also canceled by
+// Shutdown. Deriving a cancelable context and watching the shutdown costs
+// allocations, so it is only done once the function asks for Done; until
+// then, and for the many functions that never do, the context answers from
+// its parent and the shutdown context directly.
+type shutdownContext struct {
+	context.Context // parent
+	shutdown        context.Context
+
+	once    sync.Once
+	started atomic.Bool // derived is set
+	derived context.Context
+	cancel  context.CancelCauseFunc
+	stop    func() bool
+}
+
+// start derives the cancelable context, unless the call has returned.
+func (c *shutdownContext) start() {
+	c.once.Do(func() {
+		c.derived, c.cancel = context.WithCancelCause(c.Context)
+		c.stop = context.AfterFunc(c.shutdown, func() { c.cancel(ErrShutdown) })
+		c.started.Store(true)
+	})
+}
+
+// release ends the call: the context is canceled if derived, and never
+// derived afterwards.
+func (c *shutdownContext) release() {
+	c.once.Do(func() {})
+	if c.started.Load() {
+		c.stop()
+		c.cancel(nil)
+	}
+}
+
+func (c *shutdownContext) Done() <-chan struct{} {
+	c.start()
+	if !c.started.Load() {
+		return c.Context.Done() // asked for after the call returned
+	}
+	return c.derived.Done()
+}
+
+func (c *shutdownContext) Err() error {
+	if c.started.Load() {
+		c.syncShutdown()
+		return c.derived.Err()
+	}
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	if c.shutdown.Err() != nil {
+		return context.Canceled
+	}
+	return nil
+}
+
+// Value also serves context.Cause, which finds the cause through the
+// innermost cancelable context: the derived one once shut down.
+func (c *shutdownContext) Value(key any) any {
+	if c.shutdown.Err() != nil {
+		c.start()
+	}
+	if c.started.Load() {
+		c.syncShutdown()
+		return c.derived.Value(key)
+	}
+	return c.Context.Value(key)
+}
+
+// syncShutdown cancels the derived context as soon as Shutdown is called,
+// rather than when the AfterFunc goroutine gets to it.
+func (c *shutdownContext) syncShutdown() {
+	if c.shutdown.Err() != nil {
+		c.cancel(ErrShutdown)
+	}
+}
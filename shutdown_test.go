@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func shutdownTestWait(ctx context.Context, started chan<- struct{}) error {
+	close(started)
+	<-ctx.Done()
+	return context.Cause(ctx)
+}
+
+//go:noinline
+func shutdownTestBlock(started chan<- struct{}, release <-chan struct{}) bool {
+	close(started)
+	<-release
+	return true
+}
+
+func TestShutdown(t *testing.T) {
+	wait := mustNewFunction(t, shutdownTestWait)
+	t.Cleanup(resetShutdown)
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		results, err := wait.CallWithContext(context.Background(), (chan<- struct{})(started))
+		if err == nil {
+			err, _ = results[0].Interface().(error)
+		}
+		done <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-done; !errors.Is(err, ErrShutdown) {
+		t.Errorf("expected the call to end with ErrShutdown, got %v", err)
+	}
+
+	if _, err := wait.CallWithContext(context.Background(), make(chan<- struct{})); !errors.Is(err, ErrShutdown) {
+		t.Errorf("expected ErrShutdown for a call after Shutdown, got %v", err)
+	}
+}
+
+func TestShutdown_Timeout(t *testing.T) {
+	block := mustNewFunction(t, shutdownTestBlock)
+	t.Cleanup(resetShutdown)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := block.Call((chan<- struct{})(started), (<-chan struct{})(release))
+		done <- err
+	}()
+	<-started
+
+	// Calls without a context parameter cannot be canceled, only waited for
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to expire, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("expected the call in flight to complete, got %v", err)
+	}
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestShutdown_ArgsUnchanged(t *testing.T) {
+	fn := mustNewFunction(t, shutdownTestWait)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	args := []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf((chan<- struct{})(make(chan struct{})))}
+	if _, err := fn.CallWithReflect(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := args[0].Interface(); got != ctx {
+		t.Errorf("expected the caller's context to be left in args, got %v", got)
+	}
+}
+
+//go:noinline
+func shutdownTestSleep(ctx context.Context, started chan<- struct{}, release <-chan struct{}) error {
+	close(started)
+	<-release
+	return ctx.Err()
+}
+
+func TestShutdown_CancellationDisabled(t *testing.T) {
+	sleep := mustNewFunction(t, shutdownTestSleep)
+	SetShutdownCancellation(false)
+	t.Cleanup(func() {
+		SetShutdownCancellation(true)
+		resetShutdown()
+	})
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		results, err := sleep.CallWithContext(context.Background(), (chan<- struct{})(started), (<-chan struct{})(release))
+		if err == nil {
+			err, _ = results[0].Interface().(error)
+		}
+		done <- err
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- Shutdown(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("expected the context of the call to be left alone, got %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestShutdownContext_Lazy(t *testing.T) {
+	shutdown, cancel := context.WithCancelCause(context.Background())
+	ctx := &shutdownContext{Context: context.Background(), shutdown: shutdown}
+	if ctx.Err() != nil || ctx.started.Load() {
+		t.Fatal("expected a live context, derived only on demand")
+	}
+
+	cancel(ErrShutdown)
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected the context canceled by Shutdown, got %v", ctx.Err())
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, ErrShutdown) {
+		t.Errorf("expected cause ErrShutdown without a call to Done, got %v", cause)
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		t.Error("expected Done to be closed")
+	}
+	ctx.release()
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"slices"
+)
+
+// syntheticFuncName is the function name reported by functions built with
+// NewFunctionFromSignature, which have no runtime symbol of their own.
+const syntheticFuncName = "synthetic"
+
+// NewFunctionFromSignature synthesizes a Function from parameter names and
+// types, implemented by impl through reflect.MakeFunc. The optional results
+// are the result types impl must return. Synthesized functions need no DWARF
+// information and work with every Call variant, Registry, Router and adapter,
+// which makes them suitable for remote proxies, mocks and dynamic endpoints.
+//
+// Example:
+//
+//	fn, err := dwarfreflect.NewFunctionFromSignature(
+//	    []string{"userID", "name"},
+//	    []reflect.Type{reflect.TypeFor[int](), reflect.TypeFor[string]()},
+//	    func(args []reflect.Value) []reflect.Value {
+//	        return []reflect.Value{reflect.ValueOf(remote.Call("Rename", args))}
+//	    },
+//	    reflect.TypeFor[string](),
+//	)
+func NewFunctionFromSignature(names []string, types []reflect.Type, impl func(args []reflect.Value) []reflect.Value, results ...reflect.Type) (*Function, error) {
+	if len(names) != len(types) {
+		return nil, fmt.Errorf("NewFunctionFromSignature: %d names for %d types", len(names), len(types))
+	}
+	if impl == nil {
+		return nil, fmt.Errorf("NewFunctionFromSignature: nil implementation")
+	}
+	for i, name := range names {
+		if !token.IsIdentifier(name) || name == "_" {
+			return nil, fmt.Errorf("NewFunctionFromSignature: invalid parameter name %q", name)
+		}
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("NewFunctionFromSignature: duplicate parameter name %q", name)
+		}
+		if types[i] == nil {
+			return nil, fmt.Errorf("NewFunctionFromSignature: nil type for parameter %q", name)
+		}
+	}
+
+	fnType := reflect.FuncOf(types, results, false)
+	paramNames := slices.Clone(names)
+	paramTypes := slices.Clone(types)
+	resultNames := positionalResultNames(len(results))
+
+	return &Function{
+		function:     reflect.MakeFunc(fnType, impl),
+		functionType: fnType,
+		paramNames:   paramNames,
+		paramIndex:   newParamIndex(paramNames),
+		paramTypes:   paramTypes,
+		structType:   createStructType(paramNames, paramTypes),
+		resultNames:  resultNames,
+		resultType:   createResultStructType(fnType, resultNames),
+		funcName:     syntheticFuncName,
+		frames:       newFramePool(len(paramTypes)),
+		kind:         KindSynthetic,
+		provenance:   Provenance{Source: SourceSignature},
+	}, nil
+}
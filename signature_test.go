@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestSynthetic(t *testing.T) *Function {
+	t.Helper()
+	fn, err := NewFunctionFromSignature(
+		[]string{"ctx", "name", "times"},
+		[]reflect.Type{reflect.TypeFor[context.Context](), reflect.TypeFor[string](), reflect.TypeFor[int]()},
+		func(args []reflect.Value) []reflect.Value {
+			s := strings.Repeat(args[1].String(), int(args[2].Int()))
+			return []reflect.Value{reflect.ValueOf(s), reflect.Zero(errorType)}
+		},
+		reflect.TypeFor[string](), errorType,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return fn
+}
+
+func TestNewFunctionFromSignature(t *testing.T) {
+	fn := newTestSynthetic(t)
+
+	if fn.Kind() != KindSynthetic || fn.Provenance().Source != SourceSignature || fn.ModulePath() != "" {
+		t.Errorf("unexpected kind %v and provenance %v", fn.Kind(), fn.Provenance())
+	}
+	if names, _ := fn.GetNonContextParameters(); !reflect.DeepEqual(names, []string{"name", "times"}) {
+		t.Errorf("unexpected non-context parameters: %v", names)
+	}
+
+	v, err := fn.CallOne(map[string]any{"ctx": context.Background(), "name": "ab", "times": 2})
+	if err != nil || v != "abab" {
+		t.Errorf("expected abab, got %v, %v", v, err)
+	}
+
+	results, err := fn.CallWithEncodedContext(context.Background(), "json", []byte(`{"name":"x","times":3}`))
+	if err != nil || results[0].String() != "xxx" {
+		t.Errorf("expected xxx, got %v, %v", results, err)
+	}
+
+	reg := NewRegistry()
+	mustRegister(t, reg, "repeat", fn)
+	if _, err := NewRouter(reg).Dispatch(context.Background(), "repeat", []byte(`{"name":"x","times":1}`)); err != nil {
+		t.Errorf("unexpected dispatch error: %v", err)
+	}
+}
+
+func TestNewFunctionFromSignature_Errors(t *testing.T) {
+	impl := func(args []reflect.Value) []reflect.Value { return nil }
+	intType := reflect.TypeFor[int]()
+
+	tests := []struct {
+		name  string
+		names []string
+		types []reflect.Type
+		impl  func([]reflect.Value) []reflect.Value
+	}{
+		{"length mismatch", []string{"a"}, nil, impl},
+		{"nil impl", []string{"a"}, []reflect.Type{intType}, nil},
+		{"invalid name", []string{"not valid"}, []reflect.Type{intType}, impl},
+		{"duplicate name", []string{"a", "a"}, []reflect.Type{intType, intType}, impl},
+		{"nil type", []string{"a"}, []reflect.Type{nil}, impl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFunctionFromSignature(tt.names, tt.types, tt.impl); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
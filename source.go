@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
+)
+
+// sourceFunc is a function declaration parsed from registered source.
+type sourceFunc struct {
+	pkgName string   // package clause
+	dir     string   // directory in the registered file system, "" for the root
+	recv    string   // receiver parameter name, empty for plain functions
+	params  []string // unnamed parameters are ~p0, ~p1, ... as in DWARF
+	results []string // unnamed results are ~r0, ~r1, ...
+}
+
+// sourceNames holds the declarations registered with RegisterSourceFS by
+// function name, "Name" or "Receiver.Name", as packages may be embedded
+// without knowing their import path.
+var sourceNames struct {
+	mu    sync.RWMutex
+	funcs map[string][]sourceFunc
+}
+
+// RegisterSourceFS parses the Go files of fsys and uses the parameter and
+// result names of their function and method declarations when DWARF has no
+// entry for a function, notably in binaries built with -ldflags="-s -w":
+// NewFunction then works without DWARF for the registered functions, which
+// report SourceGoFile provenance. Embed the source of the handlers with
+// go:embed; there is no generate step to keep up to date.
+//
+// Declarations match runtime names by receiver type and function name, and by
+// their directory in fsys when it ends the import path, or else by package
+// name. Declarations of external test packages (package x_test) match the
+// runtime names of x_test, so test binaries stripped of DWARF can register the
+// source of their own helpers. Ambiguous matches and parameter counts that
+// disagree are ignored. Closures are not supported.
+//
+// Example:
+//
+//	//go:embed handlers/*.go
+//	var handlerSource embed.FS
+//
+//	func init() {
+//		if err := dwarfreflect.RegisterSourceFS(handlerSource); err != nil {
+//			panic(err)
+//		}
+//	}
+func RegisterSourceFS(fsys fs.FS) error {
+	fset := token.NewFileSet()
+	found := make(map[string][]sourceFunc)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name != "." && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_")) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path.Ext(name) != ".go" {
+			return nil
+		}
+
+		src, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", name, err)
+		}
+
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = ""
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				key, sf := parseSourceFunc(fn)
+				sf.pkgName, sf.dir = file.Name.Name, dir
+				found[key] = append(found[key], sf)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot register source: %w", err)
+	}
+
+	sourceNames.mu.Lock()
+	defer sourceNames.mu.Unlock()
+	if sourceNames.funcs == nil {
+		sourceNames.funcs = make(map[string][]sourceFunc)
+	}
+	for key, funcs := range found {
+		sourceNames.funcs[key] = append(sourceNames.funcs[key], funcs...)
+	}
+	return nil
+}
+
+// hasSourceNames reports whether source was registered with RegisterSourceFS.
+func hasSourceNames() bool {
+	sourceNames.mu.RLock()
+	defer sourceNames.mu.RUnlock()
+	return len(sourceNames.funcs) > 0
+}
+
+// parseSourceFunc returns the lookup key and the names of fn.
+func parseSourceFunc(fn *ast.FuncDecl) (string, sourceFunc) {
+	var sf sourceFunc
+	key := fn.Name.Name
+	if fn.Recv != nil && len(fn.Recv.List) == 1 {
+		field := fn.Recv.List[0]
+		key = receiverTypeName(field.Type) + "." + key
+		sf.recv = "~p0"
+		if len(field.Names) == 1 {
+			sf.recv = field.Names[0].Name
+		}
+	}
+	sf.params = fieldNames(fn.Type.Params, "~p")
+	sf.results = fieldNames(fn.Type.Results, "~r")
+	return key, sf
+}
+
+// receiverTypeName returns the type name of a receiver type expression such
+// as *Cache[K, V].
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// fieldNames lists the names of a parameter or result list, naming unnamed
+// fields prefix0, prefix1, ... by position.
+func fieldNames(fields *ast.FieldList, prefix string) []string {
+	var names []string
+	if fields == nil {
+		return names
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			names = append(names, fmt.Sprintf("%s%d", prefix, len(names)))
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// lookupSourceNames returns the parameter and result names registered for the
+// runtime name funcName, with paramCount parameters, the receiver first for
+// method expressions, and resultCount results. Reports false when no single
+// registered declaration matches.
+func lookupSourceNames(funcName string, paramCount, resultCount int) (params, results []string, ok bool) {
+	sym := symbols.Parse(funcName)
+	if sym.IsClosure() || sym.Wrapper != "" {
+		return nil, nil, false
+	}
+	key := sym.Name
+	if sym.IsMethod() {
+		key = sym.Receiver + "." + key
+	}
+
+	sourceNames.mu.RLock()
+	defer sourceNames.mu.RUnlock()
+
+	// External test packages share the directory of the package they test,
+	// and are told apart by their package clause
+	pkgPath, isTest := testedPackage(sym.Package)
+	pkgName := packageName(pkgPath)
+	if isTest {
+		pkgName += "_test"
+	}
+
+	// Directories are more specific than package names, which may repeat
+	match, unique := findSourceFunc(sourceNames.funcs[key], func(sf *sourceFunc) bool {
+		return sf.dir != "" && (pkgPath == sf.dir || strings.HasSuffix(pkgPath, "/"+sf.dir)) &&
+			strings.HasSuffix(sf.pkgName, "_test") == isTest
+	})
+	if match == nil && unique {
+		match, unique = findSourceFunc(sourceNames.funcs[key], func(sf *sourceFunc) bool {
+			return sf.pkgName == pkgName
+		})
+	}
+	if match == nil || !unique {
+		return nil, nil, false
+	}
+
+	params = match.params
+	if sym.IsMethod() && !sym.MethodValue {
+		params = append([]string{match.recv}, params...)
+	}
+	if len(params) != paramCount || len(match.results) != resultCount {
+		return nil, nil, false
+	}
+	return params, match.results, true
+}
+
+// findSourceFunc returns the only function of funcs matching match, and
+// reports false if several do.
+func findSourceFunc(funcs []sourceFunc, match func(*sourceFunc) bool) (*sourceFunc, bool) {
+	var found *sourceFunc
+	for i := range funcs {
+		if match(&funcs[i]) {
+			if found != nil {
+				return nil, false
+			}
+			found = &funcs[i]
+		}
+	}
+	return found, true
+}
+
+// packageName returns the conventional name of the package with import path
+// pkgPath: its last element, ignoring a major version suffix.
+func packageName(pkgPath string) string {
+	name := path.Base(pkgPath)
+	if isMajorVersion(name) && strings.Contains(pkgPath, "/") {
+		name = path.Base(path.Dir(pkgPath))
+	}
+	return name
+}
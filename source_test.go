@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+//go:noinline
+func sourceTestGreet(userName string, times int) (greeting string, err error) {
+	return strings.Repeat("hi "+userName+" ", times), nil
+}
+
+type sourceTestService struct{ names map[int]string }
+
+//go:noinline
+func (s *sourceTestService) Rename(id int, newName string) error {
+	s.names[id] = newName
+	return nil
+}
+
+// sourceTestFS holds the declarations above, as a go:embed of this package
+// would, and others of different packages.
+var sourceTestFS = fstest.MapFS{
+	"source_test.go": {Data: []byte(`package dwarfreflect
+
+func sourceTestGreet(userName string, times int) (greeting string, err error) { return "", nil }
+
+func (s *sourceTestService) Rename(id int, newName string) error { return nil }
+`)},
+	"svc/handlers/users.go": {Data: []byte(`package users
+
+func Create(ctx context.Context, name string, _ int, _ bool) (id int, _ error) { return 0, nil }
+
+func Ping(int, string) error { return nil }
+
+func (Cache[K, V]) Get(key K) (V, bool) { var v V; return v, false }
+`)},
+	"svc/handlers/users_test.go": {Data: []byte(`package users_test
+
+func Create(t *testing.T, name string) {}
+
+func newFixture(seed int64) (fixture any) { return nil }
+`)},
+	"lib/v2/lib.go": {Data: []byte(`package lib
+
+func Open(path string) error { return nil }
+`)},
+	"other/lib.go": {Data: []byte(`package lib
+
+func Open(name string) error { return nil }
+`)},
+	"testdata/broken.go": {Data: []byte(`not Go`)},
+	"README.md":          {Data: []byte(`# handlers`)},
+}
+
+// registerSourceTestFS registers sourceTestFS until the end of the test.
+func registerSourceTestFS(t *testing.T) {
+	t.Helper()
+	if err := RegisterSourceFS(sourceTestFS); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		sourceNames.mu.Lock()
+		sourceNames.funcs = nil
+		sourceNames.mu.Unlock()
+		resetFunctionInfos()
+	})
+}
+
+func TestLookupSourceNames(t *testing.T) {
+	registerSourceTestFS(t)
+
+	tests := []struct {
+		funcName        string
+		params, results int
+		wantParams      []string
+		wantResults     []string
+	}{
+		{"github.com/matteo-grella/dwarfreflect.sourceTestGreet", 2, 2, []string{"userName", "times"}, []string{"greeting", "err"}},
+		{"github.com/matteo-grella/dwarfreflect.(*sourceTestService).Rename-fm", 2, 1, []string{"id", "newName"}, []string{"~r0"}},
+		{"github.com/matteo-grella/dwarfreflect.(*sourceTestService).Rename", 3, 1, []string{"s", "id", "newName"}, []string{"~r0"}},
+		// Matched by directory whatever the package name, then by package name
+		{"example.com/svc/handlers.Create", 4, 2, []string{"ctx", "name", "_", "_"}, []string{"id", "_"}},
+		{"example.com/users/v2.Create", 4, 2, []string{"ctx", "name", "_", "_"}, []string{"id", "_"}},
+		{"example.com/svc/handlers.Ping", 2, 1, []string{"~p0", "~p1"}, []string{"~r0"}},
+		{"example.com/svc/handlers.Cache[...].Get", 2, 2, []string{"~p0", "key"}, []string{"~r0", "~r1"}},
+		{"example.com/lib/v2.Open", 1, 1, []string{"path"}, []string{"~r0"}},
+		{"example.com/other.Open", 1, 1, []string{"name"}, []string{"~r0"}},
+		// External test packages match the declarations of their package clause
+		{"example.com/svc/handlers_test.Create", 2, 0, []string{"t", "name"}, nil},
+		{"example.com/svc/handlers_test.newFixture", 1, 1, []string{"seed"}, []string{"fixture"}},
+		{"example.com/users_test.newFixture", 1, 1, []string{"seed"}, []string{"fixture"}},
+	}
+	for _, tt := range tests {
+		params, results, ok := lookupSourceNames(tt.funcName, tt.params, tt.results)
+		if !ok || !reflect.DeepEqual(params, tt.wantParams) || !reflect.DeepEqual(results, tt.wantResults) {
+			t.Errorf("%s: expected %v %v, got %v %v (%v)", tt.funcName, tt.wantParams, tt.wantResults, params, results, ok)
+		}
+	}
+
+	for _, funcName := range []string{
+		"example.com/lib.Open",                // ambiguous
+		"example.com/users.Delete",            // not declared
+		"example.com/users.Create.func1",      // closure
+		"example.com/elsewhere.Create",        // another package
+		"example.com/svc/handlers.newFixture", // declared by the test package
+	} {
+		if _, _, ok := lookupSourceNames(funcName, 1, 1); ok {
+			t.Errorf("%s: expected no match", funcName)
+		}
+	}
+	if _, _, ok := lookupSourceNames("example.com/users.Create", 3, 2); ok {
+		t.Error("expected no match with another parameter count")
+	}
+}
+
+func TestRegisterSourceFS_NewFunction(t *testing.T) {
+	registerSourceTestFS(t)
+
+	// Without DWARF (plain go test), names come from the registered source
+	fn, err := NewFunction(sourceTestGreet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(fn.paramNames, []string{"userName", "times"}) || !reflect.DeepEqual(fn.resultNames, []string{"greeting", "err"}) {
+		t.Errorf("unexpected names %v %v", fn.paramNames, fn.resultNames)
+	}
+	if resolverInitErr != nil && fn.Provenance().Source != SourceGoFile {
+		t.Errorf("expected names from source, got %v", fn.Provenance())
+	}
+
+	svc := &sourceTestService{names: map[int]string{}}
+	rename, err := NewFunction(svc.Rename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := rename.CallWithMap(map[string]any{"id": 1, "newName": "ada"}); err != nil || svc.names[1] != "ada" {
+		t.Errorf("unexpected call result %v, %v", svc.names, err)
+	}
+
+	if resolverInitErr != nil {
+		if _, err := NewFunction(testFunc1); err != resolverInitErr {
+			t.Errorf("expected the DWARF error for a function without source, got %v", err)
+		}
+	}
+}
+
+func TestRegisterSourceFS_Errors(t *testing.T) {
+	if err := RegisterSourceFS(fstest.MapFS{"bad.go": {Data: []byte(`package x; func (`)}}); err == nil {
+		t.Error("expected a parse error")
+	}
+	if hasSourceNames() {
+		t.Error("expected nothing registered after an error")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package sseadapter serves the functions of a Registry as Server-Sent Events
+// streams, for long-running calls whose clients want to follow them.
+//
+// The request path names the registered function and its arguments are
+// bound from the request by parameter name, as CallWithRequest does. The
+// response is a stream of JSON events:
+//
+//	event: progress   an update pushed by the function, see below
+//	event: item       an item received from a function returning a channel
+//	event: result     the results of the function, as ResultsToStruct (or
+//	                  ResultsToPage for paginated functions) returns them
+//	event: done       the end of the items of a function returning a channel
+//	event: error      {"message": "..."}, the error ending the call
+//
+// Functions push progress through a parameter of type
+// dwarfreflect.ProgressReporter, or of a func(T) type with no results, which
+// the adapter binds for every call (see Function.WithProgress):
+//
+//	func Export(ctx context.Context, rows int, progress func(percent int)) (path string, err error)
+//
+//	// GET /events/Export?rows=1000
+//	event: progress
+//	data: 10
+//	...
+//	event: result
+//	data: {"path":"export.csv"}
+//
+// Responses to deprecated functions carry the headers of
+// dwarfreflect.SetDeprecationHeaders.
+//
+// Example:
+//
+//	http.Handle("/events/", http.StripPrefix("/events/", sseadapter.New(reg)))
+package sseadapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// Options configures a Handler.
+type Options struct {
+	// Request configures how arguments are bound from requests.
+	Request dwarfreflect.RequestOptions
+}
+
+// Handler is an http.Handler calling the functions of a Registry and
+// streaming their progress and results as Server-Sent Events.
+type Handler struct {
+	registry *dwarfreflect.Registry
+	options  Options
+}
+
+// New creates a Handler calling the functions of reg.
+func New(reg *dwarfreflect.Registry, opts ...Options) *Handler {
+	var options Options
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return &Handler{registry: reg, options: options}
+}
+
+// ServeHTTP calls the function named by the request path, relative to the
+// prefix stripped by the mux, and streams its events until it returns or the
+// request context is done. Unknown functions are answered with 404 Not Found.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := strings.Trim(r.URL.Path, "/")
+	fn, ok := h.registry.Get(method)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown method %q", method), http.StatusNotFound)
+		return
+	}
+
+	dwarfreflect.SetDeprecationHeaders(w.Header(), fn)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	events := &eventWriter{w: w, rc: http.NewResponseController(w)}
+	defer events.close()
+
+	fn = fn.WithProgress(dwarfreflect.ProgressFunc(func(progress any) {
+		events.send("progress", progress)
+	}))
+	results, err := fn.CallWithRequest(r, h.options.Request)
+	if err != nil {
+		events.fail(err)
+		return
+	}
+
+	if fn.IsStream() {
+		items, err := fn.StreamResults(r.Context(), results)
+		if err != nil {
+			events.fail(err)
+			return
+		}
+		for item := range items {
+			events.send("item", item)
+		}
+		if err := r.Context().Err(); err != nil {
+			events.fail(err)
+			return
+		}
+		events.send("done", struct{}{})
+		return
+	}
+
+	var response any
+	if fn.IsPaginated() {
+		response, err = fn.ResultsToPage(results)
+	} else {
+		response, err = fn.ResultsToStruct(results)
+	}
+	if err != nil {
+		events.fail(err)
+		return
+	}
+	events.send("result", response)
+}
+
+// eventWriter writes events to a response, flushing each. Functions may
+// report progress from other goroutines, and even after returning: writes
+// are serialized, and dropped once the handler has returned.
+type eventWriter struct {
+	mu     sync.Mutex
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	closed bool
+}
+
+func (e *eventWriter) send(event string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		event, payload = "error", errorPayload(fmt.Errorf("cannot encode %s event: %w", event, err))
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, payload)
+	e.rc.Flush()
+}
+
+func (e *eventWriter) fail(err error) {
+	e.send("error", json.RawMessage(errorPayload(err)))
+}
+
+func (e *eventWriter) close() {
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+}
+
+func errorPayload(err error) []byte {
+	payload, _ := json.Marshal(struct {
+		Message string `json:"message"`
+	}{err.Error()})
+	return payload
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package sseadapter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+)
+
+func export(ctx context.Context, rows int, progress func(percent int)) (path string, err error) {
+	if rows <= 0 {
+		return "", errors.New("nothing to export")
+	}
+	for done := 1; done <= rows; done++ {
+		progress(done * 100 / rows)
+	}
+	return "export.csv", nil
+}
+
+func reindex(reporter dwarfreflect.ProgressReporter, shard string) (<-chan string, error) {
+	reporter.Report("started " + shard)
+	ch := make(chan string, 2)
+	ch <- shard + "-1"
+	ch <- shard + "-2"
+	close(ch)
+	return ch, nil
+}
+
+type event struct {
+	name, data string
+}
+
+func get(t *testing.T, url string) (*http.Response, []event) {
+	t.Helper()
+	reg := dwarfreflect.NewRegistry()
+	dwarfreflecttest.Register(t, reg, "Export", export)
+	dwarfreflecttest.Register(t, reg, "Reindex", reindex)
+	if fn, err := dwarfreflect.NewFunction(export); err == nil {
+		dwarfreflecttest.Register(t, reg, "ExportV0", fn.Deprecate("use Export"))
+	}
+
+	server := httptest.NewServer(http.StripPrefix("/events/", New(reg)))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + url)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var events []event
+	var current event
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		case line == "" && current.name != "":
+			events = append(events, current)
+			current = event{}
+		}
+	}
+	return resp, events
+}
+
+func format(events []event) string {
+	var parts []string
+	for _, e := range events {
+		parts = append(parts, e.name+" "+e.data)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func TestHandler_Progress(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+
+	resp, events := get(t, "/events/Export?rows=4")
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("unexpected content type: %s", got)
+	}
+	want := `progress 25; progress 50; progress 75; progress 100; result {"path":"export.csv"}`
+	if got := format(events); got != want {
+		t.Errorf("got events %s, want %s", got, want)
+	}
+}
+
+func TestHandler_Deprecated(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+
+	resp, _ := get(t, "/events/ExportV0?rows=1")
+	if resp.Header.Get("Deprecation") != "true" || !strings.Contains(resp.Header.Get("Warning"), "use Export") {
+		t.Errorf("expected deprecation headers, got %v", resp.Header)
+	}
+	if resp, _ := get(t, "/events/Export?rows=1"); resp.Header.Get("Deprecation") != "" {
+		t.Errorf("expected no deprecation headers, got %v", resp.Header)
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+
+	_, events := get(t, "/events/Reindex?shard=users")
+	want := `progress "started users"; item "users-1"; item "users-2"; done {}`
+	if got := format(events); got != want {
+		t.Errorf("got events %s, want %s", got, want)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+
+	_, events := get(t, "/events/Export?rows=0")
+	if got := format(events); got != `error {"message":"nothing to export"}` {
+		t.Errorf("unexpected events for a failed call: %s", got)
+	}
+
+	_, events = get(t, "/events/Export")
+	if len(events) != 1 || events[0].name != "error" || !strings.Contains(events[0].data, "rows") {
+		t.Errorf("unexpected events for a missing argument: %s", format(events))
+	}
+
+	resp, _ := get(t, "/events/Missing")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d for an unknown method, want 404", resp.StatusCode)
+	}
+}
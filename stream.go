@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// IsStream reports whether the function streams its results: it returns a
+// channel it can be received from, optionally followed by an error.
+//
+// Example:
+//
+//	func Tail(ctx context.Context, path string) (<-chan string, error)
+//	fn.IsStream() // true
+func (t *Function) IsStream() bool {
+	return t.StreamItemType() != nil
+}
+
+// StreamItemType returns the element type of the channel returned by a
+// streaming function, e.g. string for <-chan string, or nil if the function
+// does not stream.
+func (t *Function) StreamItemType() reflect.Type {
+	out := t.functionType.NumOut()
+	if out == 0 || out > 2 || (out == 2 && t.functionType.Out(1) != errorType) {
+		return nil
+	}
+	typ := t.functionType.Out(0)
+	if typ.Kind() != reflect.Chan || typ.ChanDir()&reflect.RecvDir == 0 {
+		return nil
+	}
+	return typ.Elem()
+}
+
+// CallStream invokes a streaming function using a map of parameter names to
+// values and returns its channel adapted to a <-chan any, or the error the
+// function returned. Items are forwarded until the function closes its
+// channel or the context passed to the function is done; either way the
+// returned channel is closed. Functions that do not stream are rejected
+// without being called.
+//
+// Example:
+//
+//	func Tail(ctx context.Context, path string) (<-chan string, error)
+//	lines, err := fn.CallStream(map[string]any{"ctx": ctx, "path": "/var/log/app.log"})
+//	for line := range lines {
+//	    fmt.Println(line)
+//	}
+func (t *Function) CallStream(argMap map[string]any, opts ...CallOptions) (<-chan any, error) {
+	if !t.IsStream() {
+		return nil, fmt.Errorf("CallStream: function %s must return a receive channel, optionally with an error, returns %v",
+			t.funcName, t.GetReturnTypes())
+	}
+
+	results, err := t.CallWithMap(argMap, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return t.StreamResults(t.mapContext(argMap), results)
+}
+
+// StreamResults adapts the results of a streaming function, as returned by
+// any Call variant, to a <-chan any, for adapters delivering items as they
+// come. It returns the trailing error of the function, if any. Items are
+// forwarded until the channel of the function closes or ctx is done, then
+// the returned channel is closed; a nil channel is an empty stream.
+//
+// Example:
+//
+//	results, err := router.Dispatch(ctx, "logs.Tail", payload)
+//	items, err := fn.StreamResults(ctx, results)
+//	for item := range items {
+//	    send(item)
+//	}
+func (t *Function) StreamResults(ctx context.Context, results []reflect.Value) (<-chan any, error) {
+	if !t.IsStream() {
+		return nil, fmt.Errorf("results %v of function %s do not stream", t.GetReturnTypes(), t.funcName)
+	}
+	if err := trailingError(results); err != nil {
+		return nil, err
+	}
+
+	items := make(chan any)
+	source := results[0]
+	if source.IsNil() {
+		close(items)
+		return items, nil
+	}
+
+	go func() {
+		defer close(items)
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: source},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+		for {
+			chosen, item, ok := reflect.Select(cases)
+			if chosen == 1 || !ok {
+				return
+			}
+			select {
+			case items <- item.Interface():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return items, nil
+}
+
+// mapContext returns the first non-nil context.Context in argMap bound to a
+// context parameter, or context.Background().
+func (t *Function) mapContext(argMap map[string]any) context.Context {
+	for _, pos := range t.GetContextPositions() {
+		if ctx, ok := argMap[t.paramNames[pos]].(context.Context); ok && ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+//go:noinline
+func testFuncCountTo(ctx context.Context, limit int) (<-chan int, error) {
+	if limit < 0 {
+		return nil, errors.New("negative limit")
+	}
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= limit; i++ {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+//go:noinline
+func testFuncTicks(ctx context.Context) chan string {
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case ch <- "tick":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func TestCallStream(t *testing.T) {
+	fn := mustNewFunction(t, testFuncCountTo)
+	if !fn.IsStream() {
+		t.Fatal("expected a function returning a receive channel to stream")
+	}
+	if got := fn.StreamItemType(); got != reflect.TypeFor[int]() {
+		t.Errorf("unexpected item type: %v", got)
+	}
+
+	items, err := fn.CallStream(map[string]any{"ctx": context.Background(), "limit": 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := slices.Collect(chanSeq(items)); !slices.Equal(got, []any{1, 2, 3}) {
+		t.Errorf("unexpected items: %v", got)
+	}
+
+	if _, err := fn.CallStream(map[string]any{"ctx": context.Background(), "limit": -1}); err == nil || err.Error() != "negative limit" {
+		t.Errorf("expected the function error, got %v", err)
+	}
+}
+
+func TestCallStream_Cancel(t *testing.T) {
+	fn := mustNewFunction(t, testFuncTicks)
+	ctx, cancel := context.WithCancel(context.Background())
+	items, err := fn.CallStream(map[string]any{"ctx": ctx})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item := <-items; item != "tick" {
+		t.Errorf("unexpected item: %v", item)
+	}
+
+	cancel()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-items:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected the stream to close once the context is done")
+		}
+	}
+}
+
+func TestCallStream_NotStream(t *testing.T) {
+	fn := mustNewFunction(t, testFuncListLetters)
+	if fn.IsStream() || fn.StreamItemType() != nil {
+		t.Error("expected a function returning a slice not to stream")
+	}
+	if _, err := fn.CallStream(map[string]any{"cursor": "", "limit": 1}); err == nil {
+		t.Error("expected an error for a function that does not stream")
+	}
+}
+
+func TestStreamResults_Nil(t *testing.T) {
+	fn := mustNewFunction(t, testFuncCountTo)
+	items, err := fn.StreamResults(context.Background(), []reflect.Value{
+		reflect.Zero(reflect.TypeFor[<-chan int]()), reflect.Zero(errorType),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := <-items; ok {
+		t.Error("expected a nil channel to be an empty stream")
+	}
+}
+
+func chanSeq(ch <-chan any) func(yield func(any) bool) {
+	return func(yield func(any) bool) {
+		for item := range ch {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
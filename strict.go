@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StrictTypeError reports an argument whose type is assignable to its
+// parameter but not identical to it, rejected by CallOptions.StrictTypes.
+type StrictTypeError struct {
+	Function string
+	Param    string
+	Want     reflect.Type // type of the parameter
+	Got      reflect.Type // type of the argument
+}
+
+func (e *StrictTypeError) Error() string {
+	return fmt.Sprintf("parameter %q of function %s: strict types require %s, got %s",
+		e.Param, e.Function, describeType(e.Want), describeType(e.Got))
+}
+
+// strictTypeMatch reports whether an argument of type from may bind a
+// parameter of type to under CallOptions.StrictTypes: the types must be
+// identical, unless to is an interface from implements.
+func strictTypeMatch(from, to reflect.Type) bool {
+	return from == to || (to.Kind() == reflect.Interface && from.Implements(to))
+}
+
+// describeType formats typ for type errors, spelling out the underlying type
+// of defined types so that Tags and []string are told apart at a glance:
+// "main.Tags (defined type over []string)".
+func describeType(typ reflect.Type) string {
+	if typ.Name() == "" || typ.PkgPath() == "" || typ.Kind() == reflect.Interface {
+		return typ.String()
+	}
+	return fmt.Sprintf("%v (defined type over %s)", typ, underlyingType(typ))
+}
+
+// underlyingType formats the underlying type of typ, the unnamed type of its
+// kind where reflection can build one and its kind otherwise.
+func underlyingType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Slice:
+		return reflect.SliceOf(typ.Elem()).String()
+	case reflect.Array:
+		return reflect.ArrayOf(typ.Len(), typ.Elem()).String()
+	case reflect.Map:
+		return reflect.MapOf(typ.Key(), typ.Elem()).String()
+	case reflect.Pointer:
+		return reflect.PointerTo(typ.Elem()).String()
+	case reflect.Chan:
+		return reflect.ChanOf(typ.ChanDir(), typ.Elem()).String()
+	}
+	return typ.Kind().String()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type strictTags []string
+
+//go:noinline
+func strictTestTag(tags strictTags, labels []string, out fmt.Stringer) int {
+	return len(tags) + len(labels)
+}
+
+func TestStrictTypes(t *testing.T) {
+	fn := mustNewFunction(t, strictTestTag)
+	args := map[string]any{"tags": []string{"a"}, "labels": strictTags{"b"}, "out": strictStringer{}}
+
+	// Assignable arguments bind by default
+	if _, err := fn.CallWithMap(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := fn.CallWithMap(args, CallOptions{StrictTypes: true})
+	var strictErr *StrictTypeError
+	if !errors.As(err, &strictErr) || strictErr.Param != "tags" {
+		t.Fatalf("expected a *StrictTypeError for tags, got %v", err)
+	}
+	if want := "strict types require dwarfreflect.strictTags (defined type over []string), got []string"; !strings.Contains(err.Error(), want) {
+		t.Errorf("expected %q, got %q", want, err)
+	}
+
+	args["tags"] = strictTags{"a"}
+	_, err = fn.CallWithMap(args, CallOptions{StrictTypes: true})
+	if !errors.As(err, &strictErr) || strictErr.Param != "labels" || strictErr.Got != reflect.TypeFor[strictTags]() {
+		t.Fatalf("expected a *StrictTypeError for labels, got %v", err)
+	}
+
+	// Identical types and interface implementations pass
+	args["labels"] = []string{"b"}
+	results, err := fn.CallWithMap(args, CallOptions{StrictTypes: true})
+	if err != nil || results[0].Int() != 2 {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+}
+
+type strictStringer struct{}
+
+func (strictStringer) String() string { return "" }
+
+func TestDescribeType(t *testing.T) {
+	for typ, want := range map[string]string{
+		describeType(reflect.TypeFor[strictTags]()):   "dwarfreflect.strictTags (defined type over []string)",
+		describeType(reflect.TypeFor[[]string]()):     "[]string",
+		describeType(reflect.TypeFor[int]()):          "int",
+		describeType(reflect.TypeFor[FunctionKind]()): "dwarfreflect.FunctionKind (defined type over int)",
+		describeType(reflect.TypeFor[fmt.Stringer]()): "fmt.Stringer",
+	} {
+		if typ != want {
+			t.Errorf("expected %q, got %q", want, typ)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/matteo-grella/dwarfreflect/symbols"
+)
+
+// Suggest returns up to n DWARF functions whose names are nearest to funcName,
+// nearest first, for diagnostics of failed lookups: inlined functions have no
+// entry, and renamed ones are only found under their new name. Names are
+// compared by edit distance on the base name, ignoring case, preferring
+// functions of the same package. Closures, wrappers and names too far from
+// funcName are never suggested.
+//
+// Example:
+//
+//	dr, _ := dwarfreflect.NewDWARFResolver("./server")
+//	dr.Suggest("main.ProcessUser", 3) // [main.ProcessUserV2 main.processUser]
+func (dr *DWARFResolver) Suggest(funcName string, n int) []string {
+	return dr.nearest(funcName, n)
+}
+
+// nearest implements Suggest.
+func (dr *DWARFResolver) nearest(funcName string, n int) []string {
+	target := symbols.Parse(funcName)
+	base := strings.ToLower(target.Name)
+	if n <= 0 || base == "" {
+		return nil
+	}
+	limit := max(1, len(base)/3)
+
+	type match struct {
+		name     string
+		distance int
+		samePkg  bool
+	}
+	var matches []match
+	for name := range dr.functions.functionNames() {
+		// Bracket-free aliases of generic instantiations are indexed as well
+		if name == funcName || strings.ContainsRune(name, '[') {
+			continue
+		}
+		sym := symbols.Parse(name)
+		if sym.Package == "" || sym.Closure != nil || sym.Wrapper != "" || sym.MethodValue {
+			continue
+		}
+		if d := editDistance(base, strings.ToLower(sym.Name)); d <= limit {
+			matches = append(matches, match{name, d, sym.Package == target.Package})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		if c := cmp.Compare(a.distance, b.distance); c != 0 {
+			return c
+		}
+		if a.samePkg != b.samePkg {
+			if a.samePkg {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	names := make([]string, 0, min(n, len(matches)))
+	for _, m := range matches[:min(n, len(matches))] {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b, in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
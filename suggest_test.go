@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"processuser", "processuserv2", 2},
+		{"kitten", "sitting", 3},
+		{"héllo", "hello", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDWARFResolver_Suggest(t *testing.T) {
+	dr := &DWARFResolver{
+		functions: indexOf(map[string][]string{
+			"app/users.ProcessUserV2":           {"id"},
+			"app/users.processUser":             {"id"},
+			"app/admin.ProcessUser":             {"id"},
+			"app/users.(*Store).ProcessUsers":   {"s", "ids"},
+			"app/users.ProcessUserV2.func1":     {},
+			"app/users.Map[go.shape.int]":       {"s"},
+			"app/users.DeleteUser":              {"id"},
+			"app/users.ProcessUserV2-fm":        {"id"},
+			"app/users.(*Store).ProcessUser-fm": {"id"},
+		}),
+		normalizers: defaultNormalizers,
+	}
+
+	want := []string{"app/users.processUser", "app/admin.ProcessUser", "app/users.(*Store).ProcessUsers"}
+	if got := dr.Suggest("app/users.ProcessUser", 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := dr.Suggest("app/users.ProcessUser", 10); len(got) != 4 {
+		t.Errorf("expected 4 suggestions, got %v", got)
+	}
+	if got := dr.Suggest("app/users.Unrelated", 3); len(got) != 0 {
+		t.Errorf("expected no suggestions, got %v", got)
+	}
+	if got := dr.Suggest("app/users.ProcessUser", 0); got != nil {
+		t.Errorf("expected no suggestions when disabled, got %v", got)
+	}
+
+	_, err := dr.discoverParameterNames("app/users.ProcessUser", 1)
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("expected an error without suggestions, got %v", err)
+	}
+
+	dr.suggestions = 2
+	_, err = dr.discoverParameterNames("app/users.ProcessUser", 1)
+	if err == nil || !strings.Contains(err.Error(), "Did you mean app/users.processUser, app/admin.ProcessUser?") {
+		t.Errorf("expected an error with suggestions, got %v", err)
+	}
+}
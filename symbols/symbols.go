@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package symbols parses Go runtime symbol names, as returned by
+// runtime.FuncForPC(pc).Name() and found in DWARF subprogram entries,
+// into their package, receiver, function and closure parts.
+//
+// Example:
+//
+//	sym := symbols.Parse("example.com/lib/v2.(*Cache[...]).Get-fm")
+//	// sym.Package: "example.com/lib/v2", sym.Receiver: "Cache",
+//	// sym.PointerReceiver: true, sym.Name: "Get", sym.TypeArgs: "...",
+//	// sym.MethodValue: true
+package symbols
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Symbol is a parsed Go symbol name.
+type Symbol struct {
+	// Package is the import path of the defining package, with escaped dots
+	// ("%2e") restored. Empty when the name has no package qualifier.
+	Package string
+
+	// Receiver is the receiver type name of a method, without pointer or type
+	// arguments. Empty for plain functions.
+	Receiver string
+
+	// PointerReceiver reports a method declared on *Receiver.
+	PointerReceiver bool
+
+	// Name is the function or method name.
+	Name string
+
+	// TypeArgs are the type arguments of a generic function or receiver type,
+	// without brackets. Runtime names use the shape placeholder "...", DWARF
+	// names list concrete types, e.g. "int,string".
+	TypeArgs string
+
+	// MethodValue reports a bound method value wrapper ("-fm" suffix), the
+	// function behind expressions like obj.Method.
+	MethodValue bool
+
+	// Closure holds the indices of nested closures, outermost first:
+	// "pkg.F.func1.2" has Closure [1 2]. Indexed init functions ("pkg.init.0")
+	// are reported as closures of init.
+	Closure []int
+
+	// Wrapper is a compiler-generated wrapper suffix such as "deferwrap1" or
+	// "gowrap2", empty when there is none.
+	Wrapper string
+}
+
+// Parse splits a Go symbol name into its parts. It never fails: names that do
+// not follow the Go symbol grammar are returned with Name set to the remainder.
+func Parse(name string) Symbol {
+	var sym Symbol
+
+	rest := name
+	if trimmed, ok := strings.CutSuffix(rest, "-fm"); ok {
+		sym.MethodValue = true
+		rest = trimmed
+	}
+
+	if pkgEnd := packageEnd(rest); pkgEnd >= 0 {
+		sym.Package = strings.ReplaceAll(rest[:pkgEnd], "%2e", ".")
+		rest = rest[pkgEnd+1:]
+	}
+
+	parts := splitTopLevel(rest)
+	if len(parts) == 0 {
+		return sym
+	}
+
+	switch {
+	case strings.HasPrefix(parts[0], "(") && strings.HasSuffix(parts[0], ")") && len(parts) > 1:
+		// Pointer or parenthesized receiver: "(*T[...]).Method"
+		receiver := strings.TrimSuffix(strings.TrimPrefix(parts[0], "("), ")")
+		receiver, sym.PointerReceiver = strings.CutPrefix(receiver, "*")
+		sym.Receiver, sym.TypeArgs = splitTypeArgs(receiver)
+		sym.Name = parts[1]
+		parts = parts[2:]
+	case len(parts) > 1 && !isClosureSegment(parts[1]):
+		// Value receiver: "T.Method"
+		sym.Receiver, sym.TypeArgs = splitTypeArgs(parts[0])
+		sym.Name = parts[1]
+		parts = parts[2:]
+	default:
+		sym.Name, sym.TypeArgs = splitTypeArgs(parts[0])
+		parts = parts[1:]
+	}
+
+	for _, part := range parts {
+		if index, ok := closureIndex(part); ok {
+			sym.Closure = append(sym.Closure, index)
+		} else {
+			sym.Wrapper = part
+		}
+	}
+
+	return sym
+}
+
+// IsMethod reports whether the symbol is a method.
+func (s Symbol) IsMethod() bool {
+	return s.Receiver != ""
+}
+
+// IsClosure reports whether the symbol is a closure inside Name.
+func (s Symbol) IsClosure() bool {
+	return len(s.Closure) > 0
+}
+
+// IsGeneric reports whether the symbol belongs to a generic function or type.
+func (s Symbol) IsGeneric() bool {
+	return s.TypeArgs != ""
+}
+
+// IsShape reports whether the type arguments are a shape placeholder rather
+// than concrete types, as in runtime names ("...") and shape-instantiated
+// symbols ("go.shape.int").
+func (s Symbol) IsShape() bool {
+	return s.TypeArgs == "..." || strings.Contains(s.TypeArgs, "go.shape.")
+}
+
+// String reassembles the symbol name in runtime form.
+func (s Symbol) String() string {
+	var b strings.Builder
+	if s.Package != "" {
+		b.WriteString(escapePackage(s.Package))
+		b.WriteByte('.')
+	}
+
+	typeArgs := ""
+	if s.TypeArgs != "" {
+		typeArgs = "[" + s.TypeArgs + "]"
+	}
+
+	switch {
+	case s.PointerReceiver:
+		b.WriteString("(*" + s.Receiver + typeArgs + ").")
+		typeArgs = ""
+	case s.Receiver != "":
+		b.WriteString(s.Receiver + typeArgs + ".")
+		typeArgs = ""
+	}
+	b.WriteString(s.Name + typeArgs)
+
+	for i, index := range s.Closure {
+		if i == 0 && s.Name != "init" {
+			b.WriteString(".func" + strconv.Itoa(index))
+		} else {
+			b.WriteString("." + strconv.Itoa(index))
+		}
+	}
+	if s.Wrapper != "" {
+		b.WriteString("." + s.Wrapper)
+	}
+	if s.MethodValue {
+		b.WriteString("-fm")
+	}
+	return b.String()
+}
+
+// escapePackage escapes dots in the last path element as the linker does.
+func escapePackage(pkg string) string {
+	lastSlash := strings.LastIndex(pkg, "/")
+	return pkg[:lastSlash+1] + strings.ReplaceAll(pkg[lastSlash+1:], ".", "%2e")
+}
+
+// packageEnd returns the index of the dot ending the package path, ignoring
+// slashes and dots inside type arguments, or -1 if there is no package.
+func packageEnd(name string) int {
+	lastSlash, depth := -1, 0
+	for i, r := range name {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				lastSlash = i
+			}
+		}
+	}
+
+	for i := lastSlash + 1; i < len(name); i++ {
+		switch name[i] {
+		case '[', '(':
+			return -1 // A type or receiver before any dot: no package qualifier
+		case '.':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits name on dots outside brackets and parentheses.
+func splitTopLevel(name string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range name {
+		switch r {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, name[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, name[start:])
+}
+
+// splitTypeArgs splits "Name[args]" into "Name" and "args".
+func splitTypeArgs(s string) (string, string) {
+	open := strings.Index(s, "[")
+	if open == -1 || !strings.HasSuffix(s, "]") {
+		return s, ""
+	}
+	return s[:open], s[open+1 : len(s)-1]
+}
+
+// isClosureSegment reports whether a name segment is compiler-generated.
+func isClosureSegment(part string) bool {
+	if _, ok := closureIndex(part); ok {
+		return true
+	}
+	for _, prefix := range []string{"deferwrap", "gowrap"} {
+		if digits, ok := strings.CutPrefix(part, prefix); ok && isDigits(digits) {
+			return true
+		}
+	}
+	return false
+}
+
+// closureIndex parses "func3" or "3" as closure index 3.
+func closureIndex(part string) (int, bool) {
+	digits := strings.TrimPrefix(part, "func")
+	if !isDigits(digits) {
+		return 0, false
+	}
+	index, err := strconv.Atoi(digits)
+	return index, err == nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
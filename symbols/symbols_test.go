@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package symbols
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Symbol
+	}{
+		{"main.foo", Symbol{Package: "main", Name: "foo"}},
+		{"funcNameOnly", Symbol{Name: "funcNameOnly"}},
+		{"github.com/user/repo/pkg.Function", Symbol{Package: "github.com/user/repo/pkg", Name: "Function"}},
+		{"pkg.(*T).Method", Symbol{Package: "pkg", Receiver: "T", PointerReceiver: true, Name: "Method"}},
+		{"pkg.(*T).Method-fm", Symbol{Package: "pkg", Receiver: "T", PointerReceiver: true, Name: "Method", MethodValue: true}},
+		{"pkg.T.Method", Symbol{Package: "pkg", Receiver: "T", Name: "Method"}},
+		{"pkg.T.Method-fm", Symbol{Package: "pkg", Receiver: "T", Name: "Method", MethodValue: true}},
+		{"pkg.F.func1", Symbol{Package: "pkg", Name: "F", Closure: []int{1}}},
+		{"pkg.F.func1.2", Symbol{Package: "pkg", Name: "F", Closure: []int{1, 2}}},
+		{"pkg.(*T).M.func3", Symbol{Package: "pkg", Receiver: "T", PointerReceiver: true, Name: "M", Closure: []int{3}}},
+		{"pkg.init.0", Symbol{Package: "pkg", Name: "init", Closure: []int{0}}},
+		{"pkg.F.deferwrap1", Symbol{Package: "pkg", Name: "F", Wrapper: "deferwrap1"}},
+		{"pkg.F.func1.gowrap2", Symbol{Package: "pkg", Name: "F", Closure: []int{1}, Wrapper: "gowrap2"}},
+		{"example.com/lib/v2.Do[...]", Symbol{Package: "example.com/lib/v2", Name: "Do", TypeArgs: "..."}},
+		{"pkg.G[int,string]", Symbol{Package: "pkg", Name: "G", TypeArgs: "int,string"}},
+		{"pkg.G[example.com/x.T]", Symbol{Package: "pkg", Name: "G", TypeArgs: "example.com/x.T"}},
+		{"pkg.G[go.shape.int]", Symbol{Package: "pkg", Name: "G", TypeArgs: "go.shape.int"}},
+		{"example.com/lib.(*List[...]).Push", Symbol{Package: "example.com/lib", Receiver: "List", PointerReceiver: true, Name: "Push", TypeArgs: "..."}},
+		{"example.com/lib.Pair[...].Swap-fm", Symbol{Package: "example.com/lib", Receiver: "Pair", Name: "Swap", TypeArgs: "...", MethodValue: true}},
+		{"gopkg.in/yaml%2ev3.Marshal", Symbol{Package: "gopkg.in/yaml.v3", Name: "Marshal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.name)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.name, got, tt.expected)
+			}
+			if s := got.String(); s != tt.name {
+				t.Errorf("Parse(%q).String() = %q", tt.name, s)
+			}
+		})
+	}
+}
+
+func TestSymbol_Predicates(t *testing.T) {
+	sym := Parse("pkg.(*List[...]).Push.func1")
+	if !sym.IsMethod() || !sym.IsClosure() || !sym.IsGeneric() || !sym.IsShape() {
+		t.Errorf("expected generic method closure with shape, got %+v", sym)
+	}
+
+	sym = Parse("pkg.G[int]")
+	if sym.IsMethod() || sym.IsClosure() || !sym.IsGeneric() || sym.IsShape() {
+		t.Errorf("expected concrete generic function, got %+v", sym)
+	}
+}
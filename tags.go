@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// AdoptTagsFrom returns a copy of the Function whose generated parameter
+// structs carry the struct tags (json, validate, form, ...) of the fields of
+// typ with the same names as the parameters, so code migrating from a
+// hand-written request struct keeps its serialization and validation
+// behavior. A field matches a parameter by Go name (userName matches
+// UserName), then by json name, then by name ignoring case; unmatched
+// parameters keep the generated tags. Adopted tags override generated ones
+// with the same key, and are overridden by StructOptions.TagBuilder. The
+// json tags of unsafe parameters are never adopted. typ must be a struct or
+// a pointer to one, with at least one matching field.
+//
+// Example:
+//
+//	type CreateUserRequest struct {
+//	    UserName string `json:"user_name" validate:"required,min=3"`
+//	    Age      int    `json:"age" validate:"gte=0"`
+//	}
+//
+//	func CreateUser(ctx context.Context, userName string, age int) error
+//	fn, err = fn.AdoptTagsFrom(reflect.TypeOf(CreateUserRequest{}))
+//	fn.GetStructType().Field(1).Tag // json:"user_name" param:"userName" validate:"required,min=3"
+func (t *Function) AdoptTagsFrom(typ reflect.Type) (*Function, error) {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot adopt tags of function %s from %v: not a struct", t.funcName, typ)
+	}
+
+	adopted := make(map[string]reflect.StructTag)
+	for i, name := range t.paramNames {
+		field, ok := matchingField(typ, name)
+		if !ok || field.Tag == "" {
+			continue
+		}
+		tag := field.Tag
+		if isUnsafeType(t.paramTypes[i]) {
+			tag = withoutTagKey(tag, "json")
+		}
+		adopted[name] = tag
+	}
+	if len(adopted) == 0 {
+		return nil, fmt.Errorf("no tagged field of %v matches a parameter of function %s", typ, t.funcName)
+	}
+
+	clone := *t
+	clone.adoptedTags = adopted
+	clone.structType = reflect.StructOf(clone.applyAdoptedTags(t.paramNames, fieldsOf(t.structType)))
+	if t.frozen != nil {
+		clone.frozen = nil // the frozen struct type predates the tags
+		return clone.Freeze(), nil
+	}
+	return &clone, nil
+}
+
+// structFields is like the structFields function, applying adopted tags.
+func (t *Function) structFields(paramNames []string, paramTypes []reflect.Type, opts StructOptions) ([]reflect.StructField, error) {
+	fields, err := structFields(t.funcName, paramNames, paramTypes, opts)
+	if err != nil || t.adoptedTags == nil {
+		return fields, err
+	}
+
+	// Tags built by opts win over adopted ones
+	ordered := make([]string, len(fields))
+	for fieldIndex, i := range fieldOrder(paramNames, opts) {
+		ordered[fieldIndex] = paramNames[i]
+	}
+	for i, name := range ordered {
+		if tag, ok := t.adoptedTags[name]; ok {
+			fields[i].Tag = mergeStructTags(tag, fields[i].Tag)
+		}
+	}
+	return fields, nil
+}
+
+// applyAdoptedTags merges adopted tags over the tags of fields, which
+// correspond to paramNames in order.
+func (t *Function) applyAdoptedTags(paramNames []string, fields []reflect.StructField) []reflect.StructField {
+	for i, name := range paramNames {
+		if tag, ok := t.adoptedTags[name]; ok {
+			fields[i].Tag = mergeStructTags(fields[i].Tag, tag)
+		}
+	}
+	return fields
+}
+
+// fieldsOf returns the fields of a struct type.
+func fieldsOf(typ reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, typ.NumField())
+	for i := range fields {
+		fields[i] = typ.Field(i)
+	}
+	return fields
+}
+
+// matchingField finds the exported field of typ, including promoted fields,
+// matching the parameter name.
+func matchingField(typ reflect.Type, paramName string) (reflect.StructField, bool) {
+	var byJSON, byFold *reflect.StructField
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if field.Name == safeFieldName(paramName) {
+			return field, true
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if byJSON == nil && jsonName == paramName {
+			byJSON = &field
+		}
+		if byFold == nil && strings.EqualFold(field.Name, paramName) {
+			byFold = &field
+		}
+	}
+	switch {
+	case byJSON != nil:
+		return *byJSON, true
+	case byFold != nil:
+		return *byFold, true
+	default:
+		return reflect.StructField{}, false
+	}
+}
+
+// tagPair is a key:"value" pair of a struct tag.
+type tagPair struct {
+	key, value string
+}
+
+// parseStructTag splits a conventional struct tag into its pairs, stopping
+// at the first malformed pair as reflect.StructTag.Lookup does.
+func parseStructTag(tag reflect.StructTag) []tagPair {
+	var pairs []tagPair
+	s := string(tag)
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		i := 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+		key := s[:i]
+		s = s[i+1:]
+
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			break
+		}
+		pairs = append(pairs, tagPair{key, value})
+		s = s[i+1:]
+	}
+	return pairs
+}
+
+// formatStructTag joins pairs into a conventional struct tag.
+func formatStructTag(pairs []tagPair) reflect.StructTag {
+	parts := make([]string, len(pairs))
+	for i, pair := range pairs {
+		parts[i] = pair.key + ":" + strconv.Quote(pair.value)
+	}
+	return reflect.StructTag(strings.Join(parts, " "))
+}
+
+// mergeStructTags returns base with the values of override for shared keys,
+// followed by the keys only override has.
+func mergeStructTags(base, override reflect.StructTag) reflect.StructTag {
+	if base == "" {
+		return override
+	}
+	if override == "" {
+		return base
+	}
+
+	merged := parseStructTag(base)
+	for _, pair := range parseStructTag(override) {
+		replaced := false
+		for i := range merged {
+			if merged[i].key == pair.key {
+				merged[i].value = pair.value
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, pair)
+		}
+	}
+	return formatStructTag(merged)
+}
+
+// withoutTagKey returns tag without the given key.
+func withoutTagKey(tag reflect.StructTag, key string) reflect.StructTag {
+	pairs := parseStructTag(tag)
+	kept := pairs[:0]
+	for _, pair := range pairs {
+		if pair.key != key {
+			kept = append(kept, pair)
+		}
+	}
+	return formatStructTag(kept)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+type tagsRequest struct {
+	Name  string `json:"full_name" validate:"required"`
+	Years int    `json:"age" form:"age"`
+	Extra bool   `json:"extra"`
+}
+
+func TestAdoptTagsFrom(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	adopted, err := fn.AdoptTagsFrom(reflect.TypeOf(&tagsRequest{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	structType := adopted.GetStructType()
+	if tag := structType.Field(0).Tag; tag != `json:"full_name" param:"name" validate:"required"` {
+		t.Errorf("unexpected tag of name: %s", tag)
+	}
+	if tag := structType.Field(1).Tag; tag != `json:"age" param:"age" form:"age"` {
+		t.Errorf("unexpected tag of age, matched by json name: %s", tag)
+	}
+	if tag := adopted.GetNonContextStructType().Field(0).Tag.Get("validate"); tag != "required" {
+		t.Errorf("expected non-context struct to adopt tags, got %q", tag)
+	}
+	if fn.GetStructType().Field(0).Tag.Get("validate") != "" {
+		t.Error("the original function must keep its tags")
+	}
+
+	params, err := adopted.UnmarshalParams([]byte(`{"full_name":"Ada","age":36}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := adopted.CallWithStruct(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := results[0].String(); got != "Ada is 36 years old" {
+		t.Errorf("unexpected result %q", got)
+	}
+
+	// TagBuilder wins over adopted tags
+	variant := adopted.GetStructTypeWithOptions(StructOptions{
+		TagBuilder: func(paramName string, paramType reflect.Type) string { return `json:"` + paramName + `"` },
+	})
+	if tag := variant.Field(0).Tag; tag != `json:"name" validate:"required"` {
+		t.Errorf("unexpected tag with TagBuilder: %s", tag)
+	}
+
+	frozen, err := fn.Freeze().AdoptTagsFrom(reflect.TypeOf(tagsRequest{}))
+	if err != nil || !frozen.IsFrozen() || frozen.GetNonContextStructType().Field(0).Tag.Get("json") != "full_name" {
+		t.Errorf("expected a frozen function with adopted tags, got %v", err)
+	}
+}
+
+func TestAdoptTagsFrom_Errors(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	if _, err := fn.AdoptTagsFrom(reflect.TypeOf(0)); err == nil {
+		t.Error("expected error for a non-struct type")
+	}
+	if _, err := fn.AdoptTagsFrom(reflect.TypeOf(struct{ Other string }{})); err == nil {
+		t.Error("expected error without matching fields")
+	}
+}
+
+func TestMergeStructTags(t *testing.T) {
+	tests := []struct {
+		base, override, want reflect.StructTag
+	}{
+		{`json:"a"`, ``, `json:"a"`},
+		{``, `json:"b"`, `json:"b"`},
+		{`json:"a" param:"a"`, `json:"b,omitempty" xml:"b"`, `json:"b,omitempty" param:"a" xml:"b"`},
+		{`json:"a" broken`, `doc:"say \"hi\""`, `json:"a" doc:"say \"hi\""`},
+	}
+	for _, tt := range tests {
+		if got := mergeStructTags(tt.base, tt.override); got != tt.want {
+			t.Errorf("mergeStructTags(%s, %s) = %s, want %s", tt.base, tt.override, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Command genfixtures writes the executable fixtures of the resolver tests:
+// tiny ELF, PE, Mach-O and universal Mach-O files without code, holding only
+// the DWARF a Go compiler would emit for
+//
+//	package main
+//
+//	type Server struct{}
+//
+//	func Greet(name string, times int) string
+//	func (s *Server) Handle(path string, code int) error
+//
+// The greet files use DWARF 4 and the greet5 files the DWARF 5 layouts of
+// newer toolchains. Real binaries weigh megabytes; these weigh hundreds of
+// bytes and exercise the same container parsing on every platform.
+//
+// Usage, from the repository root:
+//
+//	go run ./testdata/genfixtures
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DWARF constants, as in debug/dwarf
+const (
+	tagCompileUnit        = 0x11
+	tagSubprogram         = 0x2e
+	tagFormalParameter    = 0x05
+	tagInlinedSubroutine  = 0x1d
+	tagLexicalBlock       = 0x0b
+	tagVariable           = 0x34
+	attrName              = 0x03
+	attrVariableParameter = 0x4b
+	attrAbstractOrigin    = 0x31
+	attrStrOffsetsBase    = 0x72
+	formString            = 0x08
+	formFlag              = 0x0c
+	formData1             = 0x0b
+	formRef4              = 0x13
+	formSecOffset         = 0x17
+	formStrx1             = 0x25
+)
+
+type function struct {
+	name    string
+	params  []string
+	results []string
+}
+
+var functions = []function{
+	{"main.Greet", []string{"name", "times"}, []string{"~r0"}},
+	{"main.(*Server).Handle", []string{"s", "path", "code"}, []string{"~r0"}},
+}
+
+func main() {
+	dir := filepath.Join("testdata", "fixtures")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	sections := []section{
+		{"abbrev", debugAbbrev()},
+		{"info", debugInfo()},
+	}
+	fixtures := map[string][]byte{
+		"greet.elf":   buildELF(sections),
+		"greet.exe":   buildPE(sections),
+		"greet.macho": buildMachO(sections, cpuAmd64),
+		"greet.fat":   buildFat(buildMachO(sections, cpuAmd64), buildMachO(sections, cpuArm64)),
+	}
+	sections5 := dwarf5Sections()
+	fixtures["greet5.elf"] = buildELF(sections5)
+	fixtures["greet5.exe"] = buildPE(sections5)
+	fixtures["greet5.macho"] = buildMachO(sections5, cpuArm64)
+
+	for name, data := range fixtures {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// section is a DWARF section, named without its format-specific prefix.
+type section struct {
+	name string
+	data []byte
+}
+
+func debugAbbrev() []byte {
+	return []byte{
+		1, tagCompileUnit, 1, attrName, formString, 0, 0,
+		2, tagSubprogram, 1, attrName, formString, 0, 0,
+		3, tagFormalParameter, 0, attrName, formString, attrVariableParameter, formFlag, 0, 0,
+		0,
+	}
+}
+
+// debugInfo encodes a single DWARF 4 compilation unit for package main.
+func debugInfo() []byte {
+	var dies bytes.Buffer
+	cstring := func(s string) { dies.WriteString(s); dies.WriteByte(0) }
+
+	dies.WriteByte(1)
+	cstring("main")
+	for _, fn := range functions {
+		dies.WriteByte(2)
+		cstring(fn.name)
+		for _, param := range fn.params {
+			dies.WriteByte(3)
+			cstring(param)
+			dies.WriteByte(0)
+		}
+		for _, result := range fn.results {
+			dies.WriteByte(3)
+			cstring(result)
+			dies.WriteByte(1)
+		}
+		dies.WriteByte(0)
+	}
+	dies.WriteByte(0)
+
+	var unit bytes.Buffer
+	le := binary.LittleEndian
+	unit.Write(le.AppendUint32(nil, uint32(2+4+1+dies.Len()))) // unit_length
+	unit.Write(le.AppendUint16(nil, 4))                        // version
+	unit.Write(le.AppendUint32(nil, 0))                        // debug_abbrev_offset
+	unit.WriteByte(8)                                          // address_size
+	unit.Write(dies.Bytes())
+	return unit.Bytes()
+}
+
+// dwarf5Sections encodes a DWARF 5 compilation unit for package main whose
+// names are indices into .debug_str_offsets, as newer toolchains emit them.
+// Besides parameters, Greet has an inlined call with parameters of its own
+// and a lexical block; the name of the code parameter of Handle and of a
+// third function use a non-string form, which readers must tolerate.
+func dwarf5Sections() []section {
+	var str, offsets bytes.Buffer
+	le := binary.LittleEndian
+	strx := func(s string) byte {
+		index := byte(offsets.Len() / 4)
+		offsets.Write(le.AppendUint32(nil, uint32(str.Len())))
+		str.WriteString(s + "\x00")
+		return index
+	}
+
+	abbrev := []byte{
+		1, tagCompileUnit, 1, attrName, formStrx1, attrStrOffsetsBase, formSecOffset, 0, 0,
+		2, tagSubprogram, 1, attrName, formStrx1, 0, 0,
+		3, tagFormalParameter, 0, attrName, formStrx1, attrVariableParameter, formFlag, 0, 0,
+		4, tagFormalParameter, 0, attrName, formData1, attrVariableParameter, formFlag, 0, 0,
+		5, tagInlinedSubroutine, 1, attrAbstractOrigin, formRef4, 0, 0,
+		6, tagLexicalBlock, 1, 0, 0,
+		7, tagVariable, 0, attrName, formStrx1, 0, 0,
+		8, tagSubprogram, 1, attrName, formData1, 0, 0,
+		0,
+	}
+
+	dies := []byte{1, strx("main"), 8, 0, 0, 0}
+	dies = append(dies,
+		2, strx("main.Greet"),
+		3, strx("name"), 0,
+		3, strx("times"), 0,
+		3, strx("~r0"), 1,
+		5, 0, 0, 0, 0, // inlined call
+		3, strx("s"), 0,
+		0,
+		6, // lexical block
+		7, strx("i"),
+		0,
+		0,
+	)
+	dies = append(dies,
+		2, strx("main.(*Server).Handle"),
+		3, strx("s"), 0,
+		3, strx("path"), 0,
+		4, 7, 0, // code
+		3, strx("~r0"), 1,
+		0,
+	)
+	dies = append(dies,
+		8, 5,
+		3, strx("hidden"), 0,
+		0,
+		0,
+	)
+
+	var info bytes.Buffer
+	info.Write(le.AppendUint32(nil, uint32(2+1+1+4+len(dies)))) // unit_length
+	info.Write(le.AppendUint16(nil, 5))                         // version
+	info.WriteByte(1)                                           // DW_UT_compile
+	info.WriteByte(8)                                           // address_size
+	info.Write(le.AppendUint32(nil, 0))                         // debug_abbrev_offset
+	info.Write(dies)
+
+	var strOffsets bytes.Buffer
+	strOffsets.Write(le.AppendUint32(nil, uint32(4+offsets.Len()))) // unit_length
+	strOffsets.Write(le.AppendUint16(nil, 5))                       // version
+	strOffsets.Write(le.AppendUint16(nil, 0))                       // padding
+	strOffsets.Write(offsets.Bytes())
+
+	return []section{
+		{"abbrev", abbrev},
+		{"info", info.Bytes()},
+		{"str", str.Bytes()},
+		{"str_offsets", strOffsets.Bytes()},
+	}
+}
+
+// buildELF writes a 64-bit little-endian ELF executable with the DWARF
+// sections and a section name table.
+func buildELF(sections []section) []byte {
+	const (
+		headerSize  = 64
+		sectionSize = 64
+		shtProgbits = 1
+		shtStrtab   = 3
+	)
+
+	var names bytes.Buffer
+	names.WriteByte(0)
+	nameOffsets := make([]uint32, len(sections)+1)
+	for i, s := range sections {
+		nameOffsets[i] = uint32(names.Len())
+		names.WriteString(".debug_" + s.name + "\x00")
+	}
+	nameOffsets[len(sections)] = uint32(names.Len())
+	names.WriteString(".shstrtab\x00")
+
+	var body bytes.Buffer
+	offsets := make([]uint64, len(sections)+1)
+	for i, s := range sections {
+		offsets[i] = uint64(headerSize + body.Len())
+		body.Write(s.data)
+	}
+	offsets[len(sections)] = uint64(headerSize + body.Len())
+	body.Write(names.Bytes())
+	for body.Len()%8 != 0 {
+		body.WriteByte(0)
+	}
+	shoff := uint64(headerSize + body.Len())
+	shnum := len(sections) + 2 // null section first
+
+	le := binary.LittleEndian
+	var out bytes.Buffer
+	out.Write([]byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0}) // ELFCLASS64, ELFDATA2LSB, EV_CURRENT
+	out.Write(make([]byte, 8))
+	out.Write(le.AppendUint16(nil, 2))  // ET_EXEC
+	out.Write(le.AppendUint16(nil, 62)) // EM_X86_64
+	out.Write(le.AppendUint32(nil, 1))  // EV_CURRENT
+	out.Write(le.AppendUint64(nil, 0))  // entry
+	out.Write(le.AppendUint64(nil, 0))  // phoff
+	out.Write(le.AppendUint64(nil, shoff))
+	out.Write(le.AppendUint32(nil, 0)) // flags
+	out.Write(le.AppendUint16(nil, headerSize))
+	out.Write(le.AppendUint16(nil, 0)) // phentsize
+	out.Write(le.AppendUint16(nil, 0)) // phnum
+	out.Write(le.AppendUint16(nil, sectionSize))
+	out.Write(le.AppendUint16(nil, uint16(shnum)))
+	out.Write(le.AppendUint16(nil, uint16(shnum-1))) // shstrndx
+	out.Write(body.Bytes())
+
+	writeSection := func(name, typ uint32, offset, size uint64) {
+		out.Write(le.AppendUint32(nil, name))
+		out.Write(le.AppendUint32(nil, typ))
+		out.Write(le.AppendUint64(nil, 0)) // flags
+		out.Write(le.AppendUint64(nil, 0)) // addr
+		out.Write(le.AppendUint64(nil, offset))
+		out.Write(le.AppendUint64(nil, size))
+		out.Write(le.AppendUint32(nil, 0)) // link
+		out.Write(le.AppendUint32(nil, 0)) // info
+		out.Write(le.AppendUint64(nil, 1)) // addralign
+		out.Write(le.AppendUint64(nil, 0)) // entsize
+	}
+	writeSection(0, 0, 0, 0)
+	for i, s := range sections {
+		writeSection(nameOffsets[i], shtProgbits, offsets[i], uint64(len(s.data)))
+	}
+	writeSection(nameOffsets[len(sections)], shtStrtab, offsets[len(sections)], uint64(names.Len()))
+	return out.Bytes()
+}
+
+// buildPE writes a PE32+ executable with the DWARF sections. Their names
+// exceed the 8 bytes of section headers, so they are stored in the COFF
+// string table, as the Go linker does.
+func buildPE(sections []section) []byte {
+	const (
+		dosHeaderSize  = 64
+		fileHeaderSize = 20
+		sectionSize    = 40
+	)
+	le := binary.LittleEndian
+
+	var strtab bytes.Buffer
+	nameOffsets := make([]int, len(sections))
+	for i, s := range sections {
+		nameOffsets[i] = 4 + strtab.Len() // offsets count the size field
+		strtab.WriteString(".debug_" + s.name + "\x00")
+	}
+
+	headersEnd := dosHeaderSize + 4 + fileHeaderSize + len(sections)*sectionSize
+	offsets := make([]int, len(sections))
+	dataEnd := headersEnd
+	for i, s := range sections {
+		offsets[i] = dataEnd
+		dataEnd += len(s.data)
+	}
+
+	var out bytes.Buffer
+	dos := make([]byte, dosHeaderSize)
+	copy(dos, "MZ")
+	le.PutUint32(dos[0x3c:], dosHeaderSize) // e_lfanew
+	out.Write(dos)
+	out.WriteString("PE\x00\x00")
+	out.Write(le.AppendUint16(nil, 0x8664)) // IMAGE_FILE_MACHINE_AMD64
+	out.Write(le.AppendUint16(nil, uint16(len(sections))))
+	out.Write(le.AppendUint32(nil, 0))               // timestamp
+	out.Write(le.AppendUint32(nil, uint32(dataEnd))) // symbol table, empty: only strings follow
+	out.Write(le.AppendUint32(nil, 0))               // number of symbols
+	out.Write(le.AppendUint16(nil, 0))               // optional header size
+	out.Write(le.AppendUint16(nil, 0x0002))          // IMAGE_FILE_EXECUTABLE_IMAGE
+
+	for i, s := range sections {
+		name := make([]byte, 8)
+		copy(name, "/"+strconv.Itoa(nameOffsets[i]))
+		out.Write(name)
+		out.Write(le.AppendUint32(nil, uint32(len(s.data)))) // virtual size
+		out.Write(le.AppendUint32(nil, 0))                   // virtual address
+		out.Write(le.AppendUint32(nil, uint32(len(s.data)))) // raw size
+		out.Write(le.AppendUint32(nil, uint32(offsets[i])))
+		out.Write(make([]byte, 12))                 // relocations, line numbers and their counts
+		out.Write(le.AppendUint32(nil, 0x42000040)) // initialized data, discardable, readable
+	}
+	for _, s := range sections {
+		out.Write(s.data)
+	}
+	out.Write(le.AppendUint32(nil, uint32(4+strtab.Len())))
+	out.Write(strtab.Bytes())
+	return out.Bytes()
+}
+
+// Mach-O CPU types, as in debug/macho
+const (
+	cpuAmd64 = 0x01000007
+	cpuArm64 = 0x0100000c
+)
+
+// buildMachO writes a 64-bit Mach-O executable with the DWARF sections in a
+// __DWARF segment.
+func buildMachO(sections []section, cpu uint32) []byte {
+	const (
+		headerSize  = 32
+		segmentSize = 72
+		sectionSize = 80
+		lcSegment64 = 0x19
+	)
+	le := binary.LittleEndian
+	name16 := func(s string) []byte {
+		b := make([]byte, 16)
+		copy(b, s)
+		return b
+	}
+
+	cmdSize := segmentSize + len(sections)*sectionSize
+	offset := headerSize + cmdSize
+	var data bytes.Buffer
+	for _, s := range sections {
+		data.Write(s.data)
+	}
+
+	var out bytes.Buffer
+	out.Write(le.AppendUint32(nil, 0xfeedfacf)) // MH_MAGIC_64
+	out.Write(le.AppendUint32(nil, cpu))
+	out.Write(le.AppendUint32(nil, 3)) // CPU_SUBTYPE_ALL
+	out.Write(le.AppendUint32(nil, 2)) // MH_EXECUTE
+	out.Write(le.AppendUint32(nil, 1)) // number of load commands
+	out.Write(le.AppendUint32(nil, uint32(cmdSize)))
+	out.Write(le.AppendUint32(nil, 0)) // flags
+	out.Write(le.AppendUint32(nil, 0)) // reserved
+
+	out.Write(le.AppendUint32(nil, lcSegment64))
+	out.Write(le.AppendUint32(nil, uint32(cmdSize)))
+	out.Write(name16("__DWARF"))
+	out.Write(le.AppendUint64(nil, 0)) // vmaddr
+	out.Write(le.AppendUint64(nil, 0)) // vmsize
+	out.Write(le.AppendUint64(nil, uint64(offset)))
+	out.Write(le.AppendUint64(nil, uint64(data.Len())))
+	out.Write(le.AppendUint32(nil, 0)) // maxprot
+	out.Write(le.AppendUint32(nil, 0)) // initprot
+	out.Write(le.AppendUint32(nil, uint32(len(sections))))
+	out.Write(le.AppendUint32(nil, 0)) // flags
+
+	for _, s := range sections {
+		out.Write(name16("__debug_" + s.name))
+		out.Write(name16("__DWARF"))
+		out.Write(le.AppendUint64(nil, 0)) // addr
+		out.Write(le.AppendUint64(nil, uint64(len(s.data))))
+		out.Write(le.AppendUint32(nil, uint32(offset)))
+		out.Write(make([]byte, 28)) // align, relocations, flags and reserved fields
+		offset += len(s.data)
+	}
+	out.Write(data.Bytes())
+	return out.Bytes()
+}
+
+// buildFat writes a universal Mach-O file holding the given executables.
+func buildFat(files ...[]byte) []byte {
+	const align = 4 // 2^4-byte aligned slices
+	be := binary.BigEndian
+
+	var out bytes.Buffer
+	out.Write(be.AppendUint32(nil, 0xcafebabe))
+	out.Write(be.AppendUint32(nil, uint32(len(files))))
+
+	offset := 8 + 20*len(files)
+	offsets := make([]int, len(files))
+	for i, f := range files {
+		offset = (offset + 1<<align - 1) &^ (1<<align - 1)
+		offsets[i] = offset
+		out.Write(be.AppendUint32(nil, binary.LittleEndian.Uint32(f[4:]))) // cputype
+		out.Write(be.AppendUint32(nil, binary.LittleEndian.Uint32(f[8:]))) // cpusubtype
+		out.Write(be.AppendUint32(nil, uint32(offset)))
+		out.Write(be.AppendUint32(nil, uint32(len(f))))
+		out.Write(be.AppendUint32(nil, align))
+		offset += len(f)
+	}
+	for i, f := range files {
+		for out.Len() < offsets[i] {
+			out.WriteByte(0)
+		}
+		out.Write(f)
+	}
+	return out.Bytes()
+}
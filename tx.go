@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// transactor begins, commits and rolls back transactions of one type.
+type transactor struct {
+	begin    func(ctx context.Context) (reflect.Value, error)
+	commit   func(tx reflect.Value) error
+	rollback func(tx reflect.Value) error
+}
+
+// Global transactor registry used by CallInTx, pre-populated with *sql.Tx
+var (
+	transactorsMu sync.RWMutex
+	transactors   = make(map[reflect.Type]transactor)
+)
+
+func init() {
+	RegisterTransactor(beginSQLTx, (*sql.Tx).Commit, (*sql.Tx).Rollback)
+}
+
+// beginSQLTx begins a transaction on the *sql.DB injected for ctx.
+func beginSQLTx(ctx context.Context) (*sql.Tx, error) {
+	v, found, err := injectValue(ctx, reflect.TypeFor[*sql.DB]())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("no *sql.DB injector registered (see RegisterInjector and WithScoped)")
+	}
+	return v.Interface().(*sql.DB).BeginTx(ctx, nil)
+}
+
+// RegisterTransactor registers how CallInTx manages transactions of type T,
+// typically a driver-specific transaction type or interface such as pgx.Tx.
+// *sql.Tx is built in and begins transactions on the injected *sql.DB.
+// Registering a type again replaces its transactor.
+//
+// Example:
+//
+//	dwarfreflect.RegisterTransactor(
+//	    func(ctx context.Context) (pgx.Tx, error) { return pool.Begin(ctx) },
+//	    func(tx pgx.Tx) error { return tx.Commit(context.Background()) },
+//	    func(tx pgx.Tx) error { return tx.Rollback(context.Background()) },
+//	)
+func RegisterTransactor[T any](begin func(ctx context.Context) (T, error), commit, rollback func(tx T) error) {
+	transactorsMu.Lock()
+	defer transactorsMu.Unlock()
+
+	transactors[reflect.TypeFor[T]()] = transactor{
+		begin: func(ctx context.Context) (reflect.Value, error) {
+			tx, err := begin(ctx)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&tx).Elem(), nil
+		},
+		commit:   func(tx reflect.Value) error { return commit(tx.Interface().(T)) },
+		rollback: func(tx reflect.Value) error { return rollback(tx.Interface().(T)) },
+	}
+}
+
+// CallInTx is like CallInjected but runs the call inside transactions: each
+// parameter missing from the map whose type has a transactor (see
+// RegisterTransactor) receives a newly begun transaction, one per type:
+// parameters of the same type share its transaction. Transactions are
+// committed when the function returns a nil trailing error, and rolled back
+// when it returns an error or panics (the panic is then propagated).
+// A commit error is returned as the call error.
+//
+// Example:
+//
+//	dwarfreflect.RegisterInjector(func(ctx context.Context) (*sql.DB, error) { return db, nil })
+//
+//	func Transfer(tx *sql.Tx, from, to int, amount int64) error
+//	results, err := fn.CallInTx(ctx, map[string]any{"from": 1, "to": 2, "amount": 100})
+func (t *Function) CallInTx(ctx context.Context, argMap map[string]any, opts ...CallOptions) (results []reflect.Value, err error) {
+	type openTx struct {
+		transactor
+		typ reflect.Type
+		tx  reflect.Value
+	}
+	var open []openTx
+
+	rollbackAll := func() {
+		for _, o := range open {
+			_ = o.rollback(o.tx)
+		}
+	}
+
+	for i, name := range t.paramNames {
+		if _, exists := argMap[name]; exists {
+			continue
+		}
+		if slices.ContainsFunc(open, func(o openTx) bool { return o.typ == t.paramTypes[i] }) {
+			continue // injected from the scope as well
+		}
+
+		transactorsMu.RLock()
+		tr, ok := transactors[t.paramTypes[i]]
+		transactorsMu.RUnlock()
+		if !ok {
+			continue
+		}
+
+		tx, beginErr := tr.begin(ctx)
+		if beginErr != nil {
+			rollbackAll()
+			return nil, fmt.Errorf("parameter %q: beginning %v: %w", name, t.paramTypes[i], beginErr)
+		}
+		open = append(open, openTx{tr, t.paramTypes[i], tx})
+		ctx = context.WithValue(ctx, scopeKey{t.paramTypes[i]}, tx.Interface())
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			rollbackAll()
+			panic(r)
+		}
+	}()
+
+	results, err = t.CallInjected(ctx, argMap, opts...)
+	if err != nil || trailingError(results) != nil {
+		rollbackAll()
+		return results, err
+	}
+
+	for i, o := range open {
+		if commitErr := o.commit(o.tx); commitErr != nil {
+			for _, rest := range open[i+1:] {
+				_ = rest.rollback(rest.tx)
+			}
+			return results, fmt.Errorf("committing %v: %w", o.tx.Type(), commitErr)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakeDriver records transaction outcomes without a real database.
+type fakeDriver struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+type fakeConn struct{ d *fakeDriver }
+
+type fakeTx struct{ d *fakeDriver }
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return &fakeTx{c.d}, nil }
+
+func (tx *fakeTx) Commit() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	tx.d.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	tx.d.rollbacks++
+	return nil
+}
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("dwarfreflect-fake", testDriver)
+}
+
+func testFuncTransfer(tx *sql.Tx, amount int) error {
+	if tx == nil {
+		return errors.New("no transaction")
+	}
+	if amount < 0 {
+		return errors.New("negative amount")
+	}
+	if amount == 0 {
+		panic("zero amount")
+	}
+	return nil
+}
+
+func TestCallInTx(t *testing.T) {
+	fn := mustNewFunction(t, testFuncTransfer)
+
+	db, err := sql.Open("dwarfreflect-fake", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+	ctx := WithScoped(context.Background(), db)
+
+	outcome := func() (int, int) {
+		testDriver.mu.Lock()
+		defer testDriver.mu.Unlock()
+		return testDriver.commits, testDriver.rollbacks
+	}
+	commits, rollbacks := outcome()
+
+	results, err := fn.CallInTx(ctx, map[string]any{"amount": 10})
+	if err != nil || !results[0].IsNil() {
+		t.Fatalf("unexpected error: %v, %v", err, results)
+	}
+	if c, r := outcome(); c != commits+1 || r != rollbacks {
+		t.Errorf("expected a commit, got %d commits and %d rollbacks", c-commits, r-rollbacks)
+	}
+
+	results, _ = fn.CallInTx(ctx, map[string]any{"amount": -1})
+	if results[0].IsNil() {
+		t.Error("expected function error")
+	}
+	if c, r := outcome(); c != commits+1 || r != rollbacks+1 {
+		t.Errorf("expected a rollback on error, got %d commits and %d rollbacks", c-commits, r-rollbacks)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic to propagate")
+			}
+		}()
+		fn.CallInTx(ctx, map[string]any{"amount": 0})
+	}()
+	if c, r := outcome(); c != commits+1 || r != rollbacks+2 {
+		t.Errorf("expected a rollback on panic, got %d commits and %d rollbacks", c-commits, r-rollbacks)
+	}
+}
+
+func TestCallInTx_NoDB(t *testing.T) {
+	fn := mustNewFunction(t, testFuncTransfer)
+	if _, err := fn.CallInTx(context.Background(), map[string]any{"amount": 1}); err == nil {
+		t.Error("expected error without a *sql.DB injector")
+	}
+}
+
+type testUnitOfWork struct {
+	committed, rolledBack bool
+}
+
+func testFuncUnitOfWork(uow *testUnitOfWork, fail bool) error {
+	if fail {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func testFuncTwoUnitsOfWork(orders, payments *testUnitOfWork) bool {
+	return orders == payments
+}
+
+func TestRegisterTransactor(t *testing.T) {
+	var last *testUnitOfWork
+	begins := 0
+	RegisterTransactor(
+		func(ctx context.Context) (*testUnitOfWork, error) {
+			begins++
+			last = &testUnitOfWork{}
+			return last, nil
+		},
+		func(uow *testUnitOfWork) error { uow.committed = true; return nil },
+		func(uow *testUnitOfWork) error { uow.rolledBack = true; return nil },
+	)
+
+	fn := mustNewFunction(t, testFuncUnitOfWork)
+	if _, err := fn.CallInTx(context.Background(), map[string]any{"fail": false}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !last.committed || last.rolledBack {
+		t.Errorf("expected commit, got %+v", last)
+	}
+
+	fn.CallInTx(context.Background(), map[string]any{"fail": true})
+	if last.committed || !last.rolledBack {
+		t.Errorf("expected rollback, got %+v", last)
+	}
+
+	// Parameters of the same type share one transaction
+	begins = 0
+	results, err := mustNewFunction(t, testFuncTwoUnitsOfWork).CallInTx(context.Background(), map[string]any{})
+	if err != nil || !results[0].Bool() {
+		t.Errorf("expected both parameters to get the same transaction, got %v, %v", results, err)
+	}
+	if begins != 1 || !last.committed {
+		t.Errorf("expected one transaction begun and committed, got %d begun, %+v", begins, last)
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TypeScriptOptions customizes the TypeScript emitted by EmitTypeScript and
+// Registry.EmitTypeScriptClient.
+type TypeScriptOptions struct {
+	// TypeName is the prefix of the emitted interfaces, e.g. CreateUser for
+	// CreateUserParams and CreateUserResults. Defaults to the capitalized base
+	// function name, or to the registered name for registry clients.
+	TypeName string
+
+	// ClientName names the client class of EmitTypeScriptClient.
+	// Default: "Client".
+	ClientName string
+}
+
+// EmitTypeScript writes TypeScript interfaces describing the JSON of the
+// function's named arguments and results: <TypeName>Params, keyed by
+// parameter name as accepted by Router.Dispatch and CallWithJSON, and
+// <TypeName>Results, following the json tags of ResultsToStruct. context.Context
+// and unsafe parameters are left out, optional parameters (see WithRequired)
+// and parameters with a default are optional properties, and pointers are
+// nullable. Named struct types become interfaces of their own.
+//
+// Example:
+//
+//	func CreateUser(ctx context.Context, userName string, age *int) (id int, err error)
+//	fn.EmitTypeScript(os.Stdout, dwarfreflect.TypeScriptOptions{})
+//	// export interface CreateUserParams {
+//	//   userName: string;
+//	//   age?: number | null;
+//	// }
+//	//
+//	// export interface CreateUserResults {
+//	//   id: number;
+//	// }
+func (t *Function) EmitTypeScript(w io.Writer, opts TypeScriptOptions) error {
+	typeName := opts.TypeName
+	if typeName == "" {
+		typeName = capitalizeFirst(t.GetBaseFunctionName())
+	}
+
+	gen := newTSGenerator()
+	var out strings.Builder
+	gen.writeFunction(&out, t, tsIdentifier(typeName, true))
+	gen.writeNamedTypes(&out)
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// EmitTypeScriptClient writes a TypeScript module with the Params and Results
+// interfaces of every registered function, named after the registered names,
+// and a client class with one typed method per function. The client is
+// transport-agnostic: it hands the method name and the named arguments to a
+// Transport. The module exports httpTransport, which POSTs the arguments to
+// baseURL + "/" + method for a Router behind an HTTP handler, and
+// jsonRPCTransport, which sends JSON-RPC 1.0 envelopes as read by RPCServer
+// with a net/rpc/jsonrpc codec (net/rpc method names cannot contain dots).
+//
+// Example:
+//
+//	reg.Register("users.Create", CreateUser)
+//	f, _ := os.Create("web/src/api.ts")
+//	err := reg.EmitTypeScriptClient(f, dwarfreflect.TypeScriptOptions{ClientName: "API"})
+//
+//	// In TypeScript:
+//	// const api = new API(httpTransport("/rpc"));
+//	// const { id } = await api.usersCreate({ userName: "ada" });
+func (r *Registry) EmitTypeScriptClient(w io.Writer, opts TypeScriptOptions) error {
+	clientName := opts.ClientName
+	if clientName == "" {
+		clientName = "Client"
+	}
+
+	gen := newTSGenerator()
+	var out strings.Builder
+	out.WriteString("// Code generated by dwarfreflect. DO NOT EDIT.\n\n")
+	out.WriteString(tsTransports)
+
+	names := r.Names()
+	for _, name := range names {
+		fn, _ := r.Get(name)
+		out.WriteString("\n")
+		gen.writeFunction(&out, fn, tsIdentifier(name, true))
+	}
+	gen.writeNamedTypes(&out)
+
+	fmt.Fprintf(&out, "\nexport class %s {\n", tsIdentifier(clientName, true))
+	out.WriteString("  private readonly transport: Transport;\n\n")
+	out.WriteString("  constructor(transport: Transport) {\n    this.transport = transport;\n  }\n")
+	for _, name := range names {
+		typeName := tsIdentifier(name, true)
+		fn, _ := r.Get(name)
+		fmt.Fprintf(&out, "\n%s  %s(params: %sParams): Promise<%sResults> {\n", tsDeprecated(fn, "  "), tsIdentifier(name, false), typeName, typeName)
+		fmt.Fprintf(&out, "    return this.transport(%s, params) as Promise<%sResults>;\n", strconv.Quote(name), typeName)
+		out.WriteString("  }\n")
+	}
+	out.WriteString("}\n")
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// tsTransports is the transport part of generated clients.
+const tsTransports = `export type Transport = (method: string, params: unknown) => Promise<unknown>;
+
+export function httpTransport(baseURL: string, init: RequestInit = {}): Transport {
+  return async (method, params) => {
+    const response = await fetch(baseURL + "/" + method, {
+      ...init,
+      method: "POST",
+      headers: { "Content-Type": "application/json", ...init.headers },
+      body: JSON.stringify(params),
+    });
+    if (!response.ok) {
+      throw new Error(method + ": " + response.status + " " + (await response.text()));
+    }
+    return response.json();
+  };
+}
+
+export function jsonRPCTransport(url: string, service: string, init: RequestInit = {}): Transport {
+  let id = 0;
+  return async (method, params) => {
+    const response = await fetch(url, {
+      ...init,
+      method: "POST",
+      headers: { "Content-Type": "application/json", ...init.headers },
+      body: JSON.stringify({ method: service + "." + method, params: [params], id: id++ }),
+    });
+    const reply = await response.json();
+    if (reply.error) {
+      throw new Error(method + ": " + reply.error);
+    }
+    return reply.result;
+  };
+}
+`
+
+// tsGenerator maps Go types to TypeScript, collecting the interfaces of
+// named struct types so recursive types refer to themselves by name.
+type tsGenerator struct {
+	names map[reflect.Type]string // interface names of named structs
+	taken map[string]bool
+	queue []reflect.Type // named structs whose interface is not written yet
+}
+
+func newTSGenerator() *tsGenerator {
+	return &tsGenerator{names: make(map[reflect.Type]string), taken: make(map[string]bool)}
+}
+
+// writeFunction writes the Params and Results interfaces of fn.
+func (g *tsGenerator) writeFunction(out *strings.Builder, fn *Function, typeName string) {
+	names, types := fn.GetNonContextParameters()
+	deprecated := tsDeprecated(fn, "")
+	fmt.Fprintf(out, "%sexport interface %sParams {\n", deprecated, typeName)
+	for i, name := range names {
+		if isUnsafeType(types[i]) {
+			continue
+		}
+		optional := ""
+		if fn.paramMeta[name].Default != nil || fn.isOptional(name, types[i]) {
+			optional = "?"
+		}
+		fmt.Fprintf(out, "  %s%s: %s;\n", tsPropertyName(name), optional, g.typeFor(types[i]))
+	}
+	out.WriteString("}\n\n")
+
+	fmt.Fprintf(out, "%sexport interface %sResults {\n", deprecated, typeName)
+	g.writeFields(out, fn.resultType, "  ")
+	out.WriteString("}\n")
+}
+
+// tsDeprecated returns the @deprecated doc comment of deprecated functions,
+// indented by indent, and "" for others.
+func tsDeprecated(fn *Function, indent string) string {
+	message, ok := fn.Deprecation()
+	if !ok {
+		return ""
+	}
+	return indent + "/** @deprecated " + strings.ReplaceAll(message, "*/", "*\\/") + " */\n"
+}
+
+// writeNamedTypes writes the interfaces of the named structs met so far,
+// including those they refer to.
+func (g *tsGenerator) writeNamedTypes(out *strings.Builder) {
+	for len(g.queue) > 0 {
+		typ := g.queue[0]
+		g.queue = g.queue[1:]
+		fmt.Fprintf(out, "\nexport interface %s {\n", g.names[typ])
+		g.writeFields(out, typ, "  ")
+		out.WriteString("}\n")
+	}
+}
+
+// typeFor returns the TypeScript type of values of typ as encoded by
+// encoding/json.
+func (g *tsGenerator) typeFor(typ reflect.Type) string {
+	if typ.Kind() == reflect.Pointer {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if ts := g.typeFor(typ); ts != "unknown" {
+			return ts + " | null"
+		}
+		return "unknown"
+	}
+
+	switch {
+	case typ == timeType:
+		return "string"
+	case typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonUnmarshalerType):
+		return "unknown"
+	case typ.Implements(textMarshalerType):
+		return "string"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return "string" // base64
+		}
+		elem := g.typeFor(typ.Elem())
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return "Record<string, " + g.typeFor(typ.Elem()) + ">"
+	case reflect.Struct:
+		if typ.Name() == "" {
+			var fields strings.Builder
+			fields.WriteString("{ ")
+			g.writeInlineFields(&fields, typ)
+			fields.WriteString("}")
+			return fields.String()
+		}
+		return g.interfaceName(typ)
+	default:
+		return "unknown" // interfaces and anything else
+	}
+}
+
+// interfaceName returns the interface name of a named struct, queueing its
+// declaration the first time.
+func (g *tsGenerator) interfaceName(typ reflect.Type) string {
+	if name, ok := g.names[typ]; ok {
+		return name
+	}
+	base := tsIdentifier(typ.Name(), true)
+	name := base
+	for i := 2; g.taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.taken[name] = true
+	g.names[typ] = name
+	g.queue = append(g.queue, typ)
+	return name
+}
+
+// writeFields writes one property per line for the JSON fields of a struct.
+func (g *tsGenerator) writeFields(out *strings.Builder, typ reflect.Type, indent string) {
+	for _, field := range jsonFields(typ) {
+		fmt.Fprintf(out, "%s%s%s: %s;\n", indent, tsPropertyName(field.name), field.optional, g.typeFor(field.typ))
+	}
+}
+
+// writeInlineFields writes the JSON fields of an anonymous struct inline.
+func (g *tsGenerator) writeInlineFields(out *strings.Builder, typ reflect.Type) {
+	for _, field := range jsonFields(typ) {
+		fmt.Fprintf(out, "%s%s: %s; ", tsPropertyName(field.name), field.optional, g.typeFor(field.typ))
+	}
+}
+
+// jsonField is a field of a struct as encoded by encoding/json.
+type jsonField struct {
+	name     string
+	optional string // "?" for omitempty fields
+	typ      reflect.Type
+}
+
+// jsonFields lists the fields encoding/json encodes for a struct, flattening
+// untagged embedded structs.
+func jsonFields(typ reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if fieldType != typ {
+				fields = append(fields, jsonFields(fieldType)...)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		optional := ""
+		if strings.Contains(options, "omitempty") || strings.Contains(options, "omitzero") {
+			optional = "?"
+		}
+		fields = append(fields, jsonField{name: name, optional: optional, typ: field.Type})
+	}
+	return fields
+}
+
+// tsIdentifier turns a name such as "users.create" into a TypeScript
+// identifier: UsersCreate when exported is set, usersCreate otherwise.
+func tsIdentifier(name string, exported bool) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 || exported {
+			part = capitalizeFirst(part)
+		} else {
+			r := []rune(part)
+			r[0] = unicode.ToLower(r[0])
+			part = string(r)
+		}
+		b.WriteString(part)
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		id = "_" + id
+	}
+	return id
+}
+
+// tsPropertyName quotes property names that are not identifiers.
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
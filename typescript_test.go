@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tsAddress struct {
+	City string `json:"city"`
+}
+
+type tsUser struct {
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Address  *tsAddress        `json:"address"`
+	Friends  []*tsUser         `json:"friends"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created"`
+	internal int
+}
+
+func tsCreateUser(ctx context.Context, user tsUser, notify *bool, tags ...string) (id int64, err error) {
+	return 1, nil
+}
+
+func TestEmitTypeScript(t *testing.T) {
+	fn := mustNewFunction(t, tsCreateUser)
+
+	var out strings.Builder
+	if err := fn.EmitTypeScript(&out, TypeScriptOptions{TypeName: "CreateUser"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `export interface CreateUserParams {
+  user: TsUser;
+  notify?: boolean | null;
+  tags?: string[];
+}
+
+export interface CreateUserResults {
+  id: number;
+}
+
+export interface TsUser {
+  name: string;
+  email?: string;
+  address: TsAddress | null;
+  friends: (TsUser | null)[];
+  labels: Record<string, string>;
+  created: string;
+}
+
+export interface TsAddress {
+  city: string;
+}
+`
+	if out.String() != want {
+		t.Errorf("unexpected TypeScript:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestEmitTypeScriptClient(t *testing.T) {
+	reg := NewRegistry()
+	mustRegister(t, reg, "users.create", tsCreateUser)
+	mustRegister(t, reg, "greet", testFunc1)
+
+	var out strings.Builder
+	if err := reg.EmitTypeScriptClient(&out, TypeScriptOptions{ClientName: "api"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ts := out.String()
+	for _, want := range []string{
+		"export type Transport = ",
+		"export function httpTransport(",
+		"export function jsonRPCTransport(",
+		"export interface UsersCreateParams {",
+		"export interface GreetResults {\n  r0: string;\n}",
+		"export class Api {",
+		"  usersCreate(params: UsersCreateParams): Promise<UsersCreateResults> {\n" +
+			`    return this.transport("users.create", params) as Promise<UsersCreateResults>;`,
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("expected client to contain %q, got:\n%s", want, ts)
+		}
+	}
+	if strings.Count(ts, "export interface TsUser {") != 1 {
+		t.Error("expected named types to be declared once")
+	}
+}
+
+func TestTSIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		exported bool
+		want     string
+	}{
+		{"users.create", true, "UsersCreate"},
+		{"users.create", false, "usersCreate"},
+		{"Get-User", false, "getUser"},
+		{"2fa", true, "_2fa"},
+	}
+	for _, tt := range tests {
+		if got := tsIdentifier(tt.name, tt.exported); got != tt.want {
+			t.Errorf("tsIdentifier(%q, %v) = %q, want %q", tt.name, tt.exported, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build dwarfreflect_unsafe
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// LookupFunc makes the function variable fnPtr points to call the function
+// named funcName in the running binary, which may be unexported, locating its
+// code from DWARF. It is meant for test frameworks and debugging tools, and
+// is only built with the dwarfreflect_unsafe tag.
+//
+// It is UNSAFE: the signature of *fnPtr must be exactly that of the function,
+// with the receiver as first parameter for methods, or calling it corrupts
+// memory. LookupFunc only checks that the number of parameters and results
+// agrees with DWARF. funcName is the full DWARF name, e.g.
+// example.com/app/store.(*cache).evict; closures and generic functions are
+// not supported, and the function must be linked into the binary and not
+// inlined at every call site, or it has no code of its own to call.
+//
+// Example:
+//
+//	go test -tags dwarfreflect_unsafe -ldflags=-w=false ./...
+//
+//	var evict func(c *cache, key string) bool
+//	err := dwarfreflect.LookupFunc("example.com/app/store.(*cache).evict", &evict)
+//	evicted := evict(c, "stale")
+func LookupFunc(funcName string, fnPtr any) error {
+	ptrValue := reflect.ValueOf(fnPtr)
+	if ptrValue.Kind() != reflect.Pointer || ptrValue.IsNil() || ptrValue.Elem().Kind() != reflect.Func {
+		return fmt.Errorf("LookupFunc requires a non-nil pointer to a function variable, got %T", fnPtr)
+	}
+	if strings.Contains(funcName, "[") || strings.Contains(funcName, ".func") {
+		return fmt.Errorf("cannot look up function %s: closures and generic functions are not supported", funcName)
+	}
+
+	if err := loadResolver(); err != nil {
+		return err
+	}
+
+	pc, err := globalResolver.entryPC(funcName)
+	if err != nil {
+		return err
+	}
+	if runtimeFunc := runtime.FuncForPC(pc); runtimeFunc == nil || runtimeFunc.Entry() != pc || runtimeFunc.Name() != funcName {
+		return fmt.Errorf("cannot look up function %s: DWARF address %#x is not its entry point", funcName, pc)
+	}
+
+	fnType := ptrValue.Elem().Type()
+	params, found := globalResolver.functions.lookup(funcName)
+	if found && len(params) != fnType.NumIn()+fnType.NumOut() {
+		return fmt.Errorf("cannot look up function %s as %v: DWARF lists %d parameters and results %v",
+			funcName, fnType, len(params), params)
+	}
+
+	// A func value points to a closure record starting with the code address
+	code := new(uintptr)
+	*code = pc
+	*(*unsafe.Pointer)(ptrValue.UnsafePointer()) = unsafe.Pointer(code)
+	return nil
+}
+
+// NewUnexportedFunction is like NewFunction for the function named funcName,
+// which may be unexported, called through fnPtr as set by LookupFunc. It is
+// only built with the dwarfreflect_unsafe tag and is as unsafe as LookupFunc.
+//
+// Example:
+//
+//	var evict func(c *cache, key string) bool
+//	fn, err := dwarfreflect.NewUnexportedFunction("example.com/app/store.(*cache).evict", &evict)
+//	results, err := fn.CallWithMap(map[string]any{"c": c, "key": "stale"})
+func NewUnexportedFunction(funcName string, fnPtr any) (*Function, error) {
+	if err := LookupFunc(funcName, fnPtr); err != nil {
+		return nil, err
+	}
+	return NewFunction(reflect.ValueOf(fnPtr).Elem().Interface())
+}
+
+// entryPC returns the run-time entry address of the named function: its
+// DWARF low PC shifted by the load offset of the binary, which is non-zero
+// for position-independent executables.
+func (dr *DWARFResolver) entryPC(funcName string) (uintptr, error) {
+	offset, err := dr.loadOffset()
+	if err != nil {
+		return 0, err
+	}
+	lowPCs, err := dr.lowPCs(funcName)
+	if err != nil {
+		return 0, err
+	}
+	lowPC, ok := lowPCs[funcName]
+	if !ok {
+		return 0, fmt.Errorf("no DWARF entry with code for function %s in %s", funcName, dr.source())
+	}
+	return uintptr(lowPC) + offset, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build dwarfreflect_unsafe
+
+package dwarfreflect
+
+import (
+	"strings"
+	"testing"
+)
+
+type unexportedCounter struct {
+	n int
+}
+
+//go:noinline
+func (c *unexportedCounter) add(delta int) int {
+	c.n += delta
+	return c.n
+}
+
+//go:noinline
+func unexportedJoin(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+func TestLookupFunc(t *testing.T) {
+	if available, _, _ := GetDWARFStatus(); !available {
+		t.Skip("DWARF not available")
+	}
+
+	var add func(c *unexportedCounter, delta int) int
+	if err := LookupFunc("github.com/matteo-grella/dwarfreflect.(*unexportedCounter).add", &add); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := &unexportedCounter{n: 1}
+	if got := add(c, 2); got != 3 || c.n != 3 {
+		t.Errorf("unexpected result %d, counter %d", got, c.n)
+	}
+	if got := c.add(1); got != 4 { // called directly too, so it is linked in
+		t.Errorf("unexpected result %d", got)
+	}
+
+	var wrongArity func(c *unexportedCounter) int
+	if err := LookupFunc("github.com/matteo-grella/dwarfreflect.(*unexportedCounter).add", &wrongArity); err == nil {
+		t.Error("expected an error for a signature of the wrong arity")
+	}
+	if err := LookupFunc("github.com/matteo-grella/dwarfreflect.missing", &add); err == nil {
+		t.Error("expected an error for a missing function")
+	}
+	if err := LookupFunc("github.com/matteo-grella/dwarfreflect.unexportedJoin", add); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+}
+
+func TestNewUnexportedFunction(t *testing.T) {
+	if available, _, _ := GetDWARFStatus(); !available {
+		t.Skip("DWARF not available")
+	}
+
+	var join func(sep string, parts ...string) string
+	fn, err := NewUnexportedFunction("github.com/matteo-grella/dwarfreflect.unexportedJoin", &join)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names, _ := fn.GetParameterInfo(); len(names) != 2 || names[0] != "sep" || names[1] != "parts" {
+		t.Errorf("unexpected parameter names: %v", names)
+	}
+	results, err := fn.CallWithMap(map[string]any{"sep": "-", "parts": []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := results[0].String(), unexportedJoin("-", "a", "b"); got != want {
+		t.Errorf("unexpected result %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// StructVariantError reports a struct type that was not generated because the
+// function already has as many distinct struct variants as its limit allows.
+type StructVariantError struct {
+	Function string
+	Limit    int
+}
+
+func (e *StructVariantError) Error() string {
+	return fmt.Sprintf("function %s exceeds its limit of %d struct variants (precompute the variants it needs with PrecomputeStructVariants)",
+		e.Function, e.Limit)
+}
+
+// structVariants caches the struct types generated for a Function with
+// non-default StructOptions. Types created by reflect.StructOf are never
+// garbage collected, so the number of distinct variants is bounded.
+type structVariants struct {
+	mu    sync.Mutex
+	limit int
+	types map[string]reflect.Type
+}
+
+// WithStructVariantLimit returns a copy of the Function that generates at most
+// limit distinct struct types for non-default StructOptions. Types created
+// with reflect.StructOf are never garbage collected, so building options per
+// request (e.g. with request-dependent tags) leaks memory. Known variants are
+// served from a cache; beyond the limit StructVariant returns a
+// *StructVariantError and the GetStructTypeWithOptions family panics with it.
+// The cache is shared by the copies derived from the returned Function.
+//
+// Example:
+//
+//	fn = fn.WithStructVariantLimit(4)
+//	if err := fn.PrecomputeStructVariants(jsonOpts, xmlOpts); err != nil {
+//	    return err
+//	}
+//	params := fn.NewNonContextParamsPtr(jsonOpts) // served from the cache
+func (t *Function) WithStructVariantLimit(limit int) *Function {
+	clone := *t
+	clone.variants = &structVariants{limit: limit, types: make(map[string]reflect.Type)}
+	return &clone
+}
+
+// PrecomputeStructVariants generates the struct types, with and without
+// context.Context parameters, for each of opts, so they are available from
+// the cache of a Function with a variant limit. Typically called at startup
+// with every variant the program uses.
+func (t *Function) PrecomputeStructVariants(opts ...StructOptions) error {
+	for _, options := range opts {
+		if _, err := t.StructVariant(options); err != nil {
+			return err
+		}
+		if _, err := t.NonContextStructVariant(options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StructVariant returns the struct type for all function parameters generated
+// with opts, like GetStructTypeWithOptions, or a *StructVariantError if it
+// would exceed the limit set with WithStructVariantLimit, or a
+// *FieldCollisionError if parameters collide under CollisionError.
+func (t *Function) StructVariant(opts StructOptions) (reflect.Type, error) {
+	return t.structVariant(false, t.paramNames, t.paramTypes, opts)
+}
+
+// NonContextStructVariant is like StructVariant, excluding context.Context
+// parameters.
+func (t *Function) NonContextStructVariant(opts StructOptions) (reflect.Type, error) {
+	paramNames, paramTypes := t.GetNonContextParameters()
+	return t.structVariant(true, paramNames, paramTypes, opts)
+}
+
+// StructVariantCount returns the number of distinct struct variants cached by
+// a Function with a variant limit.
+func (t *Function) StructVariantCount() int {
+	if t.variants == nil {
+		return 0
+	}
+	t.variants.mu.Lock()
+	defer t.variants.mu.Unlock()
+	return len(t.variants.types)
+}
+
+// structVariant generates or looks up a struct variant. Variants are keyed by
+// their fields, since options holding functions cannot be compared.
+func (t *Function) structVariant(nonContext bool, paramNames []string, paramTypes []reflect.Type, opts StructOptions) (reflect.Type, error) {
+	fields, err := t.structFields(paramNames, paramTypes, opts)
+	if err != nil {
+		return nil, err
+	}
+	if nonContext && opts.IncludeContext && len(t.GetContextPositions()) > 0 {
+		if fields, err = t.appendContextField(paramNames, fields, opts); err != nil {
+			return nil, err
+		}
+	}
+	if t.variants == nil || isDefaultStructOptions(opts) {
+		return reflect.StructOf(groupFields(fields, len(paramNames), opts)), nil
+	}
+
+	var key strings.Builder
+	if nonContext {
+		key.WriteString("nocontext")
+	}
+	for i, paramIndex := range fieldOrder(paramNames, opts) {
+		fmt.Fprintf(&key, "\x00%d\x00%s\x00%s", paramIndex, fields[i].Name, fields[i].Tag)
+	}
+	if len(fields) > len(paramNames) {
+		fmt.Fprintf(&key, "\x00context\x00%s", fields[len(paramNames)].Name)
+	}
+	if opts.MaxFields > 0 {
+		fmt.Fprintf(&key, "\x00max\x00%d", opts.MaxFields)
+	}
+
+	v := t.variants
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if typ, ok := v.types[key.String()]; ok {
+		return typ, nil
+	}
+	if len(v.types) >= v.limit {
+		return nil, &StructVariantError{Function: t.funcName, Limit: v.limit}
+	}
+	typ := reflect.StructOf(groupFields(fields, len(paramNames), opts))
+	v.types[key.String()] = typ
+	return typ, nil
+}
+
+// groupFields groups the first paramCount of fields, those of parameters, as
+// opts.MaxFields asks. The context field stays at the top level.
+func groupFields(fields []reflect.StructField, paramCount int, opts StructOptions) []reflect.StructField {
+	if opts.MaxFields <= 0 {
+		return fields
+	}
+	return append(groupOverflow(fields[:paramCount], opts.MaxFields), fields[paramCount:]...)
+}
+
+// isDefaultStructOptions reports whether opts are the zero options, whose
+// struct types are fixed per Function and need no accounting.
+func isDefaultStructOptions(opts StructOptions) bool {
+	return opts.FieldNamer == nil && opts.TagBuilder == nil && !opts.SortFields &&
+		opts.Collisions == CollisionSuffix && opts.RenameCollision == nil && !opts.IncludeContext && opts.MaxFields == 0
+}
+
+// mustStructVariant panics with err, for getters that cannot return errors.
+func mustStructVariant(typ reflect.Type, err error) reflect.Type {
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func tagOptions(key string) StructOptions {
+	return StructOptions{
+		TagBuilder: func(paramName string, paramType reflect.Type) string {
+			return key + `:"` + paramName + `"`
+		},
+	}
+}
+
+func TestStructVariantLimit(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4).WithStructVariantLimit(2)
+
+	if err := fn.PrecomputeStructVariants(tagOptions("json")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fn.StructVariantCount() != 2 {
+		t.Errorf("expected full and non-context variants, got %d", fn.StructVariantCount())
+	}
+
+	// Equivalent options built anew are served from the cache
+	cached, err := fn.NonContextStructVariant(tagOptions("json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cached != fn.GetNonContextStructTypeWithOptions(tagOptions("json")) {
+		t.Error("expected the cached type")
+	}
+	if _, ok := cached.FieldByName("Id"); !ok || cached.NumField() != 2 {
+		t.Errorf("unexpected variant %v", cached)
+	}
+
+	// Default options are not accounted for
+	if _, err := fn.StructVariant(StructOptions{}); err != nil {
+		t.Errorf("unexpected error for default options: %v", err)
+	}
+
+	var variantErr *StructVariantError
+	if _, err := fn.StructVariant(tagOptions("xml")); !errors.As(err, &variantErr) || variantErr.Limit != 2 {
+		t.Errorf("expected *StructVariantError, got %v", err)
+	}
+	if fn.StructVariantCount() != 2 {
+		t.Errorf("refused variants must not be cached, got %d", fn.StructVariantCount())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected getters to panic beyond the limit")
+		}
+	}()
+	fn.NewNonContextParams(tagOptions("xml"))
+}
+
+func TestStructVariantLimit_Unlimited(t *testing.T) {
+	fn := mustNewFunction(t, testFunc4)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := fn.StructVariant(tagOptions(key)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if fn.StructVariantCount() != 0 {
+		t.Error("functions without a limit must not cache variants")
+	}
+
+	limited := fn.WithStructVariantLimit(1)
+	if err := limited.PrecomputeStructVariants(tagOptions("json")); err == nil {
+		t.Error("expected the non-context variant to exceed the limit")
+	}
+	if fn.StructVariantCount() != 0 {
+		t.Error("the limit must not affect the original function")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Option configures a copy of a Function made by With. Any method value of
+// the form func(*Function) *Function can be adapted into an Option, and the
+// constructors below cover the common settings.
+type Option func(*Function) *Function
+
+// With returns a copy of the Function with opts applied in order. The copy
+// shares the resolved parameter names, types and generated structs of t, so
+// the same function can be exposed with different defaults, aliases and
+// middleware on several surfaces without resolving DWARF again. t itself is
+// left unchanged.
+//
+// Example:
+//
+//	public := fn.With(
+//	    dwarfreflect.Defaults(map[string]any{"role": "user"}),
+//	    dwarfreflect.Observers(metrics),
+//	)
+//	admin := fn.With(
+//	    dwarfreflect.Aliases(map[string]string{"u": "userName"}),
+//	    dwarfreflect.Authorizers(dwarfreflect.RequireRole("admin")),
+//	)
+func (t *Function) With(opts ...Option) *Function {
+	clone := *t
+	result := &clone
+	for _, opt := range opts {
+		if opt != nil {
+			result = opt(result)
+		}
+	}
+	return result
+}
+
+// Defaults returns an Option setting the default value of each named
+// parameter, keeping its other metadata.
+//
+// Example:
+//
+//	fn = fn.With(dwarfreflect.Defaults(map[string]any{"limit": 20}))
+func Defaults(defaults map[string]any) Option {
+	return func(t *Function) *Function {
+		clone := *t
+		clone.paramMeta = maps.Clone(t.paramMeta)
+		if clone.paramMeta == nil {
+			clone.paramMeta = make(map[string]ParamMeta)
+		}
+		for param, value := range defaults {
+			meta := clone.paramMeta[param]
+			meta.Default = value
+			clone.paramMeta[param] = meta
+		}
+		return &clone
+	}
+}
+
+// Aliases returns an Option accepting alternative argument map keys for
+// parameters, given as alias to parameter name, e.g. short flags on a CLI.
+// Aliases add to those already set. An argument map giving both a parameter
+// and one of its aliases, or two aliases of the same parameter, fails to
+// bind.
+//
+// Example:
+//
+//	fn = fn.With(dwarfreflect.Aliases(map[string]string{"u": "userName", "user": "userName"}))
+//	fn.CallWithMap(map[string]any{"u": "alice", "age": 30})
+func Aliases(aliases map[string]string) Option {
+	return func(t *Function) *Function {
+		clone := *t
+		clone.aliases = maps.Clone(t.aliases)
+		if clone.aliases == nil {
+			clone.aliases = make(map[string]string, len(aliases))
+		}
+		maps.Copy(clone.aliases, aliases)
+		return &clone
+	}
+}
+
+// Observers returns an Option adding observers, as WithObserver does.
+func Observers(observers ...Observer) Option {
+	return func(t *Function) *Function {
+		for _, observer := range observers {
+			t = t.WithObserver(observer)
+		}
+		return t
+	}
+}
+
+// Authorizers returns an Option adding authorizers, as WithAuthorizer does.
+func Authorizers(authorizers ...Authorizer) Option {
+	return func(t *Function) *Function {
+		for _, authorizer := range authorizers {
+			t = t.WithAuthorizer(authorizer)
+		}
+		return t
+	}
+}
+
+// ContextDecorators returns an Option adding context decorators, as
+// WithContextDecorator does.
+func ContextDecorators(decorators ...ContextDecorator) Option {
+	return func(t *Function) *Function {
+		for _, decorator := range decorators {
+			t = t.WithContextDecorator(decorator)
+		}
+		return t
+	}
+}
+
+// resolveAliases returns argMap with alias keys replaced by the names of
+// their parameters, or argMap itself when no alias is used.
+func (t *Function) resolveAliases(argMap map[string]any) (map[string]any, error) {
+	var resolved map[string]any
+	for key, value := range argMap {
+		param, ok := t.aliases[key]
+		if !ok {
+			continue
+		}
+		if resolved == nil {
+			resolved = maps.Clone(argMap)
+		}
+		delete(resolved, key)
+		if _, exists := resolved[param]; exists {
+			return nil, fmt.Errorf("parameter %q of function %s given more than once (alias %q)", param, t.funcName, key)
+		}
+		resolved[param] = value
+	}
+	if resolved == nil {
+		return argMap, nil
+	}
+	return resolved, nil
+}
+
+// aliasKey returns the alias key of argMap naming param.
+func (t *Function) aliasKey(argMap map[string]any, param string) string {
+	for key := range argMap {
+		if t.aliases[key] == param {
+			return key
+		}
+	}
+	return ""
+}
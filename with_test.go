@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1)
+
+	denied := errors.New("denied")
+	public := fn.With(Defaults(map[string]any{"age": 18}))
+	admin := fn.With(
+		Aliases(map[string]string{"n": "name"}),
+		Authorizers(func(ctx context.Context, meta CallMeta) error { return denied }),
+	)
+
+	if public.GetStructType() != fn.GetStructType() || admin.GetStructType() != fn.GetStructType() {
+		t.Error("expected copies to share the generated struct")
+	}
+
+	results, err := public.CallWithMap(map[string]any{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Interface() != testFunc1("Ada", 18) {
+		t.Errorf("unexpected results: %v", results)
+	}
+	if _, err := fn.CallWithMap(map[string]any{"name": "Ada"}); err == nil {
+		t.Error("the original function must keep requiring age")
+	}
+
+	if _, err := admin.CallWithMap(map[string]any{"n": "Ada", "age": 36}); !errors.Is(err, denied) {
+		t.Errorf("expected the alias to bind and the authorizer to reject, got %v", err)
+	}
+	if len(fn.authorizers) != 0 || fn.aliases != nil {
+		t.Error("the original function must be unchanged")
+	}
+}
+
+func TestWith_Aliases(t *testing.T) {
+	fn := mustNewFunction(t, testFunc1).With(
+		Aliases(map[string]string{"n": "name"}),
+		Aliases(map[string]string{"fullName": "name"}),
+	)
+
+	args, err := fn.MapToArgs(map[string]any{"fullName": "Ada", "age": 36})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(args, []any{"Ada", 36}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	if _, err := fn.MapToArgs(map[string]any{"n": "Ada", "name": "Ada", "age": 36}); err == nil {
+		t.Error("expected an error for a parameter given both by name and alias")
+	}
+
+	report, _ := fn.ExplainBind(map[string]any{"n": "Ada", "age": 36})
+	if len(report.UnusedKeys) != 0 {
+		t.Errorf("expected aliases not to be reported unused, got %v", report.UnusedKeys)
+	}
+}
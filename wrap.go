@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// wrapped caches the Functions returned by Wrap by functionKey.
+var wrapped sync.Map
+
+// wrappedFunction is a Function cached by Wrap with the function value it
+// wraps, which closures and method values sharing its code do not match.
+type wrappedFunction struct {
+	value unsafe.Pointer
+	fn    *Function
+}
+
+// Wrap is like NewFunction for hot paths, such as middleware wrapping
+// handlers on every request: the Function of each function is built once and
+// returned again afterwards, cached by code address in a package-level map,
+// so repeated calls allocate nothing. Functions are immutable, and their
+// With* methods return copies, so the shared Function is safe to use
+// concurrently.
+//
+// The cache holds one Function per function, the last wrapped, for the life
+// of the program unless Purge is called: its size is bounded by the code of
+// the program, not by the calls. Closures and method values are values built
+// at run time, so one of them is only returned again for that same value;
+// others sharing its code are wrapped anew, still without repeating DWARF
+// lookups and struct generation.
+//
+// Wrap panics if fn cannot be wrapped, like regexp.MustCompile, as the
+// functions of hot paths are known at build time; use NewFunction to handle
+// the error.
+//
+// Example:
+//
+//	func Handle(w http.ResponseWriter, r *http.Request) {
+//	    fn := dwarfreflect.Wrap(CreateUser) // built once, then served from the cache
+//	    results, err := fn.CallWithRequest(r)
+//	    // ...
+//	}
+func Wrap(fn any) *Function {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		panic(fmt.Sprintf("dwarfreflect: Wrap requires a function, got %T", fn))
+	}
+	key := functionKey{pc: fnValue.Pointer(), typ: fnValue.Type()}
+	value := funcValuePointer(fn)
+
+	if cached, ok := wrapped.Load(key); ok && cached.(*wrappedFunction).value == value {
+		return cached.(*wrappedFunction).fn
+	}
+
+	wrapper, err := NewFunction(fn)
+	if err != nil {
+		panic(fmt.Sprintf("dwarfreflect: cannot wrap %T: %v", fn, err))
+	}
+	wrapped.Store(key, &wrappedFunction{value: value, fn: wrapper})
+	return wrapper
+}
+
+// Purge empties the caches of Wrap and NewFunction, releasing their memory,
+// e.g. after a plugin reload or once startup wrapping is done. Functions
+// already returned keep working; wrapping them again resolves them again.
+func Purge() {
+	wrapped.Clear()
+	resetFunctionInfos()
+}
+
+// funcValuePointer returns the pointer a function value holds: the same for
+// every use of a top-level function, and distinct for each closure or method
+// value built at run time.
+func funcValuePointer(fn any) unsafe.Pointer {
+	// A func is pointer-shaped, and stored in the data word of the interface
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&fn))[1]
+}
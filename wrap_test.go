@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package dwarfreflect
+
+import (
+	"strings"
+	"testing"
+)
+
+//go:noinline
+func wrapTestHandler(itemName string, quantity int) string {
+	return strings.Repeat(itemName, quantity)
+}
+
+// mustWrap wraps fn, skipping the test without DWARF.
+func mustWrap(t *testing.T, fn any) *Function {
+	t.Helper()
+	mustNewFunction(t, fn)
+	return Wrap(fn)
+}
+
+func TestWrap(t *testing.T) {
+	t.Cleanup(Purge)
+	fn := mustWrap(t, wrapTestHandler)
+	if again := Wrap(wrapTestHandler); again != fn {
+		t.Error("expected the cached Function")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { Wrap(wrapTestHandler) }); allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+
+	results, err := fn.CallWithMap(map[string]any{"itemName": "ab", "quantity": 2})
+	if err != nil || results[0].String() != "abab" {
+		t.Errorf("unexpected results %v, %v", results, err)
+	}
+
+	Purge()
+	if again := Wrap(wrapTestHandler); again == fn {
+		t.Error("expected a new Function after Purge")
+	}
+}
+
+func TestWrap_Closures(t *testing.T) {
+	t.Cleanup(Purge)
+	greeter := func(prefix string) func(userName string) string {
+		return func(userName string) string { return prefix + userName }
+	}
+	hello, bye := greeter("hello "), greeter("bye ")
+
+	helloFn := mustWrap(t, hello)
+	byeFn := Wrap(bye)
+	if helloFn == byeFn {
+		t.Fatal("expected closures sharing code to be wrapped apart")
+	}
+	for fn, want := range map[*Function]string{helloFn: "hello ann", byeFn: "bye ann"} {
+		results, err := fn.CallWithMap(map[string]any{"userName": "ann"})
+		if err != nil || results[0].String() != want {
+			t.Errorf("expected %q, got %v, %v", want, results, err)
+		}
+	}
+	if Wrap(bye) != byeFn {
+		t.Error("expected the last wrapped closure to be cached")
+	}
+}
+
+func TestWrap_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "requires a function") {
+			t.Errorf("expected a panic, got %v", r)
+		}
+	}()
+	Wrap(42)
+}
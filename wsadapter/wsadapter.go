@@ -0,0 +1,399 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package wsadapter serves the functions of a Registry over a websocket.
+//
+// Clients send JSON text frames naming a method, its named arguments and an
+// ID of their choice, and may keep many calls in flight on one connection:
+//
+//	{"id": "1", "method": "users.Get", "args": {"userID": 42}}
+//	{"id": "1", "cancel": true}
+//
+// Every response frame carries the ID of its call. Calls answer with a single
+// frame marked done, holding the result struct of ResultsToStruct (or the
+// page of paginated functions) or an error. Functions returning a receive
+// channel, an iter.Seq or an iter.Seq2 stream one frame per item instead,
+// then a frame marked done, carrying the error of an iter.Seq2[T, error]
+// that yields one:
+//
+//	{"id": "2", "result": {"line": "first"}}
+//	{"id": "2", "result": {"line": "second"}}
+//	{"id": "2", "done": true}
+//
+// A cancel frame, or the connection closing, cancels the context of the
+// call. IDs must be unique among the calls in flight on a connection, whose
+// number is bounded by Options.MaxCalls; requests breaking either rule are
+// answered with an error frame without being called. A function that panics
+// answers with an "internal" error frame, and the connection keeps serving.
+//
+// With Options.Idempotency, calls carrying an idempotencyKey are answered
+// with the stored response of the first call with that key, as
+// Router.DispatchIdempotent does; the frame is then marked replayed. Streaming
+// calls are never stored.
+//
+//	{"id": "3", "method": "orders.Create", "args": {"sku": "a1"}, "idempotencyKey": "k-42"}
+//	{"id": "3", "result": {"orderID": 7}, "done": true, "replayed": true}
+//
+// Example:
+//
+//	http.Handle("/ws", wsadapter.New(reg))
+package wsadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/matteo-grella/dwarfreflect"
+)
+
+// Request is a frame sent by clients.
+type Request struct {
+	ID     string          `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Args   json.RawMessage `json:"args,omitempty"`
+	Cancel bool            `json:"cancel,omitempty"`
+
+	// IdempotencyKey identifies a call to be made at most once, see
+	// Options.Idempotency.
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+}
+
+// Response is a frame sent to clients.
+type Response struct {
+	ID     string `json:"id"`
+	Result any    `json:"result,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+	Done   bool   `json:"done,omitempty"`
+
+	// Replayed marks the stored response of an earlier call with the same
+	// idempotency key.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// Error describes a failed call. Code is "unknown_method", "invalid_args",
+// "idempotency_key_reused", "duplicate_id" and "too_many_calls" when the
+// function was not called, "error" for errors it returned and "internal" when
+// it panicked.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Pair is the frame result of an item of an iter.Seq2 other than
+// iter.Seq2[T, error].
+type Pair struct {
+	Key   any `json:"key"`
+	Value any `json:"value"`
+}
+
+// Options configures a Handler.
+type Options struct {
+	// Accept configures the websocket handshake, e.g. allowed origins.
+	Accept *websocket.AcceptOptions
+
+	// Limits bounds the arguments of calls, as Router.WithLimits does.
+	Limits dwarfreflect.Limits
+
+	// Idempotency stores the responses of calls with an idempotency key for
+	// IdempotencyTTL, as Router.WithIdempotency does. Keys are scoped by the
+	// CallMeta.Caller of the request context.
+	Idempotency    dwarfreflect.IdempotencyStore
+	IdempotencyTTL time.Duration
+
+	// MaxCalls bounds the calls in flight on a connection; further requests
+	// fail with "too_many_calls" until one ends. Zero means DefaultMaxCalls.
+	MaxCalls int
+}
+
+// DefaultMaxCalls is the default of Options.MaxCalls.
+const DefaultMaxCalls = 64
+
+// Handler is an http.Handler serving a Registry over websockets.
+type Handler struct {
+	registry *dwarfreflect.Registry
+	router   *dwarfreflect.Router
+	options  Options
+}
+
+// New creates a Handler calling the functions of reg.
+func New(reg *dwarfreflect.Registry, opts ...Options) *Handler {
+	var options Options
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return &Handler{
+		registry: reg,
+		router:   dwarfreflect.NewRouter(reg).WithLimits(options.Limits).WithIdempotency(options.Idempotency, options.IdempotencyTTL),
+		options:  options,
+	}
+}
+
+// ServeHTTP upgrades the request to a websocket and serves calls until the
+// connection closes. context.Context parameters receive a context derived
+// from the request context.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, h.options.Accept)
+	if err != nil {
+		return // Accept has replied
+	}
+	defer conn.CloseNow()
+
+	if err := h.Serve(r.Context(), conn); err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+		conn.Close(websocket.StatusInternalError, "")
+		return
+	}
+	conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// Serve reads requests from conn and answers them until conn or ctx closes.
+// It returns once every call has ended.
+func (h *Handler) Serve(ctx context.Context, conn *websocket.Conn) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		calls = make(map[string]context.CancelFunc)
+		wg    sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	maxCalls := h.options.MaxCalls
+	if maxCalls <= 0 {
+		maxCalls = DefaultMaxCalls
+	}
+
+	for {
+		var req Request
+		if err := wsjson.Read(ctx, conn, &req); err != nil {
+			return err
+		}
+
+		if req.Cancel {
+			mu.Lock()
+			if cancelCall, ok := calls[req.ID]; ok {
+				cancelCall()
+			}
+			mu.Unlock()
+			continue
+		}
+
+		mu.Lock()
+		_, duplicate := calls[req.ID]
+		full := len(calls) >= maxCalls
+		if duplicate || full {
+			mu.Unlock()
+			code := "too_many_calls"
+			message := fmt.Sprintf("more than %d calls in flight", maxCalls)
+			if duplicate {
+				code, message = "duplicate_id", fmt.Sprintf("a call with id %q is in flight", req.ID)
+			}
+			if err := wsjson.Write(ctx, conn, Response{ID: req.ID, Error: &Error{Code: code, Message: message}, Done: true}); err != nil {
+				return err
+			}
+			continue
+		}
+		callCtx, cancelCall := context.WithCancel(ctx)
+		calls[req.ID] = cancelCall
+		mu.Unlock()
+
+		// The ID is released before the frame marked done is written, so
+		// that clients may reuse it as soon as they read that frame
+		release := sync.OnceFunc(func() {
+			mu.Lock()
+			delete(calls, req.ID)
+			mu.Unlock()
+			cancelCall()
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer release()
+			defer func() {
+				if r := recover(); r != nil {
+					dwarfreflect.Logger().Error("websocket call panicked", "method", req.Method, "id", req.ID, "panic", r, "stack", string(debug.Stack()))
+					release()
+					wsjson.Write(ctx, conn, Response{ID: req.ID, Error: &Error{Code: "internal", Message: fmt.Sprintf("method %s panicked", req.Method)}, Done: true})
+				}
+			}()
+			h.call(ctx, callCtx, conn, req, release)
+		}()
+	}
+}
+
+// call runs req with ctx and writes its response frames with connCtx, so that
+// canceled calls still report it, calling release before the frame marked
+// done. Write errors end the call; Serve notices the broken connection on its
+// next read.
+func (h *Handler) call(connCtx, ctx context.Context, conn *websocket.Conn, req Request, release func()) {
+	send := func(resp Response) bool {
+		resp.ID = req.ID
+		if resp.Done {
+			release()
+		}
+		return wsjson.Write(connCtx, conn, resp) == nil
+	}
+	fail := func(err error) {
+		send(Response{Error: newError(err), Done: true})
+	}
+
+	args := req.Args
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	if req.IdempotencyKey != "" && h.options.Idempotency != nil && !h.streams(req.Method) {
+		response, replayed, err := h.router.DispatchIdempotent(ctx, req.Method, req.IdempotencyKey, args)
+		if err != nil {
+			fail(err)
+			return
+		}
+		send(Response{Result: response, Done: true, Replayed: replayed})
+		return
+	}
+	results, err := h.router.Dispatch(ctx, req.Method, args)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	fn, _ := h.registry.Get(req.Method)
+	types, hasError := fn.GetReturnInfo()
+	if hasError && !results[len(results)-1].IsNil() {
+		fail(results[len(results)-1].Interface().(error))
+		return
+	}
+
+	if fn.IsStream() {
+		items, _ := fn.StreamResults(ctx, results) // the error was checked above
+		for item := range items {
+			if !send(Response{Result: item}) {
+				return
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			fail(err)
+			return
+		}
+		send(Response{Done: true})
+		return
+	}
+
+	if iterator := iteratorOf(types, hasError, results); iterator.IsValid() {
+		if err := iterate(ctx, iterator, func(item any) bool { return send(Response{Result: item}) }); err != nil {
+			fail(err)
+			return
+		}
+		send(Response{Done: true})
+		return
+	}
+
+	var response any
+	if fn.IsPaginated() {
+		response, err = fn.ResultsToPage(results)
+	} else {
+		response, err = fn.ResultsToStruct(results)
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+	send(Response{Result: response, Done: true})
+}
+
+// streams reports whether the function registered under method streams its
+// results, whose frames cannot be stored for idempotent calls.
+func (h *Handler) streams(method string) bool {
+	fn, ok := h.registry.Get(method)
+	if !ok {
+		return false
+	}
+	types, hasError := fn.GetReturnInfo()
+	if hasError {
+		types = types[:len(types)-1]
+	}
+	return fn.IsStream() || (len(types) == 1 && yieldType(types[0]) != nil)
+}
+
+// iteratorOf returns the only result besides a trailing error if it is an
+// iter.Seq or iter.Seq2, and the zero Value otherwise.
+func iteratorOf(types []reflect.Type, hasError bool, results []reflect.Value) reflect.Value {
+	n := len(types)
+	if hasError {
+		n--
+	}
+	if n != 1 || results[0].IsZero() || yieldType(types[0]) == nil {
+		return reflect.Value{}
+	}
+	return results[0]
+}
+
+// yieldType returns the yield function type of an iter.Seq or iter.Seq2
+// shaped function type, or nil.
+func yieldType(typ reflect.Type) reflect.Type {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 0 {
+		return nil
+	}
+	yield := typ.In(0)
+	if yield.Kind() != reflect.Func || yield.NumIn() < 1 || yield.NumIn() > 2 ||
+		yield.NumOut() != 1 || yield.Out(0).Kind() != reflect.Bool {
+		return nil
+	}
+	return yield
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// iterate passes the items of an iterator to emit until they end, emit
+// reports false or ctx is done. It returns the error yielded by an
+// iter.Seq2[T, error], or ctx.Err().
+func iterate(ctx context.Context, iterator reflect.Value, emit func(item any) bool) error {
+	yield := yieldType(iterator.Type())
+	var yielded error
+	iterator.Call([]reflect.Value{reflect.MakeFunc(yield, func(args []reflect.Value) []reflect.Value {
+		continueIteration := false
+		switch {
+		case ctx.Err() != nil || yielded != nil:
+		case len(args) == 2 && args[1].Type() == errorType:
+			if !args[1].IsNil() {
+				yielded = args[1].Interface().(error)
+			} else {
+				continueIteration = emit(args[0].Interface())
+			}
+		case len(args) == 2:
+			continueIteration = emit(Pair{Key: args[0].Interface(), Value: args[1].Interface()})
+		default:
+			continueIteration = emit(args[0].Interface())
+		}
+		return []reflect.Value{reflect.ValueOf(continueIteration)}
+	})})
+
+	if yielded != nil {
+		return yielded
+	}
+	return ctx.Err()
+}
+
+// newError classifies err for clients.
+func newError(err error) *Error {
+	var bindErr *dwarfreflect.BindingError
+	switch {
+	case errors.Is(err, dwarfreflect.ErrUnknownMethod):
+		return &Error{Code: "unknown_method", Message: err.Error()}
+	case errors.As(err, &bindErr):
+		return &Error{Code: "invalid_args", Message: err.Error()}
+	case errors.Is(err, dwarfreflect.ErrIdempotencyKeyReused):
+		return &Error{Code: "idempotency_key_reused", Message: err.Error()}
+	default:
+		return &Error{Code: "error", Message: err.Error()}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Matteo Grella <matteogrella@gmail.com>
+// Licensed under the MIT License. See LICENSE file for details.
+
+package wsadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/matteo-grella/dwarfreflect"
+	"github.com/matteo-grella/dwarfreflect/dwarfreflecttest"
+)
+
+func add(a, b int) (sum int) {
+	return a + b
+}
+
+func fail(reason string) error {
+	return errors.New(reason)
+}
+
+func countdown(from int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := from; i > 0; i-- {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+func lines(text string) iter.Seq[string] {
+	return strings.SplitSeq(text, "\n")
+}
+
+func parse(fields string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for field := range strings.SplitSeq(fields, ",") {
+			if field == "" {
+				yield("", errors.New("empty field"))
+				return
+			}
+			if !yield(field, nil) {
+				return
+			}
+		}
+	}
+}
+
+func wait(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type user struct {
+	Name string
+}
+
+func greet(u *user) string {
+	return "hello " + u.Name
+}
+
+var orders atomic.Int32
+
+func order(sku string) (orderID int32) {
+	return orders.Add(1)
+}
+
+func dial(t *testing.T, opts ...Options) *websocket.Conn {
+	t.Helper()
+	reg := dwarfreflect.NewRegistry()
+	for name, fn := range map[string]any{
+		"add": add, "fail": fail, "countdown": countdown, "lines": lines, "parse": parse, "wait": wait, "order": order, "greet": greet,
+	} {
+		dwarfreflecttest.Register(t, reg, name, fn)
+	}
+
+	server := httptest.NewServer(New(reg, opts...))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+type frame struct {
+	ID       string          `json:"id"`
+	Result   json.RawMessage `json:"result"`
+	Error    *Error          `json:"error"`
+	Done     bool            `json:"done"`
+	Replayed bool            `json:"replayed"`
+}
+
+func send(t *testing.T, conn *websocket.Conn, req Request) {
+	t.Helper()
+	if err := wsjson.Write(context.Background(), conn, req); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+}
+
+// collect reads frames until the call with the given ID is done.
+func collect(t *testing.T, conn *websocket.Conn, id string) []frame {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var frames []frame
+	for {
+		var f frame
+		if err := wsjson.Read(ctx, conn, &f); err != nil {
+			t.Fatalf("unexpected read error: %v", err)
+		}
+		if f.ID != id {
+			t.Fatalf("got frame for call %q, want %q", f.ID, id)
+		}
+		frames = append(frames, f)
+		if f.Done {
+			return frames
+		}
+	}
+}
+
+func results(frames []frame) string {
+	var parts []string
+	for _, f := range frames {
+		if f.Result != nil {
+			parts = append(parts, string(f.Result))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestHandler_Call(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t)
+
+	send(t, conn, Request{ID: "1", Method: "add", Args: json.RawMessage(`{"a": 2, "b": 3}`)})
+	frames := collect(t, conn, "1")
+	if len(frames) != 1 || results(frames) != `{"sum":5}` {
+		t.Errorf("got %+v, want one frame with sum 5", frames)
+	}
+}
+
+func TestHandler_Idempotency(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t, Options{Idempotency: dwarfreflect.NewMemoryIdempotencyStore(), IdempotencyTTL: time.Minute})
+	before := orders.Load()
+
+	send(t, conn, Request{ID: "1", Method: "order", Args: json.RawMessage(`{"sku": "a1"}`), IdempotencyKey: "k"})
+	first := collect(t, conn, "1")
+	send(t, conn, Request{ID: "2", Method: "order", Args: json.RawMessage(`{ "sku":"a1" }`), IdempotencyKey: "k"})
+	second := collect(t, conn, "2")
+	if results(first) != results(second) || first[0].Replayed || !second[0].Replayed {
+		t.Errorf("expected the first response replayed, got %+v and %+v", first, second)
+	}
+	if calls := orders.Load() - before; calls != 1 {
+		t.Errorf("expected one call, got %d", calls)
+	}
+
+	send(t, conn, Request{ID: "3", Method: "order", Args: json.RawMessage(`{"sku": "b2"}`), IdempotencyKey: "k"})
+	if frames := collect(t, conn, "3"); frames[0].Error == nil || frames[0].Error.Code != "idempotency_key_reused" {
+		t.Errorf("expected a reused key error, got %+v", frames)
+	}
+
+	// Streams are never stored
+	send(t, conn, Request{ID: "4", Method: "countdown", Args: json.RawMessage(`{"from": 2}`), IdempotencyKey: "s"})
+	if frames := collect(t, conn, "4"); results(frames) != "2 1" {
+		t.Errorf("expected a stream, got %+v", frames)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t)
+
+	tests := []struct {
+		req  Request
+		code string
+	}{
+		{Request{ID: "1", Method: "missing"}, "unknown_method"},
+		{Request{ID: "2", Method: "add", Args: json.RawMessage(`{"a": "two"}`)}, "invalid_args"},
+		{Request{ID: "3", Method: "fail", Args: json.RawMessage(`{"reason": "boom"}`)}, "error"},
+	}
+	for _, tt := range tests {
+		send(t, conn, tt.req)
+		frames := collect(t, conn, tt.req.ID)
+		if len(frames) != 1 || frames[0].Error == nil || frames[0].Error.Code != tt.code {
+			t.Errorf("%s: got %+v, want a %s error", tt.req.Method, frames, tt.code)
+		}
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t)
+
+	tests := []struct {
+		req  Request
+		want string
+		err  string
+	}{
+		{Request{ID: "chan", Method: "countdown", Args: json.RawMessage(`{"from": 3}`)}, `3 2 1`, ""},
+		{Request{ID: "seq", Method: "lines", Args: json.RawMessage(`{"text": "a\nb"}`)}, `"a" "b"`, ""},
+		{Request{ID: "seq2", Method: "parse", Args: json.RawMessage(`{"fields": "x,y"}`)}, `"x" "y"`, ""},
+		{Request{ID: "seq2-error", Method: "parse", Args: json.RawMessage(`{"fields": "x,"}`)}, `"x"`, "empty field"},
+	}
+	for _, tt := range tests {
+		send(t, conn, tt.req)
+		frames := collect(t, conn, tt.req.ID)
+		if got := results(frames); got != tt.want {
+			t.Errorf("%s: got results %s, want %s", tt.req.ID, got, tt.want)
+		}
+		last := frames[len(frames)-1]
+		switch {
+		case tt.err == "" && last.Error != nil:
+			t.Errorf("%s: unexpected error %v", tt.req.ID, last.Error)
+		case tt.err != "" && (last.Error == nil || last.Error.Message != tt.err):
+			t.Errorf("%s: got error %v, want %q", tt.req.ID, last.Error, tt.err)
+		}
+	}
+}
+
+func TestHandler_Cancel(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t)
+
+	send(t, conn, Request{ID: "slow", Method: "wait"})
+	send(t, conn, Request{ID: "fast", Method: "add", Args: json.RawMessage(`{"a": 1, "b": 1}`)})
+	if got := results(collect(t, conn, "fast")); got != `{"sum":2}` {
+		t.Errorf("got %s, want the fast call to answer while the slow one runs", got)
+	}
+
+	send(t, conn, Request{ID: "slow", Cancel: true})
+	frames := collect(t, conn, "slow")
+	if last := frames[len(frames)-1]; last.Error == nil || !strings.Contains(last.Error.Message, "canceled") {
+		t.Errorf("got %+v, want a cancellation error", frames)
+	}
+}
+
+func TestHandler_Panic(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t)
+
+	// The omitted pointer parameter binds nil
+	send(t, conn, Request{ID: "1", Method: "greet"})
+	if frames := collect(t, conn, "1"); len(frames) != 1 || frames[0].Error == nil || frames[0].Error.Code != "internal" {
+		t.Errorf("got %+v, want an internal error", frames)
+	}
+
+	send(t, conn, Request{ID: "2", Method: "add", Args: json.RawMessage(`{"a": 1, "b": 2}`)})
+	if got := results(collect(t, conn, "2")); got != `{"sum":3}` {
+		t.Errorf("got %s, want the connection to keep serving", got)
+	}
+}
+
+func TestHandler_InFlight(t *testing.T) {
+	dwarfreflecttest.RequireDWARF(t)
+	conn := dial(t, Options{MaxCalls: 1})
+
+	send(t, conn, Request{ID: "slow", Method: "wait"})
+	send(t, conn, Request{ID: "slow", Method: "add", Args: json.RawMessage(`{"a": 1, "b": 1}`)})
+	if frames := collect(t, conn, "slow"); frames[0].Error == nil || frames[0].Error.Code != "duplicate_id" {
+		t.Errorf("got %+v, want a duplicate id error", frames)
+	}
+
+	send(t, conn, Request{ID: "other", Method: "add", Args: json.RawMessage(`{"a": 1, "b": 1}`)})
+	if frames := collect(t, conn, "other"); frames[0].Error == nil || frames[0].Error.Code != "too_many_calls" {
+		t.Errorf("got %+v, want a too many calls error", frames)
+	}
+
+	send(t, conn, Request{ID: "slow", Cancel: true})
+	collect(t, conn, "slow")
+	send(t, conn, Request{ID: "slow", Method: "add", Args: json.RawMessage(`{"a": 1, "b": 1}`)})
+	if got := results(collect(t, conn, "slow")); got != `{"sum":2}` {
+		t.Errorf("got %s, want the id to be reusable once its call ended", got)
+	}
+}